@@ -0,0 +1,228 @@
+package openaiclient
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+// maxGrowBytes caps the pre-growth of the accumulated contents, so that large
+// token limits don't allocate megabytes for short answers.
+const maxGrowBytes = 1 << 20
+
+type (
+	// StreamAccumulator assembles the chunks of a streamed chat completion into
+	// the final response, merging content deltas and tool call fragments.
+	StreamAccumulator struct {
+		onToken func(choice int, delta string)
+		// grow is the capacity choice contents are created with, in bytes.
+		grow int
+
+		id      string
+		model   string
+		created int
+		usage   Usage
+		choices map[int]*choiceAccumulator
+
+		// last is the last updated choice, which most chunks update again.
+		last      *choiceAccumulator
+		lastIndex int
+	}
+
+	// choiceAccumulator assembles a choice of a stream.
+	choiceAccumulator struct {
+		role         Role
+		content      strings.Builder
+		refusal      strings.Builder
+		finishReason string
+		toolCalls    []ToolCall
+		arguments    []*strings.Builder
+		logprobs     *Logprobs
+	}
+)
+
+// NewStreamAccumulator creates an empty stream accumulator.
+func NewStreamAccumulator() *StreamAccumulator {
+	return &StreamAccumulator{choices: make(map[int]*choiceAccumulator)}
+}
+
+// OnToken calls fn with every content delta as it is added, along with the
+// index of its choice, e.g. to display the answer while it is generated.
+func (a *StreamAccumulator) OnToken(fn func(choice int, delta string)) *StreamAccumulator {
+	a.onToken = fn
+	return a
+}
+
+// Grow pre-allocates the contents for answers of the given number of tokens,
+// such as the max_tokens of the request, so long answers aren't copied as
+// they grow. Accumulate grows the accumulator from the max_tokens of the
+// streamed request, if set.
+func (a *StreamAccumulator) Grow(tokens int) *StreamAccumulator {
+	a.grow = min(tokens*4, maxGrowBytes)
+	for _, choice := range a.choices {
+		choice.content.Grow(a.grow)
+	}
+	return a
+}
+
+// Add merges the chunk into the response.
+func (a *StreamAccumulator) Add(chunk *ChatCompletionChunk) {
+	if a.id == "" {
+		a.id, a.model, a.created = chunk.ID, chunk.Model, chunk.Created
+	}
+	if chunk.Usage != nil {
+		a.usage = *chunk.Usage
+	}
+
+	for i := range chunk.Choices {
+		delta := &chunk.Choices[i]
+		choice := a.choice(delta.Index)
+
+		if delta.Delta.Role != "" {
+			choice.role = delta.Delta.Role
+		}
+		if delta.FinishReason != "" {
+			choice.finishReason = delta.FinishReason
+		}
+		choice.refusal.WriteString(delta.Delta.Refusal)
+
+		if delta.Delta.Content != "" {
+			choice.content.WriteString(delta.Delta.Content)
+			if a.onToken != nil {
+				a.onToken(delta.Index, delta.Delta.Content)
+			}
+		}
+
+		for _, call := range delta.Delta.ToolCalls {
+			choice.addToolCall(call)
+		}
+
+		if delta.Logprobs != nil {
+			if choice.logprobs == nil {
+				choice.logprobs = &Logprobs{}
+			}
+			choice.logprobs.Content = append(choice.logprobs.Content, delta.Logprobs.Content...)
+			choice.logprobs.Refusal = append(choice.logprobs.Refusal, delta.Logprobs.Refusal...)
+		}
+	}
+}
+
+// choice returns the choice of the given index, creating it if needed.
+func (a *StreamAccumulator) choice(index int) *choiceAccumulator {
+	if a.last != nil && a.lastIndex == index {
+		return a.last
+	}
+
+	choice, ok := a.choices[index]
+	if !ok {
+		choice = &choiceAccumulator{}
+		choice.content.Grow(a.grow)
+		a.choices[index] = choice
+	}
+
+	a.last, a.lastIndex = choice, index
+	return choice
+}
+
+// addToolCall merges the fragment into its tool call. Fragments without an
+// index start a new call if they have an ID, and continue the last one otherwise.
+func (c *choiceAccumulator) addToolCall(delta ToolCallDelta) {
+	i := len(c.toolCalls) - 1
+	switch {
+	case delta.Index != nil:
+		i = *delta.Index
+	case delta.ID != "" || i < 0:
+		i = len(c.toolCalls)
+	}
+	if i < 0 {
+		return
+	}
+
+	for len(c.toolCalls) <= i {
+		c.toolCalls = append(c.toolCalls, ToolCall{})
+		c.arguments = append(c.arguments, &strings.Builder{})
+	}
+
+	call := &c.toolCalls[i]
+	if delta.ID != "" {
+		call.ID = delta.ID
+	}
+	if delta.Type != "" {
+		call.Type = delta.Type
+	}
+	// Names are sent whole, but some servers repeat them in every fragment.
+	if delta.Function.Name != "" {
+		call.Function.Name = delta.Function.Name
+	}
+	c.arguments[i].WriteString(delta.Function.Arguments)
+}
+
+// Response returns the response assembled from the chunks added so far.
+func (a *StreamAccumulator) Response() *ChatCompletionResponse {
+	resp := ChatCompletionResponse{
+		ID:      a.id,
+		Object:  "chat.completion",
+		Model:   a.model,
+		Created: a.created,
+		Usage:   a.usage,
+		Choices: make([]Choice, 0, len(a.choices)),
+	}
+
+	for index, choice := range a.choices {
+		msg := Message{
+			Role:    choice.role,
+			Content: choice.content.String(),
+			Refusal: choice.refusal.String(),
+		}
+		if msg.Role == "" {
+			msg.Role = RoleAssistant
+		}
+
+		for i, call := range choice.toolCalls {
+			call.Function.Arguments = choice.arguments[i].String()
+			if call.Type == "" {
+				call.Type = "function"
+			}
+			msg.ToolCalls = append(msg.ToolCalls, call)
+		}
+
+		resp.Choices = append(resp.Choices, Choice{Index: index, FinishReason: choice.finishReason, Message: msg, Logprobs: choice.logprobs})
+	}
+
+	sort.Slice(resp.Choices, func(i, j int) bool {
+		return resp.Choices[i].Index < resp.Choices[j].Index
+	})
+	return &resp
+}
+
+// Accumulate adds every remaining chunk of the stream to the accumulator and
+// returns the final response, with the captured headers of the stream.
+// On error, the accumulator holds the chunks received so far; errors after
+// the first chunk are returned as a StreamInterruptedError with the partial
+// response.
+func (s *ChatCompletionStream) Accumulate(acc *StreamAccumulator) (*ChatCompletionResponse, error) {
+	if acc.grow == 0 && s.maxTokens > 0 {
+		acc.Grow(s.maxTokens)
+	}
+
+	// The chunk is reused, as the accumulator doesn't retain it.
+	var chunk ChatCompletionChunk
+	for received := false; ; received = true {
+		err := s.recv(&chunk)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if received {
+				return nil, newStreamInterruptedError(acc.Response(), err)
+			}
+			return nil, err
+		}
+		acc.Add(&chunk)
+	}
+
+	resp := acc.Response()
+	resp.ResponseMeta = s.ResponseMeta
+	return resp, nil
+}
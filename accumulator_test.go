@@ -0,0 +1,227 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamAccumulator(t *testing.T) {
+	t.Parallel()
+
+	index := func(i int) *int { return &i }
+
+	chunks := []ChatCompletionChunk{
+		{ID: "chatcmpl-1", Model: "gpt-4o", Created: 1700000000, Choices: []ChunkChoice{
+			{Index: 0, Delta: MessageDelta{Role: "assistant", Content: "Let me "}},
+			{Index: 1, Delta: MessageDelta{Role: "assistant", ToolCalls: []ToolCallDelta{
+				{Index: index(0), ID: "call_a", Type: "function", Function: FunctionCall{Name: "weather"}},
+				{Index: index(1), ID: "call_b", Type: "function", Function: FunctionCall{Name: "time"}},
+			}}},
+		}},
+		{ID: "chatcmpl-1", Choices: []ChunkChoice{
+			{Index: 0, Delta: MessageDelta{Content: "check."}},
+			{Index: 1, Delta: MessageDelta{ToolCalls: []ToolCallDelta{
+				{Index: index(1), Function: FunctionCall{Arguments: `{"tz":`}},
+				{Index: index(0), Function: FunctionCall{Arguments: `{"city":`}},
+			}}},
+		}},
+		{ID: "chatcmpl-1", Choices: []ChunkChoice{
+			{Index: 1, Delta: MessageDelta{ToolCalls: []ToolCallDelta{
+				{Index: index(0), Function: FunctionCall{Name: "weather", Arguments: `"Paris"}`}},
+				{Index: index(1), Function: FunctionCall{Arguments: `"CET"}`}},
+			}}, FinishReason: "tool_calls"},
+			{Index: 0, FinishReason: "stop"},
+		}},
+		{ID: "chatcmpl-1", Usage: &Usage{PromptTokens: 10, CompletionTokens: 20, TotalTokens: 30}},
+	}
+
+	var tokens []string
+	acc := NewStreamAccumulator().OnToken(func(choice int, delta string) {
+		assert.Equal(t, 0, choice)
+		tokens = append(tokens, delta)
+	})
+	for i := range chunks {
+		acc.Add(&chunks[i])
+	}
+
+	assert.Equal(t, []string{"Let me ", "check."}, tokens)
+	assert.Equal(t, &ChatCompletionResponse{
+		ID:      "chatcmpl-1",
+		Object:  "chat.completion",
+		Model:   "gpt-4o",
+		Created: 1700000000,
+		Usage:   Usage{PromptTokens: 10, CompletionTokens: 20, TotalTokens: 30},
+		Choices: []Choice{
+			{Index: 0, FinishReason: "stop", Message: Message{Role: "assistant", Content: "Let me check."}},
+			{Index: 1, FinishReason: "tool_calls", Message: Message{Role: "assistant", ToolCalls: []ToolCall{
+				{ID: "call_a", Type: "function", Function: FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}},
+				{ID: "call_b", Type: "function", Function: FunctionCall{Name: "time", Arguments: `{"tz":"CET"}`}},
+			}}},
+		},
+	}, acc.Response())
+}
+
+func TestStreamAccumulator_ToolCallsWithoutIndex(t *testing.T) {
+	t.Parallel()
+
+	acc := NewStreamAccumulator()
+	acc.Add(&ChatCompletionChunk{Choices: []ChunkChoice{{Delta: MessageDelta{ToolCalls: []ToolCallDelta{
+		{ID: "call_a", Function: FunctionCall{Name: "weather", Arguments: `{"city":`}},
+	}}}}})
+	acc.Add(&ChatCompletionChunk{Choices: []ChunkChoice{{Delta: MessageDelta{ToolCalls: []ToolCallDelta{
+		{Function: FunctionCall{Arguments: `"Paris"}`}},
+		{ID: "call_b", Function: FunctionCall{Name: "time", Arguments: `{}`}},
+	}}}}})
+
+	resp := acc.Response()
+
+	require.Len(t, resp.Choices, 1)
+	assert.Equal(t, []ToolCall{
+		{ID: "call_a", Type: "function", Function: FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}},
+		{ID: "call_b", Type: "function", Function: FunctionCall{Name: "time", Arguments: `{}`}},
+	}, resp.Choices[0].Message.ToolCalls)
+}
+
+func TestChatCompletionStream_Accumulate(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return sseResponse(sseEvents(t,
+				ChatCompletionChunk{ID: "chatcmpl-1", Choices: []ChunkChoice{{Delta: MessageDelta{Role: "assistant", Content: "Hello"}}}},
+				ChatCompletionChunk{ID: "chatcmpl-1", Choices: []ChunkChoice{{Delta: MessageDelta{Content: " world"}, FinishReason: "stop"}}},
+			)), nil
+		},
+	})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	resp, err := stream.Accumulate(NewStreamAccumulator())
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello world", resp.Choices[0].Message.Content)
+	assert.Equal(t, "stop", resp.Choices[0].FinishReason)
+	assert.Equal(t, "req-123", resp.Header.Get("X-Request-Id"))
+}
+
+// benchmarkStreamTokens is the length of the benchmarked streamed outputs.
+const benchmarkStreamTokens = 12000
+
+// benchmarkChunks returns the chunks of a streamed answer of the given number of tokens.
+func benchmarkChunks(tokens int) []ChatCompletionChunk {
+	words := []string{" the", " quick", " brown", " fox", " jumps", " over", " lazy", " dog"}
+
+	chunks := make([]ChatCompletionChunk, tokens)
+	for i := range chunks {
+		chunks[i] = ChatCompletionChunk{
+			ID:      "chatcmpl-123",
+			Object:  "chat.completion.chunk",
+			Model:   "gpt-4o-mini",
+			Created: 1700000000,
+			Choices: []ChunkChoice{{Delta: MessageDelta{Content: words[i%len(words)]}}},
+		}
+	}
+	return chunks
+}
+
+func BenchmarkStreamAccumulator_Add(b *testing.B) {
+	chunks := benchmarkChunks(benchmarkStreamTokens)
+
+	b.Run("default", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			acc := NewStreamAccumulator()
+			for j := range chunks {
+				acc.Add(&chunks[j])
+			}
+			_ = acc.Response()
+		}
+	})
+
+	b.Run("pre-grown", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			acc := NewStreamAccumulator().Grow(benchmarkStreamTokens)
+			for j := range chunks {
+				acc.Add(&chunks[j])
+			}
+			_ = acc.Response()
+		}
+	})
+}
+
+func BenchmarkChatCompletionStream_Accumulate(b *testing.B) {
+	var body strings.Builder
+	for _, chunk := range benchmarkChunks(benchmarkStreamTokens) {
+		data, err := json.Marshal(chunk)
+		if err != nil {
+			b.Fatal(err)
+		}
+		body.WriteString("data: " + string(data) + "\n\n")
+	}
+	body.WriteString("data: [DONE]\n\n")
+	events := body.String()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return sseResponse(events), nil
+		},
+	})
+	in := ChatCompletionRequest{Model: "gpt-4o-mini", MaxTokens: benchmarkStreamTokens}
+
+	b.SetBytes(int64(len(events)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		stream, err := client.CreateChatCompletionStream(context.Background(), in)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if _, err := stream.Accumulate(NewStreamAccumulator()); err != nil {
+			b.Fatal(err)
+		}
+		stream.Close()
+	}
+}
+
+func TestChatCompletionStream_AccumulateReusedChunks(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("a", 10000)
+	index := 0
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return sseResponse(sseEvents(t,
+				ChatCompletionChunk{Choices: []ChunkChoice{{Delta: MessageDelta{ToolCalls: []ToolCallDelta{
+					{Index: &index, ID: "call_a", Function: FunctionCall{Name: "echo", Arguments: `{"text":"`}},
+				}}}}},
+				ChatCompletionChunk{Choices: []ChunkChoice{{Delta: MessageDelta{ToolCalls: []ToolCallDelta{
+					{Index: &index, Function: FunctionCall{Arguments: long + `"}`}},
+				}}}}},
+				ChatCompletionChunk{Choices: []ChunkChoice{{Delta: MessageDelta{}, FinishReason: "tool_calls"}}},
+			)), nil
+		},
+	})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o", MaxTokens: 10})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	resp, err := stream.Accumulate(NewStreamAccumulator())
+	require.NoError(t, err)
+
+	require.Len(t, resp.Choices, 1)
+	assert.Equal(t, "tool_calls", resp.Choices[0].FinishReason)
+	assert.Equal(t, []ToolCall{
+		{ID: "call_a", Type: "function", Function: FunctionCall{Name: "echo", Arguments: `{"text":"` + long + `"}`}},
+	}, resp.Choices[0].Message.ToolCalls)
+}
@@ -0,0 +1,62 @@
+package openaiclient
+
+import "sync"
+
+// ModelAliases maps user-defined model names, such as "default-chat", to model
+// IDs. Aliases are resolved at request time and can be updated while the
+// client is in use, so model upgrades become configuration changes.
+type ModelAliases struct {
+	mu      sync.RWMutex
+	aliases map[string]string
+}
+
+// NewModelAliases creates a model alias registry with the given aliases.
+func NewModelAliases(aliases map[string]string) *ModelAliases {
+	a := ModelAliases{aliases: make(map[string]string, len(aliases))}
+	for alias, model := range aliases {
+		a.aliases[alias] = model
+	}
+	return &a
+}
+
+// Set points the alias to the model.
+func (a *ModelAliases) Set(alias, model string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.aliases[alias] = model
+}
+
+// Delete removes the alias.
+func (a *ModelAliases) Delete(alias string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.aliases, alias)
+}
+
+// Resolve returns the model the alias points to, or the name itself if it isn't an alias.
+func (a *ModelAliases) Resolve(name string) string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if model, ok := a.aliases[name]; ok {
+		return model
+	}
+	return name
+}
+
+// WithModelAliases makes the client resolve the model of every request through the aliases.
+func WithModelAliases(aliases *ModelAliases) Option {
+	return func(c *Client) {
+		c.aliases = aliases
+	}
+}
+
+// resolveModel returns the model ID of the requested model name.
+func (c *Client) resolveModel(name string) string {
+	if c.aliases == nil {
+		return name
+	}
+	return c.aliases.Resolve(name)
+}
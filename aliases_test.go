@@ -0,0 +1,61 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithModelAliases(t *testing.T) {
+	t.Parallel()
+
+	var models []string
+
+	aliases := NewModelAliases(map[string]string{
+		"default-chat":      "gpt-4o-2024-08-06",
+		"default-embedding": "text-embedding-3-small",
+	})
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in struct {
+				Model string `json:"model"`
+			}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+			models = append(models, in.Model)
+			return jsonResponse(t, ChatCompletionResponse{}), nil
+		},
+	}, WithModelAliases(aliases))
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "default-chat"})
+	require.NoError(t, err)
+
+	aliases.Set("default-chat", "gpt-4o-2024-11-20")
+
+	_, err = client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "default-chat"})
+	require.NoError(t, err)
+
+	_, err = client.CreateEmbedding(context.Background(), EmbeddingRequest{Model: "default-embedding"})
+	require.NoError(t, err)
+
+	aliases.Delete("default-chat")
+
+	_, err = client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "default-chat"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"gpt-4o-2024-08-06", "gpt-4o-2024-11-20", "text-embedding-3-small", "default-chat"}, models)
+}
+
+func TestModelAliases_Resolve(t *testing.T) {
+	t.Parallel()
+
+	aliases := NewModelAliases(map[string]string{"fast": "gpt-4o-mini"})
+
+	assert.Equal(t, "gpt-4o-mini", aliases.Resolve("fast"))
+	assert.Equal(t, "gpt-4o", aliases.Resolve("gpt-4o"))
+}
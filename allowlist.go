@@ -0,0 +1,78 @@
+package openaiclient
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrHostNotAllowed is returned when a request targets a host outside the allowlist.
+	ErrHostNotAllowed = errors.New("host not allowed")
+
+	// ErrCertificateNotPinned is returned when the server presents no pinned certificate.
+	ErrCertificateNotPinned = errors.New("certificate not pinned")
+)
+
+// WithAllowedHosts restricts outbound requests to the given hosts.
+// Requests to any other host fail with ErrHostNotAllowed before being sent.
+func WithAllowedHosts(hosts ...string) Option {
+	return func(c *Client) {
+		c.allowedHosts = make(map[string]struct{}, len(hosts))
+		for _, host := range hosts {
+			c.allowedHosts[strings.ToLower(host)] = struct{}{}
+		}
+	}
+}
+
+// checkHost verifies the request host against the allowlist, if one is configured.
+func (c *Client) checkHost(req *http.Request) error {
+	if c.allowedHosts == nil {
+		return nil
+	}
+
+	if _, ok := c.allowedHosts[strings.ToLower(req.URL.Hostname())]; !ok {
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, req.URL.Hostname())
+	}
+	return nil
+}
+
+// WithPinnedCertificates makes the transport accept only servers whose certificate
+// verified chain contains a public key matching one of the pins. Certificates the
+// server sends outside of the verified chain are ignored. Pins are the base64 encoded
+// SHA-256 digests of the DER encoded subject public key info, optionally prefixed
+// with "sha256/". Pinning is applied on top of the regular certificate verification.
+func WithPinnedCertificates(pins ...string) TransportOption {
+	pinned := make(map[string]struct{}, len(pins))
+	for _, pin := range pins {
+		pinned[strings.TrimPrefix(pin, "sha256/")] = struct{}{}
+	}
+
+	return func(t *http.Transport) {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+
+		t.TLSClientConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+			for _, chain := range cs.VerifiedChains {
+				for _, cert := range chain {
+					if _, ok := pinned[CertificatePin(cert)]; ok {
+						return nil
+					}
+				}
+			}
+			return ErrCertificateNotPinned
+		}
+	}
+}
+
+// CertificatePin returns the pin of the certificate as expected by WithPinnedCertificates.
+func CertificatePin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
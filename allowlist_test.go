@@ -0,0 +1,180 @@
+package openaiclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAllowedHosts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		hosts   []string
+		wantErr error
+	}{
+		{
+			name:    "allows listed hosts",
+			hosts:   []string{"API.openai.com"},
+			wantErr: nil,
+		},
+		{
+			name:    "rejects unlisted hosts",
+			hosts:   []string{"gateway.internal"},
+			wantErr: ErrHostNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent bool
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					sent = true
+					return &http.Response{
+						StatusCode: 200,
+						Body:       io.NopCloser(strings.NewReader("{}")),
+					}, nil
+				},
+			}, WithAllowedHosts(tt.hosts...))
+
+			_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantErr == nil, sent)
+		})
+	}
+}
+
+func TestWithPinnedCertificates(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	rootCAs := server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+
+	tests := []struct {
+		name    string
+		pin     string
+		wantErr bool
+	}{
+		{
+			name:    "accepts pinned certificates",
+			pin:     "sha256/" + CertificatePin(server.Certificate()),
+			wantErr: false,
+		},
+		{
+			name:    "rejects unpinned certificates",
+			pin:     "sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := NewTransport(WithPinnedCertificates(tt.pin))
+			transport.TLSClientConfig.RootCAs = rootCAs
+
+			resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrCertificateNotPinned)
+				return
+			}
+
+			require.NoError(t, err)
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestWithPinnedCertificates_IgnoresUnverifiedCertificates(t *testing.T) {
+	t.Parallel()
+
+	ca, caKey := newTestCertificate(t, nil, nil, true)
+	leaf, leafKey := newTestCertificate(t, ca, caKey, false)
+	pinned, _ := newTestCertificate(t, nil, nil, true)
+
+	// The server presents a CA-valid but unpinned leaf, along with the pinned
+	// certificate that is not part of its verified chain.
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{leaf.Raw, pinned.Raw},
+			PrivateKey:  leafKey,
+		}},
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca)
+
+	transport := NewTransport(WithPinnedCertificates(CertificatePin(pinned)))
+	transport.TLSClientConfig.RootCAs = rootCAs
+
+	_, err := (&http.Client{Transport: transport}).Get(server.URL)
+	assert.ErrorIs(t, err, ErrCertificateNotPinned)
+
+	transport = NewTransport(WithPinnedCertificates(CertificatePin(ca)))
+	transport.TLSClientConfig.RootCAs = rootCAs
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
+
+// newTestCertificate creates a certificate for 127.0.0.1 signed by parent, or self-signed if parent is nil.
+func newTestCertificate(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
@@ -0,0 +1,207 @@
+package openaiclient
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Assistant tool types.
+const (
+	AssistantToolCodeInterpreter = "code_interpreter"
+	AssistantToolFileSearch      = "file_search"
+	AssistantToolFunction        = "function"
+)
+
+type (
+	// AssistantRequest is the request body to create or modify an assistant.
+	// When modifying, only the set fields are changed.
+	AssistantRequest struct {
+		Model          string            `json:"model,omitempty"`
+		Name           string            `json:"name,omitempty"`
+		Description    string            `json:"description,omitempty"`
+		Instructions   string            `json:"instructions,omitempty"`
+		Tools          []AssistantTool   `json:"tools,omitempty"`
+		ToolResources  *ToolResources    `json:"tool_resources,omitempty"`
+		Metadata       map[string]string `json:"metadata,omitempty"`
+		Temperature    *float64          `json:"temperature,omitempty"`
+		TopP           *float64          `json:"top_p,omitempty"`
+		ResponseFormat *ResponseFormat   `json:"response_format,omitempty"`
+	}
+
+	// Assistant is an assistant of the Assistants API.
+	Assistant struct {
+		ResponseMeta
+		ID             string            `json:"id"`
+		Object         string            `json:"object"`
+		CreatedAt      int64             `json:"created_at"`
+		Name           string            `json:"name"`
+		Description    string            `json:"description"`
+		Model          string            `json:"model"`
+		Instructions   string            `json:"instructions"`
+		Tools          []AssistantTool   `json:"tools"`
+		ToolResources  *ToolResources    `json:"tool_resources,omitempty"`
+		Metadata       map[string]string `json:"metadata"`
+		Temperature    *float64          `json:"temperature,omitempty"`
+		TopP           *float64          `json:"top_p,omitempty"`
+		ResponseFormat *ResponseFormat   `json:"response_format,omitempty"`
+	}
+
+	// AssistantTool is a tool enabled on an assistant or a run, of type
+	// AssistantToolCodeInterpreter, AssistantToolFileSearch or AssistantToolFunction.
+	AssistantTool struct {
+		Type string `json:"type"`
+		// Function is the function of AssistantToolFunction tools.
+		Function *FunctionDefinition `json:"function,omitempty"`
+		// FileSearch configures AssistantToolFileSearch tools.
+		FileSearch *FileSearchOptions `json:"file_search,omitempty"`
+	}
+
+	// FileSearchOptions configures the file_search tool.
+	FileSearchOptions struct {
+		// MaxNumResults is the maximum number of results the tool returns, between 1 and 50.
+		MaxNumResults  int                       `json:"max_num_results,omitempty"`
+		RankingOptions *FileSearchRankingOptions `json:"ranking_options,omitempty"`
+	}
+
+	// ToolResources are the resources the assistant tools use.
+	ToolResources struct {
+		CodeInterpreter *CodeInterpreterResources `json:"code_interpreter,omitempty"`
+		FileSearch      *FileSearchResources      `json:"file_search,omitempty"`
+	}
+
+	// CodeInterpreterResources are the files available to the code_interpreter tool.
+	CodeInterpreterResources struct {
+		FileIDs []string `json:"file_ids,omitempty"`
+	}
+
+	// FileSearchResources are the vector stores searched by the file_search tool.
+	FileSearchResources struct {
+		VectorStoreIDs []string `json:"vector_store_ids,omitempty"`
+	}
+
+	// ListOptions paginates the listing endpoints of the Assistants API.
+	ListOptions struct {
+		// Limit is the page size, between 1 and 100.
+		Limit int
+		// Order is "asc" or "desc" by creation time.
+		Order string
+		// After and Before are object IDs delimiting the page.
+		After  string
+		Before string
+	}
+
+	// List is a page of objects of a listing endpoint.
+	List[T any] struct {
+		ResponseMeta
+		Object  string `json:"object"`
+		Data    []T    `json:"data"`
+		FirstID string `json:"first_id"`
+		LastID  string `json:"last_id"`
+		HasMore bool   `json:"has_more"`
+	}
+)
+
+// CreateAssistant creates an assistant.
+func (c *Client) CreateAssistant(ctx context.Context, in AssistantRequest) (*Assistant, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	in.Model = c.resolveModel(in.Model)
+	c.deprecations.check(in.Model)
+
+	var assistant Assistant
+	if err := c.post(ctx, c.url(EndpointAssistants), in, &assistant); err != nil {
+		return nil, err
+	}
+	return &assistant, nil
+}
+
+// ListAssistants lists the assistants.
+func (c *Client) ListAssistants(ctx context.Context, opts ListOptions) (*List[Assistant], error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var list List[Assistant]
+	if err := c.get(ctx, withQuery(c.url(EndpointAssistants), opts.query()), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetAssistant retrieves the assistant.
+func (c *Client) GetAssistant(ctx context.Context, id string) (*Assistant, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var assistant Assistant
+	if err := c.get(ctx, c.assistantURL(id), &assistant); err != nil {
+		return nil, err
+	}
+	return &assistant, nil
+}
+
+// ModifyAssistant modifies the set fields of the assistant.
+func (c *Client) ModifyAssistant(ctx context.Context, id string, in AssistantRequest) (*Assistant, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	if in.Model != "" {
+		in.Model = c.resolveModel(in.Model)
+		c.deprecations.check(in.Model)
+	}
+
+	var assistant Assistant
+	if err := c.post(ctx, c.assistantURL(id), in, &assistant); err != nil {
+		return nil, err
+	}
+	return &assistant, nil
+}
+
+// DeleteAssistant deletes the assistant.
+func (c *Client) DeleteAssistant(ctx context.Context, id string) (*DeletedObject, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var deleted DeletedObject
+	if err := c.delete(ctx, c.assistantURL(id), &deleted); err != nil {
+		return nil, err
+	}
+	return &deleted, nil
+}
+
+// assistantURL returns the URL of the assistant.
+func (c *Client) assistantURL(id string) string {
+	return c.url(EndpointAssistants) + "/" + url.PathEscape(id)
+}
+
+// query returns the pagination query.
+func (o ListOptions) query() url.Values {
+	query := url.Values{}
+	if o.Limit != 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Order != "" {
+		query.Set("order", o.Order)
+	}
+	if o.After != "" {
+		query.Set("after", o.After)
+	}
+	if o.Before != "" {
+		query.Set("before", o.Before)
+	}
+	return query
+}
+
+// withQuery returns the endpoint URL with the query, if any.
+func withQuery(endpoint string, query url.Values) string {
+	if len(query) == 0 {
+		return endpoint
+	}
+	return endpoint + "?" + query.Encode()
+}
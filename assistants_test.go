@@ -0,0 +1,102 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// routeClient serves the responses by method and path, e.g. "GET /v1/assistants",
+// recording the decoded JSON body of every request.
+func routeClient(t *testing.T, routes map[string]any, bodies map[string]map[string]any) *mockHTTPClient {
+	return &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, BetaAssistantsV2, req.Header.Get("OpenAI-Beta"))
+
+			route := req.Method + " " + req.URL.Path
+			if req.URL.RawQuery != "" {
+				route += "?" + req.URL.RawQuery
+			}
+
+			if req.Body != nil && bodies != nil {
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+				bodies[route] = body
+			}
+
+			payload, ok := routes[route]
+			require.True(t, ok, "unexpected request %s", route)
+			return jsonResponse(t, payload), nil
+		},
+	}
+}
+
+func TestClient_Assistants(t *testing.T) {
+	t.Parallel()
+
+	bodies := map[string]map[string]any{}
+	client := New("test_api_key", routeClient(t, map[string]any{
+		"POST /v1/assistants":          Assistant{ID: "asst_1", Model: "gpt-4o"},
+		"GET /v1/assistants?limit=2":   List[Assistant]{Data: []Assistant{{ID: "asst_1"}, {ID: "asst_2"}}, HasMore: true},
+		"GET /v1/assistants/asst_1":    Assistant{ID: "asst_1", Name: "Support"},
+		"POST /v1/assistants/asst_1":   Assistant{ID: "asst_1", Name: "Support v2"},
+		"DELETE /v1/assistants/asst_1": DeletedObject{ID: "asst_1", Deleted: true},
+	}, bodies), WithBeta(BetaAssistants), WithModelAliases(NewModelAliases(map[string]string{"chat": "gpt-4o"})))
+
+	ctx := context.Background()
+
+	assistant, err := client.CreateAssistant(ctx, AssistantRequest{
+		Model:        "chat",
+		Name:         "Support",
+		Instructions: "Answer from the docs.",
+		Tools: []AssistantTool{
+			{Type: AssistantToolFileSearch, FileSearch: &FileSearchOptions{MaxNumResults: 5}},
+			{Type: AssistantToolFunction, Function: &FunctionDefinition{Name: "open_ticket"}},
+		},
+		ToolResources: &ToolResources{FileSearch: &FileSearchResources{VectorStoreIDs: []string{"vs_1"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "asst_1", assistant.ID)
+	assert.Equal(t, map[string]any{
+		"model":        "gpt-4o",
+		"name":         "Support",
+		"instructions": "Answer from the docs.",
+		"tools": []any{
+			map[string]any{"type": "file_search", "file_search": map[string]any{"max_num_results": float64(5)}},
+			map[string]any{"type": "function", "function": map[string]any{"name": "open_ticket"}},
+		},
+		"tool_resources": map[string]any{"file_search": map[string]any{"vector_store_ids": []any{"vs_1"}}},
+	}, bodies["POST /v1/assistants"])
+
+	list, err := client.ListAssistants(ctx, ListOptions{Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, list.Data, 2)
+	assert.True(t, list.HasMore)
+
+	assistant, err = client.GetAssistant(ctx, "asst_1")
+	require.NoError(t, err)
+	assert.Equal(t, "Support", assistant.Name)
+
+	assistant, err = client.ModifyAssistant(ctx, "asst_1", AssistantRequest{Name: "Support v2"})
+	require.NoError(t, err)
+	assert.Equal(t, "Support v2", assistant.Name)
+	assert.Equal(t, map[string]any{"name": "Support v2"}, bodies["POST /v1/assistants/asst_1"])
+
+	deleted, err := client.DeleteAssistant(ctx, "asst_1")
+	require.NoError(t, err)
+	assert.True(t, deleted.Deleted)
+}
+
+func TestListOptions_Query(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "https://example.com/items", withQuery("https://example.com/items", ListOptions{}.query()))
+	assert.Equal(t,
+		"https://example.com/items?after=b&before=c&limit=10&order=desc",
+		withQuery("https://example.com/items", ListOptions{Limit: 10, Order: "desc", After: "b", Before: "c"}.query()),
+	)
+}
@@ -0,0 +1,141 @@
+package openaiclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Audio response formats. AudioFormatJSON and AudioFormatVerboseJSON are decoded,
+// the other formats are returned as is in the response Text.
+const (
+	AudioFormatJSON        = "json"
+	AudioFormatText        = "text"
+	AudioFormatSRT         = "srt"
+	AudioFormatVTT         = "vtt"
+	AudioFormatVerboseJSON = "verbose_json"
+)
+
+type (
+	// TranscriptionRequest is the request for the transcription endpoint.
+	TranscriptionRequest struct {
+		Model string
+		// File is the audio to transcribe, such as an mp3, m4a or wav file.
+		File InputFile
+		// Language is the ISO-639-1 language of the audio, e.g. "en", improving accuracy and latency.
+		Language string
+		// Prompt guides the style of the transcript or continues a previous segment.
+		Prompt         string
+		ResponseFormat string
+		Temperature    *float64
+		// TimestampGranularities are "segment" and/or "word", with AudioFormatVerboseJSON only.
+		TimestampGranularities []string
+	}
+
+	// TranslationRequest is the request for the translation endpoint,
+	// which transcribes the audio in English.
+	TranslationRequest struct {
+		Model          string
+		File           InputFile
+		Prompt         string
+		ResponseFormat string
+		Temperature    *float64
+	}
+
+	// AudioResponse is the response of the transcription and translation endpoints.
+	// Language, Duration, Segments and Words are only set with AudioFormatVerboseJSON.
+	AudioResponse struct {
+		ResponseMeta
+		Text     string         `json:"text"`
+		Language string         `json:"language,omitempty"`
+		Duration float64        `json:"duration,omitempty"`
+		Segments []AudioSegment `json:"segments,omitempty"`
+		Words    []AudioWord    `json:"words,omitempty"`
+	}
+
+	// AudioSegment is a timed segment of a transcript.
+	AudioSegment struct {
+		ID               int     `json:"id"`
+		Seek             int     `json:"seek"`
+		Start            float64 `json:"start"`
+		End              float64 `json:"end"`
+		Text             string  `json:"text"`
+		Tokens           []int   `json:"tokens"`
+		Temperature      float64 `json:"temperature"`
+		AvgLogprob       float64 `json:"avg_logprob"`
+		CompressionRatio float64 `json:"compression_ratio"`
+		NoSpeechProb     float64 `json:"no_speech_prob"`
+	}
+
+	// AudioWord is a timed word of a transcript.
+	AudioWord struct {
+		Word  string  `json:"word"`
+		Start float64 `json:"start"`
+		End   float64 `json:"end"`
+	}
+)
+
+// CreateTranscription transcribes the audio in its language.
+func (c *Client) CreateTranscription(ctx context.Context, in TranscriptionRequest) (*AudioResponse, error) {
+	in.Model = c.resolveModel(in.Model)
+	c.deprecations.check(in.Model)
+
+	form := newMultipartForm()
+	form.field("model", in.Model)
+	form.file("file", in.File)
+	form.field("language", in.Language)
+	form.field("prompt", in.Prompt)
+	form.field("response_format", in.ResponseFormat)
+	form.floatField("temperature", in.Temperature)
+	for _, granularity := range in.TimestampGranularities {
+		form.field("timestamp_granularities[]", granularity)
+	}
+	return c.postAudio(ctx, c.url(EndpointTranscriptions), form, in.ResponseFormat)
+}
+
+// CreateTranslation transcribes the audio in English.
+func (c *Client) CreateTranslation(ctx context.Context, in TranslationRequest) (*AudioResponse, error) {
+	in.Model = c.resolveModel(in.Model)
+	c.deprecations.check(in.Model)
+
+	form := newMultipartForm()
+	form.field("model", in.Model)
+	form.file("file", in.File)
+	form.field("prompt", in.Prompt)
+	form.field("response_format", in.ResponseFormat)
+	form.floatField("temperature", in.Temperature)
+	return c.postAudio(ctx, c.url(EndpointTranslations), form, in.ResponseFormat)
+}
+
+// postAudio sends the audio form and decodes the response according to its format.
+func (c *Client) postAudio(ctx context.Context, url string, form *multipartForm, format string) (*AudioResponse, error) {
+	var audioResp AudioResponse
+
+	switch format {
+	case "", AudioFormatJSON, AudioFormatVerboseJSON:
+		if err := c.postForm(ctx, url, form, &audioResp); err != nil {
+			return nil, err
+		}
+		return &audioResp, nil
+	}
+
+	resp, err := c.sendForm(ctx, url, form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	text, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response: %w", err)
+	}
+
+	audioResp.Text = string(text)
+	audioResp.setHeader(c.headerPolicy.capture(resp.Header))
+	return &audioResp, nil
+}
@@ -0,0 +1,145 @@
+package openaiclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateTranscription(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		format   string
+		response *http.Response
+		want     *AudioResponse
+	}{
+		{
+			name:   "json",
+			format: AudioFormatJSON,
+			response: jsonResponse(t, AudioResponse{
+				Text: "Hello, how can I help?",
+			}),
+			want: &AudioResponse{Text: "Hello, how can I help?"},
+		},
+		{
+			name:   "verbose json",
+			format: AudioFormatVerboseJSON,
+			response: jsonResponse(t, AudioResponse{
+				Text:     "Hello.",
+				Language: "english",
+				Duration: 1.5,
+				Segments: []AudioSegment{{Start: 0, End: 1.5, Text: "Hello."}},
+			}),
+			want: &AudioResponse{
+				Text:     "Hello.",
+				Language: "english",
+				Duration: 1.5,
+				Segments: []AudioSegment{{Start: 0, End: 1.5, Text: "Hello."}},
+			},
+		},
+		{
+			name:   "srt",
+			format: AudioFormatSRT,
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("1\n00:00:00,000 --> 00:00:01,500\nHello.\n")),
+			},
+			want: &AudioResponse{Text: "1\n00:00:00,000 --> 00:00:01,500\nHello.\n"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, DefaultBaseURL+EndpointTranscriptions, req.URL.String())
+					require.NoError(t, req.ParseMultipartForm(1<<20))
+
+					assert.Equal(t, "whisper-1", req.FormValue("model"))
+					assert.Equal(t, "pt", req.FormValue("language"))
+					assert.Equal(t, "0.2", req.FormValue("temperature"))
+					assert.Equal(t, tc.format, req.FormValue("response_format"))
+
+					require.Len(t, req.MultipartForm.File["file"], 1)
+					assert.Equal(t, "call.mp3", req.MultipartForm.File["file"][0].Filename)
+					assert.Equal(t, "audio", readFormFile(t, req.MultipartForm.File["file"][0]))
+
+					return tc.response, nil
+				},
+			})
+
+			resp, err := client.CreateTranscription(context.Background(), TranscriptionRequest{
+				Model:          "whisper-1",
+				File:           InputFile{Name: "call.mp3", Data: strings.NewReader("audio")},
+				Language:       "pt",
+				ResponseFormat: tc.format,
+				Temperature:    Ptr(0.2),
+			})
+			require.NoError(t, err)
+
+			resp.Header = nil
+			assert.Equal(t, tc.want, resp)
+		})
+	}
+}
+
+func TestClient_CreateTranscription_APIError(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"error":{"message":"invalid file format"}}`)),
+			}, nil
+		},
+	})
+
+	_, err := client.CreateTranscription(context.Background(), TranscriptionRequest{
+		File:           InputFile{Name: "call.txt", Data: strings.NewReader("text")},
+		ResponseFormat: AudioFormatText,
+	})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "invalid file format", apiErr.Message)
+}
+
+func TestClient_CreateTranslation(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, DefaultBaseURL+EndpointTranslations, req.URL.String())
+			require.NoError(t, req.ParseMultipartForm(1<<20))
+
+			assert.Equal(t, AudioFormatText, req.FormValue("response_format"))
+			assert.Empty(t, req.MultipartForm.Value["temperature"])
+			assert.Empty(t, req.MultipartForm.Value["language"])
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("Good morning.")),
+			}, nil
+		},
+	})
+
+	resp, err := client.CreateTranslation(context.Background(), TranslationRequest{
+		Model:          "whisper-1",
+		File:           InputFile{Name: "call.mp3", Data: strings.NewReader("audio")},
+		ResponseFormat: AudioFormatText,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Good morning.", resp.Text)
+}
@@ -0,0 +1,76 @@
+package openaiclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// AzureAPIKeyHeader is the header carrying the API key with Azure OpenAI.
+const AzureAPIKeyHeader = "api-key"
+
+// azureDeploymentEndpoints are the endpoints served by a model deployment with
+// Azure OpenAI; the others, such as files and assistants, belong to the resource.
+var azureDeploymentEndpoints = toSet([]string{
+	EndpointChatCompletions,
+	EndpointCompletions,
+	EndpointEmbeddings,
+	EndpointImagesGenerations,
+	EndpointTranscriptions,
+	EndpointTranslations,
+	EndpointSpeech,
+})
+
+// azureDeployment routes requests to an Azure OpenAI deployment.
+type azureDeployment struct {
+	// resourceURL is the base URL of the resource APIs, e.g. "https://contoso.openai.azure.com/openai".
+	resourceURL string
+	// deploymentURL is the base URL of the deployment APIs.
+	deploymentURL string
+}
+
+// WithAzure targets the given Azure OpenAI deployment, such as
+// WithAzure("https://contoso.openai.azure.com", "gpt-4o-prod", "2024-10-21").
+// Requests are authenticated with the api-key header and routed to
+// /openai/deployments/{deployment}/..., with the api-version query parameter.
+// The deployment determines the model, so the request model is ignored by Azure.
+// Invalid endpoints, deployments and API versions make every request fail.
+func WithAzure(endpoint, deployment, apiVersion string) Option {
+	return func(c *Client) {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+			c.optionErr = fmt.Errorf("invalid azure endpoint: %q", endpoint)
+			return
+		}
+		if deployment == "" {
+			c.optionErr = fmt.Errorf("missing azure deployment")
+			return
+		}
+
+		WithAPIVersion(apiVersion)(c)
+
+		resourceURL := strings.TrimRight(endpoint, "/") + "/openai"
+		c.azure = &azureDeployment{
+			resourceURL:   resourceURL,
+			deploymentURL: resourceURL + "/deployments/" + url.PathEscape(deployment),
+		}
+	}
+}
+
+// baseURL returns the base URL of the given endpoint.
+func (d *azureDeployment) baseURL(endpoint string) string {
+	if _, ok := azureDeploymentEndpoints[endpoint]; ok {
+		return d.deploymentURL
+	}
+	return d.resourceURL
+}
+
+// setAuthHeader authenticates the request with the given key.
+func (c *Client) setAuthHeader(req *http.Request, key string) {
+	if c.azure != nil {
+		req.Header.Set(AzureAPIKeyHeader, key)
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
+}
@@ -0,0 +1,69 @@
+package openaiclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAzure(t *testing.T) {
+	t.Parallel()
+
+	var reqs []*http.Request
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			reqs = append(reqs, req)
+			return jsonResponse(t, map[string]any{}), nil
+		},
+	}, WithAzure("https://contoso.openai.azure.com/", "gpt-4o prod", "2024-10-21"))
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	_, err = client.ListFiles(context.Background(), FileListOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, reqs, 2)
+	assert.Equal(t, "https://contoso.openai.azure.com/openai/deployments/gpt-4o%20prod/chat/completions?api-version=2024-10-21", reqs[0].URL.String())
+	assert.Equal(t, "https://contoso.openai.azure.com/openai/files?api-version=2024-10-21", reqs[1].URL.String())
+
+	for _, req := range reqs {
+		assert.Equal(t, "test_api_key", req.Header.Get(AzureAPIKeyHeader))
+		assert.Empty(t, req.Header.Get("Authorization"))
+	}
+}
+
+func TestWithAzure_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		endpoint   string
+		deployment string
+		apiVersion string
+	}{
+		{name: "relative endpoint", endpoint: "contoso.openai.azure.com", deployment: "gpt-4o", apiVersion: "2024-10-21"},
+		{name: "missing deployment", endpoint: "https://contoso.openai.azure.com", apiVersion: "2024-10-21"},
+		{name: "unknown api version", endpoint: "https://contoso.openai.azure.com", deployment: "gpt-4o", apiVersion: "2020-01-01"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					t.Error("request must not be sent")
+					return nil, nil
+				},
+			}, WithAzure(tt.endpoint, tt.deployment, tt.apiVersion))
+
+			_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+			assert.Error(t, err)
+		})
+	}
+}
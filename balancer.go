@@ -0,0 +1,76 @@
+package openaiclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync/atomic"
+)
+
+type (
+	// Backend is a client served by a Balancer, such as a client of another
+	// API key or Azure deployment.
+	Backend struct {
+		// Name identifies the backend and, with sticky routing, keys the hash
+		// of the conversations routed to it. Names must be unique.
+		Name   string
+		Client *Client
+	}
+
+	// BalancerOption configures a Balancer.
+	BalancerOption func(*Balancer)
+
+	// Balancer spreads chat traffic across backends, in turn by default.
+	Balancer struct {
+		backends []Backend
+		sticky   bool
+		next     atomic.Uint64
+	}
+)
+
+// NewBalancer creates a balancer over the backends, which must not be empty.
+func NewBalancer(backends []Backend, opts ...BalancerOption) *Balancer {
+	b := &Balancer{backends: backends}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// WithStickyRouting routes the requests of a conversation to the same backend,
+// by hash of the conversation ID, so repeated prompt prefixes hit the prompt
+// cache of the backend. Rendezvous hashing moves only the conversations of a
+// backend when it is added or removed. Requests without a conversation ID are
+// still sent in turn.
+func WithStickyRouting() BalancerOption {
+	return func(b *Balancer) {
+		b.sticky = true
+	}
+}
+
+// Pick returns the backend of the next request of the conversation, whose ID may be empty.
+func (b *Balancer) Pick(conversationID string) Backend {
+	if !b.sticky || conversationID == "" {
+		return b.backends[(b.next.Add(1)-1)%uint64(len(b.backends))]
+	}
+
+	var (
+		best  Backend
+		score uint64
+	)
+	for i, backend := range b.backends {
+		sum := sha256.Sum256([]byte(backend.Name + "\x00" + conversationID))
+		if s := binary.BigEndian.Uint64(sum[:]); i == 0 || s > score {
+			best, score = backend, s
+		}
+	}
+	return best
+}
+
+// CreateChatCompletion sends the request of the conversation to the backend
+// picked for it and returns the response along with the name of the backend.
+func (b *Balancer) CreateChatCompletion(ctx context.Context, conversationID string, in ChatCompletionRequest) (*ChatCompletionResponse, string, error) {
+	backend := b.Pick(conversationID)
+	resp, err := backend.Client.CreateChatCompletion(ctx, in)
+	return resp, backend.Name, err
+}
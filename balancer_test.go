@@ -0,0 +1,84 @@
+package openaiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBackends returns the named backends, answering with their name as the model.
+func testBackends(t *testing.T, names ...string) []Backend {
+	backends := make([]Backend, 0, len(names))
+	for _, name := range names {
+		name := name
+		backends = append(backends, Backend{Name: name, Client: New("key_"+name, &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return jsonResponse(t, ChatCompletionResponse{Model: name}), nil
+			},
+		})})
+	}
+	return backends
+}
+
+func TestBalancer_RoundRobin(t *testing.T) {
+	t.Parallel()
+
+	balancer := NewBalancer(testBackends(t, "a", "b", "c"))
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		got = append(got, balancer.Pick("conv-1").Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "a"}, got)
+}
+
+func TestBalancer_StickyRouting(t *testing.T) {
+	t.Parallel()
+
+	balancer := NewBalancer(testBackends(t, "a", "b", "c"), WithStickyRouting())
+
+	routed := map[string]string{}
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("conv-%d", i)
+		routed[id] = balancer.Pick(id).Name
+
+		for j := 0; j < 3; j++ {
+			require.Equal(t, routed[id], balancer.Pick(id).Name, "conversations must stick to their backend")
+		}
+	}
+
+	counts := map[string]int{}
+	for _, name := range routed {
+		counts[name]++
+	}
+	assert.Len(t, counts, 3, "conversations must be spread across backends")
+
+	// Removing a backend only moves its conversations.
+	shrunk := NewBalancer(testBackends(t, "a", "b"), WithStickyRouting())
+	for id, name := range routed {
+		if name != "c" {
+			assert.Equal(t, name, shrunk.Pick(id).Name)
+		}
+	}
+
+	// Requests without a conversation are sent in turn.
+	assert.Equal(t, "a", balancer.Pick("").Name)
+	assert.Equal(t, "b", balancer.Pick("").Name)
+}
+
+func TestBalancer_CreateChatCompletion(t *testing.T) {
+	t.Parallel()
+
+	balancer := NewBalancer(testBackends(t, "a", "b"), WithStickyRouting())
+
+	want := balancer.Pick("conv-1").Name
+	resp, name, err := balancer.CreateChatCompletion(context.Background(), "conv-1", ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	assert.Equal(t, want, name)
+	assert.Equal(t, want, resp.Model)
+}
@@ -0,0 +1,93 @@
+package openaiclient
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// batchErrorSample is the number of item errors detailed in a BatchError message.
+const batchErrorSample = 3
+
+// BatchItemError is the failure of an item of a batch helper.
+type BatchItemError struct {
+	// Index is the index of the item in the input of the helper.
+	Index int
+	Err   error
+}
+
+// Error implements the error interface.
+func (e BatchItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error of the item.
+func (e BatchItemError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError aggregates the failures of the items of a batch helper, such as
+// GenerateImages or ModerateAll. errors.Is and errors.As match the error of
+// any item, and errors.As also matches the BatchError itself.
+type BatchError struct {
+	// Op is the operation of the helper, such as "generate images".
+	Op string
+	// Items are the failed items, by increasing index.
+	Items []BatchItemError
+	// Total is the number of items of the batch.
+	Total int
+}
+
+// newBatchError returns the BatchError of the failed items, or nil if none failed.
+func newBatchError(op string, total int, items []BatchItemError) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Index < items[j].Index
+	})
+	return &BatchError{Op: op, Items: items, Total: total}
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	details := make([]string, 0, batchErrorSample+1)
+	for i, item := range e.Items {
+		if i == batchErrorSample {
+			details = append(details, fmt.Sprintf("and %d more", len(e.Items)-batchErrorSample))
+			break
+		}
+		details = append(details, item.Error())
+	}
+	return fmt.Sprintf("could not %s: %d of %d items failed: %s", e.Op, len(e.Items), e.Total, strings.Join(details, "; "))
+}
+
+// Unwrap returns the errors of the failed items, for errors.Is and errors.As.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Items))
+	for _, item := range e.Items {
+		errs = append(errs, item)
+	}
+	return errs
+}
+
+// Indexes returns the indexes of the failed items, in increasing order.
+func (e *BatchError) Indexes() []int {
+	indexes := make([]int, 0, len(e.Items))
+	for _, item := range e.Items {
+		indexes = append(indexes, item.Index)
+	}
+	return indexes
+}
+
+// Err returns the error of the item at the index, or nil if it didn't fail.
+func (e *BatchError) Err(index int) error {
+	i := sort.Search(len(e.Items), func(i int) bool {
+		return e.Items[i].Index >= index
+	})
+	if i < len(e.Items) && e.Items[i].Index == index {
+		return e.Items[i].Err
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBatchError(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, newBatchError("embed texts", 3, nil))
+
+	err := newBatchError("embed texts", 10, []BatchItemError{
+		{Index: 7, Err: context.DeadlineExceeded},
+		{Index: 2, Err: ErrBudgetExceeded},
+	})
+
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, []int{2, 7}, batchErr.Indexes())
+	assert.Equal(t, ErrBudgetExceeded, batchErr.Err(2))
+	assert.Equal(t, context.DeadlineExceeded, batchErr.Err(7))
+	assert.NoError(t, batchErr.Err(3))
+
+	assert.ErrorIs(t, err, ErrBudgetExceeded)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, errors.Is(err, context.Canceled))
+
+	var itemErr BatchItemError
+	require.ErrorAs(t, err, &itemErr)
+	assert.Equal(t, 2, itemErr.Index)
+}
+
+func TestBatchError_Error(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		items []BatchItemError
+		want  string
+	}{
+		{
+			name:  "single failure",
+			items: []BatchItemError{{Index: 1, Err: errors.New("boom")}},
+			want:  "could not embed texts: 1 of 10 items failed: item 1: boom",
+		},
+		{
+			name: "many failures",
+			items: []BatchItemError{
+				{Index: 0, Err: errors.New("a")},
+				{Index: 1, Err: errors.New("b")},
+				{Index: 2, Err: errors.New("c")},
+				{Index: 3, Err: errors.New("d")},
+				{Index: 4, Err: errors.New("e")},
+			},
+			want: "could not embed texts: 5 of 10 items failed: item 0: a; item 1: b; item 2: c; and 2 more",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := &BatchError{Op: "embed texts", Items: tt.items, Total: 10}
+			assert.Equal(t, tt.want, err.Error())
+		})
+	}
+}
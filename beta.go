@@ -0,0 +1,55 @@
+package openaiclient
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrBetaNotEnabled is returned when calling a beta API the client wasn't opted into with WithBeta.
+var ErrBetaNotEnabled = errors.New("beta api not enabled")
+
+// BetaAPI is an unstable API surface, whose methods only work once it's
+// enabled with WithBeta.
+type BetaAPI string
+
+// Beta APIs, valued with their OpenAI-Beta flag.
+const (
+	// BetaAssistants gates the Assistants API: assistants, threads, messages,
+	// runs, run steps and vector stores.
+	BetaAssistants BetaAPI = BetaAssistantsV2
+	// BetaRealtime gates the Realtime API.
+	BetaRealtime BetaAPI = BetaRealtimeV1
+)
+
+// WithBeta opts the client into the given beta APIs, sending their OpenAI-Beta
+// flags. Calling the methods of a beta API that wasn't enabled fails with
+// ErrBetaNotEnabled, so the unstable surfaces an application depends on show
+// in its client configuration.
+func WithBeta(apis ...BetaAPI) Option {
+	features := make([]string, 0, len(apis))
+	for _, api := range apis {
+		features = append(features, string(api))
+	}
+	return WithBetaFeatures(features...)
+}
+
+// requireBeta returns an error unless the beta API is enabled.
+func (c *Client) requireBeta(api BetaAPI) error {
+	for _, feature := range c.betaFeatures {
+		if feature == string(api) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: add WithBeta(%s) to the client options", ErrBetaNotEnabled, api.name())
+}
+
+// name returns the Go name of the API.
+func (api BetaAPI) name() string {
+	switch api {
+	case BetaAssistants:
+		return "BetaAssistants"
+	case BetaRealtime:
+		return "BetaRealtime"
+	}
+	return fmt.Sprintf("%q", string(api))
+}
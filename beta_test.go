@@ -0,0 +1,67 @@
+package openaiclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithBeta(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		opts       []Option
+		wantHeader string
+		wantErr    error
+	}{
+		{
+			name:    "not enabled",
+			wantErr: ErrBetaNotEnabled,
+		},
+		{
+			name:    "other beta enabled",
+			opts:    []Option{WithBeta(BetaRealtime)},
+			wantErr: ErrBetaNotEnabled,
+		},
+		{
+			name:       "enabled",
+			opts:       []Option{WithBeta(BetaRealtime, BetaAssistants)},
+			wantHeader: "realtime=v1,assistants=v2",
+		},
+		{
+			name:       "enabled through the feature flag",
+			opts:       []Option{WithBetaFeatures(BetaAssistantsV2)},
+			wantHeader: "assistants=v2",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					calls++
+					assert.Equal(t, tc.wantHeader, req.Header.Get("OpenAI-Beta"))
+					return jsonResponse(t, Thread{ID: "thread_1"}), nil
+				},
+			}, tc.opts...)
+
+			_, err := client.GetThread(context.Background(), "thread_1")
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Contains(t, err.Error(), "WithBeta(BetaAssistants)")
+				assert.Zero(t, calls)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 1, calls)
+		})
+	}
+}
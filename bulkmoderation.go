@@ -0,0 +1,206 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Bulk moderation defaults.
+const (
+	DefaultModerationBatchSize   = 32
+	DefaultModerationConcurrency = 4
+)
+
+type (
+	// BulkModerationOption configures ModerateAll.
+	BulkModerationOption func(*bulkModerationConfig)
+
+	bulkModerationConfig struct {
+		model       string
+		batchSize   int
+		concurrency int
+		retry       RetryPolicy
+	}
+
+	// ModerationReport aggregates the moderation of many texts.
+	ModerationReport struct {
+		// Results holds the result of every text, by index; results of failed
+		// batches are left zero.
+		Results []ModerationResult
+		// Flagged are the indexes of the flagged texts.
+		Flagged []int
+		// ByCategory are the indexes of the flagged texts by category.
+		ByCategory map[string][]int
+		// Failed are the batches that failed after all retries.
+		Failed []ModerationBatchError
+	}
+
+	// ModerationBatchError is the failure of the batch of texts [Start, End).
+	ModerationBatchError struct {
+		Start int
+		End   int
+		Err   error
+	}
+)
+
+// Error implements the error interface.
+func (e ModerationBatchError) Error() string {
+	return fmt.Sprintf("could not moderate texts %d to %d: %v", e.Start, e.End-1, e.Err)
+}
+
+// Unwrap returns the error of the batch.
+func (e ModerationBatchError) Unwrap() error {
+	return e.Err
+}
+
+// WithModerationModel sets the moderation model.
+func WithModerationModel(model string) BulkModerationOption {
+	return func(c *bulkModerationConfig) {
+		c.model = model
+	}
+}
+
+// WithModerationBatchSize sets the number of texts sent per request.
+func WithModerationBatchSize(n int) BulkModerationOption {
+	return func(c *bulkModerationConfig) {
+		c.batchSize = n
+	}
+}
+
+// WithModerationConcurrency sets the number of requests in flight.
+func WithModerationConcurrency(n int) BulkModerationOption {
+	return func(c *bulkModerationConfig) {
+		c.concurrency = n
+	}
+}
+
+// WithModerationRetry sets how failed batches are retried, DefaultRetryPolicy by default.
+// Batches are retried on top of the client's own retry policy, also on network errors.
+func WithModerationRetry(policy RetryPolicy) BulkModerationOption {
+	return func(c *bulkModerationConfig) {
+		c.retry = policy
+	}
+}
+
+// ModerateAll moderates the texts in concurrent batches, retrying failed batches,
+// and reports the flagged texts by category. Batches failing after all retries
+// are reported in the report, which is returned along with a BatchError holding
+// the error of the batch of every failed text.
+func (c *Client) ModerateAll(ctx context.Context, texts []string, opts ...BulkModerationOption) (*ModerationReport, error) {
+	cfg := bulkModerationConfig{
+		batchSize:   DefaultModerationBatchSize,
+		concurrency: DefaultModerationConcurrency,
+		retry:       DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.batchSize < 1 || cfg.concurrency < 1 {
+		return nil, fmt.Errorf("could not moderate texts: batch size and concurrency must be positive")
+	}
+
+	report := ModerationReport{
+		Results:    make([]ModerationResult, len(texts)),
+		ByCategory: make(map[string][]int),
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, cfg.concurrency)
+	)
+
+batches:
+	for start := 0; start < len(texts); start += cfg.batchSize {
+		end := min(start+cfg.batchSize, len(texts))
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			// The remaining texts are reported as a single failed batch.
+			mu.Lock()
+			report.Failed = append(report.Failed, ModerationBatchError{Start: start, End: len(texts), Err: ctx.Err()})
+			mu.Unlock()
+			break batches
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results, err := c.moderateBatch(ctx, cfg, texts[start:end])
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				report.Failed = append(report.Failed, ModerationBatchError{Start: start, End: end, Err: err})
+				return
+			}
+			copy(report.Results[start:end], results)
+		}(start, end)
+	}
+	wg.Wait()
+
+	for i, result := range report.Results {
+		if !result.Flagged {
+			continue
+		}
+
+		report.Flagged = append(report.Flagged, i)
+		for _, category := range result.FlaggedCategories() {
+			report.ByCategory[category] = append(report.ByCategory[category], i)
+		}
+	}
+
+	sort.Slice(report.Failed, func(i, j int) bool {
+		return report.Failed[i].Start < report.Failed[j].Start
+	})
+
+	var failed []BatchItemError
+	for _, batch := range report.Failed {
+		for i := batch.Start; i < batch.End; i++ {
+			failed = append(failed, BatchItemError{Index: i, Err: batch})
+		}
+	}
+	return &report, newBatchError("moderate texts", len(texts), failed)
+}
+
+// moderateBatch moderates the texts, retrying according to the policy.
+func (c *Client) moderateBatch(ctx context.Context, cfg bulkModerationConfig, texts []string) ([]ModerationResult, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.CreateModeration(ctx, ModerationRequest{Model: cfg.model, Input: texts})
+		if err == nil {
+			if len(resp.Results) != len(texts) {
+				return nil, fmt.Errorf("could not moderate texts: got %d results for %d texts", len(resp.Results), len(texts))
+			}
+			return resp.Results, nil
+		}
+
+		if !retryableBatchError(err) || attempt >= cfg.retry.MaxAttempts || ctx.Err() != nil {
+			return nil, err
+		}
+
+		if err := c.sleep(ctx, cfg.retry.delay(attempt, nil)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryableBatchError reports whether a failed batch may succeed when retried.
+func retryableBatchError(err error) bool {
+	if errors.Is(err, ErrBudgetExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return isRetryableStatus(apiErr.StatusCode) && apiErr.Code != "insufficient_quota"
+	}
+	return true
+}
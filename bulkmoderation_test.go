@@ -0,0 +1,140 @@
+package openaiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// moderationResponse flags the texts containing "hate" or "kill".
+func moderationResponse(t *testing.T, texts []string) *http.Response {
+	t.Helper()
+
+	results := make([]ModerationResult, 0, len(texts))
+	for _, text := range texts {
+		categories := map[string]bool{
+			CategoryHate:     strings.Contains(text, "hate"),
+			CategoryViolence: strings.Contains(text, "kill"),
+		}
+		results = append(results, ModerationResult{
+			Flagged:    categories[CategoryHate] || categories[CategoryViolence],
+			Categories: categories,
+		})
+	}
+	return jsonResponse(t, ModerationResponse{Results: results})
+}
+
+func TestClient_ModerateAll(t *testing.T) {
+	t.Parallel()
+
+	texts := []string{"hello", "I hate you", "nice day", "kill them", "hate and kill", "bye", "ok"}
+
+	var (
+		mu       sync.Mutex
+		batches  [][]string
+		failures = map[string]int{"nice day": 1}
+	)
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in ModerationRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			batches = append(batches, in.Input)
+
+			// The batch holding "nice day" fails once with a server error.
+			for _, text := range in.Input {
+				if failures[text] > 0 {
+					failures[text]--
+					return &http.Response{
+						StatusCode: http.StatusInternalServerError,
+						Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+					}, nil
+				}
+			}
+			return moderationResponse(t, in.Input), nil
+		},
+	})
+	client.sleep = func(context.Context, time.Duration) error { return nil }
+
+	report, err := client.ModerateAll(context.Background(), texts, WithModerationBatchSize(3), WithModerationConcurrency(2))
+	require.NoError(t, err)
+
+	assert.Len(t, batches, 4)
+	assert.Len(t, report.Results, len(texts))
+	assert.Equal(t, []int{1, 3, 4}, report.Flagged)
+	assert.Equal(t, map[string][]int{
+		CategoryHate:     {1, 4},
+		CategoryViolence: {3, 4},
+	}, report.ByCategory)
+	assert.Empty(t, report.Failed)
+}
+
+func TestClient_ModerateAll_FailedBatch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		calls = map[string]int{}
+	)
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in ModerationRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+			mu.Lock()
+			calls[in.Input[0]]++
+			mu.Unlock()
+
+			if in.Input[0] == "bad" {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"error":{"message":"invalid input"}}`)),
+				}, nil
+			}
+			return moderationResponse(t, in.Input), nil
+		},
+	})
+	client.sleep = func(context.Context, time.Duration) error { return nil }
+
+	report, err := client.ModerateAll(context.Background(), []string{"I hate you", "bad", "ok"}, WithModerationBatchSize(1))
+	require.Error(t, err)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, []int{1}, batchErr.Indexes())
+
+	// Client errors aren't retried.
+	assert.Equal(t, map[string]int{"I hate you": 1, "bad": 1, "ok": 1}, calls)
+
+	require.Len(t, report.Failed, 1)
+	assert.Equal(t, 1, report.Failed[0].Start)
+	assert.Equal(t, 2, report.Failed[0].End)
+	assert.Equal(t, []int{0}, report.Flagged)
+}
+
+func TestClient_ModerateAll_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{})
+
+	_, err := client.ModerateAll(context.Background(), []string{"hello"}, WithModerationBatchSize(0))
+	assert.Error(t, err)
+}
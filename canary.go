@@ -0,0 +1,103 @@
+package openaiclient
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type (
+	// Arm is a model configuration served by a CanaryRouter.
+	Arm struct {
+		// Name identifies the arm in the router statistics.
+		Name string
+		// Model replaces the model of routed requests, if set.
+		Model string
+		// Prepare, if set, adapts a copy of routed requests, e.g. to swap the prompt.
+		Prepare func(ChatCompletionRequest) ChatCompletionRequest
+	}
+
+	// ArmStats holds the metrics of an arm.
+	ArmStats struct {
+		Requests int
+		Errors   int
+		Usage    Usage
+		Latency  time.Duration
+	}
+
+	// CanaryRouter splits chat traffic between a control and a canary arm by
+	// percentage, enabling gradual rollouts of new models and prompts.
+	CanaryRouter struct {
+		client  *Client
+		control Arm
+		canary  Arm
+		percent float64
+		random  func() float64
+
+		mu    sync.Mutex
+		stats map[string]ArmStats
+	}
+)
+
+// NewCanaryRouter creates a router sending percent (0-100) of the traffic to the canary arm.
+func NewCanaryRouter(client *Client, control, canary Arm, percent float64) *CanaryRouter {
+	return &CanaryRouter{
+		client:  client,
+		control: control,
+		canary:  canary,
+		percent: percent,
+		random:  rand.Float64,
+		stats:   make(map[string]ArmStats, 2),
+	}
+}
+
+// CreateChatCompletion routes the request to one of the arms and returns the
+// response along with the name of the arm that served it.
+func (r *CanaryRouter) CreateChatCompletion(ctx context.Context, in ChatCompletionRequest) (*ChatCompletionResponse, string, error) {
+	arm := r.control
+	if r.random()*100 < r.percent {
+		arm = r.canary
+	}
+
+	req := in.Clone()
+	if arm.Model != "" {
+		req.Model = arm.Model
+	}
+
+	if arm.Prepare != nil {
+		req = arm.Prepare(req)
+	}
+
+	start := time.Now()
+	resp, err := r.client.CreateChatCompletion(ctx, req)
+	latency := time.Since(start)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := r.stats[arm.Name]
+	stats.Requests++
+	stats.Latency += latency
+
+	if err != nil {
+		stats.Errors++
+	} else {
+		stats.Usage = stats.Usage.add(resp.Usage)
+	}
+
+	r.stats[arm.Name] = stats
+	return resp, arm.Name, err
+}
+
+// Stats returns the metrics of every arm by name.
+func (r *CanaryRouter) Stats() map[string]ArmStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make(map[string]ArmStats, len(r.stats))
+	for name, s := range r.stats {
+		stats[name] = s
+	}
+	return stats
+}
@@ -0,0 +1,69 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanaryRouter(t *testing.T) {
+	t.Parallel()
+
+	var models []string
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in ChatCompletionRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+			models = append(models, in.Model)
+			if in.Messages[0].Content != "canary prompt" && in.Model == "canary_model" {
+				t.Errorf("canary request was not prepared: %+v", in)
+			}
+			return jsonResponse(t, ChatCompletionResponse{Usage: Usage{TotalTokens: 10}}), nil
+		},
+	})
+
+	router := NewCanaryRouter(client,
+		Arm{Name: "control", Model: "control_model"},
+		Arm{
+			Name:  "canary",
+			Model: "canary_model",
+			Prepare: func(req ChatCompletionRequest) ChatCompletionRequest {
+				req.Messages[0].Content = "canary prompt"
+				return req
+			},
+		},
+		5,
+	)
+
+	rolls := []float64{0.5, 0.01, 0.05}
+	router.random = func() float64 {
+		roll := rolls[0]
+		rolls = rolls[1:]
+		return roll
+	}
+
+	in := ChatCompletionRequest{Messages: []Message{{Role: "system", Content: "control prompt"}}}
+
+	var arms []string
+	for i := 0; i < 3; i++ {
+		_, arm, err := router.CreateChatCompletion(context.Background(), in)
+		require.NoError(t, err)
+		arms = append(arms, arm)
+	}
+
+	assert.Equal(t, []string{"control", "canary", "control"}, arms)
+	assert.Equal(t, []string{"control_model", "canary_model", "control_model"}, models)
+	assert.Equal(t, "control prompt", in.Messages[0].Content)
+
+	stats := router.Stats()
+	assert.Equal(t, 2, stats["control"].Requests)
+	assert.Equal(t, 1, stats["canary"].Requests)
+	assert.Equal(t, 10, stats["canary"].Usage.TotalTokens)
+	assert.Equal(t, 0, stats["canary"].Errors)
+}
@@ -0,0 +1,50 @@
+package openaiclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalCanonical returns the canonical JSON encoding of v, such as a request or
+// response: compact, without HTML escaping, and with the keys of every object
+// sorted, including those of raw JSON values like tool parameters and schemas.
+// Equal values always have the same encoding, which makes it suitable for
+// snapshot tests and audit hashes; use json.Indent for line-based diffs.
+func MarshalCanonical(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal value: %w", err)
+	}
+
+	// Decoding into generic values sorts the object keys when encoding them again,
+	// and json.Number keeps the numbers as they were encoded.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, fmt.Errorf("could not decode value: %w", err)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(tree); err != nil {
+		return nil, fmt.Errorf("could not marshal canonical value: %w", err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// CanonicalHash returns the hex encoded SHA-256 hash of the canonical JSON encoding of v.
+func CanonicalHash(v any) (string, error) {
+	data, err := MarshalCanonical(v)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
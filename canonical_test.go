@@ -0,0 +1,69 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalCanonical(t *testing.T) {
+	t.Parallel()
+
+	in := ChatCompletionRequest{
+		Model:            "gpt-4o",
+		Messages:         []Message{{Role: "user", Content: "Is 1 < 2 & 3 > 2?"}},
+		FrequencyPenalty: Ptr(1e-7),
+		LogitBias:        map[string]int{"50256": -100, "1": 5},
+		Tools: []Tool{{
+			Type: "function",
+			Function: FunctionDefinition{
+				Name:       "get_weather",
+				Parameters: json.RawMessage(`{"type": "object", "properties": {"unit": {}, "city": {}}}`),
+			},
+		}},
+	}
+
+	got, err := MarshalCanonical(in)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		`{"frequency_penalty":1e-7,"logit_bias":{"1":5,"50256":-100},`+
+			`"messages":[{"content":"Is 1 < 2 & 3 > 2?","role":"user"}],"model":"gpt-4o",`+
+			`"tools":[{"function":{"name":"get_weather","parameters":{"properties":{"city":{},"unit":{}},"type":"object"}},"type":"function"}]}`,
+		string(got))
+}
+
+func TestMarshalCanonical_Error(t *testing.T) {
+	t.Parallel()
+
+	_, err := MarshalCanonical(ChatCompletionRequest{Temperature: Ptr(math.Inf(1))})
+
+	assert.Error(t, err)
+}
+
+func TestCanonicalHash(t *testing.T) {
+	t.Parallel()
+
+	tool := func(parameters string) ChatCompletionRequest {
+		return ChatCompletionRequest{
+			Model: "gpt-4o",
+			Tools: []Tool{{Type: "function", Function: FunctionDefinition{Name: "f", Parameters: json.RawMessage(parameters)}}},
+		}
+	}
+
+	a, err := CanonicalHash(tool(`{"type":"object","required":["a"]}`))
+	require.NoError(t, err)
+
+	b, err := CanonicalHash(tool(`{ "required": ["a"], "type": "object" }`))
+	require.NoError(t, err)
+
+	c, err := CanonicalHash(tool(`{"type":"object","required":["b"]}`))
+	require.NoError(t, err)
+
+	assert.Len(t, a, 64)
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
@@ -0,0 +1,68 @@
+package openaiclient
+
+import "fmt"
+
+// Chunking strategy types.
+const (
+	ChunkingAuto   = "auto"
+	ChunkingStatic = "static"
+	// ChunkingOther is reported for files chunked before strategies were configurable.
+	ChunkingOther = "other"
+)
+
+// Static chunking bounds, as enforced by the API.
+const (
+	MinChunkSizeTokens = 100
+	MaxChunkSizeTokens = 4096
+)
+
+type (
+	// ChunkingStrategy sets how the files added to a vector store are split
+	// into chunks. Use AutoChunking or StaticChunkingStrategy.
+	ChunkingStrategy struct {
+		Type   string          `json:"type"`
+		Static *StaticChunking `json:"static,omitempty"`
+	}
+
+	// StaticChunking splits files into chunks of a fixed size.
+	StaticChunking struct {
+		MaxChunkSizeTokens int `json:"max_chunk_size_tokens"`
+		// ChunkOverlapTokens is the overlap between consecutive chunks, at most
+		// half of MaxChunkSizeTokens.
+		ChunkOverlapTokens int `json:"chunk_overlap_tokens"`
+	}
+)
+
+// AutoChunking lets the API choose the chunking, currently 800 token chunks overlapping by 400 tokens.
+func AutoChunking() *ChunkingStrategy {
+	return &ChunkingStrategy{Type: ChunkingAuto}
+}
+
+// StaticChunkingStrategy splits files into chunks of maxChunkSize tokens, overlapping by overlap tokens.
+func StaticChunkingStrategy(maxChunkSize, overlap int) *ChunkingStrategy {
+	return &ChunkingStrategy{
+		Type:   ChunkingStatic,
+		Static: &StaticChunking{MaxChunkSizeTokens: maxChunkSize, ChunkOverlapTokens: overlap},
+	}
+}
+
+// validate verifies a static strategy is within the API bounds, so invalid
+// strategies fail before any file is processed.
+func (s *ChunkingStrategy) validate() error {
+	if s == nil || s.Type != ChunkingStatic {
+		return nil
+	}
+
+	if s.Static == nil {
+		return fmt.Errorf("invalid chunking strategy: static chunking requires its parameters")
+	}
+
+	size, overlap := s.Static.MaxChunkSizeTokens, s.Static.ChunkOverlapTokens
+	if size < MinChunkSizeTokens || size > MaxChunkSizeTokens {
+		return fmt.Errorf("invalid chunking strategy: chunk size %d is out of [%d, %d]", size, MinChunkSizeTokens, MaxChunkSizeTokens)
+	}
+	if overlap < 0 || overlap > size/2 {
+		return fmt.Errorf("invalid chunking strategy: overlap %d must be between 0 and half the chunk size", overlap)
+	}
+	return nil
+}
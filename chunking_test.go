@@ -0,0 +1,87 @@
+package openaiclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateVectorStoreFile_ChunkingStrategy(t *testing.T) {
+	t.Parallel()
+
+	bodies := map[string]map[string]any{}
+	client := New("test_api_key", routeClient(t, map[string]any{
+		"POST /v1/vector_stores/vs_1/files": VectorStoreFile{ID: "file_1", ChunkingStrategy: StaticChunkingStrategy(512, 128)},
+		"GET /v1/vector_stores/vs_1/files/file_2": map[string]any{
+			"id":                "file_2",
+			"chunking_strategy": map[string]any{"type": "other"},
+		},
+	}, bodies), WithBeta(BetaAssistants))
+
+	ctx := context.Background()
+
+	file, err := client.CreateVectorStoreFile(ctx, "vs_1", VectorStoreFileRequest{FileID: "file_1", ChunkingStrategy: StaticChunkingStrategy(512, 128)})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"file_id": "file_1",
+		"chunking_strategy": map[string]any{
+			"type":   "static",
+			"static": map[string]any{"max_chunk_size_tokens": float64(512), "chunk_overlap_tokens": float64(128)},
+		},
+	}, bodies["POST /v1/vector_stores/vs_1/files"])
+	assert.Equal(t, 512, file.ChunkingStrategy.Static.MaxChunkSizeTokens)
+
+	file, err = client.GetVectorStoreFile(ctx, "vs_1", "file_2")
+	require.NoError(t, err)
+	assert.Equal(t, ChunkingOther, file.ChunkingStrategy.Type)
+}
+
+func TestClient_CreateVectorStore_InvalidChunkingStrategy(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			t.Fatal("unexpected request")
+			return nil, nil
+		},
+	}, WithBeta(BetaAssistants))
+
+	ctx := context.Background()
+
+	_, err := client.CreateVectorStore(ctx, VectorStoreRequest{FileIDs: []string{"file_1"}, ChunkingStrategy: StaticChunkingStrategy(50, 0)})
+	assert.Error(t, err)
+
+	_, err = client.CreateVectorStoreFileBatch(ctx, "vs_1", VectorStoreFileBatchRequest{FileIDs: []string{"file_1"}, ChunkingStrategy: StaticChunkingStrategy(800, 500)})
+	assert.Error(t, err)
+}
+
+func TestChunkingStrategy_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		strategy *ChunkingStrategy
+		wantErr  bool
+	}{
+		{name: "default", strategy: nil},
+		{name: "auto", strategy: AutoChunking()},
+		{name: "static", strategy: StaticChunkingStrategy(800, 400)},
+		{name: "bounds", strategy: StaticChunkingStrategy(MaxChunkSizeTokens, 0)},
+		{name: "too small", strategy: StaticChunkingStrategy(99, 0), wantErr: true},
+		{name: "too large", strategy: StaticChunkingStrategy(MaxChunkSizeTokens+1, 0), wantErr: true},
+		{name: "overlap too large", strategy: StaticChunkingStrategy(800, 401), wantErr: true},
+		{name: "negative overlap", strategy: StaticChunkingStrategy(800, -1), wantErr: true},
+		{name: "missing parameters", strategy: &ChunkingStrategy{Type: ChunkingStatic}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.wantErr, tc.strategy.validate() != nil)
+		})
+	}
+}
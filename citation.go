@@ -0,0 +1,208 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// DefaultCitationPattern matches citations such as [doc-1]. The first group is the chunk ID.
+	DefaultCitationPattern = regexp.MustCompile(`\[([^\[\]\s]+)\]`)
+
+	spaceBeforePunct = regexp.MustCompile(`\s+([.!?,;:])`)
+)
+
+const groundingPrompt = `You verify whether a claim is supported by the given source passages.
+Reply with a JSON object {"supported": true or false, "reason": "<one sentence>"} and nothing else.`
+
+type (
+	// RetrievedChunk is a chunk of context retrieved for a RAG answer.
+	RetrievedChunk struct {
+		ID   string
+		Text string
+	}
+
+	// ClaimSupport reports how a claim of an answer is supported.
+	ClaimSupport struct {
+		Claim string
+		// Citations are the chunk IDs cited by the claim.
+		Citations []string
+		// Unknown are the cited chunk IDs missing from the retrieved set.
+		Unknown   []string
+		Supported bool
+		Reason    string
+	}
+
+	// SupportReport is the per-claim support report of an answer.
+	SupportReport struct {
+		Claims []ClaimSupport
+	}
+
+	// CitationVerifier checks that the citations of RAG answers refer to retrieved chunks.
+	CitationVerifier struct {
+		// Pattern matches citations, the first group being the chunk ID.
+		// Defaults to DefaultCitationPattern.
+		Pattern *regexp.Regexp
+		// Client and Model, if set, are used to ask the model whether the cited
+		// chunks actually support each claim.
+		Client *Client
+		Model  string
+	}
+)
+
+// Supported reports whether every claim of the answer is supported.
+func (r SupportReport) Supported() bool {
+	for _, claim := range r.Claims {
+		if !claim.Supported {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify splits the answer into claims and checks the citations of each against the chunks.
+func (v CitationVerifier) Verify(ctx context.Context, answer string, chunks []RetrievedChunk) (*SupportReport, error) {
+	pattern := v.Pattern
+	if pattern == nil {
+		pattern = DefaultCitationPattern
+	}
+
+	if pattern.NumSubexp() < 1 {
+		return nil, fmt.Errorf("invalid citation pattern %q: no group for the chunk ID", pattern)
+	}
+
+	byID := make(map[string]RetrievedChunk, len(chunks))
+	for _, chunk := range chunks {
+		byID[chunk.ID] = chunk
+	}
+
+	var report SupportReport
+	for _, sentence := range splitClaims(answer, pattern) {
+		claim := ClaimSupport{
+			Claim: cleanClaim(pattern.ReplaceAllString(sentence, "")),
+		}
+
+		for _, m := range pattern.FindAllStringSubmatch(sentence, -1) {
+			if m[0] == "" {
+				continue
+			}
+
+			claim.Citations = append(claim.Citations, m[1])
+			if _, ok := byID[m[1]]; !ok {
+				claim.Unknown = append(claim.Unknown, m[1])
+			}
+		}
+
+		switch {
+		case len(claim.Citations) == 0:
+			claim.Reason = "no citation"
+		case len(claim.Unknown) > 0:
+			claim.Reason = "cites chunks that were not retrieved"
+		case v.Client == nil:
+			claim.Supported = true
+		default:
+			sources := make([]RetrievedChunk, 0, len(claim.Citations))
+			for _, id := range claim.Citations {
+				sources = append(sources, byID[id])
+			}
+
+			var err error
+			if claim.Supported, claim.Reason, err = v.ground(ctx, claim.Claim, sources); err != nil {
+				return nil, err
+			}
+		}
+
+		report.Claims = append(report.Claims, claim)
+	}
+	return &report, nil
+}
+
+// ground asks the model whether the sources support the claim.
+func (v CitationVerifier) ground(ctx context.Context, claim string, sources []RetrievedChunk) (bool, string, error) {
+	var b strings.Builder
+	for _, source := range sources {
+		fmt.Fprintf(&b, "[%s]\n%s\n\n", source.ID, source.Text)
+	}
+	fmt.Fprintf(&b, "Claim: %s", claim)
+
+	resp, err := v.Client.CreateChatCompletion(ctx, ChatCompletionRequest{
+		Model: v.Model,
+		Messages: []Message{
+			{Role: RoleSystem, Content: groundingPrompt},
+			{Role: RoleUser, Content: b.String()},
+		},
+	})
+	if err != nil {
+		return false, "", fmt.Errorf("could not ground claim: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return false, "", fmt.Errorf("could not ground claim: no choices returned")
+	}
+
+	for _, value := range ExtractJSONBlocks(resp.Choices[0].Message.Content) {
+		var verdict struct {
+			Supported bool   `json:"supported"`
+			Reason    string `json:"reason"`
+		}
+
+		if err := json.Unmarshal(value, &verdict); err == nil {
+			return verdict.Supported, verdict.Reason, nil
+		}
+	}
+	return false, "", fmt.Errorf("could not ground claim: unexpected verdict %q", resp.Choices[0].Message.Content)
+}
+
+// splitClaims splits text into sentences, keeping citations that follow the
+// end of a sentence with that sentence.
+func splitClaims(text string, pattern *regexp.Regexp) []string {
+	var (
+		claims []string
+		start  int
+	)
+
+	flush := func(end int) {
+		if claim := strings.TrimSpace(text[start:end]); claim != "" {
+			claims = append(claims, claim)
+		}
+		start = end
+	}
+
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\n':
+			flush(i + 1)
+		case c == '.' || c == '!' || c == '?':
+			if i+1 < len(text) && text[i+1] != ' ' && text[i+1] != '\n' && text[i+1] != '[' {
+				continue
+			}
+
+			end := i + 1
+			for {
+				rest := text[end:]
+				trimmed := strings.TrimLeft(rest, " ")
+
+				loc := pattern.FindStringIndex(trimmed)
+				if loc == nil || loc[0] != 0 || loc[1] == 0 {
+					break
+				}
+				end += len(rest) - len(trimmed) + loc[1]
+			}
+
+			flush(end)
+			i = end - 1
+		}
+	}
+
+	flush(len(text))
+	return claims
+}
+
+// cleanClaim tidies up the whitespace left behind by removed citations.
+func cleanClaim(claim string) string {
+	claim = strings.Join(strings.Fields(claim), " ")
+	return spaceBeforePunct.ReplaceAllString(claim, "$1")
+}
@@ -0,0 +1,95 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCitationVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	chunks := []RetrievedChunk{
+		{ID: "doc-1", Text: "Paris is the capital of France."},
+		{ID: "doc-2", Text: "France uses the euro."},
+	}
+
+	answer := "Paris is the capital of France [doc-1]. It uses the euro. [doc-2] Its population is 70 million [doc-9].\nIt has 3.5 rivers."
+
+	t.Run("checks citations against retrieved chunks", func(t *testing.T) {
+		t.Parallel()
+
+		report, err := CitationVerifier{}.Verify(context.Background(), answer, chunks)
+		require.NoError(t, err)
+
+		assert.Equal(t, []ClaimSupport{
+			{Claim: "Paris is the capital of France.", Citations: []string{"doc-1"}, Supported: true},
+			{Claim: "It uses the euro.", Citations: []string{"doc-2"}, Supported: true},
+			{Claim: "Its population is 70 million.", Citations: []string{"doc-9"}, Unknown: []string{"doc-9"}, Reason: "cites chunks that were not retrieved"},
+			{Claim: "It has 3.5 rivers.", Reason: "no citation"},
+		}, report.Claims)
+		assert.False(t, report.Supported())
+	})
+
+	t.Run("grounds claims with the model", func(t *testing.T) {
+		t.Parallel()
+
+		client := New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				var in ChatCompletionRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+				verdict := `{"supported": true, "reason": "stated in the source"}`
+				if strings.Contains(in.Messages[1].Content, "euro") {
+					verdict = "```json\n{\"supported\": false, \"reason\": \"not stated\"}\n```"
+				}
+
+				assert.Equal(t, "test_judge", in.Model)
+				return jsonResponse(t, ChatCompletionResponse{
+					Choices: []Choice{{Message: Message{Role: "assistant", Content: verdict}}},
+				}), nil
+			},
+		})
+
+		verifier := CitationVerifier{Client: client, Model: "test_judge"}
+
+		report, err := verifier.Verify(context.Background(), "Paris is the capital [doc-1]. It uses the euro [doc-1].", chunks)
+		require.NoError(t, err)
+
+		require.Len(t, report.Claims, 2)
+		assert.True(t, report.Claims[0].Supported)
+		assert.Equal(t, "stated in the source", report.Claims[0].Reason)
+		assert.False(t, report.Claims[1].Supported)
+		assert.Equal(t, "not stated", report.Claims[1].Reason)
+	})
+}
+
+func TestCitationVerifier_Verify_CustomPatterns(t *testing.T) {
+	t.Parallel()
+
+	chunks := []RetrievedChunk{{ID: "1", Text: "Paris is the capital of France."}}
+
+	t.Run("rejects patterns without a group", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := CitationVerifier{Pattern: regexp.MustCompile(`\[\d+\]`)}.Verify(context.Background(), "Paris [1].", chunks)
+		assert.Error(t, err)
+	})
+
+	t.Run("ignores empty matches", func(t *testing.T) {
+		t.Parallel()
+
+		report, err := CitationVerifier{Pattern: regexp.MustCompile(`\^?(\d*)`)}.Verify(context.Background(), "Paris is the capital^1. It is big.", chunks)
+		require.NoError(t, err)
+
+		require.Len(t, report.Claims, 2)
+		assert.Equal(t, []string{"1"}, report.Claims[0].Citations)
+		assert.Equal(t, "no citation", report.Claims[1].Reason)
+	})
+}
@@ -0,0 +1,80 @@
+// Command openaiclient provides maintenance tasks for applications using the
+// openaiclient package.
+//
+// Usage:
+//
+//	openaiclient replay -journal failed.jsonl
+//
+// The replay subcommand re-sends the requests journaled with
+// openaiclient.WithJournal and a FileJournal, after an outage. The API key is
+// read from the OPENAI_API_KEY environment variable.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"github.com/alesr/openaiclient"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] != "replay" {
+		return fmt.Errorf("usage: openaiclient replay -journal <path>")
+	}
+
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	journalPath := flags.String("journal", "", "path of the journal file")
+	organization := flags.String("organization", "", "OpenAI organization")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if *journalPath == "" {
+		return fmt.Errorf("the -journal flag is required")
+	}
+
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return fmt.Errorf("the OPENAI_API_KEY environment variable is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	opts := []openaiclient.Option{openaiclient.WithJournal(openaiclient.NewFileJournal(*journalPath))}
+	if *organization != "" {
+		opts = append(opts, openaiclient.WithOrganization(*organization))
+	}
+
+	client := openaiclient.New(apiKey, http.DefaultClient, opts...)
+
+	report, err := client.ReplayFailed(ctx)
+	if err != nil {
+		return fmt.Errorf("could not replay requests: %w", err)
+	}
+
+	for _, id := range report.Replayed {
+		fmt.Fprintf(out, "replayed %s\n", id)
+	}
+	for _, failure := range report.Failed {
+		fmt.Fprintf(out, "failed %s %s %s: %v\n", failure.Entry.ID, failure.Entry.Method, failure.Entry.URL, failure.Err)
+	}
+
+	fmt.Fprintf(out, "%d replayed, %d still failing\n", len(report.Replayed), len(report.Failed))
+	if len(report.Failed) > 0 {
+		return fmt.Errorf("%d requests still failing", len(report.Failed))
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "test_api_key")
+
+	testCases := []struct {
+		name    string
+		args    []string
+		wantOut string
+		wantErr bool
+	}{
+		{
+			name:    "no subcommand",
+			wantErr: true,
+		},
+		{
+			name:    "unknown subcommand",
+			args:    []string{"export"},
+			wantErr: true,
+		},
+		{
+			name:    "missing journal",
+			args:    []string{"replay"},
+			wantErr: true,
+		},
+		{
+			name:    "empty journal",
+			args:    []string{"replay", "-journal", filepath.Join(t.TempDir(), "journal.jsonl")},
+			wantOut: "0 replayed, 0 still failing\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			err := run(tc.args, &out)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOut, out.String())
+		})
+	}
+}
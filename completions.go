@@ -0,0 +1,81 @@
+package openaiclient
+
+import "context"
+
+type (
+	// CompletionRequest is the request body for the legacy completions endpoint,
+	// serving instruct models such as gpt-3.5-turbo-instruct and fine-tuned
+	// babbage-002 or davinci-002 derivatives.
+	CompletionRequest struct {
+		Model  string `json:"model"`
+		Prompt string `json:"prompt"`
+		// Suffix is the text following the completion, for insertion.
+		Suffix      string   `json:"suffix,omitempty"`
+		MaxTokens   int      `json:"max_tokens,omitempty"`
+		Temperature *float64 `json:"temperature,omitempty"`
+		TopP        *float64 `json:"top_p,omitempty"`
+		N           int      `json:"n,omitempty"`
+		// Logprobs is the number of most likely tokens, up to 5, returned with
+		// their log probabilities for every completion token.
+		Logprobs *int `json:"logprobs,omitempty"`
+		// Echo prepends the prompt to the completion.
+		Echo             bool           `json:"echo,omitempty"`
+		Stop             []string       `json:"stop,omitempty"`
+		PresencePenalty  *float64       `json:"presence_penalty,omitempty"`
+		FrequencyPenalty *float64       `json:"frequency_penalty,omitempty"`
+		LogitBias        map[string]int `json:"logit_bias,omitempty"`
+		Seed             *int           `json:"seed,omitempty"`
+		User             string         `json:"user,omitempty"`
+		// BestOf generates completions server side and returns the N ones with
+		// the highest log probability per token. It must be at least N.
+		BestOf int `json:"best_of,omitempty"`
+	}
+
+	// CompletionResponse is the response body for the legacy completions endpoint.
+	CompletionResponse struct {
+		ResponseMeta
+		ID      string             `json:"id"`
+		Object  string             `json:"object"`
+		Model   string             `json:"model"`
+		Created int                `json:"created"`
+		Choices []CompletionChoice `json:"choices"`
+		Usage   Usage              `json:"usage"`
+	}
+
+	// CompletionChoice is a completion of the prompt.
+	CompletionChoice struct {
+		Index        int                 `json:"index"`
+		Text         string              `json:"text"`
+		FinishReason string              `json:"finish_reason"`
+		Logprobs     *CompletionLogprobs `json:"logprobs"`
+	}
+
+	// CompletionLogprobs are the log probabilities of the completion tokens,
+	// returned when the request sets Logprobs. The slices are indexed by token.
+	CompletionLogprobs struct {
+		Tokens        []string             `json:"tokens"`
+		TokenLogprobs []float64            `json:"token_logprobs"`
+		TopLogprobs   []map[string]float64 `json:"top_logprobs"`
+		// TextOffset is the offset of every token in the text, echoed prompt included.
+		TextOffset []int `json:"text_offset"`
+	}
+)
+
+// CreateCompletion creates a completion for the prompt, using the legacy completions endpoint.
+func (c *Client) CreateCompletion(ctx context.Context, in CompletionRequest) (*CompletionResponse, error) {
+	in.Model = c.resolveModel(in.Model)
+	in.User = c.endUser(in.User)
+	if err := c.policies.checkModel(in.Model); err != nil {
+		return nil, err
+	}
+	c.deprecations.check(in.Model)
+
+	var compResp CompletionResponse
+	if err := c.post(ctx, c.url(EndpointCompletions), in, &compResp); err != nil {
+		return nil, err
+	}
+
+	c.usage.record(servedModel(compResp.Model, in.Model), compResp.Usage)
+	c.watermark.check(compResp.ID, compResp.Model, compResp.Usage)
+	return &compResp, nil
+}
@@ -0,0 +1,95 @@
+package openaiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateCompletion(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, DefaultBaseURL+EndpointCompletions, req.URL.String())
+
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.Equal(t, map[string]any{
+				"model":      "gpt-3.5-turbo-instruct",
+				"prompt":     "def add(a, b):",
+				"suffix":     "\n\nprint(add(1, 2))",
+				"max_tokens": float64(16),
+				"logprobs":   float64(0),
+				"echo":       true,
+				"best_of":    float64(3),
+				"stop":       []any{"\n\n"},
+			}, body)
+
+			return jsonResponse(t, map[string]any{
+				"id":      "cmpl-123",
+				"object":  "text_completion",
+				"model":   "gpt-3.5-turbo-instruct",
+				"created": 1700000000,
+				"choices": []map[string]any{{
+					"index":         0,
+					"text":          "def add(a, b):\n    return a + b",
+					"finish_reason": "stop",
+					"logprobs": map[string]any{
+						"tokens":         []string{"\n", "    return"},
+						"token_logprobs": []float64{-0.1, -0.2},
+						"top_logprobs":   []map[string]float64{{"\n": -0.1}, {"    return": -0.2}},
+						"text_offset":    []int{14, 15},
+					},
+				}},
+				"usage": map[string]int{"prompt_tokens": 5, "completion_tokens": 7, "total_tokens": 12},
+			}), nil
+		},
+	})
+
+	resp, err := client.CreateCompletion(context.Background(), CompletionRequest{
+		Model:     "gpt-3.5-turbo-instruct",
+		Prompt:    "def add(a, b):",
+		Suffix:    "\n\nprint(add(1, 2))",
+		MaxTokens: 16,
+		Logprobs:  Ptr(0),
+		Echo:      true,
+		BestOf:    3,
+		Stop:      []string{"\n\n"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, resp.Choices, 1)
+	assert.Equal(t, "def add(a, b):\n    return a + b", resp.Choices[0].Text)
+	assert.Equal(t, "stop", resp.Choices[0].FinishReason)
+	require.NotNil(t, resp.Choices[0].Logprobs)
+	assert.Equal(t, []string{"\n", "    return"}, resp.Choices[0].Logprobs.Tokens)
+	assert.Equal(t, []int{14, 15}, resp.Choices[0].Logprobs.TextOffset)
+
+	assert.Equal(t, Usage{PromptTokens: 5, CompletionTokens: 7, TotalTokens: 12}, client.Usage())
+}
+
+func TestClient_CreateCompletion_APIError(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"error":{"message":"best_of must be at least n"}}`)),
+			}, nil
+		},
+	})
+
+	_, err := client.CreateCompletion(context.Background(), CompletionRequest{Model: "davinci-002", N: 3, BestOf: 2})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "best_of must be at least n", apiErr.Message)
+}
@@ -0,0 +1,89 @@
+package openaiclient
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"sync"
+)
+
+type (
+	// CompressionStats reports how request bodies were compressed.
+	CompressionStats struct {
+		// Requests is the number of request bodies seen by the compressor.
+		Requests int
+		// Compressed is the number of request bodies that were compressed.
+		Compressed int
+		// UncompressedBytes is the size of the compressed bodies before compression.
+		UncompressedBytes int
+		// CompressedBytes is the size of the compressed bodies after compression.
+		CompressedBytes int
+	}
+
+	// compressor gzips request bodies above a size threshold.
+	compressor struct {
+		threshold int
+
+		mu    sync.Mutex
+		stats CompressionStats
+	}
+)
+
+// Ratio returns the compressed to uncompressed size ratio of the compressed bodies.
+// It returns zero if no body was compressed.
+func (s CompressionStats) Ratio() float64 {
+	if s.UncompressedBytes == 0 {
+		return 0
+	}
+	return float64(s.CompressedBytes) / float64(s.UncompressedBytes)
+}
+
+// WithCompression gzips request bodies larger than threshold bytes.
+// Smaller bodies are sent as is so they don't pay the compression cost.
+func WithCompression(threshold int) Option {
+	return func(c *Client) {
+		c.compressor = &compressor{threshold: threshold}
+	}
+}
+
+// CompressionStats returns the compression statistics of the client.
+// It returns zero stats if compression is not enabled.
+func (c *Client) CompressionStats() CompressionStats {
+	if c.compressor == nil {
+		return CompressionStats{}
+	}
+
+	c.compressor.mu.Lock()
+	defer c.compressor.mu.Unlock()
+
+	return c.compressor.stats
+}
+
+// compress returns the body to send and whether it was compressed.
+func (c *compressor) compress(body []byte) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stats.Requests++
+
+	if len(body) <= c.threshold {
+		return body, false, nil
+	}
+
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, false, fmt.Errorf("could not compress data: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, false, fmt.Errorf("could not compress data: %w", err)
+	}
+
+	c.stats.Compressed++
+	c.stats.UncompressedBytes += len(body)
+	c.stats.CompressedBytes += buf.Len()
+
+	return buf.Bytes(), true, nil
+}
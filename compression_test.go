@@ -0,0 +1,79 @@
+package openaiclient
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCompression(t *testing.T) {
+	t.Parallel()
+
+	t.Run("compresses bodies above the threshold", func(t *testing.T) {
+		t.Parallel()
+
+		input := EmbeddingRequest{
+			Model: "test_model",
+			Input: TextInput(strings.Repeat("test input ", 100)),
+		}
+
+		client := New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "gzip", req.Header.Get("Content-Encoding"))
+
+				zr, err := gzip.NewReader(req.Body)
+				require.NoError(t, err)
+
+				var body EmbeddingRequest
+				require.NoError(t, json.NewDecoder(zr).Decode(&body))
+
+				assert.Equal(t, input, body)
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader("{}")),
+				}, nil
+			},
+		}, WithCompression(128))
+
+		_, err := client.CreateEmbedding(context.Background(), input)
+		require.NoError(t, err)
+
+		stats := client.CompressionStats()
+		assert.Equal(t, 1, stats.Requests)
+		assert.Equal(t, 1, stats.Compressed)
+		assert.Greater(t, stats.UncompressedBytes, stats.CompressedBytes)
+		assert.Less(t, stats.Ratio(), 1.0)
+	})
+
+	t.Run("sends bodies below the threshold uncompressed", func(t *testing.T) {
+		t.Parallel()
+
+		client := New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				assert.Empty(t, req.Header.Get("Content-Encoding"))
+
+				var body ChatCompletionRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader("{}")),
+				}, nil
+			},
+		}, WithCompression(1024))
+
+		_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "test_model"})
+		require.NoError(t, err)
+
+		stats := client.CompressionStats()
+		assert.Equal(t, 1, stats.Requests)
+		assert.Equal(t, 0, stats.Compressed)
+		assert.Equal(t, 0.0, stats.Ratio())
+	})
+}
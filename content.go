@@ -0,0 +1,133 @@
+package openaiclient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Content part types.
+const (
+	ContentPartText       = "text"
+	ContentPartImageURL   = "image_url"
+	ContentPartInputAudio = "input_audio"
+)
+
+// Image detail levels, trading image understanding for prompt tokens.
+const (
+	ImageDetailAuto = "auto"
+	ImageDetailLow  = "low"
+	ImageDetailHigh = "high"
+)
+
+// Input audio formats.
+const (
+	AudioInputWAV = "wav"
+	AudioInputMP3 = "mp3"
+)
+
+type (
+	// ContentPart is a part of a multimodal message: a text, an image or an
+	// audio clip, depending on its Type.
+	ContentPart struct {
+		Type       string      `json:"type"`
+		Text       string      `json:"text,omitempty"`
+		ImageURL   *ImageURL   `json:"image_url,omitempty"`
+		InputAudio *InputAudio `json:"input_audio,omitempty"`
+	}
+
+	// ImageURL is an image given by URL, or inline as a base64 data URL.
+	ImageURL struct {
+		URL string `json:"url"`
+		// Detail is the detail level, such as ImageDetailLow; the API default applies when empty.
+		Detail string `json:"detail,omitempty"`
+	}
+
+	// InputAudio is an audio clip, base64 encoded.
+	InputAudio struct {
+		Data   string `json:"data"`
+		Format string `json:"format"`
+	}
+)
+
+// TextPart returns a text content part.
+func TextPart(text string) ContentPart {
+	return ContentPart{Type: ContentPartText, Text: text}
+}
+
+// ImagePart returns an image content part with the given detail level, which may be empty.
+func ImagePart(url, detail string) ContentPart {
+	return ContentPart{Type: ContentPartImageURL, ImageURL: &ImageURL{URL: url, Detail: detail}}
+}
+
+// ImageDataPart returns an image content part embedding the image as a data
+// URL of the given media type, such as "image/png".
+func ImageDataPart(data []byte, mediaType, detail string) ContentPart {
+	return ImagePart("data:"+mediaType+";base64,"+base64.StdEncoding.EncodeToString(data), detail)
+}
+
+// AudioPart returns an audio content part of the given format, such as AudioInputWAV.
+func AudioPart(data []byte, format string) ContentPart {
+	return ContentPart{
+		Type:       ContentPartInputAudio,
+		InputAudio: &InputAudio{Data: base64.StdEncoding.EncodeToString(data), Format: format},
+	}
+}
+
+// message has the fields of Message, without its JSON methods.
+type message Message
+
+// MarshalJSON implements json.Marshaler. Messages with parts send them as the
+// content; other messages send their content as a plain string.
+func (m Message) MarshalJSON() ([]byte, error) {
+	if len(m.Parts) == 0 {
+		return json.Marshal(message(m))
+	}
+
+	return json.Marshal(struct {
+		message
+		Content []ContentPart `json:"content"`
+	}{message: message(m), Content: m.Parts})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting both plain string and multipart contents.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		message
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*m = Message(raw.message)
+
+	content := bytes.TrimSpace(raw.Content)
+	switch {
+	case len(content) == 0 || bytes.Equal(content, []byte("null")):
+		return nil
+	case content[0] == '[':
+		if err := json.Unmarshal(content, &m.Parts); err != nil {
+			return fmt.Errorf("could not unmarshal content parts: %w", err)
+		}
+		return nil
+	default:
+		return json.Unmarshal(content, &m.Content)
+	}
+}
+
+// Text returns the content of the message, or the concatenated text of its parts.
+func (m Message) Text() string {
+	if len(m.Parts) == 0 {
+		return m.Content
+	}
+
+	var text strings.Builder
+	for _, part := range m.Parts {
+		if part.Type == ContentPartText {
+			text.WriteString(part.Text)
+		}
+	}
+	return text.String()
+}
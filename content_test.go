@@ -0,0 +1,117 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		msg  Message
+		want string
+	}{
+		{
+			name: "plain content",
+			msg:  Message{Role: "user", Content: "Hi"},
+			want: `{"role":"user","content":"Hi"}`,
+		},
+		{
+			name: "content parts",
+			msg: Message{Role: "user", Parts: []ContentPart{
+				TextPart("What is this?"),
+				ImagePart("https://example.com/cat.png", ImageDetailLow),
+				AudioPart([]byte("RIFF"), AudioInputWAV),
+			}},
+			want: `{"role":"user","content":[` +
+				`{"type":"text","text":"What is this?"},` +
+				`{"type":"image_url","image_url":{"url":"https://example.com/cat.png","detail":"low"}},` +
+				`{"type":"input_audio","input_audio":{"data":"UklGRg==","format":"wav"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := json.Marshal(tt.msg)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+
+			var decoded Message
+			require.NoError(t, json.Unmarshal(got, &decoded))
+			assert.Equal(t, tt.msg, decoded)
+		})
+	}
+}
+
+func TestMessage_UnmarshalJSON_NullContent(t *testing.T) {
+	t.Parallel()
+
+	var msg Message
+	require.NoError(t, json.Unmarshal([]byte(`{"role":"assistant","content":null,"tool_calls":[{"id":"call_1","type":"function"}]}`), &msg))
+
+	assert.Equal(t, Message{Role: "assistant", ToolCalls: []ToolCall{{ID: "call_1", Type: "function"}}}, msg)
+}
+
+func TestMessage_Text(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Hi", Message{Content: "Hi"}.Text())
+	assert.Equal(t, "What is this? Be brief.", Message{Parts: []ContentPart{
+		TextPart("What is this?"),
+		ImagePart("https://example.com/cat.png", ""),
+		TextPart(" Be brief."),
+	}}.Text())
+}
+
+func TestImageDataPart(t *testing.T) {
+	t.Parallel()
+
+	part := ImageDataPart([]byte("\x89PNG"), "image/png", ImageDetailAuto)
+
+	assert.Equal(t, ContentPartImageURL, part.Type)
+	assert.Equal(t, &ImageURL{URL: "data:image/png;base64,iVBORw==", Detail: ImageDetailAuto}, part.ImageURL)
+}
+
+func TestClient_CreateChatCompletion_Parts(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				Messages []map[string]any `json:"messages"`
+			}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+
+			require.Len(t, body.Messages, 2)
+			assert.Equal(t, "Be brief.", body.Messages[0]["content"])
+			assert.Equal(t, []any{
+				map[string]any{"type": "text", "text": "What is this?"},
+				map[string]any{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/cat.png", "detail": "high"}},
+			}, body.Messages[1]["content"])
+
+			return jsonResponse(t, map[string]any{
+				"choices": []map[string]any{{"message": map[string]any{"role": "assistant", "content": "A cat."}}},
+			}), nil
+		},
+	})
+
+	resp, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{
+		Model: "gpt-4o",
+		Messages: []Message{
+			{Role: "system", Content: "Be brief."},
+			{Role: "user", Parts: []ContentPart{TextPart("What is this?"), ImagePart("https://example.com/cat.png", ImageDetailHigh)}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "A cat.", resp.Choices[0].Message.Content)
+}
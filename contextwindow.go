@@ -0,0 +1,117 @@
+package openaiclient
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ErrContextTooLarge is matched by errors.Is for every ContextTooLargeError.
+var ErrContextTooLarge = errors.New("context too large")
+
+// snapshotSuffix matches the date suffix of pinned model snapshots, such as -0613 or -2024-08-06.
+var snapshotSuffix = regexp.MustCompile(`-(\d{4}|\d{4}-\d{2}-\d{2})$`)
+
+// contextWindows are the context window sizes of known models, by model ID.
+var contextWindows = map[string]int{
+	"gpt-3.5-turbo":        16385,
+	"gpt-3.5-turbo-16k":    16385,
+	"gpt-4":                8192,
+	"gpt-4-32k":            32768,
+	"gpt-4-turbo":          128000,
+	"gpt-4-turbo-preview":  128000,
+	"gpt-4-1106-preview":   128000,
+	"gpt-4-0125-preview":   128000,
+	"gpt-4-vision-preview": 128000,
+	"gpt-4.5-preview":      128000,
+	"gpt-4o":               128000,
+	"gpt-4o-mini":          128000,
+	"gpt-4.1":              1047576,
+	"gpt-4.1-mini":         1047576,
+	"gpt-4.1-nano":         1047576,
+	"o1":                   200000,
+	"o1-mini":              128000,
+	"o1-preview":           128000,
+	"o3":                   200000,
+	"o3-mini":              200000,
+	"o4-mini":              200000,
+}
+
+// ContextTooLargeError is returned when a request doesn't fit the context window of its model.
+type ContextTooLargeError struct {
+	Model         string
+	ContextWindow int
+	PromptTokens  int
+	// MaxTokens is the completion limit of the request, reserved in the window.
+	MaxTokens int
+}
+
+// Error implements the error interface.
+func (e *ContextTooLargeError) Error() string {
+	return fmt.Sprintf("context too large for %s: %d tokens exceed the %d tokens window by %d",
+		e.Model, e.PromptTokens+e.MaxTokens, e.ContextWindow, e.Overflow())
+}
+
+// Is reports whether target is ErrContextTooLarge.
+func (e *ContextTooLargeError) Is(target error) bool {
+	return target == ErrContextTooLarge
+}
+
+// Overflow returns the number of tokens exceeding the context window.
+func (e *ContextTooLargeError) Overflow() int {
+	return e.PromptTokens + e.MaxTokens - e.ContextWindow
+}
+
+// WithContextWindowCheck makes the client verify that the estimated prompt of
+// chat requests, plus their MaxTokens, fits the context window of the model before sending them,
+// returning a ContextTooLargeError instead of a server side error.
+// Windows extends or overrides the known context windows, by model ID. A window
+// also applies to the dated snapshots of its model, such as gpt-4o-2024-08-06.
+// Requests to models with unknown windows are sent unchecked.
+func WithContextWindowCheck(windows map[string]int) Option {
+	return func(c *Client) {
+		c.contextWindows = make(map[string]int, len(contextWindows)+len(windows))
+		for model, window := range contextWindows {
+			c.contextWindows[model] = window
+		}
+		for model, window := range windows {
+			c.contextWindows[model] = window
+		}
+	}
+}
+
+// checkContextWindow verifies the request fits its model's context window, if checking is enabled.
+func (c *Client) checkContextWindow(in ChatCompletionRequest) error {
+	if c.contextWindows == nil {
+		return nil
+	}
+
+	window, ok := lookupContextWindow(c.contextWindows, in.Model)
+	if !ok {
+		return nil
+	}
+
+	// The completion shares the window with the prompt.
+	tokens := EstimateRequest(in).Total
+	if tokens+in.MaxTokens <= window {
+		return nil
+	}
+
+	return &ContextTooLargeError{
+		Model:         in.Model,
+		ContextWindow: window,
+		PromptTokens:  tokens,
+		MaxTokens:     in.MaxTokens,
+	}
+}
+
+// lookupContextWindow returns the window of the model, falling back to the
+// window of the model it is a dated snapshot of.
+func lookupContextWindow(windows map[string]int, model string) (int, bool) {
+	if window, ok := windows[model]; ok {
+		return window, true
+	}
+
+	window, ok := windows[snapshotSuffix.ReplaceAllString(model, "")]
+	return window, ok
+}
@@ -0,0 +1,111 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithContextWindowCheck(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(windows map[string]int) *Client {
+		return New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader("{}")),
+				}, nil
+			},
+		}, WithContextWindowCheck(windows))
+	}
+
+	long := ChatCompletionRequest{
+		Model:    "small-model-2024-01-01",
+		Messages: []Message{{Role: "user", Content: strings.Repeat("a", 400)}},
+	}
+
+	t.Run("rejects requests exceeding the window", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient(map[string]int{"small-model": 50})
+
+		_, err := client.CreateChatCompletion(context.Background(), long)
+		require.ErrorIs(t, err, ErrContextTooLarge)
+
+		var tooLarge *ContextTooLargeError
+		require.True(t, errors.As(err, &tooLarge))
+		assert.Equal(t, 50, tooLarge.ContextWindow)
+		assert.Equal(t, EstimateRequest(long).Total-50, tooLarge.Overflow())
+	})
+
+	t.Run("sends requests within the window", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient(map[string]int{"small-model": 1000})
+
+		_, err := client.CreateChatCompletion(context.Background(), long)
+		assert.NoError(t, err)
+	})
+
+	t.Run("reserves max tokens for the completion", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient(map[string]int{"small-model": 1000})
+
+		req := long.Clone()
+		req.MaxTokens = 1000
+
+		_, err := client.CreateChatCompletion(context.Background(), req)
+
+		var tooLarge *ContextTooLargeError
+		require.True(t, errors.As(err, &tooLarge))
+		assert.Equal(t, 1000, tooLarge.MaxTokens)
+		assert.Equal(t, EstimateRequest(long).Total, tooLarge.Overflow())
+	})
+
+	t.Run("sends requests to unknown models", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient(nil)
+
+		_, err := client.CreateChatCompletion(context.Background(), long)
+		assert.NoError(t, err)
+	})
+}
+
+func TestLookupContextWindow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		model  string
+		want   int
+		wantOK bool
+	}{
+		{model: "gpt-4o-mini-2024-07-18", want: 128000, wantOK: true},
+		{model: "gpt-4-32k-0613", want: 32768, wantOK: true},
+		{model: "gpt-4-0613", want: 8192, wantOK: true},
+		{model: "gpt-4", want: 8192, wantOK: true},
+		{model: "gpt-4.5-preview", want: 128000, wantOK: true},
+		{model: "gpt-4.5-preview-2025-02-27", want: 128000, wantOK: true},
+		{model: "gpt-4-vision-preview", want: 128000, wantOK: true},
+		{model: "gpt-4o-2024-08-06", want: 128000, wantOK: true},
+		{model: "gpt-4-custom", want: 0, wantOK: false},
+		{model: "unknown", want: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			got, ok := lookupContextWindow(contextWindows, tt.model)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
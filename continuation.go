@@ -0,0 +1,98 @@
+package openaiclient
+
+import (
+	"context"
+	"strings"
+)
+
+// DefaultMaxContinuations is the number of times Continue re-requests a truncated answer by default.
+const DefaultMaxContinuations = 5
+
+type (
+	// ContinueOption configures Continue.
+	ContinueOption func(*continueConfig)
+
+	continueConfig struct {
+		maxContinuations int
+		maxTotalTokens   int
+	}
+)
+
+// WithMaxContinuations sets the maximum number of continuation requests.
+func WithMaxContinuations(n int) ContinueOption {
+	return func(c *continueConfig) {
+		c.maxContinuations = n
+	}
+}
+
+// WithMaxTotalTokens stops continuing once the merged usage reaches the given
+// number of total tokens. Zero means no limit.
+func WithMaxTotalTokens(n int) ContinueOption {
+	return func(c *continueConfig) {
+		c.maxTotalTokens = n
+	}
+}
+
+// WithPrefill returns a copy of the request ending with an assistant message
+// holding the prefix the model must continue.
+func (r ChatCompletionRequest) WithPrefill(prefix string) ChatCompletionRequest {
+	clone := r.Clone()
+	clone.Messages = append(clone.Messages, Message{Role: RoleAssistant, Content: prefix, Prefix: true})
+	return clone
+}
+
+// Continue re-requests the answer of resp, the response to in, while the model
+// stops because of the length limit, sending the partial answer as an assistant
+// prefill. It returns the last response with the parts of the first choice
+// stitched together and the usage merged across all responses, including resp.
+// If in is already prefilled, its prefix leads the stitched answer.
+// Responses that weren't truncated are returned as is.
+func (c *Client) Continue(ctx context.Context, in ChatCompletionRequest, resp *ChatCompletionResponse, opts ...ContinueOption) (*ChatCompletionResponse, error) {
+	cfg := continueConfig{maxContinuations: DefaultMaxContinuations}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if len(resp.Choices) == 0 {
+		return resp, nil
+	}
+
+	var answer strings.Builder
+
+	// A prefilled request is continued from its prefix, which the model doesn't repeat.
+	if n := len(in.Messages); n > 0 && in.Messages[n-1].Prefix {
+		answer.WriteString(in.Messages[n-1].Content)
+		in = in.Clone()
+		in.Messages = in.Messages[:n-1]
+	}
+
+	answer.WriteString(resp.Choices[0].Message.Content)
+
+	usage := resp.Usage
+
+	for i := 0; i < cfg.maxContinuations && resp.Choices[0].FinishReason == "length"; i++ {
+		if cfg.maxTotalTokens > 0 && usage.TotalTokens >= cfg.maxTotalTokens {
+			break
+		}
+
+		next, err := c.CreateChatCompletion(ctx, in.WithPrefill(answer.String()))
+		if err != nil {
+			return nil, err
+		}
+
+		usage = usage.add(next.Usage)
+
+		if len(next.Choices) == 0 {
+			break
+		}
+
+		answer.WriteString(next.Choices[0].Message.Content)
+		resp = next
+	}
+
+	stitched := *resp
+	stitched.Usage = usage
+	stitched.Choices = append([]Choice(nil), resp.Choices...)
+	stitched.Choices[0].Message.Content = answer.String()
+	return &stitched, nil
+}
@@ -0,0 +1,148 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatCompletionRequest_WithPrefill(t *testing.T) {
+	t.Parallel()
+
+	in := ChatCompletionRequest{Messages: []Message{{Role: "user", Content: "Count to 3"}}}
+
+	got := in.WithPrefill("1, 2")
+
+	assert.Equal(t, []Message{
+		{Role: "user", Content: "Count to 3"},
+		{Role: "assistant", Content: "1, 2", Prefix: true},
+	}, got.Messages)
+	assert.Len(t, in.Messages, 1)
+}
+
+func TestClient_Continue(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(requests *[]ChatCompletionRequest) *Client {
+		parts := []Choice{
+			{FinishReason: "length", Message: Message{Role: "assistant", Content: ", 3"}},
+			{FinishReason: "stop", Message: Message{Role: "assistant", Content: ", 4."}},
+		}
+
+		return New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				var in ChatCompletionRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+				part := parts[len(*requests)]
+				*requests = append(*requests, in)
+				return jsonResponse(t, ChatCompletionResponse{
+					Choices: []Choice{part},
+					Usage:   Usage{PromptTokens: 10, CompletionTokens: 2, TotalTokens: 12},
+				}), nil
+			},
+		})
+	}
+
+	in := ChatCompletionRequest{Messages: []Message{{Role: "user", Content: "Count to 4"}}}
+	first := &ChatCompletionResponse{
+		Choices: []Choice{{FinishReason: "length", Message: Message{Role: "assistant", Content: "1, 2"}}},
+		Usage:   Usage{PromptTokens: 8, CompletionTokens: 2, TotalTokens: 10},
+	}
+
+	t.Run("stitches continuations and merges usage", func(t *testing.T) {
+		t.Parallel()
+
+		var requests []ChatCompletionRequest
+
+		resp, err := newClient(&requests).Continue(context.Background(), in, first)
+		require.NoError(t, err)
+
+		assert.Equal(t, "1, 2, 3, 4.", resp.Choices[0].Message.Content)
+		assert.Equal(t, "stop", resp.Choices[0].FinishReason)
+		assert.Equal(t, Usage{PromptTokens: 28, CompletionTokens: 6, TotalTokens: 34}, resp.Usage)
+
+		require.Len(t, requests, 2)
+		assert.Equal(t, Message{Role: "assistant", Content: "1, 2", Prefix: true}, requests[0].Messages[1])
+		assert.Equal(t, Message{Role: "assistant", Content: "1, 2, 3", Prefix: true}, requests[1].Messages[1])
+		assert.Equal(t, "1, 2", first.Choices[0].Message.Content)
+	})
+
+	t.Run("limits the number of continuations", func(t *testing.T) {
+		t.Parallel()
+
+		var requests []ChatCompletionRequest
+
+		resp, err := newClient(&requests).Continue(context.Background(), in, first, WithMaxContinuations(1))
+		require.NoError(t, err)
+
+		assert.Equal(t, "1, 2, 3", resp.Choices[0].Message.Content)
+		assert.Equal(t, "length", resp.Choices[0].FinishReason)
+		assert.Len(t, requests, 1)
+	})
+
+	t.Run("limits the total tokens", func(t *testing.T) {
+		t.Parallel()
+
+		var requests []ChatCompletionRequest
+
+		resp, err := newClient(&requests).Continue(context.Background(), in, first, WithMaxTotalTokens(20))
+		require.NoError(t, err)
+
+		assert.Equal(t, "1, 2, 3", resp.Choices[0].Message.Content)
+		assert.Equal(t, 22, resp.Usage.TotalTokens)
+		assert.Len(t, requests, 1)
+	})
+}
+
+func TestClient_Continue_NotTruncated(t *testing.T) {
+	t.Parallel()
+
+	resp := &ChatCompletionResponse{
+		Choices: []Choice{{FinishReason: "stop", Message: Message{Content: "done"}}},
+	}
+
+	got, err := New("test_api_key", nil).Continue(context.Background(), ChatCompletionRequest{}, resp)
+	require.NoError(t, err)
+
+	assert.Equal(t, resp, got)
+}
+
+func TestClient_Continue_Prefilled(t *testing.T) {
+	t.Parallel()
+
+	var requests []ChatCompletionRequest
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in ChatCompletionRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+			requests = append(requests, in)
+
+			return jsonResponse(t, ChatCompletionResponse{
+				Choices: []Choice{{FinishReason: "stop", Message: Message{Role: "assistant", Content: ", 3."}}},
+			}), nil
+		},
+	}, WithLint())
+
+	in := ChatCompletionRequest{Messages: []Message{{Role: "user", Content: "Count to 3"}}}.WithPrefill("1")
+	first := &ChatCompletionResponse{
+		Choices: []Choice{{FinishReason: "length", Message: Message{Role: "assistant", Content: ", 2"}}},
+	}
+
+	resp, err := client.Continue(context.Background(), in, first)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1, 2, 3.", resp.Choices[0].Message.Content)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, []Message{
+		{Role: "user", Content: "Count to 3"},
+		{Role: "assistant", Content: "1, 2", Prefix: true},
+	}, requests[0].Messages)
+	assert.Len(t, in.Messages, 2)
+}
@@ -0,0 +1,203 @@
+package openaiclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+	// HistoryStrategy decides which past messages are sent along with a new turn.
+	HistoryStrategy interface {
+		// Add stores a message exchanged in the conversation.
+		Add(ctx context.Context, msg Message) error
+		// Select returns the past messages to send before the next message.
+		Select(ctx context.Context, next Message) ([]Message, error)
+	}
+
+	// ConversationOption configures a Conversation.
+	ConversationOption func(*Conversation)
+
+	// Conversation is a multi-turn chat with a model.
+	Conversation struct {
+		client  *Client
+		model   string
+		system  []Message
+		history HistoryStrategy
+		// cacheKey is the prompt cache key of the system messages, if any.
+		cacheKey string
+
+		mu    sync.Mutex
+		turns []Turn
+	}
+
+	// Turn is a single exchange of a conversation.
+	Turn struct {
+		Request   Message
+		Reply     Message
+		StartedAt time.Time
+		Duration  time.Duration
+		Usage     Usage
+	}
+
+	// TokenLedger is the token usage of a conversation, turn by turn.
+	TokenLedger struct {
+		Turns []LedgerEntry
+		// Total is the usage of every turn.
+		Total Usage
+	}
+
+	// LedgerEntry is the token usage of a conversation turn. The prompt tokens
+	// include the cached ones; see Usage.FreshPromptTokens.
+	LedgerEntry struct {
+		Turn      int
+		StartedAt time.Time
+		Usage     Usage
+	}
+
+	// FullHistory sends every past message with each turn.
+	FullHistory struct {
+		mu       sync.Mutex
+		messages []Message
+	}
+)
+
+// NewConversation creates a new conversation with the given model.
+// By default the full history is sent with every turn.
+func NewConversation(client *Client, model string, opts ...ConversationOption) *Conversation {
+	conv := &Conversation{
+		client:  client,
+		model:   model,
+		history: &FullHistory{},
+	}
+
+	for _, opt := range opts {
+		opt(conv)
+	}
+	return conv
+}
+
+// WithSystemPrompt sets a system message sent at the start of every turn.
+func WithSystemPrompt(prompt string) ConversationOption {
+	return func(c *Conversation) {
+		c.system = []Message{{Role: RoleSystem, Content: prompt}}
+		c.cacheKey = ""
+	}
+}
+
+// WithPromptPrefix sends the prefix messages, instead of a system prompt, at
+// the start of every turn, with the prompt cache key of the prefix.
+func WithPromptPrefix(prefix *PromptPrefix) ConversationOption {
+	return func(c *Conversation) {
+		c.system = prefix.Messages()
+		c.cacheKey = prefix.Key()
+	}
+}
+
+// WithHistoryStrategy sets the strategy selecting the past messages sent with each turn.
+func WithHistoryStrategy(strategy HistoryStrategy) ConversationOption {
+	return func(c *Conversation) {
+		c.history = strategy
+	}
+}
+
+// Send sends a user message and records the assistant's reply.
+func (c *Conversation) Send(ctx context.Context, content string) (*ChatCompletionResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	userMsg := Message{Role: RoleUser, Content: content}
+
+	past, err := c.history.Select(ctx, userMsg)
+	if err != nil {
+		return nil, fmt.Errorf("could not select history: %w", err)
+	}
+
+	messages := make([]Message, 0, len(c.system)+len(past)+1)
+	messages = append(messages, c.system...)
+	messages = append(messages, past...)
+	messages = append(messages, userMsg)
+
+	startedAt := time.Now()
+
+	resp, err := c.client.CreateChatCompletion(ctx, ChatCompletionRequest{
+		Model:          c.model,
+		Messages:       messages,
+		PromptCacheKey: c.cacheKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("could not read reply: no choices returned")
+	}
+
+	reply := resp.Choices[0].Message
+
+	for _, msg := range []Message{userMsg, reply} {
+		if err := c.history.Add(ctx, msg); err != nil {
+			return nil, fmt.Errorf("could not store history: %w", err)
+		}
+	}
+
+	c.turns = append(c.turns, Turn{
+		Request:   userMsg,
+		Reply:     reply,
+		StartedAt: startedAt,
+		Duration:  time.Since(startedAt),
+		Usage:     resp.Usage,
+	})
+	return resp, nil
+}
+
+// Messages returns every message exchanged in the conversation, excluding the system prompt.
+func (c *Conversation) Messages() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	messages := make([]Message, 0, len(c.turns)*2)
+	for _, turn := range c.turns {
+		messages = append(messages, turn.Request, turn.Reply)
+	}
+	return messages
+}
+
+// Transcript returns the turns of the conversation.
+func (c *Conversation) Transcript() Transcript {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append(Transcript(nil), c.turns...)
+}
+
+// Ledger returns the token usage of every turn of the conversation and their total.
+func (c *Conversation) Ledger() TokenLedger {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ledger := TokenLedger{Turns: make([]LedgerEntry, len(c.turns))}
+	for i, turn := range c.turns {
+		ledger.Turns[i] = LedgerEntry{Turn: i + 1, StartedAt: turn.StartedAt, Usage: turn.Usage}
+		ledger.Total = ledger.Total.add(turn.Usage)
+	}
+	return ledger
+}
+
+// Add implements HistoryStrategy.
+func (h *FullHistory) Add(_ context.Context, msg Message) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.messages = append(h.messages, msg)
+	return nil
+}
+
+// Select implements HistoryStrategy.
+func (h *FullHistory) Select(_ context.Context, _ Message) ([]Message, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]Message(nil), h.messages...), nil
+}
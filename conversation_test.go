@@ -0,0 +1,105 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newChatClient returns a client whose chat completions reply with the given
+// content and report every request sent to requests.
+func newChatClient(t *testing.T, reply string, requests *[]ChatCompletionRequest) *Client {
+	t.Helper()
+
+	return New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in ChatCompletionRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+			*requests = append(*requests, in)
+			return jsonResponse(t, ChatCompletionResponse{
+				Choices: []Choice{{Message: Message{Role: "assistant", Content: reply}}},
+			}), nil
+		},
+	})
+}
+
+func TestConversation_Send(t *testing.T) {
+	t.Parallel()
+
+	var requests []ChatCompletionRequest
+
+	conv := NewConversation(newChatClient(t, "test_reply", &requests), "test_model", WithSystemPrompt("test_system"))
+
+	_, err := conv.Send(context.Background(), "first")
+	require.NoError(t, err)
+
+	resp, err := conv.Send(context.Background(), "second")
+	require.NoError(t, err)
+	assert.Equal(t, "test_reply", resp.Choices[0].Message.Content)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "test_model", requests[1].Model)
+	assert.Equal(t, []Message{
+		{Role: "system", Content: "test_system"},
+		{Role: "user", Content: "first"},
+		{Role: "assistant", Content: "test_reply"},
+		{Role: "user", Content: "second"},
+	}, requests[1].Messages)
+
+	assert.Equal(t, []Message{
+		{Role: "user", Content: "first"},
+		{Role: "assistant", Content: "test_reply"},
+		{Role: "user", Content: "second"},
+		{Role: "assistant", Content: "test_reply"},
+	}, conv.Messages())
+}
+
+func TestConversation_Ledger(t *testing.T) {
+	t.Parallel()
+
+	usages := []Usage{
+		{PromptTokens: 1200, CompletionTokens: 30, TotalTokens: 1230},
+		{PromptTokens: 1300, CompletionTokens: 20, TotalTokens: 1320, PromptTokensDetails: PromptTokensDetails{CachedTokens: 1024}},
+	}
+
+	var calls int
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			usage := usages[calls]
+			calls++
+			return jsonResponse(t, ChatCompletionResponse{
+				Choices: []Choice{{Message: Message{Role: "assistant", Content: "test_reply"}}},
+				Usage:   usage,
+			}), nil
+		},
+	})
+
+	conv := NewConversation(client, "test_model")
+	for _, content := range []string{"first", "second"} {
+		_, err := conv.Send(context.Background(), content)
+		require.NoError(t, err)
+	}
+
+	ledger := conv.Ledger()
+
+	require.Len(t, ledger.Turns, 2)
+	assert.Equal(t, 1, ledger.Turns[0].Turn)
+	assert.Equal(t, usages[0], ledger.Turns[0].Usage)
+	assert.Equal(t, 2, ledger.Turns[1].Turn)
+	assert.Equal(t, 276, ledger.Turns[1].Usage.FreshPromptTokens())
+	assert.False(t, ledger.Turns[1].StartedAt.IsZero())
+
+	assert.Equal(t, Usage{
+		PromptTokens:        2500,
+		CompletionTokens:    50,
+		TotalTokens:         2550,
+		PromptTokensDetails: PromptTokensDetails{CachedTokens: 1024},
+	}, ledger.Total)
+	assert.Equal(t, 1476, ledger.Total.FreshPromptTokens())
+	assert.InDelta(t, 1024.0/2500, ledger.Total.CacheHitRate(), 1e-9)
+}
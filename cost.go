@@ -0,0 +1,212 @@
+package openaiclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownPrice is returned when estimating the cost of a model without a price.
+var ErrUnknownPrice = errors.New("unknown price")
+
+type (
+	// Pricing is the price of a model in USD per million tokens.
+	Pricing struct {
+		Input float64
+		// CachedInput is the price of prompt tokens served from the prompt
+		// cache; zero means the Input price.
+		CachedInput float64
+		// Output is the price of completion tokens, reasoning tokens included.
+		Output float64
+	}
+
+	// PricingTable holds the prices of models by model ID. Dated snapshots,
+	// such as "gpt-4o-2024-08-06", use the price of the longest model ID
+	// they start with.
+	PricingTable map[string]Pricing
+
+	// CostTracker accumulates the estimated cost of the requests of the
+	// clients using it, by feature and by model. It is safe for concurrent use.
+	CostTracker struct {
+		table PricingTable
+
+		mu        sync.Mutex
+		total     float64
+		byFeature map[string]float64
+		byModel   map[string]float64
+		unpriced  map[string]Usage
+	}
+
+	// costRecorder records the usage of a client into a cost tracker.
+	costRecorder struct {
+		tracker *CostTracker
+		feature string
+	}
+)
+
+// DefaultPricing are the standard prices of the OpenAI models, as published
+// when this version was released. Prices change: keep a copy up to date for
+// billing-grade estimates.
+var DefaultPricing = PricingTable{
+	GPT35Turbo:          {Input: 0.50, Output: 1.50},
+	GPT4Turbo:           {Input: 10, Output: 30},
+	GPT4o:               {Input: 2.50, CachedInput: 1.25, Output: 10},
+	GPT4oMini:           {Input: 0.15, CachedInput: 0.075, Output: 0.60},
+	GPT41:               {Input: 2, CachedInput: 0.50, Output: 8},
+	GPT41Mini:           {Input: 0.40, CachedInput: 0.10, Output: 1.60},
+	GPT41Nano:           {Input: 0.10, CachedInput: 0.025, Output: 0.40},
+	O1:                  {Input: 15, CachedInput: 7.50, Output: 60},
+	O1Mini:              {Input: 1.10, CachedInput: 0.55, Output: 4.40},
+	O3:                  {Input: 2, CachedInput: 0.50, Output: 8},
+	O3Mini:              {Input: 1.10, CachedInput: 0.55, Output: 4.40},
+	O4Mini:              {Input: 1.10, CachedInput: 0.275, Output: 4.40},
+	TextEmbedding3Small: {Input: 0.02},
+	TextEmbedding3Large: {Input: 0.13},
+	TextEmbeddingAda002: {Input: 0.10},
+}
+
+// EstimateCost returns the cost of the usage of the model in USD, with DefaultPricing.
+func EstimateCost(usage Usage, model string) (float64, error) {
+	return DefaultPricing.EstimateCost(usage, model)
+}
+
+// Price returns the price of the model, or false if it has none.
+func (t PricingTable) Price(model string) (Pricing, bool) {
+	return lookupModel(t, model)
+}
+
+// lookupModel returns the value of the model, or of the longest model ID the
+// model is a dated snapshot of.
+func lookupModel[V any](values map[string]V, model string) (V, bool) {
+	if v, ok := values[model]; ok {
+		return v, true
+	}
+
+	var (
+		best  V
+		found string
+	)
+	for id, v := range values {
+		if len(id) > len(found) && strings.HasPrefix(model, id+"-") {
+			best, found = v, id
+		}
+	}
+	return best, found != ""
+}
+
+// EstimateCost returns the cost of the usage of the model in USD, or an
+// error wrapping ErrUnknownPrice if the model has no price.
+func (t PricingTable) EstimateCost(usage Usage, model string) (float64, error) {
+	price, ok := t.Price(model)
+	if !ok {
+		return 0, fmt.Errorf("%w for model %q", ErrUnknownPrice, model)
+	}
+
+	cached := price.CachedInput
+	if cached == 0 {
+		cached = price.Input
+	}
+
+	cost := float64(usage.FreshPromptTokens())*price.Input +
+		float64(usage.PromptTokensDetails.CachedTokens)*cached +
+		float64(usage.CompletionTokens)*price.Output
+	return cost / 1e6, nil
+}
+
+// NewCostTracker creates a cost tracker pricing usage with the table, such as DefaultPricing.
+func NewCostTracker(table PricingTable) *CostTracker {
+	return &CostTracker{
+		table:     table,
+		byFeature: map[string]float64{},
+		byModel:   map[string]float64{},
+		unpriced:  map[string]Usage{},
+	}
+}
+
+// WithCostTracker records the estimated cost of the usage of the client into
+// the tracker, under the feature, so clients serving different features can
+// share a tracker.
+func WithCostTracker(tracker *CostTracker, feature string) Option {
+	return func(c *Client) {
+		c.usage.costs = &costRecorder{tracker: tracker, feature: feature}
+	}
+}
+
+// Record records the cost of the usage of the model under the feature.
+// The usage of models without a price is kept apart, see Unpriced.
+func (t *CostTracker) Record(feature, model string, usage Usage) {
+	cost, err := t.table.EstimateCost(usage, model)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err != nil {
+		t.unpriced[model] = t.unpriced[model].add(usage)
+		return
+	}
+	t.total += cost
+	t.byFeature[feature] += cost
+	t.byModel[model] += cost
+}
+
+// Total returns the cost recorded so far in USD.
+func (t *CostTracker) Total() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.total
+}
+
+// ByFeature returns the cost recorded so far in USD, by feature.
+func (t *CostTracker) ByFeature() map[string]float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return copyCosts(t.byFeature)
+}
+
+// ByModel returns the cost recorded so far in USD, by model as served.
+func (t *CostTracker) ByModel() map[string]float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return copyCosts(t.byModel)
+}
+
+// Unpriced returns the usage recorded for models without a price, by model.
+func (t *CostTracker) Unpriced() map[string]Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	unpriced := make(map[string]Usage, len(t.unpriced))
+	for model, usage := range t.unpriced {
+		unpriced[model] = usage
+	}
+	return unpriced
+}
+
+// copyCosts returns a copy of the costs.
+func copyCosts(costs map[string]float64) map[string]float64 {
+	out := make(map[string]float64, len(costs))
+	for key, cost := range costs {
+		out[key] = cost
+	}
+	return out
+}
+
+// record records the cost of the usage; a nil recorder does nothing.
+func (r *costRecorder) record(model string, usage Usage) {
+	if r == nil {
+		return
+	}
+	r.tracker.Record(r.feature, model, usage)
+}
+
+// servedModel returns the model a response was served by, falling back to the requested one.
+func servedModel(served, requested string) string {
+	if served != "" {
+		return served
+	}
+	return requested
+}
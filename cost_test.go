@@ -0,0 +1,82 @@
+package openaiclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCost(t *testing.T) {
+	t.Parallel()
+
+	usage := Usage{
+		PromptTokens:            1_000_000,
+		CompletionTokens:        100_000,
+		TotalTokens:             1_100_000,
+		PromptTokensDetails:     PromptTokensDetails{CachedTokens: 400_000},
+		CompletionTokensDetails: CompletionTokensDetails{ReasoningTokens: 50_000},
+	}
+
+	tests := []struct {
+		name  string
+		model string
+		want  float64
+	}{
+		{name: "cached prompt", model: GPT4o, want: 0.6*2.50 + 0.4*1.25 + 0.1*10},
+		{name: "dated snapshot", model: "gpt-4o-2024-08-06", want: 0.6*2.50 + 0.4*1.25 + 0.1*10},
+		{name: "longest prefix", model: "gpt-4o-mini-2024-07-18", want: 0.6*0.15 + 0.4*0.075 + 0.1*0.60},
+		{name: "no cache discount", model: GPT35Turbo, want: 1*0.50 + 0.1*1.50},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := EstimateCost(usage, tt.model)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+
+	t.Run("unknown model", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := EstimateCost(usage, "gpt-4o4")
+		assert.ErrorIs(t, err, ErrUnknownPrice)
+	})
+}
+
+func TestCostTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewCostTracker(PricingTable{"test-model": {Input: 1, Output: 2}})
+
+	newClient := func(feature, model string) *Client {
+		return New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return jsonResponse(t, map[string]any{
+					"model": model,
+					"usage": map[string]any{"prompt_tokens": 1_000_000, "completion_tokens": 500_000, "total_tokens": 1_500_000},
+				}), nil
+			},
+		}, WithCostTracker(tracker, feature))
+	}
+
+	summaries := newClient("summaries", "test-model-2025-01-01")
+	search := newClient("search", "test-model")
+	other := newClient("search", "other-model")
+
+	for _, client := range []*Client{summaries, summaries, search, other} {
+		_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "test-model"})
+		require.NoError(t, err)
+	}
+
+	assert.InDelta(t, 6.0, tracker.Total(), 1e-9)
+	assert.Equal(t, map[string]float64{"summaries": 4, "search": 2}, tracker.ByFeature())
+	assert.Equal(t, map[string]float64{"test-model-2025-01-01": 4, "test-model": 2}, tracker.ByModel())
+	assert.Equal(t, map[string]Usage{"other-model": {PromptTokens: 1_000_000, CompletionTokens: 500_000, TotalTokens: 1_500_000}}, tracker.Unpriced())
+}
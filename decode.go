@@ -0,0 +1,76 @@
+package openaiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrResponseMismatch is matched by errors.Is for strictly decoded responses
+// that don't match the expected schema.
+var ErrResponseMismatch = errors.New("response doesn't match the expected schema")
+
+// DecodeMode is the strictness of response decoding.
+type DecodeMode int
+
+const (
+	// DecodeLenient ignores unknown response fields, tolerating additive API
+	// changes. It is the default.
+	DecodeLenient DecodeMode = iota
+	// DecodeStrict rejects responses with unknown fields, mistyped fields or
+	// trailing data, so test environments catch contract drift early. Unknown
+	// fields of chat messages, decoded by their own unmarshaler, are ignored.
+	DecodeStrict
+)
+
+// WithDecodeMode sets how strictly the client decodes responses.
+func WithDecodeMode(mode DecodeMode) Option {
+	return func(c *Client) {
+		c.decodeMode = mode
+	}
+}
+
+// decode decodes the JSON value read from r into out, according to the decode mode.
+func (c *Client) decode(r io.Reader, out any) error {
+	dec := json.NewDecoder(r)
+	if c.decodeMode != DecodeStrict {
+		return dec.Decode(out)
+	}
+
+	dec.DisallowUnknownFields()
+
+	// Embeddings have an unmarshaler the decoder options don't reach.
+	target := out
+	embeddings, isEmbeddings := out.(*EmbeddingResponse)
+	var strictEmbeddings strictEmbeddingResponse
+	if isEmbeddings {
+		target = &strictEmbeddings
+	}
+
+	if err := dec.Decode(target); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+		return fmt.Errorf("%w: %w", ErrResponseMismatch, err)
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: trailing data after the response", ErrResponseMismatch)
+	}
+
+	if isEmbeddings {
+		strictEmbeddings.copyTo(embeddings)
+	}
+	return nil
+}
+
+// decodeBytes decodes the JSON value into out, according to the decode mode.
+func (c *Client) decodeBytes(data []byte, out any) error {
+	if c.decodeMode != DecodeStrict {
+		return json.Unmarshal(data, out)
+	}
+	return c.decode(bytes.NewReader(data), out)
+}
@@ -0,0 +1,168 @@
+package openaiclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDecodeMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		mode     DecodeMode
+		body     string
+		wantErr  error
+		wantID   string
+		wantFail bool
+	}{
+		{
+			name:   "lenient ignores unknown fields",
+			mode:   DecodeLenient,
+			body:   `{"id":"chatcmpl-123","system_fingerprint":"fp_123"}`,
+			wantID: "chatcmpl-123",
+		},
+		{
+			name:    "strict rejects unknown fields",
+			mode:    DecodeStrict,
+			body:    `{"id":"chatcmpl-123","system_fingerprint":"fp_123"}`,
+			wantErr: ErrResponseMismatch,
+		},
+		{
+			name:    "strict rejects mistyped fields",
+			mode:    DecodeStrict,
+			body:    `{"id":123}`,
+			wantErr: ErrResponseMismatch,
+		},
+		{
+			name:    "strict rejects trailing data",
+			mode:    DecodeStrict,
+			body:    `{"id":"chatcmpl-123"} {"id":"chatcmpl-456"}`,
+			wantErr: ErrResponseMismatch,
+		},
+		{
+			name:     "strict reports malformed responses",
+			mode:     DecodeStrict,
+			body:     `{"id":`,
+			wantFail: true,
+		},
+		{
+			name:   "strict accepts matching responses",
+			mode:   DecodeStrict,
+			body:   `{"id":"chatcmpl-123","choices":[{"index":0,"message":{"role":"assistant","content":"hi"}}]}`,
+			wantID: "chatcmpl-123",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewBufferString(tt.body)),
+					}, nil
+				},
+			}, WithDecodeMode(tt.mode))
+
+			resp, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.wantFail:
+				require.Error(t, err)
+				assert.NotErrorIs(t, err, ErrResponseMismatch)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantID, resp.ID)
+			}
+		})
+	}
+}
+
+func TestWithDecodeMode_Embeddings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mode    DecodeMode
+		body    string
+		wantErr error
+	}{
+		{
+			name: "lenient ignores unknown embedding fields",
+			mode: DecodeLenient,
+			body: `{"data":[{"index":0,"embedding":[0.5],"score":1}]}`,
+		},
+		{
+			name:    "strict rejects unknown embedding fields",
+			mode:    DecodeStrict,
+			body:    `{"data":[{"index":0,"embedding":[0.5],"score":1}]}`,
+			wantErr: ErrResponseMismatch,
+		},
+		{
+			name:    "strict rejects unknown response fields",
+			mode:    DecodeStrict,
+			body:    `{"data":[{"index":0,"embedding":[0.5]}],"id":"emb-1"}`,
+			wantErr: ErrResponseMismatch,
+		},
+		{
+			name: "strict accepts matching responses",
+			mode: DecodeStrict,
+			body: `{"object":"list","model":"text-embedding-3-small","data":[{"object":"embedding","index":0,"embedding":"AAAAPw=="}],"usage":{"prompt_tokens":1,"total_tokens":1}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewBufferString(tt.body)),
+					}, nil
+				},
+			}, WithDecodeMode(tt.mode))
+
+			resp, err := client.CreateEmbedding(context.Background(), EmbeddingRequest{Model: "text-embedding-3-small", Input: TextInput("hi")})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, resp.Data, 1)
+			assert.Equal(t, []float32{0.5}, resp.Data[0].Embedding)
+		})
+	}
+}
+
+func TestStrictEmbeddingResponse_Fields(t *testing.T) {
+	t.Parallel()
+
+	// The strict response must decode every field of EmbeddingResponse.
+	fields := func(typ reflect.Type) []string {
+		var names []string
+		for i := 0; i < typ.NumField(); i++ {
+			if tag := typ.Field(i).Tag.Get("json"); tag != "" && tag != "-" {
+				names = append(names, tag)
+			}
+		}
+		return names
+	}
+	assert.Equal(t, fields(reflect.TypeOf(EmbeddingResponse{})), fields(reflect.TypeOf(strictEmbeddingResponse{})))
+}
@@ -0,0 +1,44 @@
+package openaiclient
+
+import "context"
+
+// The misspelled names of the chat completion and embedding API are kept as
+// aliases for one release cycle, so existing code keeps compiling.
+
+type (
+	// CompletitionRequest is the former name of ChatCompletionRequest.
+	//
+	// Deprecated: Use ChatCompletionRequest.
+	CompletitionRequest = ChatCompletionRequest
+
+	// CompletitionResponse is the former name of ChatCompletionResponse.
+	//
+	// Deprecated: Use ChatCompletionResponse.
+	CompletitionResponse = ChatCompletionResponse
+
+	// EmbbedingRequest is the former name of EmbeddingRequest.
+	//
+	// Deprecated: Use EmbeddingRequest.
+	EmbbedingRequest = EmbeddingRequest
+)
+
+// CreateChatCompletition is the former name of CreateChatCompletion.
+//
+// Deprecated: Use CreateChatCompletion.
+func (c *Client) CreateChatCompletition(ctx context.Context, in ChatCompletionRequest) (*ChatCompletionResponse, error) {
+	return c.CreateChatCompletion(ctx, in)
+}
+
+// CreateChatCompletitionWithin is the former name of CreateChatCompletionWithin.
+//
+// Deprecated: Use CreateChatCompletionWithin.
+func (c *Client) CreateChatCompletitionWithin(ctx context.Context, in ChatCompletionRequest, budget LatencyBudget) (*ChatCompletionResponse, ServedBy, error) {
+	return c.CreateChatCompletionWithin(ctx, in, budget)
+}
+
+// CreateChatCompletitionInLanguage is the former name of CreateChatCompletionInLanguage.
+//
+// Deprecated: Use CreateChatCompletionInLanguage.
+func (c *Client) CreateChatCompletitionInLanguage(ctx context.Context, in ChatCompletionRequest, lang string, maxRetries int) (*ChatCompletionResponse, error) {
+	return c.CreateChatCompletionInLanguage(ctx, in, lang, maxRetries)
+}
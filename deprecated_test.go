@@ -0,0 +1,34 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecatedNames(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in ChatCompletionRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+			assert.Equal(t, "gpt-4o", in.Model)
+
+			return jsonResponse(t, ChatCompletionResponse{ID: "chatcmpl-123"}), nil
+		},
+	})
+
+	var resp *CompletitionResponse
+	resp, err := client.CreateChatCompletition(context.Background(), CompletitionRequest{Model: "gpt-4o"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "chatcmpl-123", resp.ID)
+
+	var in EmbbedingRequest = EmbeddingRequest{Model: "text-embedding-3-small"}
+	assert.Equal(t, "text-embedding-3-small", in.Model)
+}
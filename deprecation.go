@@ -0,0 +1,122 @@
+package openaiclient
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+type (
+	// Deprecation describes the deprecation of a model.
+	Deprecation struct {
+		// Replacement is the suggested replacement model.
+		Replacement string
+		// Shutdown is the date the model stops being served.
+		Shutdown time.Time
+	}
+
+	// DeprecationWarning is reported the first time a client targets a deprecated model.
+	DeprecationWarning struct {
+		Model string
+		Deprecation
+	}
+
+	// deprecationPolicy reports every deprecated model once.
+	deprecationPolicy struct {
+		models  map[string]Deprecation
+		handler func(DeprecationWarning)
+
+		mu     sync.Mutex
+		warned map[string]struct{}
+	}
+)
+
+// deprecations are the known deprecated models, by model ID.
+var deprecations = map[string]Deprecation{
+	"text-davinci-003":       {Replacement: "gpt-3.5-turbo-instruct", Shutdown: date(2024, 1, 4)},
+	"gpt-3.5-turbo-0301":     {Replacement: "gpt-3.5-turbo", Shutdown: date(2024, 9, 13)},
+	"gpt-3.5-turbo-0613":     {Replacement: "gpt-3.5-turbo", Shutdown: date(2024, 9, 13)},
+	"gpt-3.5-turbo-16k-0613": {Replacement: "gpt-3.5-turbo", Shutdown: date(2024, 9, 13)},
+	"gpt-4-vision-preview":   {Replacement: "gpt-4o", Shutdown: date(2024, 12, 6)},
+	"gpt-4-32k":              {Replacement: "gpt-4o", Shutdown: date(2025, 6, 6)},
+	"gpt-4-32k-0314":         {Replacement: "gpt-4o", Shutdown: date(2025, 6, 6)},
+	"gpt-4-32k-0613":         {Replacement: "gpt-4o", Shutdown: date(2025, 6, 6)},
+	"gpt-4.5-preview":        {Replacement: "gpt-4.1", Shutdown: date(2025, 7, 14)},
+	"o1-preview":             {Replacement: "o3", Shutdown: date(2025, 7, 28)},
+	"o1-mini":                {Replacement: "o4-mini", Shutdown: date(2025, 10, 27)},
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Retired reports whether the model was shut down at the given time.
+func (d Deprecation) Retired(now time.Time) bool {
+	return !d.Shutdown.IsZero() && !now.Before(d.Shutdown)
+}
+
+// String returns a human readable warning.
+func (w DeprecationWarning) String() string {
+	state := "is deprecated"
+	if w.Retired(time.Now()) {
+		state = "was retired"
+	}
+
+	msg := fmt.Sprintf("model %s %s", w.Model, state)
+	if !w.Shutdown.IsZero() {
+		msg += fmt.Sprintf(" (shutdown: %s)", w.Shutdown.Format(time.DateOnly))
+	}
+	if w.Replacement != "" {
+		msg += ", use " + w.Replacement + " instead"
+	}
+	return msg
+}
+
+// WithDeprecationWarnings makes the client report the first request to every
+// deprecated or retired model to the handler, with the suggested replacement.
+// Models extends or overrides the known deprecations, by model ID. A nil
+// handler logs the warnings with the standard logger.
+func WithDeprecationWarnings(handler func(DeprecationWarning), models map[string]Deprecation) Option {
+	if handler == nil {
+		handler = func(w DeprecationWarning) {
+			log.Printf("openaiclient: %s", w)
+		}
+	}
+
+	return func(c *Client) {
+		p := deprecationPolicy{
+			models:  make(map[string]Deprecation, len(deprecations)+len(models)),
+			handler: handler,
+			warned:  make(map[string]struct{}),
+		}
+		for model, d := range deprecations {
+			p.models[model] = d
+		}
+		for model, d := range models {
+			p.models[model] = d
+		}
+		c.deprecations = &p
+	}
+}
+
+// check reports the model if it is deprecated and wasn't reported yet.
+func (p *deprecationPolicy) check(model string) {
+	if p == nil {
+		return
+	}
+
+	d, ok := p.models[model]
+	if !ok {
+		return
+	}
+
+	p.mu.Lock()
+	_, warned := p.warned[model]
+	p.warned[model] = struct{}{}
+	p.mu.Unlock()
+
+	if !warned {
+		p.handler(DeprecationWarning{Model: model, Deprecation: d})
+	}
+}
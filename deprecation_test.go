@@ -0,0 +1,58 @@
+package openaiclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDeprecationWarnings(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		warnings []DeprecationWarning
+	)
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(t, ChatCompletionResponse{}), nil
+		},
+	}, WithDeprecationWarnings(func(w DeprecationWarning) {
+		mu.Lock()
+		defer mu.Unlock()
+		warnings = append(warnings, w)
+	}, map[string]Deprecation{
+		"legacy-model": {Replacement: "new-model"},
+	}))
+
+	for _, model := range []string{"gpt-4o", "gpt-4-32k", "gpt-4-32k", "legacy-model"} {
+		_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: model})
+		require.NoError(t, err)
+	}
+
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "gpt-4-32k", warnings[0].Model)
+	assert.Equal(t, "gpt-4o", warnings[0].Replacement)
+	assert.Equal(t, "legacy-model", warnings[1].Model)
+	assert.Equal(t, "model legacy-model is deprecated, use new-model instead", warnings[1].String())
+}
+
+func TestDeprecationWarning_String(t *testing.T) {
+	t.Parallel()
+
+	w := DeprecationWarning{
+		Model:       "old-model",
+		Deprecation: Deprecation{Replacement: "new-model", Shutdown: date(2024, 1, 4)},
+	}
+
+	assert.Equal(t, "model old-model was retired (shutdown: 2024-01-04), use new-model instead", w.String())
+	assert.True(t, w.Retired(date(2024, 1, 4)))
+	assert.False(t, w.Retired(date(2024, 1, 3)))
+	assert.False(t, Deprecation{}.Retired(time.Now()))
+}
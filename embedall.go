@@ -0,0 +1,222 @@
+package openaiclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Bulk embedding defaults.
+const (
+	DefaultEmbedBatchSize   = 256
+	DefaultEmbedConcurrency = 4
+)
+
+type (
+	// EmbedAllOption configures EmbedAll.
+	EmbedAllOption func(*embedAllConfig)
+
+	embedAllConfig struct {
+		template          EmbeddingRequest
+		batchSize         int
+		concurrency       int
+		requestsPerMinute int
+		tokensPerMinute   int
+		retry             RetryPolicy
+	}
+
+	// embedPace paces the requests of EmbedAll under its rate limits.
+	embedPace struct {
+		requests *pacer
+		tokens   *pacer
+	}
+)
+
+// WithEmbedRequest sets the request every batch is sent with, such as its
+// dimensions. Its model and input are ignored.
+func WithEmbedRequest(template EmbeddingRequest) EmbedAllOption {
+	return func(c *embedAllConfig) {
+		c.template = template
+	}
+}
+
+// WithEmbedBatchSize sets the maximum number of texts sent per request.
+// Batches are also kept under MaxEmbeddingInputs and MaxEmbeddingTokens.
+func WithEmbedBatchSize(n int) EmbedAllOption {
+	return func(c *embedAllConfig) {
+		c.batchSize = n
+	}
+}
+
+// WithEmbedConcurrency sets the number of requests in flight.
+func WithEmbedConcurrency(n int) EmbedAllOption {
+	return func(c *embedAllConfig) {
+		c.concurrency = n
+	}
+}
+
+// WithEmbedRateLimit paces requests so that no more than requests requests and
+// tokens estimated tokens are sent per minute, matching the limits of the
+// account. A zero limit disables its pacing.
+func WithEmbedRateLimit(requests, tokens int) EmbedAllOption {
+	return func(c *embedAllConfig) {
+		c.requestsPerMinute, c.tokensPerMinute = requests, tokens
+	}
+}
+
+// WithEmbedRetry sets how failed batches are retried, DefaultRetryPolicy by default.
+// Batches are retried on top of the client's own retry policy, also on network errors.
+func WithEmbedRetry(policy RetryPolicy) EmbedAllOption {
+	return func(c *embedAllConfig) {
+		c.retry = policy
+	}
+}
+
+// EmbedAll embeds the texts with the model in concurrent batches, pacing them
+// under the rate limits, if set, and retrying failed batches. It returns the
+// embedding of every text, by index, along with a BatchError of the texts of
+// the batches failing after all retries, whose embeddings are left nil.
+func (c *Client) EmbedAll(ctx context.Context, model string, texts []string, opts ...EmbedAllOption) ([][]float32, error) {
+	cfg := embedAllConfig{
+		batchSize:   DefaultEmbedBatchSize,
+		concurrency: DefaultEmbedConcurrency,
+		retry:       DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.batchSize < 1 || cfg.concurrency < 1 || cfg.requestsPerMinute < 0 || cfg.tokensPerMinute < 0 {
+		return nil, fmt.Errorf("could not embed texts: batch size and concurrency must be positive and rate limits not negative")
+	}
+
+	vectors := make([][]float32, len(texts))
+	if len(texts) == 0 {
+		return vectors, nil
+	}
+
+	var pace embedPace
+	if cfg.requestsPerMinute > 0 {
+		pace.requests = &pacer{interval: time.Minute / time.Duration(cfg.requestsPerMinute), now: time.Now}
+	}
+	if cfg.tokensPerMinute > 0 {
+		pace.tokens = &pacer{interval: time.Minute / time.Duration(cfg.tokensPerMinute), now: time.Now}
+	}
+
+	input := TextInput(texts...)
+	split := autoBatch{maxInputs: min(cfg.batchSize, MaxEmbeddingInputs), maxTokens: MaxEmbeddingTokens}
+
+	var (
+		mu     sync.Mutex
+		failed []BatchItemError
+		wg     sync.WaitGroup
+		sem    = make(chan struct{}, cfg.concurrency)
+	)
+
+	fail := func(start, end int, err error) {
+		err = fmt.Errorf("could not embed texts %d to %d: %w", start, end-1, err)
+		for i := start; i < end; i++ {
+			failed = append(failed, BatchItemError{Index: i, Err: err})
+		}
+	}
+
+batches:
+	for _, batch := range split.split(input) {
+		start, end := batch[0], batch[1]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			// The remaining texts fail with the context.
+			mu.Lock()
+			fail(start, len(texts), ctx.Err())
+			mu.Unlock()
+			break batches
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			batchVectors, err := c.embedBatch(ctx, cfg, model, input.slice(start, end), pace)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				fail(start, end, err)
+				return
+			}
+			copy(vectors[start:end], batchVectors)
+		}(start, end)
+	}
+	wg.Wait()
+
+	return vectors, newBatchError("embed texts", len(texts), failed)
+}
+
+// embedBatch embeds the input, retrying according to the policy, and returns
+// the embeddings by index.
+func (c *Client) embedBatch(ctx context.Context, cfg embedAllConfig, model string, input EmbeddingInput, pace embedPace) ([][]float32, error) {
+	var tokens int
+	for i := 0; i < input.Len(); i++ {
+		tokens += input.tokens(i)
+	}
+
+	req := cfg.template
+	req.Model, req.Input = model, input
+
+	for attempt := 1; ; attempt++ {
+		if err := pace.wait(ctx, c.sleep, tokens); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.CreateEmbedding(ctx, req)
+		if err == nil {
+			return embeddingsByIndex(resp, input.Len())
+		}
+
+		if !retryableBatchError(err) || attempt >= cfg.retry.MaxAttempts || ctx.Err() != nil {
+			return nil, err
+		}
+
+		if err := c.sleep(ctx, cfg.retry.delay(attempt, nil)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// wait waits for the slots of a request of the given number of tokens.
+func (p embedPace) wait(ctx context.Context, sleep func(ctx context.Context, d time.Duration) error, tokens int) error {
+	var d time.Duration
+	if p.requests != nil {
+		d = p.requests.reserve(1)
+	}
+	if p.tokens != nil {
+		d = max(d, p.tokens.reserve(tokens))
+	}
+
+	if d <= 0 {
+		return nil
+	}
+	return sleep(ctx, d)
+}
+
+// embeddingsByIndex returns the n embeddings of the response by index.
+func embeddingsByIndex(resp *EmbeddingResponse, n int) ([][]float32, error) {
+	if len(resp.Data) != n {
+		return nil, fmt.Errorf("got %d embeddings for %d texts", len(resp.Data), n)
+	}
+
+	vectors := make([][]float32, n)
+	seen := make([]bool, n)
+	for _, embedding := range resp.Data {
+		if embedding.Index < 0 || embedding.Index >= n || seen[embedding.Index] {
+			return nil, fmt.Errorf("got embedding of unexpected index %d", embedding.Index)
+		}
+		vectors[embedding.Index], seen[embedding.Index] = embedding.Embedding, true
+	}
+	return vectors, nil
+}
@@ -0,0 +1,152 @@
+package openaiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// embedAllResponse embeds every text, a number, as itself, listing the
+// embeddings in reverse order.
+func embedAllResponse(t *testing.T, texts []string) *http.Response {
+	t.Helper()
+
+	data := make([]Embedding, 0, len(texts))
+	for i := len(texts) - 1; i >= 0; i-- {
+		n, err := strconv.Atoi(texts[i])
+		require.NoError(t, err)
+		data = append(data, Embedding{Index: i, Embedding: []float32{float32(n)}})
+	}
+	return jsonResponse(t, EmbeddingResponse{Data: data})
+}
+
+func TestClient_EmbedAll(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		batches [][]string
+		sleeps  []time.Duration
+	)
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in EmbeddingRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+			assert.Equal(t, "text-embedding-3-small", in.Model)
+			assert.Equal(t, 256, in.Dimensions)
+
+			mu.Lock()
+			batches = append(batches, in.Input.Texts)
+			mu.Unlock()
+			return embedAllResponse(t, in.Input.Texts), nil
+		},
+	})
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sleeps = append(sleeps, d)
+		return nil
+	}
+
+	texts := []string{"0", "1", "2", "3", "4"}
+	vectors, err := client.EmbedAll(context.Background(), "text-embedding-3-small", texts,
+		WithEmbedRequest(EmbeddingRequest{Model: "ignored", Dimensions: 256}),
+		WithEmbedBatchSize(2),
+		WithEmbedConcurrency(2),
+		WithEmbedRateLimit(60, 0),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]float32{{0}, {1}, {2}, {3}, {4}}, vectors)
+	assert.ElementsMatch(t, [][]string{{"0", "1"}, {"2", "3"}, {"4"}}, batches)
+
+	// Requests are spaced by a second to stay under 60 requests per minute.
+	sort.Slice(sleeps, func(i, j int) bool { return sleeps[i] < sleeps[j] })
+	require.Len(t, sleeps, 2)
+	assert.InDelta(t, time.Second, sleeps[0], float64(100*time.Millisecond))
+	assert.InDelta(t, 2*time.Second, sleeps[1], float64(100*time.Millisecond))
+}
+
+func TestClient_EmbedAll_FailedBatch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		calls = map[string]int{}
+	)
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in EmbeddingRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+			mu.Lock()
+			calls[in.Input.Texts[0]]++
+			attempt := calls[in.Input.Texts[0]]
+			mu.Unlock()
+
+			switch {
+			case in.Input.Texts[0] == "1":
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"error":{"message":"invalid input"}}`)),
+				}, nil
+			case in.Input.Texts[0] == "2" && attempt == 1:
+				return nil, io.ErrUnexpectedEOF
+			}
+			return embedAllResponse(t, in.Input.Texts), nil
+		},
+	})
+	client.sleep = func(context.Context, time.Duration) error { return nil }
+
+	vectors, err := client.EmbedAll(context.Background(), "text-embedding-3-small", []string{"0", "1", "2"}, WithEmbedBatchSize(1))
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, []int{1}, batchErr.Indexes())
+
+	// Network errors are retried, client errors aren't.
+	assert.Equal(t, map[string]int{"0": 1, "1": 1, "2": 2}, calls)
+	assert.Equal(t, [][]float32{{0}, nil, {2}}, vectors)
+}
+
+func TestClient_EmbedAll_UnexpectedResponse(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(t, EmbeddingResponse{Data: []Embedding{{Index: 0}, {Index: 0}}}), nil
+		},
+	})
+
+	_, err := client.EmbedAll(context.Background(), "text-embedding-3-small", []string{"0", "1"})
+	assert.ErrorContains(t, err, "unexpected index 0")
+}
+
+func TestClient_EmbedAll_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{})
+
+	_, err := client.EmbedAll(context.Background(), "text-embedding-3-small", []string{"hello"}, WithEmbedConcurrency(0))
+	assert.Error(t, err)
+
+	vectors, err := client.EmbedAll(context.Background(), "text-embedding-3-small", nil)
+	require.NoError(t, err)
+	assert.Empty(t, vectors)
+}
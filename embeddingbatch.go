@@ -0,0 +1,103 @@
+package openaiclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Per-request limits of the embedding endpoint.
+const (
+	MaxEmbeddingInputs = 2048
+	MaxEmbeddingTokens = 300000
+)
+
+// autoBatch is the splitting of oversized embedding requests.
+type autoBatch struct {
+	maxInputs int
+	maxTokens int
+}
+
+// WithAutoBatch makes CreateEmbedding split requests with more than maxInputs
+// inputs or maxTokens estimated tokens into several requests, sent one after
+// the other. The embeddings are returned with the indexes of their inputs in
+// the original request and the usage is merged. Zero limits default to
+// MaxEmbeddingInputs and MaxEmbeddingTokens. Inputs larger than maxTokens on
+// their own are sent alone, for the API to reject.
+func WithAutoBatch(maxInputs, maxTokens int) Option {
+	return func(c *Client) {
+		if maxInputs <= 0 {
+			maxInputs = MaxEmbeddingInputs
+		}
+		if maxTokens <= 0 {
+			maxTokens = MaxEmbeddingTokens
+		}
+		c.autoBatch = &autoBatch{maxInputs: maxInputs, maxTokens: maxTokens}
+	}
+}
+
+// split returns the bounds [start, end) of the batches of the input.
+func (b *autoBatch) split(in EmbeddingInput) [][2]int {
+	var (
+		batches [][2]int
+		start   int
+		tokens  int
+	)
+	for i := 0; i < in.Len(); i++ {
+		n := in.tokens(i)
+		if i > start && (i-start == b.maxInputs || tokens+n > b.maxTokens) {
+			batches = append(batches, [2]int{start, i})
+			start, tokens = i, 0
+		}
+		tokens += n
+	}
+	return append(batches, [2]int{start, in.Len()})
+}
+
+// tokens estimates the tokens of the input at index i.
+func (in EmbeddingInput) tokens(i int) int {
+	if len(in.Tokens) > 0 {
+		return len(in.Tokens[i])
+	}
+	return EstimateTokens(in.Texts[i])
+}
+
+// slice returns the inputs [start, end).
+func (in EmbeddingInput) slice(start, end int) EmbeddingInput {
+	if len(in.Tokens) > 0 {
+		return EmbeddingInput{Tokens: in.Tokens[start:end]}
+	}
+	return EmbeddingInput{Texts: in.Texts[start:end]}
+}
+
+// createEmbeddingBatches sends the request in batches and merges their responses.
+func (c *Client) createEmbeddingBatches(ctx context.Context, in EmbeddingRequest, batches [][2]int) (*EmbeddingResponse, error) {
+	var merged *EmbeddingResponse
+	for _, batch := range batches {
+		req := in
+		req.Input = in.Input.slice(batch[0], batch[1])
+
+		var resp EmbeddingResponse
+		if err := c.post(ctx, c.url(EndpointEmbeddings), req, &resp); err != nil {
+			return nil, fmt.Errorf("could not create embeddings of inputs [%d, %d): %w", batch[0], batch[1], err)
+		}
+		c.usage.record(servedModel(resp.Model, in.Model), resp.Usage)
+
+		for i := range resp.Data {
+			resp.Data[i].Index += batch[0]
+		}
+
+		if merged == nil {
+			merged = &resp
+			merged.Data = append(make([]Embedding, 0, in.Input.Len()), resp.Data...)
+			continue
+		}
+		merged.Data = append(merged.Data, resp.Data...)
+		merged.Usage = merged.Usage.add(resp.Usage)
+	}
+
+	sort.SliceStable(merged.Data, func(i, j int) bool {
+		return merged.Data[i].Index < merged.Data[j].Index
+	})
+	return merged, nil
+}
@@ -0,0 +1,121 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoBatch_Split(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		batch autoBatch
+		in    EmbeddingInput
+		want  [][2]int
+	}{
+		{
+			name:  "empty",
+			batch: autoBatch{maxInputs: 2, maxTokens: 100},
+			want:  [][2]int{{0, 0}},
+		},
+		{
+			name:  "within limits",
+			batch: autoBatch{maxInputs: 3, maxTokens: 100},
+			in:    TextInput("a", "b", "c"),
+			want:  [][2]int{{0, 3}},
+		},
+		{
+			name:  "inputs",
+			batch: autoBatch{maxInputs: 2, maxTokens: 100},
+			in:    TextInput("a", "b", "c", "d", "e"),
+			want:  [][2]int{{0, 2}, {2, 4}, {4, 5}},
+		},
+		{
+			name:  "tokens",
+			batch: autoBatch{maxInputs: 10, maxTokens: 5},
+			in:    TokenInput([]int{1, 2, 3}, []int{4, 5}, []int{6}, []int{1, 2, 3, 4, 5, 6, 7}, []int{8}),
+			want:  [][2]int{{0, 2}, {2, 3}, {3, 4}, {4, 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.batch.split(tt.in))
+		})
+	}
+}
+
+func TestClient_CreateEmbedding_AutoBatch(t *testing.T) {
+	t.Parallel()
+
+	var batches [][]string
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in EmbeddingRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+			assert.Equal(t, "text-embedding-3-small", in.Model)
+			texts := in.Input.Texts
+			batches = append(batches, texts)
+
+			// Embeddings are returned out of order, with the index in the batch.
+			data := make([]map[string]any, len(texts))
+			for i, text := range texts {
+				data[len(texts)-1-i] = map[string]any{"object": "embedding", "index": i, "embedding": []float32{float32(len(text))}}
+			}
+			return jsonResponse(t, map[string]any{
+				"object": "list",
+				"model":  "text-embedding-3-small",
+				"data":   data,
+				"usage":  map[string]int{"prompt_tokens": len(texts), "total_tokens": len(texts)},
+			}), nil
+		},
+	}, WithAutoBatch(2, 0))
+
+	texts := []string{"a", "bb", "ccc", "dddd", "eeeee"}
+	resp, err := client.CreateEmbedding(context.Background(), EmbeddingRequest{Model: "text-embedding-3-small", Input: TextInput(texts...)})
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{{"a", "bb"}, {"ccc", "dddd"}, {"eeeee"}}, batches)
+	require.Len(t, resp.Data, len(texts))
+	for i, emb := range resp.Data {
+		assert.Equal(t, i, emb.Index)
+		assert.Equal(t, []float32{float32(len(texts[i]))}, emb.Embedding)
+	}
+	assert.Equal(t, Usage{PromptTokens: 5, TotalTokens: 5}, resp.Usage)
+	assert.Equal(t, "text-embedding-3-small", resp.Model)
+	assert.Equal(t, 5, client.Usage().TotalTokens)
+}
+
+func TestClient_CreateEmbedding_AutoBatchError(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 2 {
+				resp := jsonResponse(t, map[string]any{"error": map[string]any{"message": "too long"}})
+				resp.StatusCode = http.StatusBadRequest
+				return resp, nil
+			}
+			return jsonResponse(t, EmbeddingResponse{Data: []Embedding{{}}}), nil
+		},
+	}, WithAutoBatch(1, 0))
+
+	_, err := client.CreateEmbedding(context.Background(), EmbeddingRequest{Model: "text-embedding-3-small", Input: TextInput("a", strings.Repeat("b", 10), "c")})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.ErrorContains(t, err, "inputs [1, 2)")
+	assert.Equal(t, 2, calls)
+}
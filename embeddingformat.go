@@ -0,0 +1,113 @@
+package openaiclient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Embedding encoding formats. Base64 encoded embeddings are about a third
+// smaller than JSON arrays of floats, which adds up on large batches.
+const (
+	EncodingFormatFloat  = "float"
+	EncodingFormatBase64 = "base64"
+)
+
+type (
+	// embedding has the fields of Embedding, without its JSON methods.
+	embedding Embedding
+
+	// strictEmbeddingResponse is an EmbeddingResponse whose embeddings reject
+	// unknown fields, which decoder options don't reach through the
+	// unmarshaler of Embedding.
+	strictEmbeddingResponse struct {
+		Object string            `json:"object"`
+		Data   []strictEmbedding `json:"data"`
+		Model  string            `json:"model"`
+		Usage  Usage             `json:"usage"`
+	}
+
+	// strictEmbedding is an Embedding rejecting unknown fields.
+	strictEmbedding Embedding
+)
+
+// UnmarshalJSON implements json.Unmarshaler, accepting embeddings encoded as
+// arrays of floats or as base64 strings of little-endian float32 values.
+func (e *Embedding) UnmarshalJSON(data []byte) error {
+	return e.unmarshal(data, false)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *strictEmbedding) UnmarshalJSON(data []byte) error {
+	return (*Embedding)(e).unmarshal(data, true)
+}
+
+// unmarshal decodes the embedding, rejecting unknown fields if strict.
+func (e *Embedding) unmarshal(data []byte, strict bool) error {
+	var raw struct {
+		embedding
+		Embedding json.RawMessage `json:"embedding"`
+	}
+
+	var err error
+	if strict {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&raw)
+	} else {
+		err = json.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return err
+	}
+	*e = Embedding(raw.embedding)
+
+	vector := bytes.TrimSpace(raw.Embedding)
+	switch {
+	case len(vector) == 0 || bytes.Equal(vector, []byte("null")):
+		return nil
+	case vector[0] == '"':
+		var encoded string
+		if err := json.Unmarshal(vector, &encoded); err != nil {
+			return err
+		}
+
+		decoded, err := decodeBase64Vector(encoded)
+		if err != nil {
+			return fmt.Errorf("could not decode embedding: %w", err)
+		}
+		e.Embedding = decoded
+		return nil
+	default:
+		return json.Unmarshal(vector, &e.Embedding)
+	}
+}
+
+// decodeBase64Vector decodes a base64 string of little-endian float32 values.
+func decodeBase64Vector(encoded string) ([]float32, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(data)%4 != 0 {
+		return nil, fmt.Errorf("%d bytes are not a whole number of float32 values", len(data))
+	}
+
+	vector := make([]float32, len(data)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+	}
+	return vector, nil
+}
+
+// copyTo sets the decoded fields of the response.
+func (r *strictEmbeddingResponse) copyTo(resp *EmbeddingResponse) {
+	resp.Object, resp.Model, resp.Usage = r.Object, r.Model, r.Usage
+	resp.Data = make([]Embedding, len(r.Data))
+	for i, e := range r.Data {
+		resp.Data[i] = Embedding(e)
+	}
+}
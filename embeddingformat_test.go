@@ -0,0 +1,105 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// base64Vector encodes the vector like the API does for base64 embeddings.
+func base64Vector(vector ...float32) string {
+	data := make([]byte, 0, 4*len(vector))
+	for _, v := range vector {
+		data = binary.LittleEndian.AppendUint32(data, math.Float32bits(v))
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func TestEmbedding_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		want    Embedding
+		wantErr bool
+	}{
+		{
+			name: "floats",
+			data: `{"object":"embedding","embedding":[0.5,-0.25],"index":1}`,
+			want: Embedding{Object: "embedding", Embedding: []float32{0.5, -0.25}, Index: 1},
+		},
+		{
+			name: "base64",
+			data: `{"object":"embedding","embedding":"` + base64Vector(0.5, -0.25, 3) + `","index":2}`,
+			want: Embedding{Object: "embedding", Embedding: []float32{0.5, -0.25, 3}, Index: 2},
+		},
+		{
+			name: "null",
+			data: `{"object":"embedding","embedding":null}`,
+			want: Embedding{Object: "embedding"},
+		},
+		{
+			name:    "invalid base64",
+			data:    `{"embedding":"not base64!"}`,
+			wantErr: true,
+		},
+		{
+			name:    "partial float",
+			data:    `{"embedding":"` + base64.StdEncoding.EncodeToString([]byte{1, 2, 3, 4, 5}) + `"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got Embedding
+			err := json.Unmarshal([]byte(tt.data), &got)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_CreateEmbedding_Base64(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in map[string]any
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+			assert.Equal(t, float64(2), in["dimensions"])
+			assert.Equal(t, EncodingFormatBase64, in["encoding_format"])
+
+			return jsonResponse(t, map[string]any{
+				"object": "list",
+				"data":   []map[string]any{{"object": "embedding", "embedding": base64Vector(0.125, 1), "index": 0}},
+			}), nil
+		},
+	})
+
+	resp, err := client.CreateEmbedding(context.Background(), EmbeddingRequest{
+		Model:          "text-embedding-3-small",
+		Input:          TextInput("hello"),
+		Dimensions:     2,
+		EncodingFormat: EncodingFormatBase64,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []float32{0.125, 1}, resp.Data[0].Embedding)
+}
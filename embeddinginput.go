@@ -0,0 +1,77 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EmbeddingInput is the input of an embedding request: either texts or token
+// arrays. A single text or token array is sent as is, several as a batch,
+// producing one embedding per input in the same order.
+type EmbeddingInput struct {
+	Texts  []string
+	Tokens [][]int
+}
+
+// TextInput returns the input embedding the given texts.
+func TextInput(texts ...string) EmbeddingInput {
+	return EmbeddingInput{Texts: texts}
+}
+
+// TokenInput returns the input embedding the given token arrays.
+func TokenInput(tokens ...[]int) EmbeddingInput {
+	return EmbeddingInput{Tokens: tokens}
+}
+
+// Len returns the number of inputs.
+func (in EmbeddingInput) Len() int {
+	if len(in.Tokens) > 0 {
+		return len(in.Tokens)
+	}
+	return len(in.Texts)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (in EmbeddingInput) MarshalJSON() ([]byte, error) {
+	switch {
+	case len(in.Texts) > 0 && len(in.Tokens) > 0:
+		return nil, fmt.Errorf("could not marshal embedding input: both texts and tokens are set")
+	case len(in.Tokens) == 1:
+		return json.Marshal(in.Tokens[0])
+	case len(in.Tokens) > 1:
+		return json.Marshal(in.Tokens)
+	case len(in.Texts) == 1:
+		return json.Marshal(in.Texts[0])
+	default:
+		return json.Marshal(in.Texts)
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (in *EmbeddingInput) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*in = TextInput(text)
+		return nil
+	}
+
+	var texts []string
+	if err := json.Unmarshal(data, &texts); err == nil {
+		*in = TextInput(texts...)
+		return nil
+	}
+
+	var tokens []int
+	if err := json.Unmarshal(data, &tokens); err == nil {
+		*in = TokenInput(tokens)
+		return nil
+	}
+
+	var batch [][]int
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return fmt.Errorf("could not unmarshal embedding input: %w", err)
+	}
+
+	*in = TokenInput(batch...)
+	return nil
+}
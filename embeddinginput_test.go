@@ -0,0 +1,68 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddingInput_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input EmbeddingInput
+		want  string
+	}{
+		{name: "single text", input: TextInput("hello"), want: `"hello"`},
+		{name: "texts", input: TextInput("hello", "world"), want: `["hello","world"]`},
+		{name: "single token array", input: TokenInput([]int{1, 2}), want: `[1,2]`},
+		{name: "token arrays", input: TokenInput([]int{1, 2}, []int{3}), want: `[[1,2],[3]]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.input)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+
+			var decoded EmbeddingInput
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.Equal(t, tt.input, decoded)
+		})
+	}
+
+	_, err := json.Marshal(EmbeddingInput{Texts: []string{"a"}, Tokens: [][]int{{1}}})
+	assert.Error(t, err)
+
+	var invalid EmbeddingInput
+	assert.Error(t, json.Unmarshal([]byte(`{}`), &invalid))
+}
+
+func TestClient_CreateEmbedding_Batch(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in EmbeddingRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+			resp := EmbeddingResponse{Object: "list"}
+			for i := range in.Input.Texts {
+				resp.Data = append(resp.Data, Embedding{Index: i, Embedding: []float32{float32(i)}})
+			}
+			return jsonResponse(t, resp), nil
+		},
+	})
+
+	input := TextInput("first", "second", "third")
+	resp, err := client.CreateEmbedding(context.Background(), EmbeddingRequest{Model: "test_model", Input: input})
+	require.NoError(t, err)
+
+	require.Len(t, resp.Data, input.Len())
+	assert.Equal(t, []float32{2}, resp.Data[2].Embedding)
+}
@@ -0,0 +1,392 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+// marshal encodes the payload as JSON. Embedding and chat requests, sent on the
+// hot paths of high throughput services, are encoded without reflection into a
+// single pre-sized buffer; the output matches json.Marshal.
+func marshal(in any) ([]byte, error) {
+	switch in := in.(type) {
+	case EmbeddingRequest:
+		return in.appendJSON(make([]byte, 0, in.encodedSize()))
+	case ChatCompletionRequest:
+		return in.appendJSON(make([]byte, 0, in.encodedSize()))
+	default:
+		return json.Marshal(in)
+	}
+}
+
+// encodedSize estimates the encoded size of the request, to allocate the buffer once.
+func (r EmbeddingRequest) encodedSize() int {
+	size := 80 + len(r.Model) + len(r.EncodingFormat)
+	for _, text := range r.Input.Texts {
+		size += len(text) + 3
+	}
+	for _, tokens := range r.Input.Tokens {
+		size += 6*len(tokens) + 3
+	}
+	return size
+}
+
+// appendJSON appends the JSON encoding of the request to dst.
+func (r EmbeddingRequest) appendJSON(dst []byte) ([]byte, error) {
+	dst = append(dst, `{"model":`...)
+	dst = appendString(dst, r.Model)
+	dst = append(dst, `,"input":`...)
+
+	dst, err := r.Input.appendJSON(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Dimensions != 0 {
+		dst = append(dst, `,"dimensions":`...)
+		dst = strconv.AppendInt(dst, int64(r.Dimensions), 10)
+	}
+	if r.EncodingFormat != "" {
+		dst = append(dst, `,"encoding_format":`...)
+		dst = appendString(dst, r.EncodingFormat)
+	}
+	return append(dst, '}'), nil
+}
+
+// appendJSON appends the JSON encoding of the input to dst, like MarshalJSON.
+func (in EmbeddingInput) appendJSON(dst []byte) ([]byte, error) {
+	switch {
+	case len(in.Texts) > 0 && len(in.Tokens) > 0:
+		return nil, fmt.Errorf("could not marshal embedding input: both texts and tokens are set")
+	case len(in.Tokens) == 1:
+		return appendInts(dst, in.Tokens[0]), nil
+	case len(in.Tokens) > 1:
+		dst = append(dst, '[')
+		for i, tokens := range in.Tokens {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendInts(dst, tokens)
+		}
+		return append(dst, ']'), nil
+	case len(in.Texts) == 1:
+		return appendString(dst, in.Texts[0]), nil
+	default:
+		return appendStrings(dst, in.Texts), nil
+	}
+}
+
+// encodedSize estimates the encoded size of the request, to allocate the buffer once.
+func (r ChatCompletionRequest) encodedSize() int {
+	size := 256 + len(r.Model) + len(r.User)
+	for _, msg := range r.Messages {
+		size += 64 + len(msg.Content) + len(msg.ToolCallID)
+		for _, part := range msg.Parts {
+			size += 64 + len(part.Text)
+			if part.ImageURL != nil {
+				size += len(part.ImageURL.URL)
+			}
+			if part.InputAudio != nil {
+				size += len(part.InputAudio.Data)
+			}
+		}
+		for _, call := range msg.ToolCalls {
+			size += 96 + len(call.Function.Arguments)
+		}
+	}
+	for _, tool := range r.Tools {
+		size += 96 + len(tool.Function.Description) + len(tool.Function.Parameters)
+	}
+	return size
+}
+
+// appendJSON appends the JSON encoding of the request to dst. Fields of the
+// request rarely set on hot paths, such as tools, fall back to json.Marshal.
+func (r ChatCompletionRequest) appendJSON(dst []byte) ([]byte, error) {
+	var err error
+
+	dst = append(dst, `{"model":`...)
+	dst = appendString(dst, r.Model)
+
+	dst = append(dst, `,"messages":`...)
+	if r.Messages == nil {
+		dst = append(dst, "null"...)
+	} else {
+		dst = append(dst, '[')
+		for i, msg := range r.Messages {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			if dst, err = msg.appendJSON(dst); err != nil {
+				return nil, err
+			}
+		}
+		dst = append(dst, ']')
+	}
+
+	if dst, err = appendOptionalFloat(dst, `,"temperature":`, r.Temperature); err != nil {
+		return nil, err
+	}
+	if dst, err = appendOptionalFloat(dst, `,"top_p":`, r.TopP); err != nil {
+		return nil, err
+	}
+
+	if r.N != 0 {
+		dst = append(dst, `,"n":`...)
+		dst = strconv.AppendInt(dst, int64(r.N), 10)
+	}
+	if r.MaxTokens != 0 {
+		dst = append(dst, `,"max_tokens":`...)
+		dst = strconv.AppendInt(dst, int64(r.MaxTokens), 10)
+	}
+	if len(r.Stop) > 0 {
+		dst = append(dst, `,"stop":`...)
+		dst = appendStrings(dst, r.Stop)
+	}
+
+	if dst, err = appendOptionalFloat(dst, `,"presence_penalty":`, r.PresencePenalty); err != nil {
+		return nil, err
+	}
+	if dst, err = appendOptionalFloat(dst, `,"frequency_penalty":`, r.FrequencyPenalty); err != nil {
+		return nil, err
+	}
+
+	if len(r.LogitBias) > 0 {
+		if dst, err = appendMarshaled(dst, `,"logit_bias":`, r.LogitBias); err != nil {
+			return nil, err
+		}
+	}
+	if r.Logprobs {
+		dst = append(dst, `,"logprobs":true`...)
+	}
+	if r.TopLogprobs != 0 {
+		dst = append(dst, `,"top_logprobs":`...)
+		dst = strconv.AppendInt(dst, int64(r.TopLogprobs), 10)
+	}
+	if r.Seed != nil {
+		dst = append(dst, `,"seed":`...)
+		dst = strconv.AppendInt(dst, int64(*r.Seed), 10)
+	}
+	if r.User != "" {
+		dst = append(dst, `,"user":`...)
+		dst = appendString(dst, r.User)
+	}
+	if r.ResponseFormat != nil {
+		if dst, err = appendMarshaled(dst, `,"response_format":`, r.ResponseFormat); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(r.Tools) > 0 {
+		if dst, err = appendMarshaled(dst, `,"tools":`, r.Tools); err != nil {
+			return nil, err
+		}
+	}
+	if r.ToolChoice != nil {
+		if dst, err = appendMarshaled(dst, `,"tool_choice":`, r.ToolChoice); err != nil {
+			return nil, err
+		}
+	}
+	if r.ParallelToolCalls != nil {
+		dst = append(dst, `,"parallel_tool_calls":`...)
+		dst = strconv.AppendBool(dst, *r.ParallelToolCalls)
+	}
+	if len(r.Metadata) > 0 {
+		if dst, err = appendMarshaled(dst, `,"metadata":`, r.Metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.PromptCacheKey != "" {
+		dst = append(dst, `,"prompt_cache_key":`...)
+		dst = appendString(dst, r.PromptCacheKey)
+	}
+	if r.SafetyIdentifier != "" {
+		dst = append(dst, `,"safety_identifier":`...)
+		dst = appendString(dst, r.SafetyIdentifier)
+	}
+	return append(dst, '}'), nil
+}
+
+// appendJSON appends the JSON encoding of the message to dst.
+func (m Message) appendJSON(dst []byte) ([]byte, error) {
+	dst = append(dst, `{"role":`...)
+	dst = appendString(dst, string(m.Role))
+	if len(m.Parts) > 0 {
+		var err error
+		if dst, err = appendMarshaled(dst, `,"content":`, m.Parts); err != nil {
+			return nil, err
+		}
+	} else {
+		dst = append(dst, `,"content":`...)
+		dst = appendString(dst, m.Content)
+	}
+
+	if m.Prefix {
+		dst = append(dst, `,"prefix":true`...)
+	}
+
+	if len(m.ToolCalls) > 0 {
+		var err error
+		if dst, err = appendMarshaled(dst, `,"tool_calls":`, m.ToolCalls); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.ToolCallID != "" {
+		dst = append(dst, `,"tool_call_id":`...)
+		dst = appendString(dst, m.ToolCallID)
+	}
+
+	if m.Refusal != "" {
+		dst = append(dst, `,"refusal":`...)
+		dst = appendString(dst, m.Refusal)
+	}
+	return append(dst, '}'), nil
+}
+
+// appendMarshaled appends the key and the json.Marshal encoding of v to dst.
+func appendMarshaled(dst []byte, key string, v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dst = append(dst, key...)
+	return append(dst, data...), nil
+}
+
+// appendOptionalFloat appends the key and the value to dst, if the value is set.
+func appendOptionalFloat(dst []byte, key string, f *float64) ([]byte, error) {
+	if f == nil {
+		return dst, nil
+	}
+
+	dst = append(dst, key...)
+	return appendFloat(dst, *f)
+}
+
+// appendFloat appends the number to dst, formatted like encoding/json.
+func appendFloat(dst []byte, f float64) ([]byte, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, fmt.Errorf("json: unsupported value: %s", strconv.FormatFloat(f, 'g', -1, 64))
+	}
+
+	// Large and small exponents use the exponent format, as in ES6.
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+
+	dst = strconv.AppendFloat(dst, f, format, -1, 64)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		n := len(dst)
+		if n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst, nil
+}
+
+// appendInts appends the JSON array of the numbers to dst.
+func appendInts(dst []byte, values []int) []byte {
+	if values == nil {
+		return append(dst, "null"...)
+	}
+
+	dst = append(dst, '[')
+	for i, v := range values {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = strconv.AppendInt(dst, int64(v), 10)
+	}
+	return append(dst, ']')
+}
+
+// appendStrings appends the JSON array of the strings to dst.
+func appendStrings(dst []byte, values []string) []byte {
+	if values == nil {
+		return append(dst, "null"...)
+	}
+
+	dst = append(dst, '[')
+	for i, v := range values {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendString(dst, v)
+	}
+	return append(dst, ']')
+}
+
+const hexDigits = "0123456789abcdef"
+
+// htmlSafe holds the ASCII characters appended to JSON strings unescaped.
+var htmlSafe = func() (safe [utf8.RuneSelf]bool) {
+	for b := 0x20; b < utf8.RuneSelf; b++ {
+		safe[b] = b != '"' && b != '\\' && b != '<' && b != '>' && b != '&'
+	}
+	return safe
+}()
+
+// appendString appends the JSON string of s to dst, escaped like encoding/json:
+// HTML characters are escaped and invalid UTF-8 is replaced by U+FFFD.
+func appendString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if htmlSafe[b] {
+				i++
+				continue
+			}
+
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, `\ufffd`...)
+		case r == '\u2028' || r == '\u2029':
+			// Line and paragraph separators break JavaScript parsers.
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[r&0xF])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
@@ -0,0 +1,253 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal_EmbeddingRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   EmbeddingRequest
+	}{
+		{name: "no input", in: EmbeddingRequest{Model: "text-embedding-3-small"}},
+		{name: "single text", in: EmbeddingRequest{Model: "text-embedding-3-small", Input: TextInput("hello")}},
+		{name: "texts", in: EmbeddingRequest{Model: "text-embedding-3-small", Input: TextInput("hello", "world")}},
+		{name: "single token array", in: EmbeddingRequest{Model: "text-embedding-3-small", Input: TokenInput([]int{1, -2, 3})}},
+		{name: "token arrays", in: EmbeddingRequest{Model: "text-embedding-3-small", Input: TokenInput([]int{1}, nil, []int{})}},
+		{name: "options", in: EmbeddingRequest{Model: "text-embedding-3-small", Input: TextInput("hello"), Dimensions: 256, EncodingFormat: EncodingFormatBase64}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			want, err := json.Marshal(tt.in)
+			require.NoError(t, err)
+
+			got, err := marshal(tt.in)
+			require.NoError(t, err)
+
+			assert.Equal(t, string(want), string(got))
+		})
+	}
+}
+
+func TestMarshal_EmbeddingRequestInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	_, err := marshal(EmbeddingRequest{Input: EmbeddingInput{Texts: []string{"a"}, Tokens: [][]int{{1}}}})
+
+	assert.Error(t, err)
+}
+
+func TestMarshal_ChatCompletionRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   ChatCompletionRequest
+	}{
+		{name: "empty", in: ChatCompletionRequest{}},
+		{
+			name: "messages",
+			in: ChatCompletionRequest{
+				Model: "gpt-4o",
+				Messages: []Message{
+					{Role: "system", Content: "Quote <b>\"exactly\"</b> & escape \\ \n\r\t\x01\x1f"},
+					{Role: "user", Content: "h\u00e9llo \u4e16\u754c \u2028\u2029"},
+					{Role: "assistant", Content: "The answer is", Prefix: true},
+				},
+			},
+		},
+		{
+			name: "every parameter",
+			in: ChatCompletionRequest{
+				Model:            "gpt-4o",
+				Messages:         []Message{},
+				Temperature:      Ptr(0.7),
+				TopP:             Ptr(0.0),
+				N:                2,
+				MaxTokens:        256,
+				Stop:             []string{"\n\n", "END"},
+				PresencePenalty:  Ptr(-1.5),
+				FrequencyPenalty: Ptr(1e-7),
+				LogitBias:        map[string]int{"50256": -100, "1": 5},
+				Logprobs:         true,
+				TopLogprobs:      5,
+				Seed:             Ptr(42),
+				User:             "user-123",
+				ResponseFormat:   JSONSchemaFormat("answer", json.RawMessage(`{"type":"object"}`), true),
+				Tools: []Tool{{
+					Type: "function",
+					Function: FunctionDefinition{
+						Name:       "get_weather",
+						Parameters: json.RawMessage(`{"type": "object",  "properties": {}}`),
+					},
+				}},
+				ToolChoice:        &ToolChoice{Mode: "auto"},
+				ParallelToolCalls: Ptr(false),
+				Metadata:          map[string]string{"feature": "search", "team": "<core>"},
+				PromptCacheKey:    "support-agent-v2",
+				SafetyIdentifier:  "3f2a9c",
+			},
+		},
+		{
+			name: "tool calls",
+			in: ChatCompletionRequest{
+				Model: "gpt-4o",
+				Messages: []Message{
+					{Role: "assistant", ToolCalls: []ToolCall{{ID: "call_1", Type: "function", Function: FunctionCall{Name: "f", Arguments: `{"a":1}`}}}},
+					{Role: "tool", Content: "42", ToolCallID: "call_1"},
+					{Role: "assistant", Refusal: "I can't help with that."},
+				},
+				ToolChoice: &ToolChoice{Function: "f"},
+			},
+		},
+		{
+			name: "content parts",
+			in: ChatCompletionRequest{
+				Model: "gpt-4o",
+				Messages: []Message{{Role: "user", Parts: []ContentPart{
+					TextPart("What is <this>?"),
+					ImagePart("https://example.com/cat.png?a=1&b=2", ImageDetailHigh),
+					AudioPart([]byte("RIFF"), AudioInputWAV),
+				}}},
+			},
+		},
+		{
+			name: "exponent floats",
+			in:   ChatCompletionRequest{Temperature: Ptr(1e21), TopP: Ptr(-2.5e-10), PresencePenalty: Ptr(123456.789)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			want, err := json.Marshal(tt.in)
+			require.NoError(t, err)
+
+			got, err := marshal(tt.in)
+			require.NoError(t, err)
+
+			assert.Equal(t, string(want), string(got))
+		})
+	}
+}
+
+func TestMarshal_InvalidUTF8(t *testing.T) {
+	t.Parallel()
+
+	in := ChatCompletionRequest{Messages: []Message{{Role: "user", Content: "bad \xff\xfe bytes"}}}
+
+	// Go versions differ in escaping the replacement character.
+	want, err := json.Marshal(in)
+	require.NoError(t, err)
+
+	got, err := marshal(in)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(want), string(got))
+}
+
+func TestMarshal_ChatCompletionRequestUnsupportedFloat(t *testing.T) {
+	t.Parallel()
+
+	_, err := marshal(ChatCompletionRequest{Temperature: Ptr(math.NaN())})
+
+	assert.Error(t, err)
+}
+
+func TestMarshal_OtherPayloads(t *testing.T) {
+	t.Parallel()
+
+	in := ModerationRequest{Model: "omni-moderation-latest", Input: []string{"<hello>"}}
+
+	want, err := json.Marshal(in)
+	require.NoError(t, err)
+
+	got, err := marshal(in)
+	require.NoError(t, err)
+
+	assert.Equal(t, want, got)
+}
+
+// benchmarkEmbeddingRequest is a typical batched embedding request.
+func benchmarkEmbeddingRequest() EmbeddingRequest {
+	texts := make([]string, 64)
+	for i := range texts {
+		texts[i] = strings.Repeat("The quick brown fox jumps over the lazy dog. ", 8)
+	}
+	return EmbeddingRequest{Model: "text-embedding-3-small", Input: TextInput(texts...)}
+}
+
+// benchmarkChatCompletionRequest is a typical multi-turn chat request.
+func benchmarkChatCompletionRequest() ChatCompletionRequest {
+	in := ChatCompletionRequest{
+		Model:       "gpt-4o-mini",
+		Temperature: Ptr(0.2),
+		MaxTokens:   512,
+		Messages:    []Message{{Role: "system", Content: "You are a helpful assistant."}},
+	}
+	for i := 0; i < 8; i++ {
+		in.Messages = append(in.Messages,
+			Message{Role: "user", Content: strings.Repeat("Tell me more about it, please. ", 4)},
+			Message{Role: "assistant", Content: strings.Repeat("Here is what I know about it. ", 16)},
+		)
+	}
+	return in
+}
+
+func BenchmarkMarshal_EmbeddingRequest(b *testing.B) {
+	in := benchmarkEmbeddingRequest()
+
+	b.Run("encoding/json", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(in); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("fast path", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := marshal(in); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMarshal_ChatCompletionRequest(b *testing.B) {
+	in := benchmarkChatCompletionRequest()
+
+	b.Run("encoding/json", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(in); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("fast path", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := marshal(in); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
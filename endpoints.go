@@ -0,0 +1,63 @@
+package openaiclient
+
+import "strings"
+
+// Endpoint paths, relative to the API base URL.
+const (
+	EndpointEmbeddings        = "/embeddings"
+	EndpointChatCompletions   = "/chat/completions"
+	EndpointModels            = "/models"
+	EndpointCompletions       = "/completions"
+	EndpointImagesGenerations = "/images/generations"
+	EndpointImagesEdits       = "/images/edits"
+	EndpointImagesVariations  = "/images/variations"
+	EndpointTranscriptions    = "/audio/transcriptions"
+	EndpointTranslations      = "/audio/translations"
+	EndpointSpeech            = "/audio/speech"
+	EndpointModerations       = "/moderations"
+	EndpointFiles             = "/files"
+	EndpointAssistants        = "/assistants"
+	EndpointThreads           = "/threads"
+	EndpointVectorStores      = "/vector_stores"
+	EndpointResponses         = "/responses"
+)
+
+// DefaultBaseURL is the base URL of the OpenAI API.
+const DefaultBaseURL = "https://api.openai.com/v1"
+
+// WithBaseURL sets the base URL of the API, to target OpenAI-compatible backends
+// such as Azure OpenAI, LocalAI, vLLM, Ollama or an internal proxy.
+// The base URL usually ends with the API version, e.g. "http://localhost:11434/v1".
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithPathOverrides remaps endpoint paths for gateways exposing the API under
+// different routes. Keys are endpoint paths such as EndpointChatCompletions;
+// values are either paths relative to the base URL or absolute URLs.
+func WithPathOverrides(paths map[string]string) Option {
+	return func(c *Client) {
+		c.paths = make(map[string]string, len(paths))
+		for endpoint, path := range paths {
+			c.paths[endpoint] = path
+		}
+	}
+}
+
+// url returns the URL of the given endpoint.
+func (c *Client) url(endpoint string) string {
+	path := endpoint
+	if override, ok := c.paths[endpoint]; ok {
+		path = override
+	}
+
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	if c.azure != nil {
+		return c.azure.baseURL(endpoint) + path
+	}
+	return c.baseURL + path
+}
@@ -0,0 +1,78 @@
+package openaiclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPathOverrides(t *testing.T) {
+	t.Parallel()
+
+	var urls []string
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			urls = append(urls, req.URL.String())
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("{}")),
+			}, nil
+		},
+	}, WithPathOverrides(map[string]string{
+		EndpointChatCompletions: "/deployments/chat",
+		EndpointEmbeddings:      "https://gateway.internal/embed",
+	}))
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+	require.NoError(t, err)
+
+	_, err = client.CreateEmbedding(context.Background(), EmbeddingRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"https://api.openai.com/v1/deployments/chat",
+		"https://gateway.internal/embed",
+	}, urls)
+}
+
+func TestClient_DefaultEndpoints(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", nil)
+
+	assert.Equal(t, "https://api.openai.com/v1/chat/completions", client.url(EndpointChatCompletions))
+	assert.Equal(t, "https://api.openai.com/v1/embeddings", client.url(EndpointEmbeddings))
+}
+
+func TestWithBaseURL(t *testing.T) {
+	t.Parallel()
+
+	var urls []string
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			urls = append(urls, req.URL.String())
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("{}")),
+			}, nil
+		},
+	}, WithBaseURL("http://localhost:11434/v1/"))
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+	require.NoError(t, err)
+
+	_, err = client.CreateEmbedding(context.Background(), EmbeddingRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"http://localhost:11434/v1/chat/completions",
+		"http://localhost:11434/v1/embeddings",
+	}, urls)
+}
@@ -0,0 +1,57 @@
+package openaiclient
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// WithDefaultUser sets the user of the chat, completion and image requests
+// that don't set one. OpenAI uses this stable end-user identifier for abuse
+// monitoring; requests setting their own user override it.
+func WithDefaultUser(user string) Option {
+	return func(c *Client) {
+		c.defaultUser = user
+	}
+}
+
+// WithUserHashing replaces the user and safety identifier of every request by
+// their HashUser with the secret, so end-user identifiers such as emails
+// aren't sent to the API.
+func WithUserHashing(secret string) Option {
+	return func(c *Client) {
+		c.userSecret = []byte(secret)
+	}
+}
+
+// HashUser returns the hex encoded HMAC-SHA256 of the user keyed by the
+// secret: stable for a user, without revealing who it is.
+func HashUser(user, secret string) string {
+	return hashUser(user, []byte(secret))
+}
+
+// hashUser returns the HMAC-SHA256 of the user keyed by the secret.
+func hashUser(user string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(user))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// endUser returns the user to send, given the user of the request.
+func (c *Client) endUser(user string) string {
+	if user == "" {
+		user = c.defaultUser
+	}
+	if user == "" || c.userSecret == nil {
+		return user
+	}
+	return hashUser(user, c.userSecret)
+}
+
+// safetyIdentifier returns the safety identifier to send, given the one of the request.
+func (c *Client) safetyIdentifier(id string) string {
+	if id == "" || c.userSecret == nil {
+		return id
+	}
+	return hashUser(id, c.userSecret)
+}
@@ -0,0 +1,106 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashUser(t *testing.T) {
+	t.Parallel()
+
+	hash := HashUser("jane@example.com", "secret")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashUser("jane@example.com", "secret"))
+	assert.NotEqual(t, hash, HashUser("john@example.com", "secret"))
+	assert.NotEqual(t, hash, HashUser("jane@example.com", "other secret"))
+}
+
+func TestClient_EndUser(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts []Option
+		user string
+		want string
+	}{
+		{name: "unset"},
+		{name: "request", user: "user-1", want: "user-1"},
+		{name: "default", opts: []Option{WithDefaultUser("service-a")}, want: "service-a"},
+		{name: "override", opts: []Option{WithDefaultUser("service-a")}, user: "user-1", want: "user-1"},
+		{name: "hashed", opts: []Option{WithUserHashing("secret")}, user: "user-1", want: HashUser("user-1", "secret")},
+		{name: "hashed default", opts: []Option{WithDefaultUser("service-a"), WithUserHashing("")}, want: HashUser("service-a", "")},
+		{name: "hashed unset", opts: []Option{WithUserHashing("secret")}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var users []string
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					var in struct {
+						User *string `json:"user"`
+					}
+					require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+					if in.User == nil {
+						users = append(users, "")
+					} else {
+						require.NotEmpty(t, *in.User)
+						users = append(users, *in.User)
+					}
+					return jsonResponse(t, map[string]any{}), nil
+				},
+			}, tt.opts...)
+
+			_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o", User: tt.user})
+			require.NoError(t, err)
+
+			_, err = client.CreateCompletion(context.Background(), CompletionRequest{Model: "gpt-3.5-turbo-instruct", User: tt.user})
+			require.NoError(t, err)
+
+			_, err = client.CreateImage(context.Background(), ImageRequest{Model: "dall-e-3", Prompt: "A cat", User: tt.user})
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{tt.want, tt.want, tt.want}, users)
+		})
+	}
+}
+
+func TestClient_SafetyIdentifier(t *testing.T) {
+	t.Parallel()
+
+	var ids []string
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in struct {
+				SafetyIdentifier string `json:"safety_identifier"`
+			}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+			ids = append(ids, in.SafetyIdentifier)
+			return jsonResponse(t, map[string]any{}), nil
+		},
+	}, WithUserHashing("secret"))
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o", SafetyIdentifier: "user-1"})
+	require.NoError(t, err)
+
+	_, err = client.CreateResponse(context.Background(), ResponseRequest{Model: "gpt-4o", SafetyIdentifier: "user-1"})
+	require.NoError(t, err)
+
+	_, err = client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	hashed := HashUser("user-1", "secret")
+	assert.Equal(t, []string{hashed, hashed, ""}, ids)
+}
@@ -0,0 +1,102 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize caps how much of an error response body is read.
+const maxErrorBodySize = 64 << 10
+
+// APIError is returned when the API responds with a non-200 status code.
+// Use errors.As to inspect it.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Type       string
+	Param      string
+	Code       string
+	// RateLimit is the rate limit state reported with the error, if any.
+	RateLimit *RateLimitInfo
+	// RequestID is the ID of the failed request, if any, to give to OpenAI support.
+	RequestID string
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	var details []string
+	if e.Type != "" {
+		details = append(details, "type: "+e.Type)
+	}
+	if e.Code != "" {
+		details = append(details, "code: "+e.Code)
+	}
+	if e.Param != "" {
+		details = append(details, "param: "+e.Param)
+	}
+	if e.RequestID != "" {
+		details = append(details, "request id: "+e.RequestID)
+	}
+
+	if len(details) > 0 {
+		msg += " (" + strings.Join(details, ", ") + ")"
+	}
+	return msg
+}
+
+// newAPIError builds an APIError from the response, decoding the OpenAI error body if present.
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := APIError{StatusCode: resp.StatusCode, RequestID: resp.Header.Get(HeaderRequestID)}
+	if info, ok := ParseRateLimit(resp.Header); ok {
+		apiErr.RateLimit = &info
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil || len(body) == 0 {
+		return &apiErr
+	}
+
+	if !apiErr.parseBody(body) {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	return &apiErr
+}
+
+// parseBody sets the details of the error from an OpenAI error body.
+// It reports false if the body isn't one.
+func (e *APIError) parseBody(body []byte) bool {
+	var payload struct {
+		Error *struct {
+			Message string          `json:"message"`
+			Type    string          `json:"type"`
+			Param   *string         `json:"param"`
+			Code    json.RawMessage `json:"code"`
+		} `json:"error"`
+	}
+
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Error == nil {
+		return false
+	}
+
+	e.Message = payload.Error.Message
+	e.Type = payload.Error.Type
+
+	if payload.Error.Param != nil {
+		e.Param = *payload.Error.Param
+	}
+
+	// The code is a string for most errors, but null or a number for some.
+	var code any
+	if err := json.Unmarshal(payload.Error.Code, &code); err == nil && code != nil {
+		e.Code = fmt.Sprint(code)
+	}
+	return true
+}
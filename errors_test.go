@@ -0,0 +1,84 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       *APIError
+		wantMsg    string
+	}{
+		{
+			name:       "decodes the error body",
+			statusCode: 429,
+			body:       `{"error": {"message": "Rate limit reached", "type": "requests", "param": null, "code": "rate_limit_exceeded"}}`,
+			want: &APIError{
+				StatusCode: 429,
+				Message:    "Rate limit reached",
+				Type:       "requests",
+				Code:       "rate_limit_exceeded",
+			},
+			wantMsg: "unexpected status code: 429: Rate limit reached (type: requests, code: rate_limit_exceeded)",
+		},
+		{
+			name:       "decodes params and null codes",
+			statusCode: 400,
+			body:       `{"error": {"message": "Invalid value", "type": "invalid_request_error", "param": "messages", "code": null}}`,
+			want: &APIError{
+				StatusCode: 400,
+				Message:    "Invalid value",
+				Type:       "invalid_request_error",
+				Param:      "messages",
+			},
+			wantMsg: "unexpected status code: 400: Invalid value (type: invalid_request_error, param: messages)",
+		},
+		{
+			name:       "keeps non json bodies as message",
+			statusCode: 502,
+			body:       "Bad Gateway\n",
+			want:       &APIError{StatusCode: 502, Message: "Bad Gateway"},
+			wantMsg:    "unexpected status code: 502: Bad Gateway",
+		},
+		{
+			name:       "handles empty bodies",
+			statusCode: 500,
+			body:       "",
+			want:       &APIError{StatusCode: 500},
+			wantMsg:    "unexpected status code: 500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: tt.statusCode,
+						Body:       io.NopCloser(strings.NewReader(tt.body)),
+					}, nil
+				},
+			})
+
+			_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+
+			var apiErr *APIError
+			require.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tt.want, apiErr)
+			assert.EqualError(t, err, tt.wantMsg)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package openaiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var tableDelimiter = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+
+type (
+	// CodeBlock is a fenced code block extracted from Markdown text.
+	CodeBlock struct {
+		// Language is the first word of the fence info string, if any.
+		Language string
+		Code     string
+	}
+
+	// Table is a Markdown table extracted from text.
+	Table struct {
+		Header []string
+		Rows   [][]string
+	}
+)
+
+// ExtractCodeBlocks returns the fenced code blocks of the text, optionally only
+// those in one of the given languages (case insensitive). A block left open at
+// the end of the text, as in truncated completions, runs to the end of the text.
+func ExtractCodeBlocks(text string, languages ...string) []CodeBlock {
+	var (
+		blocks []CodeBlock
+		fence  string
+		lang   string
+		code   []string
+		inside bool
+	)
+
+	keep := func() {
+		if len(languages) == 0 {
+			blocks = append(blocks, CodeBlock{Language: lang, Code: strings.Join(code, "\n")})
+			return
+		}
+
+		for _, l := range languages {
+			if strings.EqualFold(l, lang) {
+				blocks = append(blocks, CodeBlock{Language: lang, Code: strings.Join(code, "\n")})
+				return
+			}
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if !inside {
+			if marker := fenceMarker(trimmed); marker != "" {
+				fence, inside, code = marker, true, nil
+
+				lang = ""
+				if info := strings.Fields(trimmed[len(marker):]); len(info) > 0 {
+					lang = info[0]
+				}
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			keep()
+			inside = false
+			continue
+		}
+
+		code = append(code, strings.TrimSuffix(line, "\r"))
+	}
+
+	if inside {
+		keep()
+	}
+	return blocks
+}
+
+// fenceMarker returns the opening fence of the line, or an empty string if the line opens no code block.
+func fenceMarker(line string) string {
+	for _, c := range []string{"`", "~"} {
+		n := len(line) - len(strings.TrimLeft(line, c))
+		if n >= 3 {
+			return line[:n]
+		}
+	}
+	return ""
+}
+
+// ExtractJSONBlocks returns the JSON values of the text. Values in json code
+// blocks are preferred; if there are none, the text is scanned for valid
+// objects and arrays, which handles bare JSON surrounded by prose.
+func ExtractJSONBlocks(text string) []json.RawMessage {
+	var values []json.RawMessage
+
+	for _, block := range ExtractCodeBlocks(text, "json") {
+		if json.Valid([]byte(block.Code)) {
+			values = append(values, json.RawMessage(strings.TrimSpace(block.Code)))
+		}
+	}
+
+	if len(values) > 0 {
+		return values
+	}
+
+	data := []byte(text)
+	for i := 0; i < len(data); i++ {
+		if data[i] != '{' && data[i] != '[' {
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(data[i:]))
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			continue
+		}
+
+		values = append(values, value)
+		i += int(dec.InputOffset()) - 1
+	}
+	return values
+}
+
+// ExtractTables returns the Markdown (GitHub flavored) tables of the text.
+func ExtractTables(text string) []Table {
+	lines := strings.Split(text, "\n")
+
+	var tables []Table
+	for i := 0; i+1 < len(lines); i++ {
+		header := strings.TrimSpace(lines[i])
+		if !strings.Contains(header, "|") || !tableDelimiter.MatchString(strings.TrimSpace(lines[i+1])) {
+			continue
+		}
+
+		table := Table{Header: tableCells(header)}
+
+		i += 2
+		for ; i < len(lines); i++ {
+			row := strings.TrimSpace(lines[i])
+			if row == "" || !strings.Contains(row, "|") {
+				break
+			}
+			table.Rows = append(table.Rows, tableCells(row))
+		}
+
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+// tableCells splits a table row into its trimmed cells, honouring escaped pipes.
+func tableCells(row string) []string {
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, `\|`) {
+		row = strings.TrimSuffix(row, "|")
+	}
+
+	var (
+		cells []string
+		cell  strings.Builder
+	)
+
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
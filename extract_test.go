@@ -0,0 +1,88 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractCodeBlocks(t *testing.T) {
+	t.Parallel()
+
+	text := "Here you go:\n\n```go\nfmt.Println(\"hi\")\n```\n\n~~~~python\nprint('hi')\n```\nstill python\n~~~~\n\n```\nplain\n```\n\n```bash\necho truncated"
+
+	t.Run("returns every block", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []CodeBlock{
+			{Language: "go", Code: `fmt.Println("hi")`},
+			{Language: "python", Code: "print('hi')\n```\nstill python"},
+			{Language: "", Code: "plain"},
+			{Language: "bash", Code: "echo truncated"},
+		}, ExtractCodeBlocks(text))
+	})
+
+	t.Run("filters by language", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []CodeBlock{
+			{Language: "python", Code: "print('hi')\n```\nstill python"},
+		}, ExtractCodeBlocks(text, "Python"))
+	})
+}
+
+func TestExtractJSONBlocks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want []json.RawMessage
+	}{
+		{
+			name: "prefers json code blocks",
+			text: "Result {\"ignored\": true}\n```json\n{\"a\": 1}\n```",
+			want: []json.RawMessage{json.RawMessage(`{"a": 1}`)},
+		},
+		{
+			name: "scans bare json",
+			text: `The answer is {"a": {"b": [1, 2]}} and [3] but not {broken.`,
+			want: []json.RawMessage{json.RawMessage(`{"a": {"b": [1, 2]}}`), json.RawMessage(`[3]`)},
+		},
+		{
+			name: "returns nothing without json",
+			text: "no json here",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExtractJSONBlocks(tt.text))
+		})
+	}
+}
+
+func TestExtractTables(t *testing.T) {
+	t.Parallel()
+
+	text := `Comparison:
+
+| Model | Price \| unit |
+|:------|------:|
+| small | 1 |
+| large | 10 |
+
+Done.`
+
+	assert.Equal(t, []Table{
+		{
+			Header: []string{"Model", "Price | unit"},
+			Rows: [][]string{
+				{"small", "1"},
+				{"large", "10"},
+			},
+		},
+	}, ExtractTables(text))
+}
@@ -0,0 +1,141 @@
+package openaiclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// File purposes.
+const (
+	FilePurposeAssistants = "assistants"
+	FilePurposeBatch      = "batch"
+	FilePurposeFineTune   = "fine-tune"
+	FilePurposeVision     = "vision"
+	FilePurposeUserData   = "user_data"
+	FilePurposeEvals      = "evals"
+)
+
+type (
+	// FileUploadRequest is the request for the file upload endpoint.
+	FileUploadRequest struct {
+		File    InputFile
+		Purpose string
+	}
+
+	// FileListOptions filters and paginates the file listing.
+	FileListOptions struct {
+		Purpose string
+		// Limit is the page size, between 1 and 10000.
+		Limit int
+		// Order is "asc" or "desc" by creation time.
+		Order string
+		// After is the ID of the file to list from, for pagination.
+		After string
+	}
+
+	// File is an uploaded file.
+	File struct {
+		ResponseMeta
+		ID        string `json:"id"`
+		Object    string `json:"object"`
+		Bytes     int64  `json:"bytes"`
+		CreatedAt int64  `json:"created_at"`
+		ExpiresAt int64  `json:"expires_at,omitempty"`
+		Filename  string `json:"filename"`
+		Purpose   string `json:"purpose"`
+	}
+
+	// FileList is the response body of the file listing endpoint.
+	FileList struct {
+		ResponseMeta
+		Object  string `json:"object"`
+		Data    []File `json:"data"`
+		FirstID string `json:"first_id,omitempty"`
+		LastID  string `json:"last_id,omitempty"`
+		HasMore bool   `json:"has_more"`
+	}
+
+	// DeletedObject is the response body of the deletion endpoints.
+	DeletedObject struct {
+		ResponseMeta
+		ID      string `json:"id"`
+		Object  string `json:"object"`
+		Deleted bool   `json:"deleted"`
+	}
+)
+
+// UploadFile uploads a file for the given purpose, such as FilePurposeBatch.
+func (c *Client) UploadFile(ctx context.Context, in FileUploadRequest) (*File, error) {
+	form := newMultipartForm()
+	form.field("purpose", in.Purpose)
+	form.file("file", in.File)
+
+	var file File
+	if err := c.postForm(ctx, c.url(EndpointFiles), form, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// ListFiles lists the uploaded files.
+func (c *Client) ListFiles(ctx context.Context, opts FileListOptions) (*FileList, error) {
+	query := url.Values{}
+	if opts.Purpose != "" {
+		query.Set("purpose", opts.Purpose)
+	}
+	if opts.Limit != 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Order != "" {
+		query.Set("order", opts.Order)
+	}
+	if opts.After != "" {
+		query.Set("after", opts.After)
+	}
+
+	var list FileList
+	if err := c.get(ctx, withQuery(c.url(EndpointFiles), query), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetFile retrieves the file metadata.
+func (c *Client) GetFile(ctx context.Context, id string) (*File, error) {
+	var file File
+	if err := c.get(ctx, c.fileURL(id), &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// DeleteFile deletes the file.
+func (c *Client) DeleteFile(ctx context.Context, id string) (*DeletedObject, error) {
+	var deleted DeletedObject
+	if err := c.delete(ctx, c.fileURL(id), &deleted); err != nil {
+		return nil, err
+	}
+	return &deleted, nil
+}
+
+// GetFileContent returns the content of the file, which the caller must close.
+func (c *Client) GetFileContent(ctx context.Context, id string) (io.ReadCloser, error) {
+	resp, err := c.send(ctx, http.MethodGet, c.fileURL(id)+"/content", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, newAPIError(resp)
+	}
+	return resp.Body, nil
+}
+
+// fileURL returns the URL of the file.
+func (c *Client) fileURL(id string) string {
+	return c.url(EndpointFiles) + "/" + url.PathEscape(id)
+}
@@ -0,0 +1,166 @@
+package openaiclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UploadFile(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, DefaultBaseURL+EndpointFiles, req.URL.String())
+			require.NoError(t, req.ParseMultipartForm(1<<20))
+
+			assert.Equal(t, FilePurposeBatch, req.FormValue("purpose"))
+			require.Len(t, req.MultipartForm.File["file"], 1)
+			assert.Equal(t, "requests.jsonl", req.MultipartForm.File["file"][0].Filename)
+			assert.Equal(t, `{"custom_id":"1"}`, readFormFile(t, req.MultipartForm.File["file"][0]))
+
+			return jsonResponse(t, File{ID: "file-abc", Object: "file", Bytes: 17, Filename: "requests.jsonl", Purpose: FilePurposeBatch}), nil
+		},
+	})
+
+	file, err := client.UploadFile(context.Background(), FileUploadRequest{
+		File:    InputFile{Name: "requests.jsonl", Data: strings.NewReader(`{"custom_id":"1"}`)},
+		Purpose: FilePurposeBatch,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "file-abc", file.ID)
+	assert.Equal(t, int64(17), file.Bytes)
+}
+
+func TestClient_ListFiles(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		opts    FileListOptions
+		wantURL string
+	}{
+		{
+			name:    "all files",
+			wantURL: DefaultBaseURL + EndpointFiles,
+		},
+		{
+			name:    "filtered page",
+			opts:    FileListOptions{Purpose: FilePurposeFineTune, Limit: 10, Order: "asc", After: "file-abc"},
+			wantURL: DefaultBaseURL + EndpointFiles + "?after=file-abc&limit=10&order=asc&purpose=fine-tune",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, http.MethodGet, req.Method)
+					assert.Equal(t, tc.wantURL, req.URL.String())
+					return jsonResponse(t, FileList{Object: "list", Data: []File{{ID: "file-def"}}, HasMore: true}), nil
+				},
+			})
+
+			list, err := client.ListFiles(context.Background(), tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, []File{{ID: "file-def"}}, list.Data)
+			assert.True(t, list.HasMore)
+		})
+	}
+}
+
+func TestClient_GetFile(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, DefaultBaseURL+EndpointFiles+"/file-abc", req.URL.String())
+			return jsonResponse(t, File{ID: "file-abc", Filename: "data.jsonl"}), nil
+		},
+	})
+
+	file, err := client.GetFile(context.Background(), "file-abc")
+	require.NoError(t, err)
+	assert.Equal(t, "data.jsonl", file.Filename)
+}
+
+func TestClient_DeleteFile(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodDelete, req.Method)
+			assert.Equal(t, DefaultBaseURL+EndpointFiles+"/file-abc", req.URL.String())
+			return jsonResponse(t, DeletedObject{ID: "file-abc", Object: "file", Deleted: true}), nil
+		},
+	})
+
+	deleted, err := client.DeleteFile(context.Background(), "file-abc")
+	require.NoError(t, err)
+	assert.True(t, deleted.Deleted)
+}
+
+func TestClient_GetFileContent(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		response    *http.Response
+		wantContent string
+		wantErr     bool
+	}{
+		{
+			name: "content",
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("line 1\nline 2\n")),
+			},
+			wantContent: "line 1\nline 2\n",
+		},
+		{
+			name: "not found",
+			response: &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"error":{"message":"No such File object"}}`)),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, DefaultBaseURL+EndpointFiles+"/file-abc/content", req.URL.String())
+					return tc.response, nil
+				},
+			})
+
+			content, err := client.GetFileContent(context.Background(), "file-abc")
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer content.Close()
+
+			data, err := io.ReadAll(content)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantContent, string(data))
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package openaiclient
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultFilterHoldback is the number of bytes a StreamFilter holds back by default.
+// Matches longer than the holdback may be missed when split across deltas.
+const DefaultFilterHoldback = 64
+
+type (
+	// FilterRule replaces every match of Pattern with Replacement.
+	// The replacement is inserted literally.
+	FilterRule struct {
+		Pattern     string
+		Replacement string
+	}
+
+	// OutputFilter rewrites model output according to its rules, for both
+	// complete responses and streamed deltas.
+	OutputFilter struct {
+		re           *regexp.Regexp
+		groups       []int
+		replacements []string
+		holdback     int
+	}
+
+	// StreamFilter applies an OutputFilter to streamed deltas. Text that may be
+	// part of a match continuing in the next delta is held back until more text
+	// arrives or the stream is flushed.
+	StreamFilter struct {
+		filter  *OutputFilter
+		matcher *StreamMatcher
+		offset  int
+	}
+)
+
+// WordsRule returns a rule replacing the given words, case insensitively and
+// only as whole words.
+func WordsRule(replacement string, words ...string) FilterRule {
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		quoted = append(quoted, regexp.QuoteMeta(word))
+	}
+
+	return FilterRule{
+		Pattern:     `(?i:\b(?:` + strings.Join(quoted, "|") + `)\b)`,
+		Replacement: replacement,
+	}
+}
+
+// NewOutputFilter compiles the given rules into a filter. When rules overlap,
+// the leftmost match wins, and the first rule among matches starting at the same position.
+func NewOutputFilter(rules ...FilterRule) (*OutputFilter, error) {
+	patterns := make([]string, 0, len(rules))
+	for i, rule := range rules {
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return nil, fmt.Errorf("could not compile rule %d: %w", i, err)
+		}
+		patterns = append(patterns, fmt.Sprintf("(?P<rule%d>%s)", i, rule.Pattern))
+	}
+
+	re, err := regexp.Compile(strings.Join(patterns, "|"))
+	if err != nil {
+		return nil, fmt.Errorf("could not compile rules: %w", err)
+	}
+
+	f := OutputFilter{
+		re:           re,
+		groups:       make([]int, len(rules)),
+		replacements: make([]string, len(rules)),
+		holdback:     DefaultFilterHoldback,
+	}
+
+	for i, rule := range rules {
+		f.groups[i] = re.SubexpIndex(fmt.Sprintf("rule%d", i))
+		f.replacements[i] = rule.Replacement
+	}
+	return &f, nil
+}
+
+// WithHoldback sets the number of bytes stream filters hold back, which should
+// be at least the length of the longest expected match.
+func (f *OutputFilter) WithHoldback(n int) *OutputFilter {
+	f.holdback = n
+	return f
+}
+
+// Apply returns the filtered text.
+func (f *OutputFilter) Apply(text string) string {
+	var b strings.Builder
+
+	last := 0
+	for _, m := range f.re.FindAllStringSubmatchIndex(text, -1) {
+		if m[0] == m[1] {
+			continue
+		}
+
+		b.WriteString(text[last:m[0]])
+		b.WriteString(f.replacement(m))
+		last = m[1]
+	}
+
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// ApplyResponse filters the message content of every choice of the response in place.
+func (f *OutputFilter) ApplyResponse(resp *ChatCompletionResponse) {
+	for i := range resp.Choices {
+		resp.Choices[i].Message.Content = f.Apply(resp.Choices[i].Message.Content)
+	}
+}
+
+// Stream returns a new stream filter applying the filter to streamed deltas.
+func (f *OutputFilter) Stream() *StreamFilter {
+	return &StreamFilter{
+		filter:  f,
+		matcher: NewStreamMatcher(f.re, f.holdback),
+	}
+}
+
+// replacement returns the replacement of the rule that produced the match.
+func (f *OutputFilter) replacement(match []int) string {
+	for i, group := range f.groups {
+		if match[2*group] >= 0 {
+			return f.replacements[i]
+		}
+	}
+	return ""
+}
+
+// Write adds a delta to the stream and returns the filtered text safe to emit.
+func (s *StreamFilter) Write(delta string) string {
+	return s.replace(s.matcher.Write(delta))
+}
+
+// Flush returns the filtered remainder of the stream.
+func (s *StreamFilter) Flush() string {
+	return s.replace(s.matcher.Flush())
+}
+
+// replace applies the rule replacements to the text released by the matcher.
+func (s *StreamFilter) replace(text string, matches []StreamMatch) string {
+	var b strings.Builder
+
+	last := 0
+	for _, m := range matches {
+		start := m.Offset - s.offset
+
+		b.WriteString(text[last:start])
+		b.WriteString(s.filter.replacement(m.index))
+		last = start + len(m.Text)
+	}
+
+	b.WriteString(text[last:])
+	s.offset += len(text)
+	return b.String()
+}
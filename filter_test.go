@@ -0,0 +1,105 @@
+package openaiclient
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputFilter_Apply(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewOutputFilter(
+		WordsRule("****", "darn", "heck"),
+		FilterRule{Pattern: `\b\d{3}-\d{2}-\d{4}\b`, Replacement: "[redacted]"},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "replaces listed words", text: "Darn it, what the heck.", want: "**** it, what the ****."},
+		{name: "keeps words containing listed words", text: "The darning needle.", want: "The darning needle."},
+		{name: "replaces regex matches", text: "SSN: 123-45-6789.", want: "SSN: [redacted]."},
+		{name: "keeps clean text", text: "All good.", want: "All good."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, filter.Apply(tt.text))
+		})
+	}
+}
+
+func TestOutputFilter_ApplyResponse(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewOutputFilter(WordsRule("****", "darn"))
+	require.NoError(t, err)
+
+	resp := &ChatCompletionResponse{
+		Choices: []Choice{
+			{Message: Message{Content: "darn"}},
+			{Message: Message{Content: "fine"}},
+		},
+	}
+
+	filter.ApplyResponse(resp)
+
+	assert.Equal(t, "****", resp.Choices[0].Message.Content)
+	assert.Equal(t, "fine", resp.Choices[1].Message.Content)
+}
+
+func TestStreamFilter(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewOutputFilter(
+		WordsRule("****", "darn"),
+		FilterRule{Pattern: `secret-\d+`, Replacement: "[redacted]"},
+	)
+	require.NoError(t, err)
+	filter.WithHoldback(12)
+
+	text := "Oh darn, the code is secret-12345 and darning is a hobby. Ünïcödé darn"
+	want := filter.Apply(text)
+
+	for _, size := range []int{1, 2, 3, 5, 8, 13} {
+		stream := filter.Stream()
+
+		var b strings.Builder
+		for i := 0; i < len(text); i += size {
+			end := i + size
+			if end > len(text) {
+				end = len(text)
+			}
+			b.WriteString(stream.Write(text[i:end]))
+		}
+		b.WriteString(stream.Flush())
+
+		assert.Equal(t, want, b.String(), "chunk size %d", size)
+	}
+
+	assert.Equal(t, "Oh ****, the code is [redacted] and darning is a hobby. Ünïcödé ****", want)
+}
+
+func TestNewOutputFilter_InvalidRule(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewOutputFilter(FilterRule{Pattern: "("})
+	assert.Error(t, err)
+}
+
+func TestStreamFilter_NoHoldback(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewOutputFilter(WordsRule("****", "darn"))
+	require.NoError(t, err)
+
+	stream := filter.WithHoldback(0).Stream()
+	assert.Equal(t, "oh ****", stream.Write("oh darn"))
+	assert.Empty(t, stream.Flush())
+}
@@ -0,0 +1,174 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrGlossaryViolation is returned when the model keeps dropping protected terms from translations.
+var ErrGlossaryViolation = errors.New("translation glossary violated")
+
+type (
+	// GlossaryTranslationRequest is a text to translate with a glossary.
+	GlossaryTranslationRequest struct {
+		Model string
+		Text  string
+		// SourceLanguage and TargetLanguage are ISO 639-1 codes, such as "de",
+		// or language names. The source language is detected by the model when empty.
+		SourceLanguage string
+		TargetLanguage string
+		// Glossary maps source terms to the translation they must be given.
+		Glossary map[string]string
+		// DoNotTranslate are the terms kept verbatim, such as product names.
+		DoNotTranslate []string
+		// MaxRetries is how many times the model is re-asked when protected terms are missing.
+		MaxRetries int
+	}
+
+	// GlossaryTranslation is the translation of a text.
+	GlossaryTranslation struct {
+		Text string
+		// Missing are the expected terms absent from the translation.
+		Missing  []string
+		Response *ChatCompletionResponse
+	}
+)
+
+// TranslateWithGlossary translates the text, instructing the model to follow
+// the glossary and keep the do-not-translate terms verbatim. The translation is
+// checked to contain the expected term of every protected term found in the
+// text, and the model is re-asked up to MaxRetries times with the missing terms.
+// It returns the last translation along with ErrGlossaryViolation if every attempt failed.
+func (c *Client) TranslateWithGlossary(ctx context.Context, in GlossaryTranslationRequest) (*GlossaryTranslation, error) {
+	if in.TargetLanguage == "" {
+		return nil, fmt.Errorf("could not translate: missing target language")
+	}
+
+	req := ChatCompletionRequest{
+		Model: in.Model,
+		Messages: []Message{
+			{Role: RoleSystem, Content: glossaryPrompt(in)},
+			{Role: RoleUser, Content: in.Text},
+		},
+	}
+	expected := expectedTerms(in)
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.CreateChatCompletion(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Choices) == 0 {
+			return nil, fmt.Errorf("could not translate: no choices returned")
+		}
+
+		reply := resp.Choices[0].Message
+		translation := GlossaryTranslation{
+			Text:     reply.Content,
+			Missing:  missingTerms(reply.Content, expected),
+			Response: resp,
+		}
+		if len(translation.Missing) == 0 {
+			return &translation, nil
+		}
+
+		if attempt >= in.MaxRetries {
+			return &translation, ErrGlossaryViolation
+		}
+
+		req.Messages = append(req.Messages, reply, Message{
+			Role: RoleUser,
+			Content: "Your translation is missing these required terms: " + strings.Join(translation.Missing, ", ") +
+				". Translate the text again, using them exactly as given.",
+		})
+	}
+}
+
+// glossaryPrompt returns the system prompt of the translation.
+func glossaryPrompt(in GlossaryTranslationRequest) string {
+	var prompt strings.Builder
+
+	prompt.WriteString("Translate the text of the user")
+	if in.SourceLanguage != "" {
+		prompt.WriteString(" from " + languageLabel(in.SourceLanguage))
+	}
+	prompt.WriteString(" to " + languageLabel(in.TargetLanguage) + ". Reply with the translation only.")
+
+	if len(in.Glossary) > 0 {
+		prompt.WriteString("\n\nTranslate these terms exactly as given:")
+		for _, term := range sortedKeys(in.Glossary) {
+			fmt.Fprintf(&prompt, "\n- %q: %q", term, in.Glossary[term])
+		}
+	}
+
+	if len(in.DoNotTranslate) > 0 {
+		prompt.WriteString("\n\nKeep these terms verbatim, without translating them:")
+		for _, term := range in.DoNotTranslate {
+			fmt.Fprintf(&prompt, "\n- %q", term)
+		}
+	}
+	return prompt.String()
+}
+
+// languageLabel returns the English name of the ISO 639-1 language, or the language as is.
+func languageLabel(lang string) string {
+	if name, ok := languageName[lang]; ok {
+		return name
+	}
+	return lang
+}
+
+// expectedTerms returns the terms the translation must contain, for the
+// protected terms found in the text, in sorted order.
+func expectedTerms(in GlossaryTranslationRequest) []string {
+	text := strings.ToLower(in.Text)
+
+	seen := map[string]struct{}{}
+	var terms []string
+	add := func(term string) {
+		if _, ok := seen[term]; !ok {
+			seen[term] = struct{}{}
+			terms = append(terms, term)
+		}
+	}
+
+	for source, target := range in.Glossary {
+		if strings.Contains(text, strings.ToLower(source)) {
+			add(target)
+		}
+	}
+	for _, term := range in.DoNotTranslate {
+		if strings.Contains(text, strings.ToLower(term)) {
+			add(term)
+		}
+	}
+
+	sort.Strings(terms)
+	return terms
+}
+
+// missingTerms returns the terms absent from the translation, ignoring case.
+func missingTerms(translation string, terms []string) []string {
+	translation = strings.ToLower(translation)
+
+	var missing []string
+	for _, term := range terms {
+		if !strings.Contains(translation, strings.ToLower(term)) {
+			missing = append(missing, term)
+		}
+	}
+	return missing
+}
+
+// sortedKeys returns the keys of the map in sorted order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
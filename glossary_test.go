@@ -0,0 +1,91 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_TranslateWithGlossary(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(replies []string, requests *[]ChatCompletionRequest) *Client {
+		return New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				var in ChatCompletionRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+				reply := replies[len(*requests)]
+				*requests = append(*requests, in)
+				return jsonResponse(t, ChatCompletionResponse{
+					Choices: []Choice{{Message: Message{Role: "assistant", Content: reply}}},
+				}), nil
+			},
+		})
+	}
+
+	in := GlossaryTranslationRequest{
+		Model:          "gpt-4o",
+		Text:           "Open the Dashboard in Acme Cloud to see your invoice.",
+		SourceLanguage: "en",
+		TargetLanguage: "de",
+		Glossary:       map[string]string{"dashboard": "Übersicht", "invoice": "Rechnung", "refund": "Erstattung"},
+		DoNotTranslate: []string{"Acme Cloud"},
+		MaxRetries:     1,
+	}
+
+	t.Run("re-asks until the terms are preserved", func(t *testing.T) {
+		t.Parallel()
+
+		var requests []ChatCompletionRequest
+		client := newClient([]string{
+			"Öffnen Sie das Dashboard in Acme Wolke, um Ihre Rechnung zu sehen.",
+			"Öffnen Sie die Übersicht in Acme Cloud, um Ihre Rechnung zu sehen.",
+		}, &requests)
+
+		got, err := client.TranslateWithGlossary(context.Background(), in)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Öffnen Sie die Übersicht in Acme Cloud, um Ihre Rechnung zu sehen.", got.Text)
+		assert.Empty(t, got.Missing)
+
+		require.Len(t, requests, 2)
+		system := requests[0].Messages[0].Content
+		assert.Contains(t, system, "from English to German")
+		assert.Contains(t, system, `"dashboard": "Übersicht"`)
+		assert.Contains(t, system, `"Acme Cloud"`)
+		assert.Equal(t, in.Text, requests[0].Messages[1].Content)
+
+		require.Len(t, requests[1].Messages, 4)
+		assert.Contains(t, requests[1].Messages[3].Content, "Acme Cloud, Übersicht")
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		t.Parallel()
+
+		var requests []ChatCompletionRequest
+		client := newClient([]string{
+			"Öffnen Sie das Dashboard, um Ihre Rechnung zu sehen.",
+			"Öffnen Sie das Dashboard, um Ihre Rechnung zu sehen.",
+		}, &requests)
+
+		got, err := client.TranslateWithGlossary(context.Background(), in)
+
+		assert.ErrorIs(t, err, ErrGlossaryViolation)
+		require.NotNil(t, got)
+		assert.Equal(t, []string{"Acme Cloud", "Übersicht"}, got.Missing)
+		assert.Len(t, requests, 2)
+	})
+
+	t.Run("requires a target language", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New("test_api_key", &mockHTTPClient{}).TranslateWithGlossary(context.Background(), GlossaryTranslationRequest{Text: "Hello"})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,132 @@
+package openaiclient
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Response metadata headers.
+const (
+	// HeaderRequestID is the ID of the request, to give to OpenAI support.
+	HeaderRequestID = "X-Request-Id"
+	// HeaderProcessingMS is the time the API took to process the request, in milliseconds.
+	HeaderProcessingMS = "Openai-Processing-Ms"
+	// HeaderVersion is the version of the API that served the request.
+	HeaderVersion = "Openai-Version"
+)
+
+// DefaultCapturedHeaders are the response headers retained when no capture policy is configured.
+var DefaultCapturedHeaders = []string{HeaderRequestID, "X-Ratelimit-*"}
+
+type (
+	// ResponseMeta holds the response headers retained by the client's capture policy.
+	ResponseMeta struct {
+		Header http.Header `json:"-"`
+	}
+
+	// headerPolicy selects which response headers are retained.
+	headerPolicy struct {
+		names    map[string]struct{}
+		prefixes []string
+	}
+
+	// metaSetter is implemented by responses embedding ResponseMeta.
+	metaSetter interface {
+		setHeader(header http.Header)
+	}
+)
+
+func (m *ResponseMeta) setHeader(header http.Header) {
+	m.Header = header
+}
+
+// RequestID returns the ID of the request, if captured.
+func (m ResponseMeta) RequestID() string {
+	return m.Header.Get(HeaderRequestID)
+}
+
+// ProcessingTime returns the time the API took to process the request. It
+// reports false if the time wasn't captured, which takes adding
+// HeaderProcessingMS to the captured headers.
+func (m ResponseMeta) ProcessingTime() (time.Duration, bool) {
+	ms, err := strconv.ParseFloat(m.Header.Get(HeaderProcessingMS), 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(ms * float64(time.Millisecond)), true
+}
+
+// APIVersion returns the version of the API that served the request, if
+// captured with HeaderVersion.
+func (m ResponseMeta) APIVersion() string {
+	return m.Header.Get(HeaderVersion)
+}
+
+// OnResponseMeta returns a middleware calling hook with the metadata of each
+// response, holding all its headers, e.g. to log request IDs of failed calls.
+func OnResponseMeta(hook func(req *http.Request, statusCode int, meta ResponseMeta)) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if err == nil {
+				hook(req, resp.StatusCode, ResponseMeta{Header: resp.Header})
+			}
+			return resp, err
+		}
+	}
+}
+
+// WithCapturedHeaders sets the response headers retained on response objects,
+// replacing DefaultCapturedHeaders. Names are case insensitive and a trailing
+// "*" matches every header with the given prefix. Passing no names disables capturing.
+func WithCapturedHeaders(names ...string) Option {
+	return func(c *Client) {
+		c.headerPolicy = newHeaderPolicy(names)
+	}
+}
+
+func newHeaderPolicy(names []string) *headerPolicy {
+	p := headerPolicy{names: make(map[string]struct{}, len(names))}
+
+	for _, name := range names {
+		name = strings.ToLower(name)
+		if prefix, ok := strings.CutSuffix(name, "*"); ok {
+			p.prefixes = append(p.prefixes, prefix)
+			continue
+		}
+		p.names[name] = struct{}{}
+	}
+	return &p
+}
+
+// capture returns the headers to retain, or nil if none match the policy.
+func (p *headerPolicy) capture(header http.Header) http.Header {
+	var captured http.Header
+
+	for key, values := range header {
+		if !p.matches(strings.ToLower(key)) {
+			continue
+		}
+
+		if captured == nil {
+			captured = make(http.Header)
+		}
+		captured[key] = append([]string(nil), values...)
+	}
+	return captured
+}
+
+func (p *headerPolicy) matches(key string) bool {
+	if _, ok := p.names[key]; ok {
+		return true
+	}
+
+	for _, prefix := range p.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package openaiclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseHeaderCapture(t *testing.T) {
+	t.Parallel()
+
+	header := http.Header{
+		"X-Request-Id":                   {"req_123"},
+		"X-Ratelimit-Remaining-Requests": {"99"},
+		"Openai-Processing-Ms":           {"42"},
+		"Set-Cookie":                     {"session=secret"},
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want http.Header
+	}{
+		{
+			name: "retains request id and rate limit headers by default",
+			opts: nil,
+			want: http.Header{
+				"X-Request-Id":                   {"req_123"},
+				"X-Ratelimit-Remaining-Requests": {"99"},
+			},
+		},
+		{
+			name: "retains configured headers",
+			opts: []Option{WithCapturedHeaders("openai-processing-ms")},
+			want: http.Header{
+				"Openai-Processing-Ms": {"42"},
+			},
+		},
+		{
+			name: "retains nothing when capturing is disabled",
+			opts: []Option{WithCapturedHeaders()},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 200,
+						Header:     header,
+						Body:       io.NopCloser(strings.NewReader("{}")),
+					}, nil
+				},
+			}, tt.opts...)
+
+			compResp, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, compResp.Header)
+
+			embResp, err := client.CreateEmbedding(context.Background(), EmbeddingRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, embResp.Header)
+		})
+	}
+}
+
+func TestResponseMeta(t *testing.T) {
+	t.Parallel()
+
+	meta := ResponseMeta{Header: http.Header{
+		"X-Request-Id":         {"req_123"},
+		"Openai-Processing-Ms": {"42.5"},
+		"Openai-Version":       {"2020-10-01"},
+	}}
+
+	assert.Equal(t, "req_123", meta.RequestID())
+	assert.Equal(t, "2020-10-01", meta.APIVersion())
+
+	processing, ok := meta.ProcessingTime()
+	assert.True(t, ok)
+	assert.Equal(t, 42500*time.Microsecond, processing)
+
+	var empty ResponseMeta
+	assert.Empty(t, empty.RequestID())
+	_, ok = empty.ProcessingTime()
+	assert.False(t, ok)
+}
+
+func TestOnResponseMeta(t *testing.T) {
+	t.Parallel()
+
+	var (
+		statuses []int
+		ids      []string
+	)
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Header:     http.Header{"X-Request-Id": {"req_456"}, "Openai-Version": {"2020-10-01"}},
+				Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"bad","type":"invalid_request_error"}}`)),
+			}, nil
+		},
+	}, WithCapturedHeaders(), WithMiddleware(OnResponseMeta(func(req *http.Request, statusCode int, meta ResponseMeta) {
+		statuses = append(statuses, statusCode)
+		ids = append(ids, meta.RequestID())
+	})))
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "req_456", apiErr.RequestID)
+	assert.ErrorContains(t, err, "request id: req_456")
+
+	assert.Equal(t, []int{http.StatusBadRequest}, statuses)
+	assert.Equal(t, []string{"req_456"}, ids, "the hook must see headers that aren't captured")
+}
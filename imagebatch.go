@@ -0,0 +1,176 @@
+package openaiclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultImageConcurrency is the number of image requests GenerateImages keeps in flight by default.
+const DefaultImageConcurrency = 4
+
+type (
+	// ImageBatchOption configures GenerateImages.
+	ImageBatchOption func(*imageBatchConfig)
+
+	imageBatchConfig struct {
+		template        ImageRequest
+		imagesPerMinute int
+		concurrency     int
+		progress        func(ImageProgress)
+	}
+
+	// ImageProgress reports the completion of a prompt of GenerateImages.
+	ImageProgress struct {
+		// Index is the index of the completed prompt.
+		Index int
+		Err   error
+		// Done is the number of completed prompts, out of Total.
+		Done  int
+		Total int
+	}
+
+	// ImageResult is the outcome of a prompt of GenerateImages.
+	ImageResult struct {
+		Prompt   string
+		Response *ImageResponse
+		Err      error
+	}
+)
+
+// WithImageRequest sets the request every prompt is sent with, such as its
+// model, size and response format. Its prompt is ignored.
+func WithImageRequest(template ImageRequest) ImageBatchOption {
+	return func(c *imageBatchConfig) {
+		c.template = template
+	}
+}
+
+// WithImagesPerMinute paces requests so that no more than n images are
+// requested per minute, matching the images-per-minute limit of the account.
+// Zero disables pacing.
+func WithImagesPerMinute(n int) ImageBatchOption {
+	return func(c *imageBatchConfig) {
+		c.imagesPerMinute = n
+	}
+}
+
+// WithImageConcurrency sets the number of requests in flight.
+func WithImageConcurrency(n int) ImageBatchOption {
+	return func(c *imageBatchConfig) {
+		c.concurrency = n
+	}
+}
+
+// WithImageProgress calls fn every time a prompt completes, from the goroutine
+// that served it.
+func WithImageProgress(fn func(ImageProgress)) ImageBatchOption {
+	return func(c *imageBatchConfig) {
+		c.progress = fn
+	}
+}
+
+// GenerateImages generates the images of every prompt concurrently, pacing the
+// requests under the images-per-minute limit, if set. It returns a result per
+// prompt, in order, along with a BatchError of the failed prompts.
+func (c *Client) GenerateImages(ctx context.Context, prompts []string, opts ...ImageBatchOption) ([]ImageResult, error) {
+	cfg := imageBatchConfig{concurrency: DefaultImageConcurrency}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.concurrency < 1 || cfg.imagesPerMinute < 0 {
+		return nil, fmt.Errorf("could not generate images: concurrency must be positive and images per minute not negative")
+	}
+
+	var pace *pacer
+	if cfg.imagesPerMinute > 0 {
+		pace = &pacer{interval: time.Minute / time.Duration(cfg.imagesPerMinute), now: time.Now}
+	}
+
+	// Requests for several images count as many against the limit.
+	images := max(cfg.template.N, 1)
+
+	var (
+		results = make([]ImageResult, len(prompts))
+		mu      sync.Mutex
+		done    int
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, cfg.concurrency)
+	)
+
+	for i, prompt := range prompts {
+		results[i].Prompt = prompt
+
+		wg.Add(1)
+		go func(i int, prompt string) {
+			defer wg.Done()
+
+			resp, err := c.generateImage(ctx, cfg.template, prompt, pace, images, sem)
+
+			mu.Lock()
+			results[i].Response, results[i].Err = resp, err
+			done++
+			progress := ImageProgress{Index: i, Err: err, Done: done, Total: len(prompts)}
+			mu.Unlock()
+
+			if cfg.progress != nil {
+				cfg.progress(progress)
+			}
+		}(i, prompt)
+	}
+	wg.Wait()
+
+	var failed []BatchItemError
+	for i, result := range results {
+		if result.Err != nil {
+			failed = append(failed, BatchItemError{Index: i, Err: result.Err})
+		}
+	}
+	return results, newBatchError("generate images", len(prompts), failed)
+}
+
+// generateImage generates the image of the prompt once a slot is free and the pace allows.
+func (c *Client) generateImage(ctx context.Context, template ImageRequest, prompt string, pace *pacer, images int, sem chan struct{}) (*ImageResponse, error) {
+	select {
+	case sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-sem }()
+
+	if pace != nil {
+		if err := c.sleep(ctx, pace.reserve(images)); err != nil {
+			return nil, err
+		}
+	}
+
+	in := template
+	in.Prompt = prompt
+	return c.CreateImage(ctx, in)
+}
+
+// pacer spaces requests evenly over time.
+type pacer struct {
+	interval time.Duration
+	now      func() time.Time
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// reserve reserves the next slot for the given number of units and returns how long to wait for it.
+func (p *pacer) reserve(units int) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	slot := p.next
+	if slot.Before(now) {
+		slot = now
+	}
+
+	p.next = slot.Add(p.interval * time.Duration(units))
+	return slot.Sub(now)
+}
@@ -0,0 +1,118 @@
+package openaiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GenerateImages(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in ImageRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+			assert.Equal(t, "dall-e-3", in.Model)
+
+			if in.Prompt == "forbidden" {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"error":{"code":"content_policy_violation"}}`)),
+				}, nil
+			}
+			return jsonResponse(t, ImageResponse{Data: []Image{{URL: "https://example.com/" + in.Prompt}}}), nil
+		},
+	})
+
+	var (
+		mu      sync.Mutex
+		sleeps  []time.Duration
+		updates []ImageProgress
+	)
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sleeps = append(sleeps, d)
+		return nil
+	}
+
+	prompts := []string{"cat", "forbidden", "dog"}
+	results, err := client.GenerateImages(context.Background(), prompts,
+		WithImageRequest(ImageRequest{Model: "dall-e-3", Prompt: "ignored"}),
+		WithImagesPerMinute(3),
+		WithImageConcurrency(2),
+		WithImageProgress(func(progress ImageProgress) {
+			mu.Lock()
+			defer mu.Unlock()
+			updates = append(updates, progress)
+		}),
+	)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "content_policy_violation", apiErr.Code)
+
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, []int{1}, batchErr.Indexes())
+	assert.Equal(t, 3, batchErr.Total)
+
+	require.Len(t, results, 3)
+	for i, result := range results {
+		assert.Equal(t, prompts[i], result.Prompt)
+	}
+	assert.Equal(t, "https://example.com/cat", results[0].Response.Data[0].URL)
+	assert.Error(t, results[1].Err)
+	assert.Nil(t, results[1].Response)
+	assert.Equal(t, "https://example.com/dog", results[2].Response.Data[0].URL)
+
+	// Requests are spaced by 20 seconds to stay under 3 images per minute.
+	sort.Slice(sleeps, func(i, j int) bool { return sleeps[i] < sleeps[j] })
+	require.Len(t, sleeps, 3)
+	assert.InDelta(t, 0, sleeps[0], float64(time.Second))
+	assert.InDelta(t, 20*time.Second, sleeps[1], float64(time.Second))
+	assert.InDelta(t, 40*time.Second, sleeps[2], float64(time.Second))
+
+	require.Len(t, updates, 3)
+	var done []int
+	for _, update := range updates {
+		done = append(done, update.Done)
+		assert.Equal(t, 3, update.Total)
+		assert.Equal(t, update.Index == 1, update.Err != nil)
+	}
+	assert.Equal(t, []int{1, 2, 3}, done)
+}
+
+func TestClient_GenerateImages_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{})
+
+	_, err := client.GenerateImages(context.Background(), []string{"cat"}, WithImageConcurrency(0))
+	assert.Error(t, err)
+}
+
+func TestPacer_Reserve(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := pacer{interval: 10 * time.Second, now: func() time.Time { return now }}
+
+	assert.Equal(t, time.Duration(0), p.reserve(1))
+	assert.Equal(t, 10*time.Second, p.reserve(2))
+	assert.Equal(t, 30*time.Second, p.reserve(1))
+
+	// Idle time isn't banked.
+	now = now.Add(time.Hour)
+	assert.Equal(t, time.Duration(0), p.reserve(1))
+}
@@ -0,0 +1,179 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+)
+
+// Image response formats.
+const (
+	ImageFormatURL     = "url"
+	ImageFormatB64JSON = "b64_json"
+)
+
+type (
+	// ImageRequest is the request body for the image generation endpoint.
+	ImageRequest struct {
+		Model  string `json:"model,omitempty"`
+		Prompt string `json:"prompt"`
+		N      int    `json:"n,omitempty"`
+		// Size is e.g. "1024x1024".
+		Size    string `json:"size,omitempty"`
+		Quality string `json:"quality,omitempty"`
+		Style   string `json:"style,omitempty"`
+		// ResponseFormat is ImageFormatURL or ImageFormatB64JSON.
+		ResponseFormat string `json:"response_format,omitempty"`
+		User           string `json:"user,omitempty"`
+	}
+
+	// ImageEditRequest is the request for the image edit endpoint.
+	ImageEditRequest struct {
+		Model  string
+		Prompt string
+		// Images are the images to edit. Models such as gpt-image-1 accept
+		// several images, DALL·E 2 a single one.
+		Images []InputFile
+		// Mask is an optional image whose transparent areas mark where to edit.
+		Mask           *InputFile
+		N              int
+		Size           string
+		ResponseFormat string
+		User           string
+	}
+
+	// ImageVariationRequest is the request for the image variation endpoint.
+	ImageVariationRequest struct {
+		Model          string
+		Image          InputFile
+		N              int
+		Size           string
+		ResponseFormat string
+		User           string
+	}
+
+	// ImageResponse is the response body of the image endpoints.
+	ImageResponse struct {
+		ResponseMeta
+		Created int64   `json:"created"`
+		Data    []Image `json:"data"`
+	}
+
+	// Image is a generated image, either hosted at URL or inlined as base64.
+	Image struct {
+		URL     string `json:"url,omitempty"`
+		B64JSON string `json:"b64_json,omitempty"`
+		// RevisedPrompt is the prompt DALL·E 3 rewrote the request prompt into.
+		RevisedPrompt string `json:"revised_prompt,omitempty"`
+	}
+
+	// PromptRevision reports how the model rewrote the prompt of an image request.
+	PromptRevision struct {
+		Model   string
+		Prompt  string
+		Revised string
+		// Index is the index of the image in the response data.
+		Index int
+	}
+)
+
+// WithPromptRevisionHook calls hook for every generated image whose prompt the
+// model revised, so applications can log or display how it was rewritten.
+func WithPromptRevisionHook(hook func(PromptRevision)) Option {
+	return func(c *Client) {
+		c.promptRevisionHook = hook
+	}
+}
+
+// Bytes returns the decoded image of a base64 response.
+func (img Image) Bytes() ([]byte, error) {
+	if img.B64JSON == "" {
+		return nil, fmt.Errorf("could not decode image: no base64 data, the image is hosted at %q", img.URL)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(img.B64JSON)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode image: %w", err)
+	}
+	return data, nil
+}
+
+// CreateImage generates images from the prompt.
+func (c *Client) CreateImage(ctx context.Context, in ImageRequest) (*ImageResponse, error) {
+	in.Model = c.resolveModel(in.Model)
+	in.User = c.endUser(in.User)
+	c.deprecations.check(in.Model)
+
+	var resp ImageResponse
+	if err := c.post(ctx, c.url(EndpointImagesGenerations), in, &resp); err != nil {
+		return nil, err
+	}
+
+	if c.promptRevisionHook != nil {
+		for i, img := range resp.Data {
+			if img.RevisedPrompt != "" && img.RevisedPrompt != in.Prompt {
+				c.promptRevisionHook(PromptRevision{Model: in.Model, Prompt: in.Prompt, Revised: img.RevisedPrompt, Index: i})
+			}
+		}
+	}
+	return &resp, nil
+}
+
+// CreateImageEdit edits images according to the prompt.
+func (c *Client) CreateImageEdit(ctx context.Context, in ImageEditRequest) (*ImageResponse, error) {
+	if len(in.Images) == 0 {
+		return nil, fmt.Errorf("could not edit image: no image given")
+	}
+
+	in.Model = c.resolveModel(in.Model)
+	in.User = c.endUser(in.User)
+	c.deprecations.check(in.Model)
+
+	form := newMultipartForm()
+	form.field("model", in.Model)
+	form.field("prompt", in.Prompt)
+
+	imageField := "image"
+	if len(in.Images) > 1 {
+		imageField = "image[]"
+	}
+	for _, image := range in.Images {
+		form.file(imageField, image)
+	}
+
+	if in.Mask != nil {
+		form.file("mask", *in.Mask)
+	}
+
+	form.intField("n", in.N)
+	form.field("size", in.Size)
+	form.field("response_format", in.ResponseFormat)
+	form.field("user", in.User)
+
+	var resp ImageResponse
+	if err := c.postForm(ctx, c.url(EndpointImagesEdits), form, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateImageVariation creates variations of the image.
+func (c *Client) CreateImageVariation(ctx context.Context, in ImageVariationRequest) (*ImageResponse, error) {
+	in.Model = c.resolveModel(in.Model)
+	in.User = c.endUser(in.User)
+	c.deprecations.check(in.Model)
+
+	form := newMultipartForm()
+	form.field("model", in.Model)
+	form.file("image", in.Image)
+	form.intField("n", in.N)
+	form.field("size", in.Size)
+	form.field("response_format", in.ResponseFormat)
+	form.field("user", in.User)
+
+	var resp ImageResponse
+	if err := c.postForm(ctx, c.url(EndpointImagesVariations), form, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
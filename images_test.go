@@ -0,0 +1,228 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateImage(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, DefaultBaseURL+EndpointImagesGenerations, req.URL.String())
+			assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+			var in map[string]any
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+			assert.Equal(t, map[string]any{
+				"model":           "dall-e-3",
+				"prompt":          "a lighthouse at dusk",
+				"size":            "1024x1024",
+				"response_format": ImageFormatB64JSON,
+			}, in)
+
+			return jsonResponse(t, ImageResponse{
+				Created: 1700000000,
+				Data:    []Image{{B64JSON: base64.StdEncoding.EncodeToString([]byte("png"))}},
+			}), nil
+		},
+	})
+
+	resp, err := client.CreateImage(context.Background(), ImageRequest{
+		Model:          "dall-e-3",
+		Prompt:         "a lighthouse at dusk",
+		Size:           "1024x1024",
+		ResponseFormat: ImageFormatB64JSON,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+
+	data, err := resp.Data[0].Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png"), data)
+}
+
+func TestWithPromptRevisionHook(t *testing.T) {
+	t.Parallel()
+
+	var revisions []PromptRevision
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(t, ImageResponse{
+				Data: []Image{
+					{URL: "https://example.com/0.png", RevisedPrompt: "a lighthouse at dusk"},
+					{URL: "https://example.com/1.png", RevisedPrompt: "a red lighthouse on a cliff at dusk"},
+					{URL: "https://example.com/2.png"},
+				},
+			}), nil
+		},
+	}, WithPromptRevisionHook(func(revision PromptRevision) {
+		revisions = append(revisions, revision)
+	}))
+
+	resp, err := client.CreateImage(context.Background(), ImageRequest{Model: "dall-e-3", Prompt: "a lighthouse at dusk"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "a red lighthouse on a cliff at dusk", resp.Data[1].RevisedPrompt)
+	assert.Equal(t, []PromptRevision{{
+		Model:   "dall-e-3",
+		Prompt:  "a lighthouse at dusk",
+		Revised: "a red lighthouse on a cliff at dusk",
+		Index:   1,
+	}}, revisions)
+}
+
+func TestClient_CreateImageEdit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		images     []InputFile
+		wantField  string
+		wantImages []string
+	}{
+		{
+			name:       "single image",
+			images:     []InputFile{{Name: "room.png", Data: strings.NewReader("room")}},
+			wantField:  "image",
+			wantImages: []string{"room"},
+		},
+		{
+			name: "several images",
+			images: []InputFile{
+				{Name: "room.png", Data: strings.NewReader("room")},
+				{Name: "lamp.png", Data: strings.NewReader("lamp")},
+			},
+			wantField:  "image[]",
+			wantImages: []string{"room", "lamp"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, DefaultBaseURL+EndpointImagesEdits, req.URL.String())
+					require.NoError(t, req.ParseMultipartForm(1<<20))
+
+					assert.Equal(t, "gpt-image-1", req.FormValue("model"))
+					assert.Equal(t, "add a lamp", req.FormValue("prompt"))
+					assert.Equal(t, "2", req.FormValue("n"))
+					assert.Empty(t, req.MultipartForm.Value["size"])
+
+					var images []string
+					for _, header := range req.MultipartForm.File[tc.wantField] {
+						images = append(images, readFormFile(t, header))
+					}
+					assert.Equal(t, tc.wantImages, images)
+
+					require.Len(t, req.MultipartForm.File["mask"], 1)
+					assert.Equal(t, "mask.png", req.MultipartForm.File["mask"][0].Filename)
+					assert.Equal(t, "mask", readFormFile(t, req.MultipartForm.File["mask"][0]))
+
+					return jsonResponse(t, ImageResponse{Data: []Image{{URL: "https://example.com/image.png"}}}), nil
+				},
+			})
+
+			resp, err := client.CreateImageEdit(context.Background(), ImageEditRequest{
+				Model:  "gpt-image-1",
+				Prompt: "add a lamp",
+				Images: tc.images,
+				Mask:   &InputFile{Name: "mask.png", Data: strings.NewReader("mask")},
+				N:      2,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []Image{{URL: "https://example.com/image.png"}}, resp.Data)
+		})
+	}
+}
+
+func TestClient_CreateImageEdit_NoImage(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			t.Fatal("unexpected request")
+			return nil, nil
+		},
+	})
+
+	_, err := client.CreateImageEdit(context.Background(), ImageEditRequest{Prompt: "add a lamp"})
+	require.Error(t, err)
+}
+
+func TestClient_CreateImageVariation(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, DefaultBaseURL+EndpointImagesVariations, req.URL.String())
+			require.NoError(t, req.ParseMultipartForm(1<<20))
+
+			assert.Equal(t, ImageFormatURL, req.FormValue("response_format"))
+			require.Len(t, req.MultipartForm.File["image"], 1)
+			assert.Equal(t, "cat.png", req.MultipartForm.File["image"][0].Filename)
+			assert.Equal(t, "cat", readFormFile(t, req.MultipartForm.File["image"][0]))
+
+			return jsonResponse(t, ImageResponse{Data: []Image{{URL: "https://example.com/cat.png"}}}), nil
+		},
+	})
+
+	resp, err := client.CreateImageVariation(context.Background(), ImageVariationRequest{
+		Image:          InputFile{Name: "cat.png", Data: strings.NewReader("cat")},
+		ResponseFormat: ImageFormatURL,
+	})
+	require.NoError(t, err)
+	assert.Len(t, resp.Data, 1)
+}
+
+func TestClient_CreateImageVariation_NoData(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			t.Fatal("unexpected request")
+			return nil, nil
+		},
+	})
+
+	_, err := client.CreateImageVariation(context.Background(), ImageVariationRequest{Image: InputFile{Name: "cat.png"}})
+	require.Error(t, err)
+}
+
+func TestImage_Bytes(t *testing.T) {
+	t.Parallel()
+
+	_, err := Image{URL: "https://example.com/image.png"}.Bytes()
+	assert.Error(t, err)
+
+	_, err = Image{B64JSON: "not base64!"}.Bytes()
+	assert.Error(t, err)
+}
+
+// readFormFile returns the content of an uploaded form file.
+func readFormFile(t *testing.T, header *multipart.FileHeader) string {
+	t.Helper()
+
+	file, err := header.Open()
+	require.NoError(t, err)
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,264 @@
+package openaiclient
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+type (
+	// JournalEntry is a failed request recorded for replay. It holds no
+	// credentials: the API key and headers are set again on replay.
+	JournalEntry struct {
+		// ID is the idempotency key of the request, preserved on replay so the
+		// server can deduplicate requests that did go through.
+		ID          string    `json:"id"`
+		Method      string    `json:"method"`
+		URL         string    `json:"url"`
+		ContentType string    `json:"content_type"`
+		Body        []byte    `json:"body"`
+		Err         string    `json:"error"`
+		FailedAt    time.Time `json:"failed_at"`
+	}
+
+	// RequestJournal stores failed requests until they're replayed.
+	RequestJournal interface {
+		Append(ctx context.Context, entry JournalEntry) error
+		Entries(ctx context.Context) ([]JournalEntry, error)
+		Remove(ctx context.Context, id string) error
+	}
+
+	// ReplayReport is the outcome of Client.ReplayFailed.
+	ReplayReport struct {
+		// Replayed are the IDs of the entries replayed successfully and removed from the journal.
+		Replayed []string
+		// Failed are the entries still failing, left in the journal.
+		Failed []ReplayFailure
+	}
+
+	// ReplayFailure is an entry that failed again on replay.
+	ReplayFailure struct {
+		Entry JournalEntry
+		Err   error
+	}
+)
+
+// WithJournal records the requests with a body, such as completions and
+// uploads, that fail with a network error, a rate limit or a server error into
+// the journal, after retries, so they can be replayed with Client.ReplayFailed
+// once the outage is over. Journaled requests carry an Idempotency-Key header.
+func WithJournal(journal RequestJournal) Option {
+	return func(c *Client) {
+		c.journal = journal
+	}
+}
+
+// ReplayFailed re-sends the journaled requests with their original idempotency
+// keys, removing those that succeed from the journal. Responses of replayed
+// requests are discarded.
+func (c *Client) ReplayFailed(ctx context.Context) (*ReplayReport, error) {
+	if c.journal == nil {
+		return nil, fmt.Errorf("could not replay requests: no journal configured")
+	}
+
+	entries, err := c.journal.Entries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not read journal: %w", err)
+	}
+
+	var report ReplayReport
+	for _, entry := range entries {
+		if err := c.replay(ctx, entry); err != nil {
+			if ctx.Err() != nil {
+				return &report, ctx.Err()
+			}
+			report.Failed = append(report.Failed, ReplayFailure{Entry: entry, Err: err})
+			continue
+		}
+
+		if err := c.journal.Remove(ctx, entry.ID); err != nil {
+			return &report, fmt.Errorf("could not remove replayed request %s from journal: %w", entry.ID, err)
+		}
+		report.Replayed = append(report.Replayed, entry.ID)
+	}
+	return &report, nil
+}
+
+// replay re-sends the journaled request.
+func (c *Client) replay(ctx context.Context, entry JournalEntry) error {
+	if c.optionErr != nil {
+		return fmt.Errorf("invalid client option: %w", c.optionErr)
+	}
+
+	resp, err := c.transmit(ctx, entry.Method, entry.URL, &requestBody{
+		data:           entry.Body,
+		contentType:    entry.ContentType,
+		idempotencyKey: entry.ID,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(resp)
+	}
+	return nil
+}
+
+// failed reports whether the request failed in a way replaying may fix later.
+// Requests canceled by the caller and client errors aren't worth replaying.
+func failed(resp *http.Response, err error) bool {
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			return isRetryableStatus(apiErr.StatusCode)
+		}
+		return !errors.Is(err, context.Canceled)
+	}
+	return isRetryableStatus(resp.StatusCode)
+}
+
+// journalFailure records the failed request in the journal.
+func (c *Client) journalFailure(ctx context.Context, method, url string, body *requestBody, resp *http.Response, err error) error {
+	entry := JournalEntry{
+		ID:          body.idempotencyKey,
+		Method:      method,
+		URL:         url,
+		ContentType: body.contentType,
+		Body:        body.data,
+		FailedAt:    time.Now().UTC(),
+	}
+
+	if err != nil {
+		entry.Err = err.Error()
+	} else {
+		entry.Err = fmt.Sprintf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	// The caller's context may be expired already, which is no reason to drop the entry.
+	if err := c.journal.Append(context.WithoutCancel(ctx), entry); err != nil {
+		return fmt.Errorf("could not journal failed request: %w", err)
+	}
+	return nil
+}
+
+// newIdempotencyKey returns a random idempotency key.
+func newIdempotencyKey() (string, error) {
+	var key [16]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		return "", fmt.Errorf("could not generate idempotency key: %w", err)
+	}
+	return hex.EncodeToString(key[:]), nil
+}
+
+// FileJournal is a RequestJournal stored as JSON lines in a file, which
+// survives restarts. It is safe for concurrent use within a process.
+type FileJournal struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileJournal creates a journal stored in the file at path, created on the first failure.
+func NewFileJournal(path string) *FileJournal {
+	return &FileJournal{path: path}
+}
+
+// Append appends the entry to the file.
+func (j *FileJournal) Append(_ context.Context, entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("could not marshal journal entry: %w", err)
+	}
+
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("could not open journal: %w", err)
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("could not write journal: %w", err)
+	}
+	return f.Close()
+}
+
+// Entries returns the entries of the file, oldest first.
+func (j *FileJournal) Entries(_ context.Context) ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.read()
+}
+
+// Remove removes the entry with the given ID from the file.
+func (j *FileJournal) Remove(_ context.Context, id string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries, err := j.read()
+	if err != nil {
+		return err
+	}
+
+	tmp := j.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("could not open journal: %w", err)
+	}
+
+	enc := json.NewEncoder(f)
+	for _, entry := range entries {
+		if entry.ID == id {
+			continue
+		}
+		if err := enc.Encode(entry); err != nil {
+			f.Close()
+			return fmt.Errorf("could not write journal: %w", err)
+		}
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("could not write journal: %w", err)
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// read reads the entries of the file.
+func (j *FileJournal) read() ([]JournalEntry, error) {
+	f, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open journal: %w", err)
+	}
+	defer f.Close()
+
+	var entries []JournalEntry
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64<<20)
+	for scanner.Scan() {
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("could not decode journal entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read journal: %w", err)
+	}
+	return entries, nil
+}
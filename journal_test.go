@@ -0,0 +1,172 @@
+package openaiclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithJournal(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		response    *http.Response
+		err         error
+		wantJournal bool
+	}{
+		{
+			name:        "server error",
+			response:    &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(bytes.NewBufferString(`{}`))},
+			wantJournal: true,
+		},
+		{
+			name:        "network error",
+			err:         errors.New("connection refused"),
+			wantJournal: true,
+		},
+		{
+			name:     "client error",
+			response: &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(bytes.NewBufferString(`{}`))},
+		},
+		{
+			name: "canceled",
+			err:  context.Canceled,
+		},
+		{
+			name:     "success",
+			response: jsonResponse(t, ChatCompletionResponse{}),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			journal := NewFileJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+
+			var idempotencyKey string
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					idempotencyKey = req.Header.Get("Idempotency-Key")
+					return tc.response, tc.err
+				},
+			}, WithJournal(journal), WithCompression(1))
+
+			_, _ = client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+			assert.Len(t, idempotencyKey, 32)
+
+			entries, err := journal.Entries(context.Background())
+			require.NoError(t, err)
+
+			if !tc.wantJournal {
+				assert.Empty(t, entries)
+				return
+			}
+
+			require.Len(t, entries, 1)
+			assert.Equal(t, idempotencyKey, entries[0].ID)
+			assert.Equal(t, http.MethodPost, entries[0].Method)
+			assert.Equal(t, DefaultBaseURL+EndpointChatCompletions, entries[0].URL)
+			assert.Equal(t, "application/json", entries[0].ContentType)
+			assert.JSONEq(t, `{"model":"gpt-4o","messages":null}`, string(entries[0].Body))
+			assert.NotEmpty(t, entries[0].Err)
+			assert.NotContains(t, entries[0].Err, "test_api_key")
+		})
+	}
+}
+
+func TestClient_ReplayFailed(t *testing.T) {
+	t.Parallel()
+
+	journal := NewFileJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+
+	down := true
+	var replayedKeys []string
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if down {
+				return nil, errors.New("connection refused")
+			}
+
+			replayedKeys = append(replayedKeys, req.Header.Get("Idempotency-Key"))
+			assert.Equal(t, "Bearer rotated_key", req.Header.Get("Authorization"))
+
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+
+			if bytes.Contains(body, []byte("still-down")) {
+				return &http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+			}
+			return jsonResponse(t, ChatCompletionResponse{}), nil
+		},
+	}, WithJournal(journal))
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.Error(t, err)
+	_, err = client.CreateEmbedding(context.Background(), EmbeddingRequest{Model: "still-down"})
+	require.Error(t, err)
+
+	entries, err := journal.Entries(context.Background())
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	down = false
+	client.apiKey = "rotated_key"
+
+	report, err := client.ReplayFailed(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{entries[0].ID, entries[1].ID}, replayedKeys)
+	assert.Equal(t, []string{entries[0].ID}, report.Replayed)
+	require.Len(t, report.Failed, 1)
+	assert.Equal(t, entries[1].ID, report.Failed[0].Entry.ID)
+
+	var apiErr *APIError
+	require.ErrorAs(t, report.Failed[0].Err, &apiErr)
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+
+	left, err := journal.Entries(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, entries[1:], left)
+}
+
+func TestClient_ReplayFailed_NoJournal(t *testing.T) {
+	t.Parallel()
+
+	_, err := New("test_api_key", &mockHTTPClient{}).ReplayFailed(context.Background())
+	assert.Error(t, err)
+}
+
+func TestFileJournal(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	journal := NewFileJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+
+	entries, err := journal.Entries(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, journal.Append(ctx, JournalEntry{ID: id, Method: http.MethodPost, Body: []byte(`{"id":"` + id + `"}`)}))
+	}
+
+	require.NoError(t, journal.Remove(ctx, "b"))
+
+	entries, err = journal.Entries(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "a", entries[0].ID)
+	assert.Equal(t, "c", entries[1].ID)
+	assert.Equal(t, []byte(`{"id":"c"}`), entries[1].Body)
+}
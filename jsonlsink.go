@@ -0,0 +1,147 @@
+package openaiclient
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Default flush policy of a JSONLSink.
+const (
+	DefaultJSONLFlushEvery    = 100
+	DefaultJSONLFlushInterval = time.Second
+)
+
+type (
+	// JSONLSinkConfig is the flush policy of a JSONLSink.
+	JSONLSinkConfig struct {
+		// FlushEvery flushes after this number of records. Defaults to
+		// DefaultJSONLFlushEvery; 1 flushes every record.
+		FlushEvery int
+		// FlushInterval flushes on the first record written this long after the
+		// last flush. Defaults to DefaultJSONLFlushInterval.
+		FlushInterval time.Duration
+	}
+
+	// JSONLSink writes records, such as completed responses, as JSON Lines.
+	// Records are encoded before being buffered and only whole lines are
+	// flushed, so a crash leaves at most the last line incomplete; see
+	// RepairJSONL. It is safe for concurrent use.
+	JSONLSink struct {
+		cfg JSONLSinkConfig
+		now func() time.Time
+
+		mu        sync.Mutex
+		w         *bufio.Writer
+		line      bytes.Buffer
+		buffered  int
+		lastFlush time.Time
+	}
+)
+
+// NewJSONLSink creates a sink writing to w with the flush policy of cfg.
+func NewJSONLSink(w io.Writer, cfg JSONLSinkConfig) *JSONLSink {
+	if cfg.FlushEvery <= 0 {
+		cfg.FlushEvery = DefaultJSONLFlushEvery
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultJSONLFlushInterval
+	}
+
+	return &JSONLSink{
+		cfg:       cfg,
+		now:       time.Now,
+		w:         bufio.NewWriter(w),
+		lastFlush: time.Now(),
+	}
+}
+
+// Write writes the JSON encoding of the record as a line.
+func (s *JSONLSink) Write(record any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The encoder ends the line with a newline, and escapes those in strings.
+	s.line.Reset()
+	enc := json.NewEncoder(&s.line)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(record); err != nil {
+		return fmt.Errorf("could not marshal record: %w", err)
+	}
+
+	// Lines larger than the buffer are written through, whole.
+	if s.line.Len() > s.w.Available() && s.w.Buffered() > 0 {
+		if err := s.flush(); err != nil {
+			return err
+		}
+	}
+	if _, err := s.w.Write(s.line.Bytes()); err != nil {
+		return fmt.Errorf("could not write record: %w", err)
+	}
+	s.buffered++
+
+	if s.buffered >= s.cfg.FlushEvery || s.now().Sub(s.lastFlush) >= s.cfg.FlushInterval {
+		return s.flush()
+	}
+	return nil
+}
+
+// Flush writes the buffered records to the underlying writer.
+func (s *JSONLSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.flush()
+}
+
+// flush writes the buffered records; s.mu must be held.
+func (s *JSONLSink) flush() error {
+	if err := s.w.Flush(); err != nil {
+		return fmt.Errorf("could not flush records: %w", err)
+	}
+	s.buffered = 0
+	s.lastFlush = s.now()
+	return nil
+}
+
+// RepairJSONL truncates the JSON Lines file after its last complete line,
+// dropping the partial line left by a crash, so records can be appended
+// again through a file opened with os.O_APPEND. It returns the number of
+// bytes dropped.
+func RepairJSONL(f *os.File) (int64, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("could not stat file: %w", err)
+	}
+
+	// Scan backwards for the last newline.
+	size := info.Size()
+	buf := make([]byte, 4096)
+	end := size
+	for end > 0 {
+		start := max(end-int64(len(buf)), 0)
+		chunk := buf[:end-start]
+		if _, err := f.ReadAt(chunk, start); err != nil {
+			return 0, fmt.Errorf("could not read file: %w", err)
+		}
+
+		if i := bytes.LastIndexByte(chunk, '\n'); i >= 0 {
+			end = start + int64(i) + 1
+			break
+		}
+		end = start
+	}
+
+	if end == size {
+		return 0, nil
+	}
+	if err := f.Truncate(end); err != nil {
+		return 0, fmt.Errorf("could not truncate file: %w", err)
+	}
+	return size - end, nil
+}
@@ -0,0 +1,142 @@
+package openaiclient
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingWriter records the writes to the underlying buffer.
+type countingWriter struct {
+	bytes.Buffer
+	writes []string
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, string(p))
+	return w.Buffer.Write(p)
+}
+
+func TestJSONLSink(t *testing.T) {
+	t.Parallel()
+
+	var w countingWriter
+	sink := NewJSONLSink(&w, JSONLSinkConfig{FlushEvery: 2, FlushInterval: time.Hour})
+
+	require.NoError(t, sink.Write(ChatCompletionResponse{ID: "chatcmpl-1", Choices: []Choice{{Message: Message{Role: RoleAssistant, Content: "line\nbreak <b>"}}}}))
+	assert.Empty(t, w.writes, "records are buffered until the flush")
+
+	require.NoError(t, sink.Write(map[string]string{"id": "chatcmpl-2"}))
+	require.NoError(t, sink.Write(map[string]string{"id": "chatcmpl-3", "note": "<b>"}))
+	require.Len(t, w.writes, 1)
+
+	require.NoError(t, sink.Flush())
+	require.Len(t, w.writes, 2)
+
+	lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+
+	var first ChatCompletionResponse
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "line\nbreak <b>", first.Choices[0].Message.Content)
+	assert.Equal(t, `{"id":"chatcmpl-3","note":"<b>"}`, lines[2])
+
+	for _, write := range w.writes {
+		assert.True(t, strings.HasSuffix(write, "\n"), "only whole lines must be written")
+	}
+}
+
+func TestJSONLSink_FlushInterval(t *testing.T) {
+	t.Parallel()
+
+	var w countingWriter
+	sink := NewJSONLSink(&w, JSONLSinkConfig{FlushEvery: 100, FlushInterval: time.Minute})
+
+	now := time.Now()
+	sink.now = func() time.Time { return now }
+	sink.lastFlush = now
+
+	require.NoError(t, sink.Write(1))
+	assert.Empty(t, w.writes)
+
+	now = now.Add(time.Minute)
+	require.NoError(t, sink.Write(2))
+	assert.Equal(t, []string{"1\n2\n"}, w.writes)
+}
+
+func TestJSONLSink_LargeRecords(t *testing.T) {
+	t.Parallel()
+
+	var w countingWriter
+	sink := NewJSONLSink(&w, JSONLSinkConfig{})
+
+	large := strings.Repeat("a", 3*4096)
+	require.NoError(t, sink.Write("small"))
+	require.NoError(t, sink.Write(large))
+	require.NoError(t, sink.Write(strings.Repeat("b", 3000)))
+	require.NoError(t, sink.Write(strings.Repeat("c", 3000)))
+	require.NoError(t, sink.Flush())
+
+	for _, write := range w.writes {
+		assert.True(t, strings.HasSuffix(write, "\n"), "only whole lines must be written")
+	}
+
+	scanner := bufio.NewScanner(&w.Buffer)
+	scanner.Buffer(nil, 1<<20)
+	var lines int
+	for scanner.Scan() {
+		lines++
+	}
+	assert.Equal(t, 4, lines)
+}
+
+func TestRepairJSONL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		want        string
+		wantDropped int64
+	}{
+		{name: "empty"},
+		{name: "complete", content: "{\"a\":1}\n{\"b\":2}\n", want: "{\"a\":1}\n{\"b\":2}\n"},
+		{name: "partial line", content: "{\"a\":1}\n{\"b\":", want: "{\"a\":1}\n", wantDropped: 5},
+		{name: "only a partial line", content: "{\"a\":", wantDropped: 5},
+		{name: "partial line over several blocks", content: "{}\n" + strings.Repeat("x", 10000), want: "{}\n", wantDropped: 10000},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "records.jsonl")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND, 0)
+			require.NoError(t, err)
+			defer f.Close()
+
+			dropped, err := RepairJSONL(f)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDropped, dropped)
+
+			sink := NewJSONLSink(f, JSONLSinkConfig{})
+			require.NoError(t, sink.Write(map[string]int{"c": 3}))
+			require.NoError(t, sink.Flush())
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want+"{\"c\":3}\n", string(data))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package openaiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// KeyProvider supplies the API key of every request, so keys can rotate
+// without recreating the client.
+type KeyProvider interface {
+	// APIKey returns the current API key.
+	APIKey(ctx context.Context) (string, error)
+	// Refresh discards the current API key, e.g. after it was rejected, and returns a fresh one.
+	Refresh(ctx context.Context) (string, error)
+}
+
+// WithKeyProvider makes the client fetch the API key from the provider before
+// every request instead of using the key given to New. The first 401 response
+// of a request is taken as a sign the key rotated: the key is refreshed and the
+// request retried once before the authentication error is returned.
+func WithKeyProvider(provider KeyProvider) Option {
+	return func(c *Client) {
+		c.keyProvider = provider
+	}
+}
+
+// key returns the API key for the next request.
+func (c *Client) key(ctx context.Context) (string, error) {
+	if c.keyProvider == nil {
+		return c.apiKey, nil
+	}
+
+	key, err := c.keyProvider.APIKey(ctx)
+	if err != nil {
+		return "", fmt.Errorf("could not get api key: %w", err)
+	}
+	return key, nil
+}
+
+// shouldRefreshKey reports whether the response calls for a key refresh before retrying.
+func (c *Client) shouldRefreshKey(resp *http.Response, refreshed bool) bool {
+	return c.keyProvider != nil && !refreshed && resp.StatusCode == http.StatusUnauthorized
+}
+
+// refreshKey returns a fresh API key from the provider.
+func (c *Client) refreshKey(ctx context.Context) (string, error) {
+	key, err := c.keyProvider.Refresh(ctx)
+	if err != nil {
+		return "", fmt.Errorf("could not refresh api key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,110 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rotatingKeyProvider struct {
+	keys       []string
+	current    int
+	refreshes  int
+	refreshErr error
+}
+
+func (p *rotatingKeyProvider) APIKey(context.Context) (string, error) {
+	return p.keys[p.current], nil
+}
+
+func (p *rotatingKeyProvider) Refresh(context.Context) (string, error) {
+	p.refreshes++
+	if p.refreshErr != nil {
+		return "", p.refreshErr
+	}
+
+	if p.current < len(p.keys)-1 {
+		p.current++
+	}
+	return p.keys[p.current], nil
+}
+
+func TestWithKeyProvider(t *testing.T) {
+	t.Parallel()
+
+	// newClient returns a client whose server only accepts the valid key.
+	newClient := func(provider KeyProvider, valid string, seen *[]string) *Client {
+		return New("", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				auth := req.Header.Get("Authorization")
+				*seen = append(*seen, auth)
+
+				if auth != "Bearer "+valid {
+					return &http.Response{
+						StatusCode: http.StatusUnauthorized,
+						Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"invalid key"}}`)),
+					}, nil
+				}
+				return jsonResponse(t, ChatCompletionResponse{}), nil
+			},
+		}, WithKeyProvider(provider))
+	}
+
+	t.Run("uses the provided key", func(t *testing.T) {
+		t.Parallel()
+
+		var seen []string
+		provider := &rotatingKeyProvider{keys: []string{"key-1"}}
+
+		_, err := newClient(provider, "key-1", &seen).CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"Bearer key-1"}, seen)
+		assert.Zero(t, provider.refreshes)
+	})
+
+	t.Run("refreshes the key once on 401", func(t *testing.T) {
+		t.Parallel()
+
+		var seen []string
+		provider := &rotatingKeyProvider{keys: []string{"key-1", "key-2"}}
+
+		_, err := newClient(provider, "key-2", &seen).CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"Bearer key-1", "Bearer key-2"}, seen)
+		assert.Equal(t, 1, provider.refreshes)
+	})
+
+	t.Run("returns the auth error after one refresh", func(t *testing.T) {
+		t.Parallel()
+
+		var seen []string
+		provider := &rotatingKeyProvider{keys: []string{"key-1", "key-2"}}
+
+		_, err := newClient(provider, "key-3", &seen).CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+		assert.Len(t, seen, 2)
+		assert.Equal(t, 1, provider.refreshes)
+	})
+
+	t.Run("returns refresh errors", func(t *testing.T) {
+		t.Parallel()
+
+		var seen []string
+		errRefresh := errors.New("vault unavailable")
+		provider := &rotatingKeyProvider{keys: []string{"key-1"}, refreshErr: errRefresh}
+
+		_, err := newClient(provider, "key-2", &seen).CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+		assert.ErrorIs(t, err, errRefresh)
+	})
+}
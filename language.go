@@ -0,0 +1,150 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ErrLanguageMismatch is returned when the model keeps answering in another language.
+var ErrLanguageMismatch = errors.New("response language mismatch")
+
+// languageName is the English name of every detectable language by ISO 639-1 code.
+var languageName = map[string]string{
+	"de": "German",
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"it": "Italian",
+	"nl": "Dutch",
+	"pt": "Portuguese",
+}
+
+// languageSamples are frequent words the trigram profiles are built from.
+var languageSamples = map[string]string{
+	"de": "der die und in den von zu das mit sich des auf für ist im dem nicht ein eine als auch es an werden aus er hat dass sie nach wird bei einer um am sind noch wie einem über einen so zum war haben nur oder aber vor zur bis mehr durch man sein wurde sei ich wir ihr können müssen sehr heute schon gibt wenn weil diese dieser wieder immer zwischen gegen",
+	"en": "the of and to in is that for it as was with be by on not he this are or his from at which but have an they you were her she there would their we him been has when who will more no if out so said what up its about into than them can only other new some could time these two may then do first any my now such like our over me even most made after also did many before must through back years where much your way well down should because each just those people how too little state good very make world still own see men work long get here between both life being under never day same another know while last might us great old year off come since against go came right used take three",
+	"es": "de la que el en y a los del se las por un para con no una su al lo como más pero sus le ya o este sí porque esta entre cuando muy sin sobre también me hasta hay donde quien desde todo nos durante todos uno les ni contra otros ese eso ante ellos esto mí antes algunos qué unos yo otro otras otra él tanto esa estos mucho quienes nada muchos cual poco ella estar estas algunas algo nosotros mi mis tú te ti tu tus ellas nosotras vosotros usted ustedes bien está estoy hacer puede tiene año ciudad",
+	"fr": "le de un être et à il avoir ne je son que se qui ce dans en du elle au pour pas que vous par sur faire plus dire me on mon lui nous comme mais pouvoir avec tout y aller voir en bien où sans tu ou leur homme si deux mari moi vouloir te femme venir quand grand celui notre devoir là jour prendre même votre tout rien petit encore aussi quelque dont tout mer trouver donner temps ça peu même falloir sous parler alors main chose ton mettre vie savoir très les des est une sont cette ces",
+	"it": "di che è e la il un a per in una sono mi non ho ma lo ha le si ti cosa con da come io questo qui bene se sei del hai no tu mio della al sì gli tutto solo me lui allora mia ci era lei fatto quando sta così voglio niente fare molto ne dove perché anche chi nel sua tuo grazie suo essere vero noi questa sempre gli degli delle nella sulla loro ancora dopo oggi",
+	"nl": "de en van ik te dat die in een hij het niet zijn is was op aan met als voor had er maar om hem dan zou of wat mijn men dit zo door over ze zich bij ook tot je mij uit der daar haar naar heb hoe heeft hebben deze u want nog zal me zij nu ge geen omdat iets worden toch al waren veel meer doen toen moet ben zonder kan hun dus alles onder ja eens hier wie werd altijd doch wordt wezen kunnen ons zelf tegen na reeds wil kon niets uw iemand geweest andere",
+	"pt": "de a o que e do da em um para é com não uma os no se na por mais as dos como mas foi ao ele das tem à seu sua ou ser quando muito há nos já está eu também só pelo pela até isso ela entre era depois sem mesmo aos ter seus quem nas me esse eles estão você tinha foram essa num nem suas meu às minha têm numa pelos elas havia seja qual será nós tenho lhe deles essas esses pelas este fosse dele tu te vocês vos lhes meus minhas não então ainda",
+}
+
+var languageProfiles = buildLanguageProfiles()
+
+// buildLanguageProfiles computes the character trigram profile of every sample.
+func buildLanguageProfiles() map[string]map[string]int {
+	profiles := make(map[string]map[string]int, len(languageSamples))
+	for lang, sample := range languageSamples {
+		counts := trigrams(sample)
+
+		grams := make([]string, 0, len(counts))
+		for gram := range counts {
+			grams = append(grams, gram)
+		}
+
+		sort.Slice(grams, func(i, j int) bool {
+			if counts[grams[i]] != counts[grams[j]] {
+				return counts[grams[i]] > counts[grams[j]]
+			}
+			return grams[i] < grams[j]
+		})
+
+		// Store the rank of each trigram; lower ranks are more characteristic.
+		ranks := make(map[string]int, len(grams))
+		for i, gram := range grams {
+			ranks[gram] = i
+		}
+		profiles[lang] = ranks
+	}
+	return profiles
+}
+
+// trigrams counts the character trigrams of every word, padded with spaces.
+func trigrams(text string) map[string]int {
+	counts := make(map[string]int)
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+	return counts
+}
+
+// DetectLanguage returns the ISO 639-1 code of the most likely language of the
+// text using a lightweight character trigram model. It returns an empty string
+// if the text contains no recognizable trigrams. Supported languages are
+// German, English, Spanish, French, Italian, Dutch and Portuguese.
+func DetectLanguage(text string) string {
+	counts := trigrams(text)
+
+	var (
+		best      string
+		bestScore float64
+	)
+
+	for lang, ranks := range languageProfiles {
+		var score float64
+		for gram, n := range counts {
+			if rank, ok := ranks[gram]; ok {
+				score += float64(n) / float64(rank+10)
+			}
+		}
+
+		if score > bestScore || (score == bestScore && lang < best) {
+			best, bestScore = lang, score
+		}
+	}
+
+	if bestScore == 0 {
+		return ""
+	}
+	return best
+}
+
+// CreateChatCompletionInLanguage creates a chat completion and re-prompts the
+// model up to maxRetries times when the reply isn't written in lang (ISO 639-1).
+// It returns the last response along with ErrLanguageMismatch if every attempt failed.
+func (c *Client) CreateChatCompletionInLanguage(ctx context.Context, in ChatCompletionRequest, lang string, maxRetries int) (*ChatCompletionResponse, error) {
+	name, ok := languageName[lang]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language: %q", lang)
+	}
+
+	req := in.Clone()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.CreateChatCompletion(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(resp.Choices) == 0 {
+			return resp, nil
+		}
+
+		reply := resp.Choices[0].Message
+		if DetectLanguage(reply.Content) == lang {
+			return resp, nil
+		}
+
+		if attempt >= maxRetries {
+			return resp, ErrLanguageMismatch
+		}
+
+		req.Messages = append(req.Messages, reply, Message{
+			Role:    RoleUser,
+			Content: fmt.Sprintf("Your answer was not written in %s. Please answer again, in %s only.", name, name),
+		})
+	}
+}
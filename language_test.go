@@ -0,0 +1,98 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "The weather is nice today and we are going to the park with the children.", want: "en"},
+		{text: "El tiempo está muy bien hoy y vamos al parque con los niños.", want: "es"},
+		{text: "Il fait très beau aujourd'hui et nous allons au parc avec les enfants.", want: "fr"},
+		{text: "Das Wetter ist heute schön und wir gehen mit den Kindern in den Park.", want: "de"},
+		{text: "Oggi il tempo è molto bello e andiamo al parco con i bambini.", want: "it"},
+		{text: "O tempo está muito bom hoje e nós vamos ao parque com as crianças.", want: "pt"},
+		{text: "Het weer is vandaag mooi en we gaan met de kinderen naar het park.", want: "nl"},
+		{text: "12345 !!!", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectLanguage(tt.text))
+		})
+	}
+}
+
+func TestClient_CreateChatCompletionInLanguage(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(replies []string, requests *[]ChatCompletionRequest) *Client {
+		return New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				var in ChatCompletionRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+				reply := replies[len(*requests)]
+				*requests = append(*requests, in)
+				return jsonResponse(t, ChatCompletionResponse{
+					Choices: []Choice{{Message: Message{Role: "assistant", Content: reply}}},
+				}), nil
+			},
+		})
+	}
+
+	in := ChatCompletionRequest{Messages: []Message{{Role: "user", Content: "¿Qué tiempo hace?"}}}
+
+	t.Run("re-prompts until the language matches", func(t *testing.T) {
+		t.Parallel()
+
+		var requests []ChatCompletionRequest
+		client := newClient([]string{
+			"The weather is nice and sunny today.",
+			"El tiempo está muy bien y hace sol hoy.",
+		}, &requests)
+
+		resp, err := client.CreateChatCompletionInLanguage(context.Background(), in, "es", 2)
+		require.NoError(t, err)
+
+		assert.Equal(t, "El tiempo está muy bien y hace sol hoy.", resp.Choices[0].Message.Content)
+		require.Len(t, requests, 2)
+		assert.Len(t, requests[1].Messages, 3)
+		assert.Contains(t, requests[1].Messages[2].Content, "Spanish")
+		assert.Len(t, in.Messages, 1)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		t.Parallel()
+
+		var requests []ChatCompletionRequest
+		client := newClient([]string{
+			"The weather is nice and sunny today.",
+			"The weather is still nice and sunny.",
+		}, &requests)
+
+		resp, err := client.CreateChatCompletionInLanguage(context.Background(), in, "es", 1)
+
+		assert.ErrorIs(t, err, ErrLanguageMismatch)
+		assert.NotNil(t, resp)
+		assert.Len(t, requests, 2)
+	})
+
+	t.Run("rejects unsupported languages", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New("test_api_key", nil).CreateChatCompletionInLanguage(context.Background(), in, "xx", 1)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,152 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrLatencyBudgetExceeded is returned when a request exceeds its latency budget
+// and no fallback model is configured.
+var ErrLatencyBudgetExceeded = errors.New("latency budget exceeded")
+
+type (
+	// LatencyBudget bounds the time a request may take.
+	LatencyBudget struct {
+		// Budget is the time the model has to answer.
+		Budget time.Duration
+		// Fallback, if set, is the model the request is sent to once the budget
+		// is exceeded, typically a faster one. The fallback request isn't bounded
+		// by the budget.
+		Fallback string
+	}
+
+	// ServedBy reports which path served a request bounded by a latency budget.
+	ServedBy struct {
+		// Model is the model that answered.
+		Model string
+		// Fallback is true when the primary model exceeded the budget.
+		Fallback bool
+		// Latency is the time to the answer, including the aborted attempt.
+		Latency time.Duration
+	}
+)
+
+// CreateChatCompletionWithin creates a chat completion, aborting the request
+// once it exceeds the budget and falling back to the budget's fallback model, if any.
+// It reports which model served the request.
+func (c *Client) CreateChatCompletionWithin(ctx context.Context, in ChatCompletionRequest, budget LatencyBudget) (*ChatCompletionResponse, ServedBy, error) {
+	start := time.Now()
+	served := ServedBy{Model: c.resolveModel(in.Model)}
+
+	budgetCtx, cancel := context.WithTimeout(ctx, budget.Budget)
+	resp, err := c.CreateChatCompletion(budgetCtx, in)
+	exceeded := budgetCtx.Err() != nil && ctx.Err() == nil
+	cancel()
+
+	if err == nil || !exceeded {
+		served.Latency = time.Since(start)
+		return resp, served, err
+	}
+
+	if budget.Fallback == "" {
+		served.Latency = time.Since(start)
+		return nil, served, fmt.Errorf("%w: %s did not answer within %s", ErrLatencyBudgetExceeded, served.Model, budget.Budget)
+	}
+
+	fallback := in.Clone()
+	fallback.Model = budget.Fallback
+
+	served = ServedBy{Model: c.resolveModel(fallback.Model), Fallback: true}
+	resp, err = c.CreateChatCompletion(ctx, fallback)
+	served.Latency = time.Since(start)
+	return resp, served, err
+}
+
+// CreateChatCompletionStreamWithin creates a streamed chat completion, aborting
+// the stream if its first token doesn't arrive within the budget and falling
+// back to the budget's fallback model, if any. Once the first token arrived,
+// the stream is no longer bounded. The latency reported is the time to the
+// first token, or to the end of streams without any.
+func (c *Client) CreateChatCompletionStreamWithin(ctx context.Context, in ChatCompletionRequest, budget LatencyBudget) (*ChatCompletionStream, ServedBy, error) {
+	start := time.Now()
+	served := ServedBy{Model: c.resolveModel(in.Model)}
+
+	stream, exceeded, err := c.streamWithin(ctx, in, budget.Budget)
+	if err == nil || !exceeded {
+		served.Latency = time.Since(start)
+		return stream, served, err
+	}
+
+	if budget.Fallback == "" {
+		served.Latency = time.Since(start)
+		return nil, served, fmt.Errorf("%w: %s did not start answering within %s", ErrLatencyBudgetExceeded, served.Model, budget.Budget)
+	}
+
+	fallback := in.Clone()
+	fallback.Model = budget.Fallback
+
+	served = ServedBy{Model: c.resolveModel(fallback.Model), Fallback: true}
+	stream, err = c.CreateChatCompletionStream(ctx, fallback)
+	served.Latency = time.Since(start)
+	return stream, served, err
+}
+
+// streamWithin opens the stream and waits for its first token, canceling it
+// once the budget elapses. It reports whether the budget was exceeded.
+func (c *Client) streamWithin(ctx context.Context, in ChatCompletionRequest, budget time.Duration) (*ChatCompletionStream, bool, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	timer := time.AfterFunc(budget, cancel)
+
+	fail := func(err error) (*ChatCompletionStream, bool, error) {
+		// The timer may fire concurrently with another failure.
+		exceeded := !timer.Stop() && ctx.Err() == nil
+		cancel()
+		return nil, exceeded, err
+	}
+
+	stream, err := c.CreateChatCompletionStream(streamCtx, in)
+	if err != nil {
+		return fail(err)
+	}
+
+	var pending []ChatCompletionChunk
+	for {
+		var chunk ChatCompletionChunk
+		err := stream.recv(&chunk)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			stream.Close()
+			return fail(err)
+		}
+
+		pending = append(pending, chunk)
+		if hasToken(&chunk) {
+			break
+		}
+	}
+
+	if !timer.Stop() {
+		stream.Close()
+		return fail(fmt.Errorf("could not read stream: %w", context.DeadlineExceeded))
+	}
+
+	// EOF is returned once the pending chunks are consumed.
+	stream.pending = pending
+	stream.cancel = cancel
+	return stream, false, nil
+}
+
+// hasToken reports whether the chunk carries generated content.
+func hasToken(chunk *ChatCompletionChunk) bool {
+	for _, choice := range chunk.Choices {
+		if choice.Delta.Content != "" || choice.Delta.Refusal != "" || len(choice.Delta.ToolCalls) > 0 {
+			return true
+		}
+	}
+	return false
+}
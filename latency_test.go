@@ -0,0 +1,231 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateChatCompletionWithin(t *testing.T) {
+	t.Parallel()
+
+	// slowModelClient answers instantly, except for the slow model which waits for the request to be canceled.
+	slowModelClient := func(t *testing.T, models *[]string) *mockHTTPClient {
+		return &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				var in ChatCompletionRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+				*models = append(*models, in.Model)
+
+				if in.Model == "slow" {
+					<-req.Context().Done()
+					return nil, req.Context().Err()
+				}
+				return jsonResponse(t, ChatCompletionResponse{Model: in.Model}), nil
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		model      string
+		budget     LatencyBudget
+		wantModels []string
+		wantServed ServedBy
+		wantErr    error
+	}{
+		{
+			name:       "within budget",
+			model:      "fast",
+			budget:     LatencyBudget{Budget: time.Minute, Fallback: "faster"},
+			wantModels: []string{"fast"},
+			wantServed: ServedBy{Model: "fast"},
+		},
+		{
+			name:       "fallback",
+			model:      "slow",
+			budget:     LatencyBudget{Budget: 10 * time.Millisecond, Fallback: "fast"},
+			wantModels: []string{"slow", "fast"},
+			wantServed: ServedBy{Model: "fast", Fallback: true},
+		},
+		{
+			name:       "no fallback",
+			model:      "slow",
+			budget:     LatencyBudget{Budget: 10 * time.Millisecond},
+			wantModels: []string{"slow"},
+			wantServed: ServedBy{Model: "slow"},
+			wantErr:    ErrLatencyBudgetExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var models []string
+			client := New("test_api_key", slowModelClient(t, &models))
+
+			resp, served, err := client.CreateChatCompletionWithin(context.Background(), ChatCompletionRequest{Model: tc.model}, tc.budget)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantServed.Model, resp.Model)
+			}
+
+			assert.Equal(t, tc.wantModels, models)
+			assert.Equal(t, tc.wantServed.Model, served.Model)
+			assert.Equal(t, tc.wantServed.Fallback, served.Fallback)
+			assert.Positive(t, served.Latency)
+		})
+	}
+}
+
+func TestClient_CreateChatCompletionWithin_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls int
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			cancel()
+			return nil, req.Context().Err()
+		},
+	})
+
+	_, served, err := client.CreateChatCompletionWithin(ctx, ChatCompletionRequest{Model: "gpt-4o"}, LatencyBudget{Budget: time.Minute, Fallback: "gpt-4o-mini"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.False(t, errors.Is(err, ErrLatencyBudgetExceeded))
+	assert.False(t, served.Fallback)
+	assert.Equal(t, 1, calls)
+}
+
+func TestClient_CreateChatCompletionStreamWithin(t *testing.T) {
+	t.Parallel()
+
+	// slowStreamClient streams instantly, except for the slow model which only
+	// sends the role before waiting for the request to be canceled.
+	slowStreamClient := func(t *testing.T, models *[]string) *mockHTTPClient {
+		return &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				var in ChatCompletionRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+				*models = append(*models, in.Model)
+
+				role := ChatCompletionChunk{Model: in.Model, Choices: []ChunkChoice{{Delta: MessageDelta{Role: "assistant"}}}}
+				if in.Model != "slow" {
+					content := ChatCompletionChunk{Model: in.Model, Choices: []ChunkChoice{{Delta: MessageDelta{Content: "Hi"}, FinishReason: "stop"}}}
+					return sseResponse(sseEvents(t, role, content)), nil
+				}
+
+				data, err := json.Marshal(role)
+				require.NoError(t, err)
+
+				r, w := io.Pipe()
+				go func() {
+					w.Write([]byte("data: " + string(data) + "\n\n"))
+					<-req.Context().Done()
+					w.CloseWithError(req.Context().Err())
+				}()
+
+				resp := sseResponse("")
+				resp.Body = r
+				return resp, nil
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		model      string
+		budget     LatencyBudget
+		wantModels []string
+		wantServed ServedBy
+		wantErr    error
+	}{
+		{
+			name:       "within budget",
+			model:      "fast",
+			budget:     LatencyBudget{Budget: time.Minute, Fallback: "faster"},
+			wantModels: []string{"fast"},
+			wantServed: ServedBy{Model: "fast"},
+		},
+		{
+			name:       "fallback",
+			model:      "slow",
+			budget:     LatencyBudget{Budget: 10 * time.Millisecond, Fallback: "fast"},
+			wantModels: []string{"slow", "fast"},
+			wantServed: ServedBy{Model: "fast", Fallback: true},
+		},
+		{
+			name:       "no fallback",
+			model:      "slow",
+			budget:     LatencyBudget{Budget: 10 * time.Millisecond},
+			wantModels: []string{"slow"},
+			wantServed: ServedBy{Model: "slow"},
+			wantErr:    ErrLatencyBudgetExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var models []string
+			client := New("test_api_key", slowStreamClient(t, &models))
+
+			stream, served, err := client.CreateChatCompletionStreamWithin(context.Background(), ChatCompletionRequest{Model: tc.model}, tc.budget)
+
+			assert.Equal(t, tc.wantModels, models)
+			assert.Equal(t, tc.wantServed.Model, served.Model)
+			assert.Equal(t, tc.wantServed.Fallback, served.Fallback)
+			assert.Positive(t, served.Latency)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			defer stream.Close()
+
+			resp, err := stream.Accumulate(NewStreamAccumulator())
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantServed.Model, resp.Model)
+			assert.Equal(t, "Hi", resp.Choices[0].Message.Content)
+		})
+	}
+}
+
+func TestClient_CreateChatCompletionStreamWithin_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls int
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			cancel()
+			return nil, req.Context().Err()
+		},
+	})
+
+	_, served, err := client.CreateChatCompletionStreamWithin(ctx, ChatCompletionRequest{Model: "gpt-4o"}, LatencyBudget{Budget: time.Minute, Fallback: "gpt-4o-mini"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.False(t, errors.Is(err, ErrLatencyBudgetExceeded))
+	assert.False(t, served.Fallback)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,156 @@
+package openaiclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidRequest is matched by errors.Is for every LintError.
+var ErrInvalidRequest = errors.New("invalid request")
+
+// textOnlyModels are the known models without image input, by model ID.
+var textOnlyModels = map[string]struct{}{
+	"gpt-3.5-turbo":          {},
+	"gpt-3.5-turbo-16k":      {},
+	"gpt-3.5-turbo-instruct": {},
+	"gpt-4":                  {},
+	"gpt-4-32k":              {},
+	"gpt-4-turbo-preview":    {},
+	"gpt-4-1106-preview":     {},
+	"gpt-4-0125-preview":     {},
+	"o1-mini":                {},
+	"o1-preview":             {},
+	"o3-mini":                {},
+}
+
+// LintIssue is a mistake found in a single message of a request.
+type LintIssue struct {
+	// Index is the position of the offending message in the request.
+	Index   int
+	Message string
+}
+
+// LintError lists every issue found by Lint.
+type LintError []LintIssue
+
+// Error implements the error interface.
+func (e LintError) Error() string {
+	issues := make([]string, 0, len(e))
+	for _, issue := range e {
+		issues = append(issues, fmt.Sprintf("message %d: %s", issue.Index, issue.Message))
+	}
+	return "invalid request: " + strings.Join(issues, "; ")
+}
+
+// Is reports whether target is ErrInvalidRequest.
+func (e LintError) Is(target error) bool {
+	return target == ErrInvalidRequest
+}
+
+// Lint checks the request for common mistakes the API would reject, or
+// silently misinterpret, and returns a LintError describing each of them.
+func (r ChatCompletionRequest) Lint() error {
+	var (
+		issues LintError
+		// pending are the tool calls of the last assistant message not answered yet.
+		pending    map[string]struct{}
+		calls      []ToolCall
+		pendingMsg int
+	)
+
+	unanswered := func() {
+		for _, call := range calls {
+			if _, ok := pending[call.ID]; ok {
+				issues = append(issues, LintIssue{Index: pendingMsg, Message: fmt.Sprintf("tool call %q has no tool message answering it", call.ID)})
+			}
+		}
+		pending, calls = nil, nil
+	}
+
+	for i, msg := range r.Messages {
+		if !msg.Role.Valid() {
+			issues = append(issues, LintIssue{Index: i, Message: fmt.Sprintf("unknown role %q", msg.Role)})
+			continue
+		}
+
+		if msg.Role == RoleTool {
+			switch _, ok := pending[msg.ToolCallID]; {
+			case pending == nil:
+				issues = append(issues, LintIssue{Index: i, Message: "tool message without a preceding assistant message with tool calls"})
+			case !ok:
+				issues = append(issues, LintIssue{Index: i, Message: fmt.Sprintf("tool message answers unknown tool call %q", msg.ToolCallID)})
+			default:
+				delete(pending, msg.ToolCallID)
+			}
+		} else {
+			unanswered()
+		}
+
+		if len(msg.ToolCalls) > 0 {
+			if msg.Role != RoleAssistant {
+				issues = append(issues, LintIssue{Index: i, Message: "only assistant messages can carry tool calls"})
+			}
+
+			pending, calls, pendingMsg = make(map[string]struct{}, len(msg.ToolCalls)), msg.ToolCalls, i
+			for _, call := range msg.ToolCalls {
+				pending[call.ID] = struct{}{}
+			}
+		}
+
+		if strings.TrimSpace(msg.Content) == "" && len(msg.Parts) == 0 && len(msg.ToolCalls) == 0 {
+			issues = append(issues, LintIssue{Index: i, Message: string(msg.Role) + " message has empty content and no tool calls"})
+		}
+
+		if msg.Content != "" && len(msg.Parts) > 0 {
+			issues = append(issues, LintIssue{Index: i, Message: "message has both content and parts, the content is not sent"})
+		}
+
+		for _, part := range msg.Parts {
+			if issue := r.lintPart(part); issue != "" {
+				issues = append(issues, LintIssue{Index: i, Message: issue})
+			}
+		}
+
+		if msg.Prefix && (msg.Role != RoleAssistant || i != len(r.Messages)-1) {
+			issues = append(issues, LintIssue{Index: i, Message: "prefix is only allowed on the last message, from the assistant"})
+		}
+	}
+	unanswered()
+
+	if len(issues) > 0 {
+		return issues
+	}
+	return nil
+}
+
+// lintPart returns the mistake in the content part, if any.
+func (r ChatCompletionRequest) lintPart(part ContentPart) string {
+	switch part.Type {
+	case ContentPartText:
+		return ""
+	case ContentPartImageURL:
+		if part.ImageURL == nil || part.ImageURL.URL == "" {
+			return "image part has no url"
+		}
+		if _, ok := textOnlyModels[snapshotSuffix.ReplaceAllString(r.Model, "")]; ok {
+			return fmt.Sprintf("image part sent to %s, which doesn't accept images", r.Model)
+		}
+		return ""
+	case ContentPartInputAudio:
+		if part.InputAudio == nil || part.InputAudio.Data == "" {
+			return "audio part has no data"
+		}
+		return ""
+	default:
+		return fmt.Sprintf("unknown content part type %q", part.Type)
+	}
+}
+
+// WithLint makes the client lint chat requests before sending them,
+// returning a LintError instead of a round trip to a server side error.
+func WithLint() Option {
+	return func(c *Client) {
+		c.lint = true
+	}
+}
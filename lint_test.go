@@ -0,0 +1,203 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatCompletionRequest_Lint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		messages []Message
+		want     LintError
+	}{
+		{
+			name: "valid request",
+			messages: []Message{
+				{Role: "system", Content: "Be brief."},
+				{Role: "user", Content: "Hi"},
+				{Role: "assistant", Content: "Hello", Prefix: true},
+			},
+		},
+		{
+			name:     "unknown role",
+			messages: []Message{{Role: "bot", Content: "Hi"}},
+			want:     LintError{{Index: 0, Message: `unknown role "bot"`}},
+		},
+		{
+			name:     "empty content",
+			messages: []Message{{Role: "user", Content: "Hi"}, {Role: "assistant", Content: " "}},
+			want:     LintError{{Index: 1, Message: "assistant message has empty content and no tool calls"}},
+		},
+		{
+			name: "answered tool calls",
+			messages: []Message{
+				{Role: "user", Content: "Weather in Paris and Rome?"},
+				{Role: "assistant", ToolCalls: []ToolCall{{ID: "call_1"}, {ID: "call_2"}}},
+				{Role: "tool", ToolCallID: "call_2", Content: "sunny"},
+				{Role: "tool", ToolCallID: "call_1", Content: "rainy"},
+				{Role: "assistant", Content: "Paris is rainy, Rome is sunny."},
+			},
+		},
+		{
+			name: "unanswered and unknown tool calls",
+			messages: []Message{
+				{Role: "user", Content: "Weather in Paris and Rome?"},
+				{Role: "assistant", ToolCalls: []ToolCall{{ID: "call_1"}, {ID: "call_2"}}},
+				{Role: "tool", ToolCallID: "call_3", Content: "sunny"},
+				{Role: "user", Content: "Well?"},
+			},
+			want: LintError{
+				{Index: 2, Message: `tool message answers unknown tool call "call_3"`},
+				{Index: 1, Message: `tool call "call_1" has no tool message answering it`},
+				{Index: 1, Message: `tool call "call_2" has no tool message answering it`},
+			},
+		},
+		{
+			name:     "tool calls outside assistant messages",
+			messages: []Message{{Role: "user", Content: "Hi", ToolCalls: []ToolCall{{ID: "call_1"}}}, {Role: "tool", ToolCallID: "call_1", Content: "ok"}},
+			want:     LintError{{Index: 0, Message: "only assistant messages can carry tool calls"}},
+		},
+		{
+			name:     "tool message without tool calls",
+			messages: []Message{{Role: "user", Content: "Hi"}, {Role: "tool", Content: "42"}},
+			want:     LintError{{Index: 1, Message: "tool message without a preceding assistant message with tool calls"}},
+		},
+		{
+			name: "misplaced prefix",
+			messages: []Message{
+				{Role: "user", Content: "Hi", Prefix: true},
+				{Role: "assistant", Content: "Hello", Prefix: true},
+				{Role: "user", Content: "Bye"},
+			},
+			want: LintError{
+				{Index: 0, Message: "prefix is only allowed on the last message, from the assistant"},
+				{Index: 1, Message: "prefix is only allowed on the last message, from the assistant"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ChatCompletionRequest{Model: "test_model", Messages: tt.messages}.Lint()
+
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidRequest)
+
+			var lintErr LintError
+			require.True(t, errors.As(err, &lintErr))
+			assert.Equal(t, tt.want, lintErr)
+		})
+	}
+}
+
+func TestChatCompletionRequest_Lint_Parts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		model string
+		parts []ContentPart
+		want  LintError
+	}{
+		{
+			name:  "image sent to a vision model",
+			model: "gpt-4o-2024-08-06",
+			parts: []ContentPart{TextPart("What is this?"), ImagePart("https://example.com/cat.png", ImageDetailLow)},
+		},
+		{
+			name:  "image sent to a text only model",
+			model: "gpt-3.5-turbo-0125",
+			parts: []ContentPart{TextPart("What is this?"), ImagePart("https://example.com/cat.png", "")},
+			want:  LintError{{Index: 0, Message: "image part sent to gpt-3.5-turbo-0125, which doesn't accept images"}},
+		},
+		{
+			name:  "image sent to an unknown model",
+			model: "llava",
+			parts: []ContentPart{ImagePart("https://example.com/cat.png", "")},
+		},
+		{
+			name:  "malformed parts",
+			model: "gpt-4o",
+			parts: []ContentPart{{Type: ContentPartImageURL}, {Type: ContentPartInputAudio}, {Type: "video"}},
+			want: LintError{
+				{Index: 0, Message: "image part has no url"},
+				{Index: 0, Message: "audio part has no data"},
+				{Index: 0, Message: `unknown content part type "video"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ChatCompletionRequest{Model: tt.model, Messages: []Message{{Role: "user", Parts: tt.parts}}}.Lint()
+
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var lintErr LintError
+			require.ErrorAs(t, err, &lintErr)
+			assert.Equal(t, tt.want, lintErr)
+		})
+	}
+}
+
+func TestChatCompletionRequest_Lint_ContentAndParts(t *testing.T) {
+	t.Parallel()
+
+	err := ChatCompletionRequest{
+		Model:    "gpt-4o",
+		Messages: []Message{{Role: "user", Content: "Describe it.", Parts: []ContentPart{TextPart("What is this?")}}},
+	}.Lint()
+
+	var lintErr LintError
+	require.ErrorAs(t, err, &lintErr)
+	assert.Equal(t, LintError{{Index: 0, Message: "message has both content and parts, the content is not sent"}}, lintErr)
+}
+
+func TestLintError_Error(t *testing.T) {
+	t.Parallel()
+
+	err := LintError{
+		{Index: 0, Message: `unknown role "bot"`},
+		{Index: 2, Message: "user message has empty content"},
+	}
+
+	assert.Equal(t, `invalid request: message 0: unknown role "bot"; message 2: user message has empty content`, err.Error())
+}
+
+func TestWithLint(t *testing.T) {
+	t.Parallel()
+
+	var called bool
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			called = true
+			return jsonResponse(t, ChatCompletionResponse{}), nil
+		},
+	}, WithLint())
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{
+		Model:    "test_model",
+		Messages: []Message{{Role: "user"}},
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+	assert.False(t, called)
+}
@@ -0,0 +1,46 @@
+package openaiclient
+
+import "math"
+
+// MaxTopLogprobs is the maximum number of alternatives returned per token.
+const MaxTopLogprobs = 20
+
+type (
+	// Logprobs are the log probabilities of the tokens of a choice, returned
+	// when ChatCompletionRequest.Logprobs is set. ChatCompletionRequest.TopLogprobs
+	// adds the most likely alternatives of every token, up to MaxTopLogprobs.
+	Logprobs struct {
+		Content []TokenLogprob `json:"content"`
+		Refusal []TokenLogprob `json:"refusal,omitempty"`
+	}
+
+	// TokenLogprob is the log probability of a token of the output, with the
+	// most likely alternatives at its position.
+	TokenLogprob struct {
+		Token   string  `json:"token"`
+		Logprob float64 `json:"logprob"`
+		// Bytes is the UTF-8 encoding of the token, which may be a partial
+		// character, or nil if the token has no byte representation.
+		Bytes []int `json:"bytes"`
+		// TopLogprobs are the most likely tokens at this position, most likely
+		// first, as many as ChatCompletionRequest.TopLogprobs.
+		TopLogprobs []TopLogprob `json:"top_logprobs"`
+	}
+
+	// TopLogprob is an alternative token at a position of the output.
+	TopLogprob struct {
+		Token   string  `json:"token"`
+		Logprob float64 `json:"logprob"`
+		Bytes   []int   `json:"bytes"`
+	}
+)
+
+// Probability returns the probability of the token, between 0 and 1.
+func (t TokenLogprob) Probability() float64 {
+	return math.Exp(t.Logprob)
+}
+
+// Probability returns the probability of the token, between 0 and 1.
+func (t TopLogprob) Probability() float64 {
+	return math.Exp(t.Logprob)
+}
@@ -0,0 +1,95 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateChatCompletion_Logprobs(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in map[string]any
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+			assert.Equal(t, true, in["logprobs"])
+			assert.Equal(t, 2.0, in["top_logprobs"])
+
+			return jsonResponse(t, map[string]any{
+				"choices": []map[string]any{{
+					"finish_reason": "stop",
+					"message":       map[string]any{"role": "assistant", "content": "spam"},
+					"logprobs": map[string]any{
+						"content": []map[string]any{{
+							"token":   "spam",
+							"logprob": -0.01,
+							"bytes":   []int{115, 112, 97, 109},
+							"top_logprobs": []map[string]any{
+								{"token": "spam", "logprob": -0.01, "bytes": []int{115, 112, 97, 109}},
+								{"token": "ham", "logprob": -4.6, "bytes": []int{104, 97, 109}},
+							},
+						}},
+						"refusal": nil,
+					},
+				}},
+			}), nil
+		},
+	})
+
+	resp, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{
+		Model:       GPT4oMini,
+		Messages:    []Message{{Role: RoleUser, Content: "Spam or ham?"}},
+		Logprobs:    true,
+		TopLogprobs: 2,
+	})
+	require.NoError(t, err)
+
+	logprobs := resp.Choices[0].Logprobs
+	require.NotNil(t, logprobs)
+	require.Len(t, logprobs.Content, 1)
+
+	token := logprobs.Content[0]
+	assert.Equal(t, "spam", token.Token)
+	assert.Equal(t, []int{115, 112, 97, 109}, token.Bytes)
+	assert.InDelta(t, 0.99, token.Probability(), 0.001)
+	require.Len(t, token.TopLogprobs, 2)
+	assert.Equal(t, "ham", token.TopLogprobs[1].Token)
+	assert.InDelta(t, math.Exp(-4.6), token.TopLogprobs[1].Probability(), 1e-9)
+}
+
+func TestChatCompletion_WithoutLogprobs(t *testing.T) {
+	t.Parallel()
+
+	var choice Choice
+	require.NoError(t, json.Unmarshal([]byte(`{"index": 0, "message": {"role": "assistant", "content": "Hi"}, "logprobs": null}`), &choice))
+	assert.Nil(t, choice.Logprobs)
+
+	data, err := json.Marshal(ChatCompletionRequest{Model: GPT4oMini})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "logprobs")
+}
+
+func TestStreamAccumulator_Logprobs(t *testing.T) {
+	t.Parallel()
+
+	acc := NewStreamAccumulator()
+	acc.Add(&ChatCompletionChunk{Choices: []ChunkChoice{{
+		Delta:    MessageDelta{Role: RoleAssistant, Content: "Hel"},
+		Logprobs: &Logprobs{Content: []TokenLogprob{{Token: "Hel", Logprob: -0.1}}},
+	}}})
+	acc.Add(&ChatCompletionChunk{Choices: []ChunkChoice{{
+		Delta:    MessageDelta{Content: "lo"},
+		Logprobs: &Logprobs{Content: []TokenLogprob{{Token: "lo", Logprob: -0.2}}},
+	}}})
+	acc.Add(&ChatCompletionChunk{Choices: []ChunkChoice{{FinishReason: "stop"}}})
+
+	resp := acc.Response()
+	require.Len(t, resp.Choices, 1)
+	assert.Equal(t, &Logprobs{Content: []TokenLogprob{{Token: "Hel", Logprob: -0.1}, {Token: "lo", Logprob: -0.2}}}, resp.Choices[0].Logprobs)
+}
@@ -0,0 +1,130 @@
+package openaiclient
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+type (
+	// StreamMatch is a match found by a StreamMatcher.
+	StreamMatch struct {
+		// Text is the matched text.
+		Text string
+		// Offset is the byte offset of the match from the start of the stream.
+		Offset int
+
+		// index holds the submatch indexes relative to the stream start.
+		index []int
+	}
+
+	// StreamMatcher finds pattern matches in streamed text, including matches
+	// split across chunk boundaries. Text that may still be part of a match is
+	// held back until the next chunk arrives or the stream is flushed, so the
+	// released text can be safely shown to users.
+	StreamMatcher struct {
+		re       *regexp.Regexp
+		holdback int
+
+		buf     string
+		offset  int
+		context string
+	}
+)
+
+// NewStreamMatcher creates a matcher for the given pattern. The holdback is the
+// number of trailing bytes held back on every write and should be at least the
+// length of the longest possible match; with no holdback, every write is
+// released whole.
+func NewStreamMatcher(re *regexp.Regexp, holdback int) *StreamMatcher {
+	return &StreamMatcher{re: re, holdback: holdback}
+}
+
+// NewPhraseMatcher creates a matcher for literal phrases, such as stop phrases or markers.
+func NewPhraseMatcher(phrases ...string) *StreamMatcher {
+	quoted := make([]string, 0, len(phrases))
+
+	var longest int
+	for _, phrase := range phrases {
+		quoted = append(quoted, regexp.QuoteMeta(phrase))
+		if len(phrase) > longest {
+			longest = len(phrase)
+		}
+	}
+
+	return NewStreamMatcher(regexp.MustCompile(strings.Join(quoted, "|")), longest)
+}
+
+// Write adds a chunk to the stream. It returns the text released by the
+// matcher along with the matches it contains.
+func (m *StreamMatcher) Write(chunk string) (string, []StreamMatch) {
+	m.buf += chunk
+
+	if m.holdback <= 0 {
+		return m.release(len(m.buf))
+	}
+
+	cut := len(m.buf) - m.holdback
+	if cut <= 0 {
+		return "", nil
+	}
+
+	// Never split a multi-byte character.
+	for cut > 0 && cut < len(m.buf) && !utf8.RuneStart(m.buf[cut]) {
+		cut--
+	}
+	return m.release(cut)
+}
+
+// Flush releases the remaining text of the stream along with the matches it contains.
+func (m *StreamMatcher) Flush() (string, []StreamMatch) {
+	return m.release(len(m.buf))
+}
+
+// release returns the buffered text up to cut, moving the cut back so no match straddles it.
+func (m *StreamMatcher) release(cut int) (string, []StreamMatch) {
+	// The last released rune is kept as context so word boundaries are
+	// evaluated as if the stream was never split.
+	text := m.context + m.buf
+	shift := len(m.context)
+
+	var matches []StreamMatch
+	for _, idx := range m.re.FindAllStringSubmatchIndex(text, -1) {
+		start, end := idx[0]-shift, idx[1]-shift
+		if start < 0 || start == end {
+			continue
+		}
+
+		if end > cut {
+			if start < cut {
+				cut = start
+			}
+			break
+		}
+
+		abs := make([]int, len(idx))
+		for i, v := range idx {
+			abs[i] = -1
+			if v >= 0 {
+				abs[i] = v - shift + m.offset
+			}
+		}
+
+		matches = append(matches, StreamMatch{
+			Text:   m.buf[start:end],
+			Offset: m.offset + start,
+			index:  abs,
+		})
+	}
+
+	released := m.buf[:cut]
+
+	if released != "" {
+		_, size := utf8.DecodeLastRuneInString(released)
+		m.context = released[len(released)-size:]
+	}
+
+	m.buf = m.buf[cut:]
+	m.offset += cut
+	return released, matches
+}
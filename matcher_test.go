@@ -0,0 +1,108 @@
+package openaiclient
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhraseMatcher(t *testing.T) {
+	t.Parallel()
+
+	matcher := NewPhraseMatcher("[DONE]", "STOP")
+
+	var (
+		released strings.Builder
+		matches  []StreamMatch
+	)
+
+	for _, chunk := range []string{"Hello [DO", "NE] and ST", "OP", "!"} {
+		text, found := matcher.Write(chunk)
+		released.WriteString(text)
+		matches = append(matches, found...)
+	}
+
+	text, found := matcher.Flush()
+	released.WriteString(text)
+	matches = append(matches, found...)
+
+	assert.Equal(t, "Hello [DONE] and STOP!", released.String())
+	if assert.Len(t, matches, 2) {
+		assert.Equal(t, "[DONE]", matches[0].Text)
+		assert.Equal(t, 6, matches[0].Offset)
+		assert.Equal(t, "STOP", matches[1].Text)
+		assert.Equal(t, 17, matches[1].Offset)
+	}
+}
+
+func TestStreamMatcher_HoldsBackPartialMatches(t *testing.T) {
+	t.Parallel()
+
+	matcher := NewPhraseMatcher("STOP")
+
+	text, matches := matcher.Write("abcdefST")
+	assert.Equal(t, "abcd", text)
+	assert.Empty(t, matches)
+
+	text, matches = matcher.Write("OP")
+	assert.Equal(t, "ef", text)
+	assert.Empty(t, matches)
+
+	text, matches = matcher.Flush()
+	assert.Equal(t, "STOP", text)
+	assert.Len(t, matches, 1)
+}
+
+func TestStreamMatcher_KeepsWordBoundaryContext(t *testing.T) {
+	t.Parallel()
+
+	// The first write releases "d", leaving "arn" in the buffer where it must
+	// not be mistaken for a whole word.
+	matcher := NewStreamMatcher(regexp.MustCompile(`\barn\b`), 3)
+
+	var matches []StreamMatch
+	for _, chunk := range []string{"darn", " arn"} {
+		_, found := matcher.Write(chunk)
+		matches = append(matches, found...)
+	}
+
+	_, found := matcher.Flush()
+	matches = append(matches, found...)
+
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, 5, matches[0].Offset)
+	}
+}
+
+func TestStreamMatcher_NoHoldback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		matcher *StreamMatcher
+	}{
+		{name: "zero holdback", matcher: NewStreamMatcher(regexp.MustCompile("STOP"), 0)},
+		{name: "no phrases", matcher: NewPhraseMatcher()},
+		{name: "empty phrase", matcher: NewPhraseMatcher("")},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Every write is released whole, including multi-byte characters.
+			text, _ := tt.matcher.Write("abc")
+			assert.Equal(t, "abc", text)
+
+			text, _ = tt.matcher.Write("dé")
+			assert.Equal(t, "dé", text)
+
+			text, matches := tt.matcher.Flush()
+			assert.Empty(t, text)
+			assert.Empty(t, matches)
+		})
+	}
+}
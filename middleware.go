@@ -0,0 +1,57 @@
+package openaiclient
+
+import (
+	"net/http"
+	"time"
+)
+
+type (
+	// RoundTripFunc sends a request and returns its response, like HTTPClient.Do.
+	RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+	// Middleware wraps the sending of requests, e.g. to inject tracing headers,
+	// log payloads or record latencies. It must call next to send the request.
+	Middleware func(next RoundTripFunc) RoundTripFunc
+)
+
+// WithMiddleware wraps every HTTP request sent by the client, including retries
+// and redirects, with the middlewares. The first middleware is the outermost.
+// Request bodies can be read again with req.GetBody.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// OnRequest returns a middleware calling hook before each request is sent.
+// The hook may modify the request, such as adding headers.
+func OnRequest(hook func(req *http.Request)) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			hook(req)
+			return next(req)
+		}
+	}
+}
+
+// OnResponse returns a middleware calling hook with the response or error of
+// each request, and the time until the response headers were received.
+func OnResponse(hook func(req *http.Request, resp *http.Response, err error, latency time.Duration)) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			hook(req, resp, err, time.Since(start))
+			return resp, err
+		}
+	}
+}
+
+// do sends the request with the HTTP client, through the middlewares.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	roundTrip := RoundTripFunc(c.httpClient.Do)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		roundTrip = c.middlewares[i](roundTrip)
+	}
+	return roundTrip(req)
+}
@@ -0,0 +1,83 @@
+package openaiclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var (
+		order     []string
+		responses int
+		body      string
+	)
+
+	named := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next(req)
+			}
+		}
+	}
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "trace-123", req.Header.Get("Traceparent"))
+			return jsonResponse(t, ChatCompletionResponse{ID: "chatcmpl-123"}), nil
+		},
+	},
+		WithMiddleware(named("outer"), named("inner")),
+		WithMiddleware(OnRequest(func(req *http.Request) {
+			req.Header.Set("Traceparent", "trace-123")
+
+			data, err := req.GetBody()
+			require.NoError(t, err)
+			payload, err := io.ReadAll(data)
+			require.NoError(t, err)
+			body = string(payload)
+		})),
+		WithMiddleware(OnResponse(func(req *http.Request, resp *http.Response, err error, latency time.Duration) {
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.GreaterOrEqual(t, latency, time.Duration(0))
+			responses++
+		})),
+	)
+
+	resp, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "chatcmpl-123", resp.ID)
+	assert.Equal(t, []string{"outer", "inner"}, order)
+	assert.Equal(t, 1, responses)
+	assert.JSONEq(t, `{"model":"gpt-4o","messages":null}`, body)
+}
+
+func TestWithMiddleware_Redirects(t *testing.T) {
+	t.Parallel()
+
+	var calls, seen int
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return redirectResponse(http.StatusTemporaryRedirect, "/v2/chat/completions"), nil
+			}
+			return jsonResponse(t, ChatCompletionResponse{}), nil
+		},
+	}, WithRedirects(), WithMiddleware(OnRequest(func(*http.Request) { seen++ })))
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, seen)
+}
@@ -0,0 +1,107 @@
+package openaiclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Chat models.
+const (
+	GPT35Turbo = "gpt-3.5-turbo"
+	GPT4Turbo  = "gpt-4-turbo"
+	GPT4o      = "gpt-4o"
+	GPT4oMini  = "gpt-4o-mini"
+	GPT41      = "gpt-4.1"
+	GPT41Mini  = "gpt-4.1-mini"
+	GPT41Nano  = "gpt-4.1-nano"
+	O1         = "o1"
+	O1Mini     = "o1-mini"
+	O3         = "o3"
+	O3Mini     = "o3-mini"
+	O4Mini     = "o4-mini"
+)
+
+// Embedding models.
+const (
+	TextEmbedding3Small = "text-embedding-3-small"
+	TextEmbedding3Large = "text-embedding-3-large"
+	TextEmbeddingAda002 = "text-embedding-ada-002"
+)
+
+// Image, audio and moderation models. Speech models are listed with the speech endpoint.
+const (
+	DallE2               = "dall-e-2"
+	DallE3               = "dall-e-3"
+	GPTImage1            = "gpt-image-1"
+	Whisper1             = "whisper-1"
+	OmniModerationLatest = "omni-moderation-latest"
+)
+
+type (
+	// Model is a model available to the API key.
+	Model struct {
+		ResponseMeta
+		ID      string `json:"id"`
+		Object  string `json:"object"`
+		Created int64  `json:"created"`
+		OwnedBy string `json:"owned_by"`
+	}
+
+	// ModelList is the response body of the models listing endpoint.
+	ModelList struct {
+		ResponseMeta
+		Object string  `json:"object"`
+		Data   []Model `json:"data"`
+	}
+)
+
+// Has reports whether the list holds the model with the given ID.
+func (l ModelList) Has(id string) bool {
+	for _, model := range l.Data {
+		if model.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ListModels lists the models available to the API key.
+func (c *Client) ListModels(ctx context.Context) (*ModelList, error) {
+	var list ModelList
+	if err := c.get(ctx, c.url(EndpointModels), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetModel retrieves the model with the given ID, or its alias.
+func (c *Client) GetModel(ctx context.Context, id string) (*Model, error) {
+	var model Model
+	if err := c.get(ctx, c.url(EndpointModels)+"/"+url.PathEscape(c.resolveModel(id)), &model); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+// ValidateModels verifies the API key has access to every given model, or
+// alias, with a single listing request, e.g. to fail fast at startup.
+func (c *Client) ValidateModels(ctx context.Context, models ...string) error {
+	list, err := c.ListModels(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list models: %w", err)
+	}
+
+	var unknown []string
+	for _, model := range models {
+		if !list.Has(c.resolveModel(model)) {
+			unknown = append(unknown, c.resolveModel(model))
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("models not available: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
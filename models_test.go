@@ -0,0 +1,83 @@
+package openaiclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// modelsClient serves the listing of the given models and retrieves them by ID.
+func modelsClient(t *testing.T, ids ...string) *mockHTTPClient {
+	return &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodGet, req.Method)
+
+			list := ModelList{Object: "list"}
+			for _, id := range ids {
+				list.Data = append(list.Data, Model{ID: id, Object: "model", OwnedBy: "openai"})
+			}
+
+			if req.URL.Path == "/v1"+EndpointModels {
+				return jsonResponse(t, list), nil
+			}
+
+			for _, model := range list.Data {
+				if req.URL.Path == "/v1"+EndpointModels+"/"+model.ID {
+					return jsonResponse(t, model), nil
+				}
+			}
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"error":{"code":"model_not_found"}}`)),
+			}, nil
+		},
+	}
+}
+
+func TestClient_ListModels(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", modelsClient(t, "gpt-4o", "text-embedding-3-small"))
+
+	list, err := client.ListModels(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, list.Data, 2)
+	assert.Equal(t, "gpt-4o", list.Data[0].ID)
+	assert.True(t, list.Has("text-embedding-3-small"))
+	assert.False(t, list.Has("gpt-4"))
+}
+
+func TestClient_GetModel(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", modelsClient(t, "gpt-4o"), WithModelAliases(NewModelAliases(map[string]string{"chat": "gpt-4o"})))
+
+	model, err := client.GetModel(context.Background(), "chat")
+	require.NoError(t, err)
+	assert.Equal(t, "gpt-4o", model.ID)
+	assert.Equal(t, "openai", model.OwnedBy)
+
+	_, err = client.GetModel(context.Background(), "gpt-5-unknown")
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "model_not_found", apiErr.Code)
+}
+
+func TestClient_ValidateModels(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", modelsClient(t, "gpt-4o", "gpt-4o-mini"))
+
+	assert.NoError(t, client.ValidateModels(context.Background(), "gpt-4o", "gpt-4o-mini"))
+
+	err := client.ValidateModels(context.Background(), "gpt-4o", "o3", "gpt-4.1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "o3, gpt-4.1")
+}
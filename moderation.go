@@ -0,0 +1,73 @@
+package openaiclient
+
+import (
+	"context"
+	"sort"
+)
+
+// Moderation categories.
+const (
+	CategoryHarassment            = "harassment"
+	CategoryHarassmentThreatening = "harassment/threatening"
+	CategoryHate                  = "hate"
+	CategoryHateThreatening       = "hate/threatening"
+	CategoryIllicit               = "illicit"
+	CategoryIllicitViolent        = "illicit/violent"
+	CategorySelfHarm              = "self-harm"
+	CategorySelfHarmIntent        = "self-harm/intent"
+	CategorySelfHarmInstructions  = "self-harm/instructions"
+	CategorySexual                = "sexual"
+	CategorySexualMinors          = "sexual/minors"
+	CategoryViolence              = "violence"
+	CategoryViolenceGraphic       = "violence/graphic"
+)
+
+type (
+	// ModerationRequest is the request body for the moderation endpoint.
+	ModerationRequest struct {
+		// Model is e.g. "omni-moderation-latest"; the API default applies when empty.
+		Model string `json:"model,omitempty"`
+		// Input holds the texts to classify, one result is returned per text.
+		Input []string `json:"input"`
+	}
+
+	// ModerationResponse is the response body for the moderation endpoint.
+	ModerationResponse struct {
+		ResponseMeta
+		ID      string             `json:"id"`
+		Model   string             `json:"model"`
+		Results []ModerationResult `json:"results"`
+	}
+
+	// ModerationResult is the classification of an input, with categories
+	// and scores keyed by category, such as CategoryHate.
+	ModerationResult struct {
+		Flagged        bool               `json:"flagged"`
+		Categories     map[string]bool    `json:"categories"`
+		CategoryScores map[string]float64 `json:"category_scores"`
+	}
+)
+
+// FlaggedCategories returns the categories the input was flagged for.
+func (r ModerationResult) FlaggedCategories() []string {
+	var categories []string
+	for category, flagged := range r.Categories {
+		if flagged {
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// CreateModeration classifies whether the inputs are potentially harmful.
+func (c *Client) CreateModeration(ctx context.Context, in ModerationRequest) (*ModerationResponse, error) {
+	in.Model = c.resolveModel(in.Model)
+	c.deprecations.check(in.Model)
+
+	var modResp ModerationResponse
+	if err := c.post(ctx, c.url(EndpointModerations), in, &modResp); err != nil {
+		return nil, err
+	}
+	return &modResp, nil
+}
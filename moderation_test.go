@@ -0,0 +1,56 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateModeration(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, DefaultBaseURL+EndpointModerations, req.URL.String())
+
+			var in ModerationRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+			assert.Equal(t, ModerationRequest{Model: "omni-moderation-latest", Input: []string{"hello", "I will hurt you"}}, in)
+
+			return jsonResponse(t, map[string]any{
+				"id":    "modr-123",
+				"model": "omni-moderation-latest",
+				"results": []map[string]any{
+					{
+						"flagged":         false,
+						"categories":      map[string]bool{"violence": false, "harassment": false},
+						"category_scores": map[string]float64{"violence": 0.001, "harassment": 0.002},
+					},
+					{
+						"flagged":         true,
+						"categories":      map[string]bool{"violence": true, "harassment/threatening": true, "hate": false},
+						"category_scores": map[string]float64{"violence": 0.98, "harassment/threatening": 0.85, "hate": 0.01},
+					},
+				},
+			}), nil
+		},
+	})
+
+	resp, err := client.CreateModeration(context.Background(), ModerationRequest{
+		Model: "omni-moderation-latest",
+		Input: []string{"hello", "I will hurt you"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+
+	assert.False(t, resp.Results[0].Flagged)
+	assert.Empty(t, resp.Results[0].FlaggedCategories())
+
+	assert.True(t, resp.Results[1].Flagged)
+	assert.Equal(t, []string{CategoryHarassmentThreatening, CategoryViolence}, resp.Results[1].FlaggedCategories())
+	assert.Equal(t, 0.98, resp.Results[1].CategoryScores[CategoryViolence])
+}
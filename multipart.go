@@ -0,0 +1,106 @@
+package openaiclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+)
+
+// InputFile is a file uploaded with a request.
+type InputFile struct {
+	// Name is the file name; its extension tells the API the file format.
+	Name string
+	Data io.Reader
+}
+
+// multipartForm builds a multipart/form-data request body, keeping the first error.
+type multipartForm struct {
+	buf bytes.Buffer
+	w   *multipart.Writer
+	err error
+}
+
+func newMultipartForm() *multipartForm {
+	f := multipartForm{}
+	f.w = multipart.NewWriter(&f.buf)
+	return &f
+}
+
+// field writes the field, unless the value is empty.
+func (f *multipartForm) field(name, value string) {
+	if f.err != nil || value == "" {
+		return
+	}
+	f.err = f.w.WriteField(name, value)
+}
+
+// intField writes the field, unless the value is zero.
+func (f *multipartForm) intField(name string, value int) {
+	if value != 0 {
+		f.field(name, strconv.Itoa(value))
+	}
+}
+
+// floatField writes the field, unless the value is nil.
+func (f *multipartForm) floatField(name string, value *float64) {
+	if value != nil {
+		f.field(name, strconv.FormatFloat(*value, 'f', -1, 64))
+	}
+}
+
+// file writes the file.
+func (f *multipartForm) file(name string, file InputFile) {
+	if f.err != nil {
+		return
+	}
+
+	if file.Data == nil {
+		f.err = fmt.Errorf("file %s of field %s has no data", file.Name, name)
+		return
+	}
+
+	part, err := f.w.CreateFormFile(name, file.Name)
+	if err != nil {
+		f.err = err
+		return
+	}
+	_, f.err = io.Copy(part, file.Data)
+}
+
+// body returns the encoded form as a request body. The form is read into
+// memory, so requests can be retried.
+func (f *multipartForm) body() (*requestBody, error) {
+	if f.err == nil {
+		f.err = f.w.Close()
+	}
+	if f.err != nil {
+		return nil, fmt.Errorf("could not encode form: %w", f.err)
+	}
+	return &requestBody{data: f.buf.Bytes(), contentType: f.w.FormDataContentType()}, nil
+}
+
+// postForm sends the multipart form to the given url and decodes the response into out.
+func (c *Client) postForm(ctx context.Context, url string, form *multipartForm, out any) error {
+	resp, err := c.sendForm(ctx, url, form)
+	if err != nil {
+		return err
+	}
+	return c.decodeResponse(resp, out)
+}
+
+// sendForm sends the multipart form to the given url and returns the undecoded response.
+func (c *Client) sendForm(ctx context.Context, url string, form *multipartForm) (*http.Response, error) {
+	if c.usage.exhausted() {
+		return nil, ErrBudgetExceeded
+	}
+
+	body, err := form.body()
+	if err != nil {
+		return nil, err
+	}
+	return c.send(ctx, http.MethodPost, url, body)
+}
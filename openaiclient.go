@@ -3,20 +3,29 @@ package openaiclient
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 )
 
 type (
 	// EmbeddingRequest is the request body for the embedding endpoint.
-	EmbbedingRequest struct {
-		Model string `json:"model"`
-		Input string `json:"input"`
+	EmbeddingRequest struct {
+		Model string         `json:"model"`
+		Input EmbeddingInput `json:"input"`
+		// Dimensions shortens the embeddings to the number of dimensions,
+		// for text-embedding-3 and later models.
+		Dimensions int `json:"dimensions,omitempty"`
+		// EncodingFormat is the format of the embeddings on the wire, such as
+		// EncodingFormatBase64; they're decoded transparently either way.
+		EncodingFormat string `json:"encoding_format,omitempty"`
 	}
 
 	// EmbeddingResponse is the response body for the embedding endpoint.
 	EmbeddingResponse struct {
+		ResponseMeta
 		Object string      `json:"object"`
 		Data   []Embedding `json:"data"`
 		Model  string      `json:"model"`
@@ -32,19 +41,81 @@ type (
 
 	// Usage is the token usage data.
 	Usage struct {
-		PromptTokens     int `json:"prompt_tokens"`
-		TotalTokens      int `json:"total_tokens"`
-		CompletionTokens int `json:"completion_tokens"`
+		PromptTokens        int                 `json:"prompt_tokens"`
+		TotalTokens         int                 `json:"total_tokens"`
+		CompletionTokens    int                 `json:"completion_tokens"`
+		PromptTokensDetails PromptTokensDetails `json:"prompt_tokens_details"`
+		// CompletionTokensDetails is the breakdown of the completion tokens,
+		// zero for models that don't report it.
+		CompletionTokensDetails CompletionTokensDetails `json:"completion_tokens_details"`
+		// Estimated reports whether the usage was estimated by the client, for
+		// streams whose server didn't send it. The estimate is sent in an extra
+		// last chunk without choices.
+		Estimated bool `json:"estimated,omitempty"`
 	}
 
-	// CompletitionRequest is the request body for the completition endpoint.
-	CompletitionRequest struct {
-		Model    string    `json:"model"`
-		Messages []Message `json:"messages"`
+	// PromptTokensDetails is the breakdown of the prompt tokens.
+	PromptTokensDetails struct {
+		// CachedTokens are the prompt tokens served from the prompt cache.
+		CachedTokens int `json:"cached_tokens"`
+		// AudioTokens are the prompt tokens of audio input.
+		AudioTokens int `json:"audio_tokens"`
 	}
 
-	// CompletitionResponse is the response body for the completition endpoint.
-	CompletitionResponse struct {
+	// CompletionTokensDetails is the breakdown of the completion tokens.
+	CompletionTokensDetails struct {
+		// ReasoningTokens are the completion tokens the model reasoned with,
+		// billed as output but not part of the message, for reasoning models.
+		ReasoningTokens int `json:"reasoning_tokens"`
+		// AudioTokens are the completion tokens of audio output.
+		AudioTokens int `json:"audio_tokens"`
+	}
+
+	// ChatCompletionRequest is the request body for the chat completion endpoint.
+	// Optional parameters are omitted when unset, so the API defaults apply;
+	// pointer fields distinguish an explicit zero from an unset value.
+	ChatCompletionRequest struct {
+		Model            string          `json:"model"`
+		Messages         []Message       `json:"messages"`
+		Temperature      *float64        `json:"temperature,omitempty"`
+		TopP             *float64        `json:"top_p,omitempty"`
+		N                int             `json:"n,omitempty"`
+		MaxTokens        int             `json:"max_tokens,omitempty"`
+		Stop             []string        `json:"stop,omitempty"`
+		PresencePenalty  *float64        `json:"presence_penalty,omitempty"`
+		FrequencyPenalty *float64        `json:"frequency_penalty,omitempty"`
+		LogitBias        map[string]int  `json:"logit_bias,omitempty"`
+		Logprobs         bool            `json:"logprobs,omitempty"`
+		TopLogprobs      int             `json:"top_logprobs,omitempty"`
+		Seed             *int            `json:"seed,omitempty"`
+		User             string          `json:"user,omitempty"`
+		ResponseFormat   *ResponseFormat `json:"response_format,omitempty"`
+
+		Tools             []Tool      `json:"tools,omitempty"`
+		ToolChoice        *ToolChoice `json:"tool_choice,omitempty"`
+		ParallelToolCalls *bool       `json:"parallel_tool_calls,omitempty"`
+
+		// Metadata tags the request, such as for stored completions and policies.
+		Metadata map[string]string `json:"metadata,omitempty"`
+
+		// PromptCacheKey routes requests sharing a prompt prefix to the same
+		// prompt cache, raising its hit rate; see PromptPrefix.
+		PromptCacheKey string `json:"prompt_cache_key,omitempty"`
+		// SafetyIdentifier is a stable identifier of the end user for abuse
+		// monitoring, superseding User. It is hashed like User by WithUserHashing.
+		SafetyIdentifier string `json:"safety_identifier,omitempty"`
+	}
+
+	// ResponseFormat is the format the model must output, such as "text" or "json_object".
+	ResponseFormat struct {
+		Type string `json:"type"`
+		// JSONSchema is the schema the output must match, for the "json_schema" type.
+		JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+	}
+
+	// ChatCompletionResponse is the response body for the chat completion endpoint.
+	ChatCompletionResponse struct {
+		ResponseMeta
 		ID      string   `json:"id"`
 		Object  string   `json:"object"`
 		Model   string   `json:"model"`
@@ -53,17 +124,31 @@ type (
 		Usage   Usage    `json:"usage"`
 	}
 
-	// CompletitionResponse is the response body for the completition endpoint.
+	// Choice is a chat completion choice.
 	Choice struct {
 		Index        int     `json:"index"`
 		FinishReason string  `json:"finish_reason"`
 		Message      Message `json:"message"`
+		// Logprobs are the log probabilities of the output tokens, if requested.
+		Logprobs *Logprobs `json:"logprobs,omitempty"`
 	}
 
-	// CompletitionResponse is the response body for the completition endpoint.
+	// Message is a chat message.
 	Message struct {
-		Role    string `json:"role"`
+		Role    Role   `json:"role"`
 		Content string `json:"content"`
+		// Parts is the multimodal content of the message, such as text and
+		// images. When set, it is sent instead of Content.
+		Parts []ContentPart `json:"-"`
+		// Prefix marks a trailing assistant message as a prefix the model must
+		// continue, for providers supporting assistant prefill.
+		Prefix bool `json:"prefix,omitempty"`
+		// ToolCalls are the tools the assistant calls.
+		ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+		// ToolCallID is the call a tool message answers.
+		ToolCallID string `json:"tool_call_id,omitempty"`
+		// Refusal is the reason the model refused to answer, with structured outputs.
+		Refusal string `json:"refusal,omitempty"`
 	}
 
 	// HTTPClient is an interface that our Client and MockClient should satisfy
@@ -73,81 +158,344 @@ type (
 
 	// Client is the OpenAI client.
 	Client struct {
-		apiKey     string
-		httpClient HTTPClient
+		apiKey       string
+		organization string
+		baseURL      string
+		httpClient   HTTPClient
+		usage        *usageTracker
+		signer       *requestSigner
+		compressor   *compressor
+		allowedHosts map[string]struct{}
+		headerPolicy *headerPolicy
+		paths        map[string]string
+		apiVersion   string
+		betaFeatures []string
+		optionErr    error
+
+		contextWindows map[string]int
+		retry          *RetryPolicy
+		keyProvider    KeyProvider
+		deprecations   *deprecationPolicy
+		aliases        *ModelAliases
+		lint           bool
+		sleep          func(ctx context.Context, d time.Duration) error
+
+		promptRevisionHook func(PromptRevision)
+		journal            RequestJournal
+		redirects          *redirectPolicy
+		decodeMode         DecodeMode
+		watermark          *tokenWatermark
+		azure              *azureDeployment
+		middlewares        []Middleware
+		throttle           *throttle
+		streamCache        *streamCache
+		autoBatch          *autoBatch
+		defaultUser        string
+		userSecret         []byte
+		policies           policies
+		deltaMode          DeltaMode
 	}
 )
 
-// New creates a new OpenAI client.
-func New(apiKey string, httpClient HTTPClient) *Client {
-	return &Client{
-		apiKey:     apiKey,
-		httpClient: httpClient,
+// New creates a new OpenAI client. A nil httpClient uses an *http.Client with
+// DefaultTimeout, the transport of NewTransport and the proxy from the environment.
+func New(apiKey string, httpClient HTTPClient, opts ...Option) *Client {
+	if httpClient == nil {
+		httpClient = newDefaultHTTPClient()
+	}
+
+	c := &Client{
+		apiKey:       apiKey,
+		baseURL:      DefaultBaseURL,
+		httpClient:   httpClient,
+		usage:        &usageTracker{},
+		headerPolicy: newHeaderPolicy(DefaultCapturedHeaders),
+		sleep:        sleep,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CreateEmbedding creates an embedding for every input of the request.
+func (c *Client) CreateEmbedding(ctx context.Context, in EmbeddingRequest) (*EmbeddingResponse, error) {
+	in.Model = c.resolveModel(in.Model)
+	if err := c.policies.checkModel(in.Model); err != nil {
+		return nil, err
+	}
+	c.deprecations.check(in.Model)
+
+	if c.autoBatch != nil {
+		if batches := c.autoBatch.split(in.Input); len(batches) > 1 {
+			return c.createEmbeddingBatches(ctx, in, batches)
+		}
+	}
+
+	var embResp EmbeddingResponse
+	if err := c.post(ctx, c.url(EndpointEmbeddings), in, &embResp); err != nil {
+		return nil, err
+	}
+
+	c.usage.record(servedModel(embResp.Model, in.Model), embResp.Usage)
+	return &embResp, nil
+}
+
+// CreateChatCompletion creates a chat completion for the given messages.
+func (c *Client) CreateChatCompletion(ctx context.Context, in ChatCompletionRequest) (*ChatCompletionResponse, error) {
+	in.Model = c.resolveModel(in.Model)
+	in.User = c.endUser(in.User)
+	in.SafetyIdentifier = c.safetyIdentifier(in.SafetyIdentifier)
+
+	if err := c.policies.apply(&in); err != nil {
+		return nil, err
+	}
+
+	if c.lint {
+		if err := in.Lint(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.checkContextWindow(in); err != nil {
+		return nil, err
 	}
+
+	c.deprecations.check(in.Model)
+
+	var compResp ChatCompletionResponse
+	if err := c.post(ctx, c.url(EndpointChatCompletions), in, &compResp); err != nil {
+		return nil, err
+	}
+
+	c.usage.record(servedModel(compResp.Model, in.Model), compResp.Usage)
+	c.watermark.check(compResp.ID, compResp.Model, compResp.Usage)
+	return &compResp, nil
 }
 
-// CreateEmbedding creates an embedding for the given text.
-func (c *Client) CreateEmbedding(ctx context.Context, in EmbbedingRequest) (*EmbeddingResponse, error) {
-	jsonData, err := json.Marshal(in)
+// Usage returns the token usage accumulated by the client so far.
+func (c *Client) Usage() Usage {
+	return c.usage.total()
+}
+
+// post sends the JSON encoded payload to the given url and decodes the response into out.
+func (c *Client) post(ctx context.Context, url string, in, out any) error {
+	if c.usage.exhausted() {
+		return ErrBudgetExceeded
+	}
+
+	jsonData, err := marshal(in)
 	if err != nil {
-		return nil, fmt.Errorf("could not marshal data: %w", err)
+		return fmt.Errorf("could not marshal data: %w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, "https://api.openai.com/v1/embeddings", bytes.NewBuffer(jsonData))
+	resp, err := c.send(ctx, http.MethodPost, url, &requestBody{data: jsonData, contentType: "application/json"})
 	if err != nil {
-		return nil, fmt.Errorf("could not create request: %w", err)
+		return err
 	}
+	return c.decodeResponse(resp, out)
+}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Content-Type", "application/json")
+// get sends a GET request to the given url and decodes the response into out.
+func (c *Client) get(ctx context.Context, url string, out any) error {
+	resp, err := c.send(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	return c.decodeResponse(resp, out)
+}
 
-	resp, err := c.httpClient.Do(req)
+// delete sends a DELETE request to the given url and decodes the response into out.
+func (c *Client) delete(ctx context.Context, url string, out any) error {
+	resp, err := c.send(ctx, http.MethodDelete, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not send request: %w", err)
+		return err
 	}
+	return c.decodeResponse(resp, out)
+}
 
-	defer resp.Body.Close()
+// requestBody is an encoded request body.
+type requestBody struct {
+	data        []byte
+	contentType string
+	compressed  bool
+	// idempotencyKey, if set, is sent in the Idempotency-Key header.
+	idempotencyKey string
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+// send sends the request with the optional body, retrying it according to the
+// client's policies, and returns the response of the last attempt.
+// Retryable failures are returned as errors; other responses are returned as is.
+// With a journal, requests with a body that fail are journaled for replay.
+func (c *Client) send(ctx context.Context, method, url string, body *requestBody) (*http.Response, error) {
+	if c.optionErr != nil {
+		return nil, fmt.Errorf("invalid client option: %w", c.optionErr)
 	}
 
-	var embResp EmbeddingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&embResp); err != nil {
-		return nil, fmt.Errorf("could not decode response: %w", err)
+	if c.journal == nil || body == nil {
+		return c.transmit(ctx, method, url, body)
 	}
-	return &embResp, nil
+
+	journaled := *body
+	if journaled.idempotencyKey == "" {
+		key, err := newIdempotencyKey()
+		if err != nil {
+			return nil, err
+		}
+		journaled.idempotencyKey = key
+	}
+
+	resp, err := c.transmit(ctx, method, url, &journaled)
+	if failed(resp, err) {
+		if jerr := c.journalFailure(ctx, method, url, &journaled, resp, err); jerr != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, errors.Join(err, jerr)
+		}
+	}
+	return resp, err
 }
 
-// CreateChatCompletition creates a completition for the given messages.
-func (c *Client) CreateChatCompletition(ctx context.Context, in CompletitionRequest) (*CompletitionResponse, error) {
-	jsonData, err := json.Marshal(in)
+// transmit sends the request like send, without journaling it.
+func (c *Client) transmit(ctx context.Context, method, url string, body *requestBody) (*http.Response, error) {
+	if body != nil && c.compressor != nil {
+		data, compressed, err := c.compressor.compress(body.data)
+		if err != nil {
+			return nil, err
+		}
+		body = &requestBody{data: data, contentType: body.contentType, compressed: compressed, idempotencyKey: body.idempotencyKey}
+	}
+
+	key, err := c.key(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("could not marshal data: %w", err)
+		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, "https://api.openai.com/v1/chat/completions", bytes.NewBuffer(jsonData))
+	var (
+		refreshed bool
+		redirects int
+	)
+	for attempt := 1; ; attempt++ {
+		req, err := c.newRequest(ctx, method, url, key, body)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.throttle.wait(ctx, c.sleep); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("could not send request: %w", err)
+		}
+		c.throttle.observe(resp.Header)
+
+		if c.redirects != nil && isFollowedRedirect(resp.StatusCode) {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			if url, err = c.redirectTarget(req, resp, redirects); err != nil {
+				return nil, err
+			}
+
+			// Following a redirect doesn't count as an attempt.
+			redirects++
+			attempt--
+			continue
+		}
+
+		if c.shouldRefreshKey(resp, refreshed) {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			if key, err = c.refreshKey(ctx); err != nil {
+				return nil, err
+			}
+
+			// Retrying with the refreshed key doesn't count as an attempt.
+			refreshed = true
+			attempt--
+			continue
+		}
+
+		if c.retry != nil && isRetryableStatus(resp.StatusCode) {
+			apiErr := newAPIError(resp)
+			resp.Body.Close()
+
+			if !c.retry.shouldRetry(apiErr, attempt) {
+				return nil, apiErr
+			}
+
+			if err := c.sleep(ctx, c.retry.delay(attempt, resp.Header)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return resp, nil
+	}
+}
+
+// newRequest creates a request with the given body and the client's headers.
+func (c *Client) newRequest(ctx context.Context, method, url, key string, body *requestBody) (*http.Request, error) {
+	var (
+		reader io.Reader
+		data   []byte
+	)
+	if body != nil {
+		data = body.data
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
 	if err != nil {
 		return nil, fmt.Errorf("could not create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Content-Type", "application/json")
+	if err := c.checkHost(req); err != nil {
+		return nil, err
+	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("could not send request: %w", err)
+	c.setVersionHeaders(req)
+
+	c.setAuthHeader(req, key)
+
+	if body != nil {
+		req.Header.Set("Content-Type", body.contentType)
+		if body.compressed {
+			req.Header.Set("Content-Encoding", "gzip")
+		}
+		if body.idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", body.idempotencyKey)
+		}
+	}
+
+	if c.organization != "" {
+		req.Header.Set("OpenAI-Organization", c.organization)
+	}
+
+	if c.signer != nil {
+		c.signer.sign(req, data)
 	}
+	return req, nil
+}
 
+// decodeResponse decodes the response into out, or returns an APIError for non-200 responses.
+func (c *Client) decodeResponse(resp *http.Response, out any) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return newAPIError(resp)
 	}
 
-	var compResp CompletitionResponse
-	if err := json.NewDecoder(resp.Body).Decode(&compResp); err != nil {
-		return nil, fmt.Errorf("could not decode response: %w", err)
+	if err := c.decode(resp.Body, out); err != nil {
+		return fmt.Errorf("could not decode response: %w", err)
 	}
-	return &compResp, nil
+
+	if m, ok := out.(metaSetter); ok {
+		m.setHeader(c.headerPolicy.capture(resp.Header))
+	}
+	return nil
 }
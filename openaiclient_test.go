@@ -7,8 +7,10 @@ import (
 	"io"
 
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,6 +26,19 @@ func (m *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	return m.DoFunc(req)
 }
 
+// jsonResponse returns a 200 response with the JSON encoded payload as body.
+func jsonResponse(t *testing.T, payload any) *http.Response {
+	t.Helper()
+
+	payloadBytes, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(bytes.NewReader(payloadBytes)),
+	}
+}
+
 func TestClient_CreateEmbedding(t *testing.T) {
 	t.Parallel()
 
@@ -90,7 +105,7 @@ func TestClient_CreateEmbedding(t *testing.T) {
 				},
 			})
 
-			embResp, err := client.CreateEmbedding(context.Background(), EmbbedingRequest{})
+			embResp, err := client.CreateEmbedding(context.Background(), EmbeddingRequest{})
 
 			assert.Equal(t, tt.wantErr, err != nil)
 			assert.Equal(t, tt.want, embResp)
@@ -98,7 +113,7 @@ func TestClient_CreateEmbedding(t *testing.T) {
 	}
 }
 
-func TestClient_CreateChatCompletition(t *testing.T) {
+func TestClient_CreateChatCompletion(t *testing.T) {
 	t.Parallel()
 
 	t.Run("client send correct headers", func(t *testing.T) {
@@ -115,14 +130,14 @@ func TestClient_CreateChatCompletition(t *testing.T) {
 			},
 		})
 
-		_, err := client.CreateChatCompletition(context.Background(), CompletitionRequest{})
+		_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
 		require.NoError(t, err)
 	})
 
 	t.Run("client send correct parameters", func(t *testing.T) {
 		t.Parallel()
 
-		input := CompletitionRequest{
+		input := ChatCompletionRequest{
 			Model: "test_model",
 			Messages: []Message{
 				{
@@ -140,7 +155,7 @@ func TestClient_CreateChatCompletition(t *testing.T) {
 				bodyBytes, err := io.ReadAll(req.Body)
 				require.NoError(t, err)
 
-				var body CompletitionRequest
+				var body ChatCompletionRequest
 				err = json.Unmarshal(bodyBytes, &body)
 				require.NoError(t, err)
 
@@ -153,14 +168,45 @@ func TestClient_CreateChatCompletition(t *testing.T) {
 			},
 		})
 
-		_, err := client.CreateChatCompletition(context.Background(), input)
+		_, err := client.CreateChatCompletion(context.Background(), input)
 		require.NoError(t, err)
 	})
 
+	t.Run("records the usage details", func(t *testing.T) {
+		t.Parallel()
+
+		client := New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{"usage": {
+						"prompt_tokens": 10, "completion_tokens": 20, "total_tokens": 30,
+						"prompt_tokens_details": {"cached_tokens": 4, "audio_tokens": 2},
+						"completion_tokens_details": {"reasoning_tokens": 12, "audio_tokens": 1}
+					}}`)),
+				}, nil
+			},
+		})
+
+		for i := 0; i < 2; i++ {
+			compResp, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, CompletionTokensDetails{ReasoningTokens: 12, AudioTokens: 1}, compResp.Usage.CompletionTokensDetails)
+		}
+
+		assert.Equal(t, Usage{
+			PromptTokens:            20,
+			CompletionTokens:        40,
+			TotalTokens:             60,
+			PromptTokensDetails:     PromptTokensDetails{CachedTokens: 8, AudioTokens: 4},
+			CompletionTokensDetails: CompletionTokensDetails{ReasoningTokens: 24, AudioTokens: 2},
+		}, client.Usage())
+	})
+
 	t.Run("test bad status code and invalid payload", func(t *testing.T) {
 		t.Parallel()
 
-		payload := CompletitionResponse{
+		payload := ChatCompletionResponse{
 			ID:      "test_id",
 			Object:  "test_completion",
 			Model:   "test_model",
@@ -188,7 +234,7 @@ func TestClient_CreateChatCompletition(t *testing.T) {
 		tests := []struct {
 			name     string
 			response *http.Response
-			want     *CompletitionResponse
+			want     *ChatCompletionResponse
 			wantErr  bool
 		}{
 			{
@@ -228,7 +274,7 @@ func TestClient_CreateChatCompletition(t *testing.T) {
 					},
 				})
 
-				compResp, err := client.CreateChatCompletition(context.Background(), CompletitionRequest{})
+				compResp, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
 
 				assert.Equal(t, tt.wantErr, err != nil)
 				assert.Equal(t, tt.want, compResp)
@@ -236,3 +282,124 @@ func TestClient_CreateChatCompletition(t *testing.T) {
 		}
 	})
 }
+
+func TestClient_ContextPropagation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("requests carry the caller context", func(t *testing.T) {
+		t.Parallel()
+
+		type ctxKey struct{}
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+		client := New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "value", req.Context().Value(ctxKey{}))
+				return jsonResponse(t, EmbeddingResponse{}), nil
+			},
+		})
+
+		_, err := client.CreateEmbedding(ctx, EmbeddingRequest{})
+		require.NoError(t, err)
+	})
+
+	t.Run("cancellation aborts the http call", func(t *testing.T) {
+		t.Parallel()
+
+		received := make(chan struct{})
+		release := make(chan struct{})
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(received)
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+
+		client := New("test_api_key", server.Client(), WithBaseURL(server.URL))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-received
+			cancel()
+		}()
+
+		_, err := client.CreateChatCompletion(ctx, ChatCompletionRequest{})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("deadline aborts the http call", func(t *testing.T) {
+		t.Parallel()
+
+		release := make(chan struct{})
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+
+		client := New("test_api_key", server.Client(), WithBaseURL(server.URL))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := client.CreateEmbedding(ctx, EmbeddingRequest{})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestChatCompletionRequest_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("omits unset parameters", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(ChatCompletionRequest{Model: "test_model"})
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{"model":"test_model","messages":null}`, string(data))
+	})
+
+	t.Run("sends explicit zeros", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(ChatCompletionRequest{
+			Model:            "test_model",
+			Temperature:      Ptr(0.0),
+			TopP:             Ptr(1.0),
+			N:                2,
+			MaxTokens:        100,
+			Stop:             []string{"END"},
+			PresencePenalty:  Ptr(0.0),
+			FrequencyPenalty: Ptr(0.5),
+			LogitBias:        map[string]int{"50256": -100},
+			Seed:             Ptr(0),
+			User:             "user-1",
+			ResponseFormat:   &ResponseFormat{Type: "json_object"},
+		})
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"model": "test_model",
+			"messages": null,
+			"temperature": 0,
+			"top_p": 1,
+			"n": 2,
+			"max_tokens": 100,
+			"stop": ["END"],
+			"presence_penalty": 0,
+			"frequency_penalty": 0.5,
+			"logit_bias": {"50256": -100},
+			"seed": 0,
+			"user": "user-1",
+			"response_format": {"type": "json_object"}
+		}`, string(data))
+	})
+}
@@ -0,0 +1,542 @@
+// Package openaiclientpb provides the protobuf schema of the core openaiclient
+// types along with converters, so conversations can be stored or sent over
+// gRPC without ad-hoc JSON blobs.
+package openaiclientpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative openaiclient.proto
+
+import "github.com/alesr/openaiclient"
+
+// FromMessage converts a message to its protobuf representation.
+func FromMessage(in openaiclient.Message) *Message {
+	return &Message{
+		Role:       string(in.Role),
+		Content:    in.Content,
+		Prefix:     in.Prefix,
+		ToolCalls:  FromToolCalls(in.ToolCalls),
+		ToolCallId: in.ToolCallID,
+		Parts:      FromContentParts(in.Parts),
+		Refusal:    in.Refusal,
+	}
+}
+
+// ToMessage converts a protobuf message to a message.
+func ToMessage(in *Message) openaiclient.Message {
+	return openaiclient.Message{
+		Role:       openaiclient.Role(in.GetRole()),
+		Content:    in.GetContent(),
+		Prefix:     in.GetPrefix(),
+		ToolCalls:  ToToolCalls(in.GetToolCalls()),
+		ToolCallID: in.GetToolCallId(),
+		Parts:      ToContentParts(in.GetParts()),
+		Refusal:    in.GetRefusal(),
+	}
+}
+
+// FromContentParts converts content parts to their protobuf representation.
+func FromContentParts(in []openaiclient.ContentPart) []*ContentPart {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*ContentPart, 0, len(in))
+	for _, part := range in {
+		pbPart := ContentPart{Type: part.Type, Text: part.Text}
+		if part.ImageURL != nil {
+			pbPart.ImageUrl = part.ImageURL.URL
+			pbPart.ImageDetail = part.ImageURL.Detail
+		}
+		if part.InputAudio != nil {
+			pbPart.InputAudioData = part.InputAudio.Data
+			pbPart.InputAudioFormat = part.InputAudio.Format
+		}
+		out = append(out, &pbPart)
+	}
+	return out
+}
+
+// ToContentParts converts protobuf content parts to content parts.
+func ToContentParts(in []*ContentPart) []openaiclient.ContentPart {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]openaiclient.ContentPart, 0, len(in))
+	for _, pbPart := range in {
+		part := openaiclient.ContentPart{Type: pbPart.GetType(), Text: pbPart.GetText()}
+		switch part.Type {
+		case openaiclient.ContentPartImageURL:
+			part.ImageURL = &openaiclient.ImageURL{URL: pbPart.GetImageUrl(), Detail: pbPart.GetImageDetail()}
+		case openaiclient.ContentPartInputAudio:
+			part.InputAudio = &openaiclient.InputAudio{Data: pbPart.GetInputAudioData(), Format: pbPart.GetInputAudioFormat()}
+		}
+		out = append(out, part)
+	}
+	return out
+}
+
+// FromToolCalls converts tool calls to their protobuf representation.
+func FromToolCalls(in []openaiclient.ToolCall) []*ToolCall {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*ToolCall, 0, len(in))
+	for _, call := range in {
+		out = append(out, &ToolCall{
+			Id:                call.ID,
+			Type:              call.Type,
+			FunctionName:      call.Function.Name,
+			FunctionArguments: call.Function.Arguments,
+		})
+	}
+	return out
+}
+
+// ToToolCalls converts protobuf tool calls to tool calls.
+func ToToolCalls(in []*ToolCall) []openaiclient.ToolCall {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]openaiclient.ToolCall, 0, len(in))
+	for _, call := range in {
+		out = append(out, openaiclient.ToolCall{
+			ID:   call.GetId(),
+			Type: call.GetType(),
+			Function: openaiclient.FunctionCall{
+				Name:      call.GetFunctionName(),
+				Arguments: call.GetFunctionArguments(),
+			},
+		})
+	}
+	return out
+}
+
+// FromTools converts tools to their protobuf representation.
+func FromTools(in []openaiclient.Tool) []*Tool {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*Tool, 0, len(in))
+	for _, tool := range in {
+		out = append(out, &Tool{
+			Type:        tool.Type,
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			Parameters:  tool.Function.Parameters,
+			Strict:      tool.Function.Strict,
+		})
+	}
+	return out
+}
+
+// ToTools converts protobuf tools to tools.
+func ToTools(in []*Tool) []openaiclient.Tool {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]openaiclient.Tool, 0, len(in))
+	for _, tool := range in {
+		out = append(out, openaiclient.Tool{
+			Type: tool.GetType(),
+			Function: openaiclient.FunctionDefinition{
+				Name:        tool.GetName(),
+				Description: tool.GetDescription(),
+				Parameters:  tool.GetParameters(),
+				Strict:      tool.GetStrict(),
+			},
+		})
+	}
+	return out
+}
+
+// FromMessages converts messages to their protobuf representation.
+func FromMessages(in []openaiclient.Message) []*Message {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*Message, 0, len(in))
+	for _, msg := range in {
+		out = append(out, FromMessage(msg))
+	}
+	return out
+}
+
+// ToMessages converts protobuf messages to messages.
+func ToMessages(in []*Message) []openaiclient.Message {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]openaiclient.Message, 0, len(in))
+	for _, msg := range in {
+		out = append(out, ToMessage(msg))
+	}
+	return out
+}
+
+// FromUsage converts usage to its protobuf representation.
+func FromUsage(in openaiclient.Usage) *Usage {
+	return &Usage{
+		PromptTokens:     int64(in.PromptTokens),
+		CompletionTokens: int64(in.CompletionTokens),
+		TotalTokens:      int64(in.TotalTokens),
+		PromptTokensDetails: &PromptTokensDetails{
+			CachedTokens: int64(in.PromptTokensDetails.CachedTokens),
+			AudioTokens:  int64(in.PromptTokensDetails.AudioTokens),
+		},
+		CompletionTokensDetails: &CompletionTokensDetails{
+			ReasoningTokens: int64(in.CompletionTokensDetails.ReasoningTokens),
+			AudioTokens:     int64(in.CompletionTokensDetails.AudioTokens),
+		},
+		Estimated: in.Estimated,
+	}
+}
+
+// ToUsage converts protobuf usage to usage.
+func ToUsage(in *Usage) openaiclient.Usage {
+	return openaiclient.Usage{
+		PromptTokens:     int(in.GetPromptTokens()),
+		CompletionTokens: int(in.GetCompletionTokens()),
+		TotalTokens:      int(in.GetTotalTokens()),
+		PromptTokensDetails: openaiclient.PromptTokensDetails{
+			CachedTokens: int(in.GetPromptTokensDetails().GetCachedTokens()),
+			AudioTokens:  int(in.GetPromptTokensDetails().GetAudioTokens()),
+		},
+		CompletionTokensDetails: openaiclient.CompletionTokensDetails{
+			ReasoningTokens: int(in.GetCompletionTokensDetails().GetReasoningTokens()),
+			AudioTokens:     int(in.GetCompletionTokensDetails().GetAudioTokens()),
+		},
+		Estimated: in.GetEstimated(),
+	}
+}
+
+// FromChatCompletionRequest converts a chat request to its protobuf representation.
+func FromChatCompletionRequest(in openaiclient.ChatCompletionRequest) *ChatCompletionRequest {
+	out := ChatCompletionRequest{
+		Model:             in.Model,
+		Messages:          FromMessages(in.Messages),
+		Temperature:       in.Temperature,
+		TopP:              in.TopP,
+		N:                 int64(in.N),
+		MaxTokens:         int64(in.MaxTokens),
+		Stop:              in.Stop,
+		PresencePenalty:   in.PresencePenalty,
+		FrequencyPenalty:  in.FrequencyPenalty,
+		User:              in.User,
+		Logprobs:          in.Logprobs,
+		TopLogprobs:       int64(in.TopLogprobs),
+		Tools:             FromTools(in.Tools),
+		ParallelToolCalls: in.ParallelToolCalls,
+		Metadata:          in.Metadata,
+		PromptCacheKey:    in.PromptCacheKey,
+		SafetyIdentifier:  in.SafetyIdentifier,
+	}
+
+	if in.ToolChoice != nil {
+		out.ToolChoice = &ToolChoice{Mode: in.ToolChoice.Mode, Function: in.ToolChoice.Function}
+	}
+
+	if in.Seed != nil {
+		seed := int64(*in.Seed)
+		out.Seed = &seed
+	}
+
+	if in.LogitBias != nil {
+		out.LogitBias = make(map[string]int64, len(in.LogitBias))
+		for token, bias := range in.LogitBias {
+			out.LogitBias[token] = int64(bias)
+		}
+	}
+
+	if in.ResponseFormat != nil {
+		out.ResponseFormat = in.ResponseFormat.Type
+		if schema := in.ResponseFormat.JSONSchema; schema != nil {
+			out.JsonSchema = &JSONSchema{Name: schema.Name, Description: schema.Description, Schema: schema.Schema, Strict: schema.Strict}
+		}
+	}
+	return &out
+}
+
+// ToChatCompletionRequest converts a protobuf chat request to a chat request.
+func ToChatCompletionRequest(in *ChatCompletionRequest) openaiclient.ChatCompletionRequest {
+	out := openaiclient.ChatCompletionRequest{
+		Model:             in.GetModel(),
+		Messages:          ToMessages(in.GetMessages()),
+		Temperature:       in.Temperature,
+		TopP:              in.TopP,
+		N:                 int(in.GetN()),
+		MaxTokens:         int(in.GetMaxTokens()),
+		Stop:              in.GetStop(),
+		PresencePenalty:   in.PresencePenalty,
+		FrequencyPenalty:  in.FrequencyPenalty,
+		User:              in.GetUser(),
+		Logprobs:          in.GetLogprobs(),
+		TopLogprobs:       int(in.GetTopLogprobs()),
+		Tools:             ToTools(in.GetTools()),
+		ParallelToolCalls: in.ParallelToolCalls,
+		Metadata:          in.GetMetadata(),
+		PromptCacheKey:    in.GetPromptCacheKey(),
+		SafetyIdentifier:  in.GetSafetyIdentifier(),
+	}
+
+	if in.ToolChoice != nil {
+		out.ToolChoice = &openaiclient.ToolChoice{Mode: in.ToolChoice.GetMode(), Function: in.ToolChoice.GetFunction()}
+	}
+
+	if in.Seed != nil {
+		seed := int(in.GetSeed())
+		out.Seed = &seed
+	}
+
+	if in.LogitBias != nil {
+		out.LogitBias = make(map[string]int, len(in.LogitBias))
+		for token, bias := range in.GetLogitBias() {
+			out.LogitBias[token] = int(bias)
+		}
+	}
+
+	if in.GetResponseFormat() != "" {
+		out.ResponseFormat = &openaiclient.ResponseFormat{Type: in.GetResponseFormat()}
+		if schema := in.GetJsonSchema(); schema != nil {
+			out.ResponseFormat.JSONSchema = &openaiclient.JSONSchema{
+				Name:        schema.GetName(),
+				Description: schema.GetDescription(),
+				Schema:      schema.GetSchema(),
+				Strict:      schema.GetStrict(),
+			}
+		}
+	}
+	return out
+}
+
+// FromChatCompletionResponse converts a chat response to its protobuf representation.
+func FromChatCompletionResponse(in openaiclient.ChatCompletionResponse) *ChatCompletionResponse {
+	out := ChatCompletionResponse{
+		Id:      in.ID,
+		Object:  in.Object,
+		Model:   in.Model,
+		Created: int64(in.Created),
+		Usage:   FromUsage(in.Usage),
+	}
+
+	for _, choice := range in.Choices {
+		out.Choices = append(out.Choices, &Choice{
+			Index:        int64(choice.Index),
+			FinishReason: choice.FinishReason,
+			Message:      FromMessage(choice.Message),
+			Logprobs:     FromLogprobs(choice.Logprobs),
+		})
+	}
+	return &out
+}
+
+// ToChatCompletionResponse converts a protobuf chat response to a chat response.
+func ToChatCompletionResponse(in *ChatCompletionResponse) openaiclient.ChatCompletionResponse {
+	out := openaiclient.ChatCompletionResponse{
+		ID:      in.GetId(),
+		Object:  in.GetObject(),
+		Model:   in.GetModel(),
+		Created: int(in.GetCreated()),
+		Usage:   ToUsage(in.GetUsage()),
+	}
+
+	for _, choice := range in.GetChoices() {
+		out.Choices = append(out.Choices, openaiclient.Choice{
+			Index:        int(choice.GetIndex()),
+			FinishReason: choice.GetFinishReason(),
+			Message:      ToMessage(choice.GetMessage()),
+			Logprobs:     ToLogprobs(choice.GetLogprobs()),
+		})
+	}
+	return out
+}
+
+// FromEmbeddingRequest converts an embedding request to its protobuf representation.
+func FromEmbeddingRequest(in openaiclient.EmbeddingRequest) *EmbeddingRequest {
+	out := EmbeddingRequest{
+		Model:          in.Model,
+		Input:          in.Input.Texts,
+		Dimensions:     int64(in.Dimensions),
+		EncodingFormat: in.EncodingFormat,
+	}
+
+	for _, tokens := range in.Input.Tokens {
+		array := TokenArray{Tokens: make([]int64, 0, len(tokens))}
+		for _, token := range tokens {
+			array.Tokens = append(array.Tokens, int64(token))
+		}
+		out.Tokens = append(out.Tokens, &array)
+	}
+	return &out
+}
+
+// ToEmbeddingRequest converts a protobuf embedding request to an embedding request.
+func ToEmbeddingRequest(in *EmbeddingRequest) openaiclient.EmbeddingRequest {
+	out := openaiclient.EmbeddingRequest{
+		Model:          in.GetModel(),
+		Input:          openaiclient.TextInput(in.GetInput()...),
+		Dimensions:     int(in.GetDimensions()),
+		EncodingFormat: in.GetEncodingFormat(),
+	}
+
+	for _, array := range in.GetTokens() {
+		tokens := make([]int, 0, len(array.GetTokens()))
+		for _, token := range array.GetTokens() {
+			tokens = append(tokens, int(token))
+		}
+		out.Input.Tokens = append(out.Input.Tokens, tokens)
+	}
+	return out
+}
+
+// FromEmbeddingResponse converts an embedding response to its protobuf representation.
+func FromEmbeddingResponse(in openaiclient.EmbeddingResponse) *EmbeddingResponse {
+	out := EmbeddingResponse{
+		Object: in.Object,
+		Model:  in.Model,
+		Usage:  FromUsage(in.Usage),
+	}
+
+	for _, emb := range in.Data {
+		out.Data = append(out.Data, &Embedding{
+			Object:    emb.Object,
+			Embedding: emb.Embedding,
+			Index:     int64(emb.Index),
+		})
+	}
+	return &out
+}
+
+// ToEmbeddingResponse converts a protobuf embedding response to an embedding response.
+func ToEmbeddingResponse(in *EmbeddingResponse) openaiclient.EmbeddingResponse {
+	out := openaiclient.EmbeddingResponse{
+		Object: in.GetObject(),
+		Model:  in.GetModel(),
+		Usage:  ToUsage(in.GetUsage()),
+	}
+
+	for _, emb := range in.GetData() {
+		out.Data = append(out.Data, openaiclient.Embedding{
+			Object:    emb.GetObject(),
+			Embedding: emb.GetEmbedding(),
+			Index:     int(emb.GetIndex()),
+		})
+	}
+	return out
+}
+
+// FromModerationRequest converts a moderation request to its protobuf representation.
+func FromModerationRequest(in openaiclient.ModerationRequest) *ModerationRequest {
+	return &ModerationRequest{Model: in.Model, Input: in.Input}
+}
+
+// ToModerationRequest converts a protobuf moderation request to a moderation request.
+func ToModerationRequest(in *ModerationRequest) openaiclient.ModerationRequest {
+	return openaiclient.ModerationRequest{Model: in.GetModel(), Input: in.GetInput()}
+}
+
+// FromModerationResponse converts a moderation response to its protobuf representation.
+func FromModerationResponse(in openaiclient.ModerationResponse) *ModerationResponse {
+	out := ModerationResponse{Id: in.ID, Model: in.Model}
+
+	for _, result := range in.Results {
+		out.Results = append(out.Results, &ModerationResult{
+			Flagged:        result.Flagged,
+			Categories:     result.Categories,
+			CategoryScores: result.CategoryScores,
+		})
+	}
+	return &out
+}
+
+// ToModerationResponse converts a protobuf moderation response to a moderation response.
+func ToModerationResponse(in *ModerationResponse) openaiclient.ModerationResponse {
+	out := openaiclient.ModerationResponse{ID: in.GetId(), Model: in.GetModel()}
+
+	for _, result := range in.GetResults() {
+		out.Results = append(out.Results, openaiclient.ModerationResult{
+			Flagged:        result.GetFlagged(),
+			Categories:     result.GetCategories(),
+			CategoryScores: result.GetCategoryScores(),
+		})
+	}
+	return out
+}
+
+// FromLogprobs converts logprobs to their protobuf representation.
+func FromLogprobs(in *openaiclient.Logprobs) *Logprobs {
+	if in == nil {
+		return nil
+	}
+	return &Logprobs{Content: fromTokenLogprobs(in.Content), Refusal: fromTokenLogprobs(in.Refusal)}
+}
+
+// ToLogprobs converts protobuf logprobs to logprobs.
+func ToLogprobs(in *Logprobs) *openaiclient.Logprobs {
+	if in == nil {
+		return nil
+	}
+	return &openaiclient.Logprobs{Content: toTokenLogprobs(in.GetContent()), Refusal: toTokenLogprobs(in.GetRefusal())}
+}
+
+func fromTokenLogprobs(in []openaiclient.TokenLogprob) []*TokenLogprob {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*TokenLogprob, 0, len(in))
+	for _, token := range in {
+		pb := TokenLogprob{Token: token.Token, Logprob: token.Logprob, Bytes: fromTokenBytes(token.Bytes)}
+		for _, top := range token.TopLogprobs {
+			pb.TopLogprobs = append(pb.TopLogprobs, &TokenLogprob{Token: top.Token, Logprob: top.Logprob, Bytes: fromTokenBytes(top.Bytes)})
+		}
+		out = append(out, &pb)
+	}
+	return out
+}
+
+func toTokenLogprobs(in []*TokenLogprob) []openaiclient.TokenLogprob {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]openaiclient.TokenLogprob, 0, len(in))
+	for _, pb := range in {
+		token := openaiclient.TokenLogprob{Token: pb.GetToken(), Logprob: pb.GetLogprob(), Bytes: toTokenBytes(pb.GetBytes())}
+		for _, top := range pb.GetTopLogprobs() {
+			token.TopLogprobs = append(token.TopLogprobs, openaiclient.TopLogprob{Token: top.GetToken(), Logprob: top.GetLogprob(), Bytes: toTokenBytes(top.GetBytes())})
+		}
+		out = append(out, token)
+	}
+	return out
+}
+
+// fromTokenBytes converts the bytes of a token, which the API sends as integers.
+func fromTokenBytes(in []int) []byte {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]byte, 0, len(in))
+	for _, b := range in {
+		out = append(out, byte(b))
+	}
+	return out
+}
+
+func toTokenBytes(in []byte) []int {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out := make([]int, 0, len(in))
+	for _, b := range in {
+		out = append(out, int(b))
+	}
+	return out
+}
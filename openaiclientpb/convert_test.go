@@ -0,0 +1,238 @@
+package openaiclientpb
+
+import (
+	"testing"
+
+	"github.com/alesr/openaiclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestChatCompletionRequest(t *testing.T) {
+	t.Parallel()
+
+	req := openaiclient.ChatCompletionRequest{
+		Model: "test_model",
+		Messages: []openaiclient.Message{
+			{Role: "system", Content: "test_system"},
+			{Role: "user", Content: "test_user"},
+			{Role: "assistant", Content: "test_prefix", Prefix: true},
+		},
+	}
+
+	data, err := proto.Marshal(FromChatCompletionRequest(req))
+	require.NoError(t, err)
+
+	var decoded ChatCompletionRequest
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+
+	assert.Equal(t, req, ToChatCompletionRequest(&decoded))
+}
+
+func TestChatCompletionRequest_Parameters(t *testing.T) {
+	t.Parallel()
+
+	req := openaiclient.ChatCompletionRequest{
+		Model:            "test_model",
+		Temperature:      openaiclient.Ptr(0.0),
+		TopP:             openaiclient.Ptr(0.9),
+		N:                2,
+		MaxTokens:        100,
+		Stop:             []string{"END"},
+		PresencePenalty:  openaiclient.Ptr(0.1),
+		FrequencyPenalty: openaiclient.Ptr(0.2),
+		LogitBias:        map[string]int{"50256": -100},
+		Seed:             openaiclient.Ptr(42),
+		Logprobs:         true,
+		TopLogprobs:      3,
+		User:             "user-1",
+		ResponseFormat:   &openaiclient.ResponseFormat{Type: "json_object"},
+		Metadata:         map[string]string{"feature": "search"},
+		PromptCacheKey:   "support-agent-v2",
+		SafetyIdentifier: "3f2a9c",
+	}
+
+	data, err := proto.Marshal(FromChatCompletionRequest(req))
+	require.NoError(t, err)
+
+	var decoded ChatCompletionRequest
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+
+	assert.Equal(t, req, ToChatCompletionRequest(&decoded))
+}
+
+func TestChatCompletionRequest_JSONSchema(t *testing.T) {
+	t.Parallel()
+
+	req := openaiclient.ChatCompletionRequest{
+		Model:          "test_model",
+		Messages:       []openaiclient.Message{{Role: "assistant", Refusal: "I can't help with that."}},
+		ResponseFormat: openaiclient.JSONSchemaFormat("answer", []byte(`{"type":"object"}`), true),
+	}
+
+	data, err := proto.Marshal(FromChatCompletionRequest(req))
+	require.NoError(t, err)
+
+	var decoded ChatCompletionRequest
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+
+	assert.Equal(t, req, ToChatCompletionRequest(&decoded))
+}
+
+func TestChatCompletionRequest_Tools(t *testing.T) {
+	t.Parallel()
+
+	req := openaiclient.ChatCompletionRequest{
+		Model: "test_model",
+		Messages: []openaiclient.Message{
+			{Role: "user", Content: "Weather?"},
+			{Role: "assistant", ToolCalls: []openaiclient.ToolCall{
+				{ID: "call_1", Type: "function", Function: openaiclient.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}},
+			}},
+			{Role: "tool", ToolCallID: "call_1", Content: "sunny"},
+		},
+		Tools:             []openaiclient.Tool{openaiclient.NewFunctionTool("weather", "Get weather", []byte(`{"type":"object"}`))},
+		ToolChoice:        openaiclient.ToolChoiceFunction("weather"),
+		ParallelToolCalls: openaiclient.Ptr(false),
+	}
+
+	data, err := proto.Marshal(FromChatCompletionRequest(req))
+	require.NoError(t, err)
+
+	var decoded ChatCompletionRequest
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+
+	assert.Equal(t, req, ToChatCompletionRequest(&decoded))
+}
+
+func TestChatCompletionRequest_Parts(t *testing.T) {
+	t.Parallel()
+
+	req := openaiclient.ChatCompletionRequest{
+		Model: "test_model",
+		Messages: []openaiclient.Message{
+			{Role: "user", Parts: []openaiclient.ContentPart{
+				openaiclient.TextPart("What is this?"),
+				openaiclient.ImagePart("https://example.com/cat.png", openaiclient.ImageDetailLow),
+				openaiclient.AudioPart([]byte("RIFF"), openaiclient.AudioInputWAV),
+			}},
+		},
+	}
+
+	data, err := proto.Marshal(FromChatCompletionRequest(req))
+	require.NoError(t, err)
+
+	var decoded ChatCompletionRequest
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+
+	assert.Equal(t, req, ToChatCompletionRequest(&decoded))
+}
+
+func TestChatCompletionResponse(t *testing.T) {
+	t.Parallel()
+
+	resp := openaiclient.ChatCompletionResponse{
+		ID:      "test_id",
+		Object:  "chat.completion",
+		Model:   "test_model",
+		Created: 123,
+		Choices: []openaiclient.Choice{
+			{
+				Index:        0,
+				FinishReason: "stop",
+				Message:      openaiclient.Message{Role: "assistant", Content: "test_content"},
+				Logprobs: &openaiclient.Logprobs{Content: []openaiclient.TokenLogprob{{
+					Token:       "test",
+					Logprob:     -0.1,
+					Bytes:       []int{116, 101, 115, 116},
+					TopLogprobs: []openaiclient.TopLogprob{{Token: "test", Logprob: -0.1, Bytes: []int{116, 101, 115, 116}}, {Token: "rest", Logprob: -2.5}},
+				}}},
+			},
+		},
+		Usage: openaiclient.Usage{PromptTokens: 1, CompletionTokens: 1, TotalTokens: 2, PromptTokensDetails: openaiclient.PromptTokensDetails{CachedTokens: 1, AudioTokens: 1}, CompletionTokensDetails: openaiclient.CompletionTokensDetails{ReasoningTokens: 1, AudioTokens: 1}},
+	}
+
+	data, err := proto.Marshal(FromChatCompletionResponse(resp))
+	require.NoError(t, err)
+
+	var decoded ChatCompletionResponse
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+
+	assert.Equal(t, resp, ToChatCompletionResponse(&decoded))
+}
+
+func TestEmbedding(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []openaiclient.EmbeddingInput{
+		openaiclient.TextInput("test_input"),
+		openaiclient.TextInput("test_input", "other_input"),
+		openaiclient.TokenInput([]int{1, 2}, []int{3}),
+	} {
+		req := openaiclient.EmbeddingRequest{Model: "test_model", Input: input, Dimensions: 256, EncodingFormat: openaiclient.EncodingFormatBase64}
+
+		data, err := proto.Marshal(FromEmbeddingRequest(req))
+		require.NoError(t, err)
+
+		var decoded EmbeddingRequest
+		require.NoError(t, proto.Unmarshal(data, &decoded))
+
+		assert.Equal(t, req, ToEmbeddingRequest(&decoded))
+	}
+
+	resp := openaiclient.EmbeddingResponse{
+		Object: "list",
+		Model:  "test_model",
+		Data: []openaiclient.Embedding{
+			{Object: "embedding", Embedding: []float32{0.5, 0.25}, Index: 0},
+		},
+		Usage: openaiclient.Usage{PromptTokens: 2, TotalTokens: 2, Estimated: true},
+	}
+
+	data, err := proto.Marshal(FromEmbeddingResponse(resp))
+	require.NoError(t, err)
+
+	var decoded EmbeddingResponse
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+
+	assert.Equal(t, resp, ToEmbeddingResponse(&decoded))
+}
+
+func TestModeration(t *testing.T) {
+	t.Parallel()
+
+	req := openaiclient.ModerationRequest{Model: "omni-moderation-latest", Input: []string{"test_input"}}
+
+	data, err := proto.Marshal(FromModerationRequest(req))
+	require.NoError(t, err)
+
+	var decodedReq ModerationRequest
+	require.NoError(t, proto.Unmarshal(data, &decodedReq))
+	assert.Equal(t, req, ToModerationRequest(&decodedReq))
+
+	resp := openaiclient.ModerationResponse{
+		ID:    "modr-1",
+		Model: "omni-moderation-latest",
+		Results: []openaiclient.ModerationResult{{
+			Flagged:        true,
+			Categories:     map[string]bool{openaiclient.CategoryHate: true},
+			CategoryScores: map[string]float64{openaiclient.CategoryHate: 0.9},
+		}},
+	}
+
+	data, err = proto.Marshal(FromModerationResponse(resp))
+	require.NoError(t, err)
+
+	var decoded ModerationResponse
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+	assert.Equal(t, resp, ToModerationResponse(&decoded))
+}
+
+func TestNilMessages(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, FromMessages(nil))
+	assert.Nil(t, ToMessages(nil))
+	assert.Equal(t, openaiclient.Usage{}, ToUsage(nil))
+}
@@ -0,0 +1,15 @@
+module github.com/alesr/openaiclient/openaiclientpb
+
+go 1.22
+
+require (
+	github.com/alesr/openaiclient v0.1.0
+	github.com/stretchr/testify v1.8.4
+	google.golang.org/protobuf v1.36.6
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,317 @@
+// Package grpcgateway serves the chat completion, embedding and moderation
+// operations of a configured client as the OpenAI gRPC service defined in
+// openaiclient.proto, so services in other languages can share one OpenAI
+// egress point holding the API key, retries, budgets and policies.
+//
+// The gateway implements the gRPC protocol on net/http without depending on
+// grpc-go. It requires HTTP/2: serve it over TLS, or with unencrypted HTTP/2
+// enabled in http.Server.Protocols for plaintext connections.
+package grpcgateway
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alesr/openaiclient"
+	"github.com/alesr/openaiclient/openaiclientpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// ServicePath is the path prefix of the methods of the OpenAI service.
+const ServicePath = "/openaiclient.v1.OpenAI/"
+
+// DefaultMaxMessageSize is the default maximum size of a request message, in bytes.
+const DefaultMaxMessageSize = 4 << 20
+
+// gRPC status codes, as defined by the gRPC protocol.
+const (
+	CodeOK                = 0
+	CodeCanceled          = 1
+	CodeInvalidArgument   = 3
+	CodeDeadlineExceeded  = 4
+	CodeNotFound          = 5
+	CodePermissionDenied  = 7
+	CodeResourceExhausted = 8
+	CodeUnimplemented     = 12
+	CodeInternal          = 13
+	CodeUnavailable       = 14
+	CodeUnauthenticated   = 16
+)
+
+type (
+	// Option configures a Gateway.
+	Option func(*Gateway)
+
+	// Gateway is an http.Handler serving the OpenAI gRPC service with a client.
+	Gateway struct {
+		client         *openaiclient.Client
+		maxMessageSize int
+	}
+
+	// method is a unary method of the service.
+	method func(ctx context.Context, c *openaiclient.Client, data []byte) (proto.Message, error)
+
+	// statusError is an error with a gRPC status code.
+	statusError struct {
+		code int
+		err  error
+	}
+)
+
+var methods = map[string]method{
+	"CreateChatCompletion": unary(func(ctx context.Context, c *openaiclient.Client, in *openaiclientpb.ChatCompletionRequest) (proto.Message, error) {
+		resp, err := c.CreateChatCompletion(ctx, openaiclientpb.ToChatCompletionRequest(in))
+		if err != nil {
+			return nil, err
+		}
+		return openaiclientpb.FromChatCompletionResponse(*resp), nil
+	}),
+	"CreateEmbedding": unary(func(ctx context.Context, c *openaiclient.Client, in *openaiclientpb.EmbeddingRequest) (proto.Message, error) {
+		resp, err := c.CreateEmbedding(ctx, openaiclientpb.ToEmbeddingRequest(in))
+		if err != nil {
+			return nil, err
+		}
+		return openaiclientpb.FromEmbeddingResponse(*resp), nil
+	}),
+	"CreateModeration": unary(func(ctx context.Context, c *openaiclient.Client, in *openaiclientpb.ModerationRequest) (proto.Message, error) {
+		resp, err := c.CreateModeration(ctx, openaiclientpb.ToModerationRequest(in))
+		if err != nil {
+			return nil, err
+		}
+		return openaiclientpb.FromModerationResponse(*resp), nil
+	}),
+}
+
+// unary returns the method decoding its request into a T before calling fn.
+func unary[T any, PT interface {
+	*T
+	proto.Message
+}](fn func(ctx context.Context, c *openaiclient.Client, in PT) (proto.Message, error)) method {
+	return func(ctx context.Context, c *openaiclient.Client, data []byte) (proto.Message, error) {
+		in := PT(new(T))
+		if err := proto.Unmarshal(data, in); err != nil {
+			return nil, &statusError{code: CodeInvalidArgument, err: fmt.Errorf("could not unmarshal request: %w", err)}
+		}
+		return fn(ctx, c, in)
+	}
+}
+
+// New creates a gateway serving the operations of the client.
+func New(client *openaiclient.Client, opts ...Option) *Gateway {
+	g := &Gateway{client: client, maxMessageSize: DefaultMaxMessageSize}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// WithMaxMessageSize sets the maximum size of a request message, in bytes.
+// Larger requests fail with CodeResourceExhausted.
+func WithMaxMessageSize(n int) Option {
+	return func(g *Gateway) {
+		g.maxMessageSize = n
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ProtoMajor != 2 || !isGRPC(r.Header.Get("Content-Type")) {
+		http.Error(w, "gRPC requests must be HTTP/2 POST requests with an application/grpc content type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+
+	resp, err := g.serve(r)
+	if err == nil {
+		err = writeMessage(w, resp)
+	}
+	writeStatus(w, err)
+}
+
+// serve calls the method of the request.
+func (g *Gateway) serve(r *http.Request) (proto.Message, error) {
+	name, ok := strings.CutPrefix(r.URL.Path, ServicePath)
+	m := methods[name]
+	if !ok || m == nil {
+		return nil, &statusError{code: CodeUnimplemented, err: fmt.Errorf("unknown method %s", r.URL.Path)}
+	}
+
+	if enc := r.Header.Get("Grpc-Encoding"); enc != "" && enc != "identity" {
+		return nil, &statusError{code: CodeUnimplemented, err: fmt.Errorf("unsupported compression %q", enc)}
+	}
+
+	ctx := r.Context()
+	if header := r.Header.Get("Grpc-Timeout"); header != "" {
+		timeout, err := parseTimeout(header)
+		if err != nil {
+			return nil, &statusError{code: CodeInvalidArgument, err: err}
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	data, err := readMessage(r.Body, g.maxMessageSize)
+	if err != nil {
+		return nil, err
+	}
+	return m(ctx, g.client, data)
+}
+
+// isGRPC reports whether the content type is a gRPC one with protobuf messages.
+func isGRPC(contentType string) bool {
+	return contentType == "application/grpc" || contentType == "application/grpc+proto"
+}
+
+// readMessage reads the single length-prefixed message of a unary request.
+func readMessage(r io.Reader, maxSize int) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, &statusError{code: CodeInvalidArgument, err: fmt.Errorf("could not read message prefix: %w", err)}
+	}
+	if prefix[0] != 0 {
+		return nil, &statusError{code: CodeUnimplemented, err: errors.New("compressed messages are not supported")}
+	}
+
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if uint64(size) > uint64(maxSize) {
+		return nil, &statusError{code: CodeResourceExhausted, err: fmt.Errorf("message of %d bytes exceeds the limit of %d", size, maxSize)}
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, &statusError{code: CodeInvalidArgument, err: fmt.Errorf("could not read message: %w", err)}
+	}
+	return data, nil
+}
+
+// writeMessage writes the length-prefixed message.
+func writeMessage(w io.Writer, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return &statusError{code: CodeInternal, err: fmt.Errorf("could not marshal response: %w", err)}
+	}
+
+	frame := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	if _, err := w.Write(append(frame, data...)); err != nil {
+		return &statusError{code: CodeUnavailable, err: fmt.Errorf("could not write response: %w", err)}
+	}
+	return nil
+}
+
+// writeStatus sets the status trailers of the error.
+func writeStatus(w http.ResponseWriter, err error) {
+	code, message := CodeOK, ""
+	if err != nil {
+		code, message = Code(err), err.Error()
+	}
+
+	w.Header().Set("Grpc-Status", strconv.Itoa(code))
+	if message != "" {
+		w.Header().Set("Grpc-Message", encodeMessage(message))
+	}
+}
+
+// Code returns the gRPC status code of an error returned by the client:
+// API errors map by HTTP status, invalid requests to CodeInvalidArgument and
+// an exhausted budget to CodeResourceExhausted.
+func Code(err error) int {
+	var se *statusError
+	if errors.As(err, &se) {
+		return se.code
+	}
+
+	var apiErr *openaiclient.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		return httpCode(apiErr.StatusCode)
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeDeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return CodeCanceled
+	case errors.Is(err, openaiclient.ErrInvalidRequest):
+		return CodeInvalidArgument
+	case errors.Is(err, openaiclient.ErrBudgetExceeded):
+		return CodeResourceExhausted
+	default:
+		return CodeInternal
+	}
+}
+
+// httpCode returns the gRPC status code of an HTTP status code.
+func httpCode(statusCode int) int {
+	switch {
+	case statusCode == http.StatusBadRequest:
+		return CodeInvalidArgument
+	case statusCode == http.StatusUnauthorized:
+		return CodeUnauthenticated
+	case statusCode == http.StatusForbidden:
+		return CodePermissionDenied
+	case statusCode == http.StatusNotFound:
+		return CodeNotFound
+	case statusCode == http.StatusTooManyRequests:
+		return CodeResourceExhausted
+	case statusCode >= 500:
+		return CodeUnavailable
+	default:
+		return CodeInternal
+	}
+}
+
+// parseTimeout parses a grpc-timeout header, such as "100m" for 100 milliseconds.
+func parseTimeout(header string) (time.Duration, error) {
+	units := map[byte]time.Duration{
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+		'm': time.Millisecond,
+		'u': time.Microsecond,
+		'n': time.Nanosecond,
+	}
+
+	if len(header) < 2 || len(header) > 9 {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", header)
+	}
+	unit, ok := units[header[len(header)-1]]
+	value, err := strconv.ParseInt(header[:len(header)-1], 10, 64)
+	if !ok || err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", header)
+	}
+	return time.Duration(value) * unit, nil
+}
+
+// encodeMessage percent-encodes the status message as the gRPC protocol requires.
+func encodeMessage(message string) string {
+	var b strings.Builder
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c < ' ' || c > '~' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// Error implements the error interface.
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *statusError) Unwrap() error {
+	return e.err
+}
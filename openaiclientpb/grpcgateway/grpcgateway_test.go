@@ -0,0 +1,282 @@
+package grpcgateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alesr/openaiclient"
+	"github.com/alesr/openaiclient/openaiclientpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// doFunc is an openaiclient.HTTPClient calling itself.
+type doFunc func(req *http.Request) (*http.Response, error)
+
+func (f doFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newServer starts an HTTP/2 server of a gateway whose client calls upstream.
+func newServer(t *testing.T, upstream doFunc, opts ...Option) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewUnstartedServer(New(openaiclient.New("test_api_key", upstream), opts...))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// call sends a unary gRPC request and returns the response message, status and status message.
+func call(t *testing.T, srv *httptest.Server, method string, in proto.Message, header http.Header) ([]byte, string, string) {
+	t.Helper()
+
+	data, err := proto.Marshal(in)
+	require.NoError(t, err)
+
+	frame := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+ServicePath+method, bytes.NewReader(append(frame, data...)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/grpc")
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/grpc", resp.Header.Get("Content-Type"))
+
+	status, message := resp.Trailer.Get("Grpc-Status"), resp.Trailer.Get("Grpc-Message")
+	if status == "" {
+		// Trailers-only responses carry the status in the headers.
+		status, message = resp.Header.Get("Grpc-Status"), resp.Header.Get("Grpc-Message")
+	}
+
+	if len(body) == 0 {
+		return nil, status, message
+	}
+	require.GreaterOrEqual(t, len(body), 5)
+	require.Equal(t, int(binary.BigEndian.Uint32(body[1:5])), len(body)-5)
+	return body[5:], status, message
+}
+
+// jsonResponse returns an upstream response with the JSON body.
+func jsonResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestGateway_CreateChatCompletion(t *testing.T) {
+	t.Parallel()
+
+	srv := newServer(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/v1/chat/completions", req.URL.Path)
+		assert.Equal(t, "Bearer test_api_key", req.Header.Get("Authorization"))
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"content":"Hi"`)
+
+		return jsonResponse(http.StatusOK, `{
+			"id": "chatcmpl-1",
+			"model": "gpt-4o-mini",
+			"choices": [{"finish_reason": "stop", "message": {"role": "assistant", "content": "Hello!"}}],
+			"usage": {"prompt_tokens": 1, "completion_tokens": 2, "total_tokens": 3}
+		}`), nil
+	})
+
+	data, status, message := call(t, srv, "CreateChatCompletion", openaiclientpb.FromChatCompletionRequest(openaiclient.ChatCompletionRequest{
+		Model:    openaiclient.GPT4oMini,
+		Messages: []openaiclient.Message{{Role: openaiclient.RoleUser, Content: "Hi"}},
+	}), nil)
+	require.Equal(t, "0", status, message)
+
+	var out openaiclientpb.ChatCompletionResponse
+	require.NoError(t, proto.Unmarshal(data, &out))
+
+	resp := openaiclientpb.ToChatCompletionResponse(&out)
+	assert.Equal(t, "chatcmpl-1", resp.ID)
+	assert.Equal(t, "Hello!", resp.Choices[0].Message.Content)
+	assert.Equal(t, 3, resp.Usage.TotalTokens)
+}
+
+func TestGateway_CreateEmbedding(t *testing.T) {
+	t.Parallel()
+
+	srv := newServer(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/v1/embeddings", req.URL.Path)
+		return jsonResponse(http.StatusOK, `{"object": "list", "model": "text-embedding-3-small", "data": [{"object": "embedding", "embedding": [0.5, 0.25], "index": 0}]}`), nil
+	})
+
+	data, status, message := call(t, srv, "CreateEmbedding", openaiclientpb.FromEmbeddingRequest(openaiclient.EmbeddingRequest{
+		Model: openaiclient.TextEmbedding3Small,
+		Input: openaiclient.TextInput("test_input"),
+	}), nil)
+	require.Equal(t, "0", status, message)
+
+	var out openaiclientpb.EmbeddingResponse
+	require.NoError(t, proto.Unmarshal(data, &out))
+	assert.Equal(t, []float32{0.5, 0.25}, out.GetData()[0].GetEmbedding())
+}
+
+func TestGateway_CreateModeration(t *testing.T) {
+	t.Parallel()
+
+	srv := newServer(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/v1/moderations", req.URL.Path)
+		return jsonResponse(http.StatusOK, `{"id": "modr-1", "model": "omni-moderation-latest", "results": [{"flagged": true, "categories": {"hate": true}, "category_scores": {"hate": 0.9}}]}`), nil
+	})
+
+	data, status, message := call(t, srv, "CreateModeration", &openaiclientpb.ModerationRequest{Input: []string{"test_input"}}, nil)
+	require.Equal(t, "0", status, message)
+
+	var out openaiclientpb.ModerationResponse
+	require.NoError(t, proto.Unmarshal(data, &out))
+	assert.True(t, out.GetResults()[0].GetFlagged())
+	assert.Equal(t, map[string]bool{"hate": true}, out.GetResults()[0].GetCategories())
+}
+
+func TestGateway_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		method   string
+		header   http.Header
+		upstream doFunc
+		opts     []Option
+		want     int
+	}{
+		{
+			name:   "unknown method",
+			method: "CreateImage",
+			want:   CodeUnimplemented,
+		},
+		{
+			name:   "compression",
+			method: "CreateModeration",
+			header: http.Header{"Grpc-Encoding": []string{"gzip"}},
+			want:   CodeUnimplemented,
+		},
+		{
+			name:   "message too large",
+			method: "CreateModeration",
+			opts:   []Option{WithMaxMessageSize(4)},
+			want:   CodeResourceExhausted,
+		},
+		{
+			name:   "unauthenticated upstream",
+			method: "CreateModeration",
+			upstream: func(*http.Request) (*http.Response, error) {
+				return jsonResponse(http.StatusUnauthorized, `{"error": {"message": "Incorrect API key provided", "type": "invalid_request_error"}}`), nil
+			},
+			want: CodeUnauthenticated,
+		},
+		{
+			name:   "deadline",
+			method: "CreateModeration",
+			header: http.Header{"Grpc-Timeout": []string{"1m"}},
+			upstream: func(req *http.Request) (*http.Response, error) {
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			},
+			want: CodeDeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			upstream := tt.upstream
+			if upstream == nil {
+				upstream = func(*http.Request) (*http.Response, error) {
+					t.Error("unexpected upstream request")
+					return nil, errors.New("unexpected upstream request")
+				}
+			}
+
+			srv := newServer(t, upstream, tt.opts...)
+			data, status, message := call(t, srv, tt.method, &openaiclientpb.ModerationRequest{Input: []string{"test_input"}}, tt.header)
+
+			assert.Nil(t, data)
+			assert.Equal(t, fmt.Sprint(tt.want), status)
+			assert.NotEmpty(t, message)
+		})
+	}
+}
+
+func TestGateway_RejectsNonGRPCRequests(t *testing.T) {
+	t.Parallel()
+
+	srv := newServer(t, nil)
+
+	resp, err := srv.Client().Post(srv.URL+ServicePath+"CreateModeration", "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+}
+
+func TestCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{err: &openaiclient.APIError{StatusCode: http.StatusTooManyRequests}, want: CodeResourceExhausted},
+		{err: fmt.Errorf("wrapped: %w", &openaiclient.APIError{StatusCode: http.StatusBadGateway}), want: CodeUnavailable},
+		{err: &openaiclient.APIError{StatusCode: http.StatusNotFound}, want: CodeNotFound},
+		{err: fmt.Errorf("%w: no messages", openaiclient.ErrInvalidRequest), want: CodeInvalidArgument},
+		{err: openaiclient.ErrBudgetExceeded, want: CodeResourceExhausted},
+		{err: context.Canceled, want: CodeCanceled},
+		{err: errors.New("boom"), want: CodeInternal},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Code(tt.err), tt.err.Error())
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	t.Parallel()
+
+	got, err := parseTimeout("250m")
+	require.NoError(t, err)
+	assert.Equal(t, 250*time.Millisecond, got)
+
+	for _, header := range []string{"", "5", "5x", "-1S", "1234567890S"} {
+		_, err := parseTimeout(header)
+		assert.Error(t, err, header)
+	}
+}
+
+func TestEncodeMessage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "100%25 caf%C3%A9%0A", encodeMessage("100% café\n"))
+}
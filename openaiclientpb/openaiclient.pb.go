@@ -0,0 +1,1853 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: openaiclient.proto
+
+package openaiclientpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Message is a chat message.
+type Message struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Role       string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Content    string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Prefix     bool                   `protobuf:"varint,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	ToolCalls  []*ToolCall            `protobuf:"bytes,4,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	ToolCallId string                 `protobuf:"bytes,5,opt,name=tool_call_id,json=toolCallId,proto3" json:"tool_call_id,omitempty"`
+	// parts is the multimodal content, sent instead of content when set.
+	Parts []*ContentPart `protobuf:"bytes,6,rep,name=parts,proto3" json:"parts,omitempty"`
+	// refusal is the reason the model refused to answer, with structured outputs.
+	Refusal       string `protobuf:"bytes,7,opt,name=refusal,proto3" json:"refusal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	mi := &file_openaiclient_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Message) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Message) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Message) GetPrefix() bool {
+	if x != nil {
+		return x.Prefix
+	}
+	return false
+}
+
+func (x *Message) GetToolCalls() []*ToolCall {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return nil
+}
+
+func (x *Message) GetToolCallId() string {
+	if x != nil {
+		return x.ToolCallId
+	}
+	return ""
+}
+
+func (x *Message) GetParts() []*ContentPart {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+func (x *Message) GetRefusal() string {
+	if x != nil {
+		return x.Refusal
+	}
+	return ""
+}
+
+// ContentPart is a part of a multimodal message: a text, an image or an audio clip.
+type ContentPart struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Type        string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Text        string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	ImageUrl    string                 `protobuf:"bytes,3,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	ImageDetail string                 `protobuf:"bytes,4,opt,name=image_detail,json=imageDetail,proto3" json:"image_detail,omitempty"`
+	// input_audio_data is the base64 encoded audio clip.
+	InputAudioData   string `protobuf:"bytes,5,opt,name=input_audio_data,json=inputAudioData,proto3" json:"input_audio_data,omitempty"`
+	InputAudioFormat string `protobuf:"bytes,6,opt,name=input_audio_format,json=inputAudioFormat,proto3" json:"input_audio_format,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ContentPart) Reset() {
+	*x = ContentPart{}
+	mi := &file_openaiclient_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContentPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentPart) ProtoMessage() {}
+
+func (x *ContentPart) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentPart.ProtoReflect.Descriptor instead.
+func (*ContentPart) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ContentPart) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ContentPart) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ContentPart) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *ContentPart) GetImageDetail() string {
+	if x != nil {
+		return x.ImageDetail
+	}
+	return ""
+}
+
+func (x *ContentPart) GetInputAudioData() string {
+	if x != nil {
+		return x.InputAudioData
+	}
+	return ""
+}
+
+func (x *ContentPart) GetInputAudioFormat() string {
+	if x != nil {
+		return x.InputAudioFormat
+	}
+	return ""
+}
+
+// ToolCall is a call to a tool requested by the model.
+type ToolCall struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type         string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	FunctionName string                 `protobuf:"bytes,3,opt,name=function_name,json=functionName,proto3" json:"function_name,omitempty"`
+	// function_arguments are the JSON encoded arguments.
+	FunctionArguments string `protobuf:"bytes,4,opt,name=function_arguments,json=functionArguments,proto3" json:"function_arguments,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ToolCall) Reset() {
+	*x = ToolCall{}
+	mi := &file_openaiclient_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolCall) ProtoMessage() {}
+
+func (x *ToolCall) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolCall.ProtoReflect.Descriptor instead.
+func (*ToolCall) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ToolCall) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ToolCall) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ToolCall) GetFunctionName() string {
+	if x != nil {
+		return x.FunctionName
+	}
+	return ""
+}
+
+func (x *ToolCall) GetFunctionArguments() string {
+	if x != nil {
+		return x.FunctionArguments
+	}
+	return ""
+}
+
+// Tool is a function tool the model may call.
+type Tool struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Type        string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// parameters is the JSON schema of the function arguments.
+	Parameters    []byte `protobuf:"bytes,4,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	Strict        bool   `protobuf:"varint,5,opt,name=strict,proto3" json:"strict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tool) Reset() {
+	*x = Tool{}
+	mi := &file_openaiclient_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tool) ProtoMessage() {}
+
+func (x *Tool) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tool.ProtoReflect.Descriptor instead.
+func (*Tool) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Tool) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Tool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tool) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Tool) GetParameters() []byte {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *Tool) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+// ToolChoice is either a mode, such as "auto", or a function to call.
+type ToolChoice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	Function      string                 `protobuf:"bytes,2,opt,name=function,proto3" json:"function,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolChoice) Reset() {
+	*x = ToolChoice{}
+	mi := &file_openaiclient_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolChoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolChoice) ProtoMessage() {}
+
+func (x *ToolChoice) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolChoice.ProtoReflect.Descriptor instead.
+func (*ToolChoice) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ToolChoice) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ToolChoice) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+// Usage is the token usage data.
+type Usage struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	PromptTokens        int64                  `protobuf:"varint,1,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	CompletionTokens    int64                  `protobuf:"varint,2,opt,name=completion_tokens,json=completionTokens,proto3" json:"completion_tokens,omitempty"`
+	TotalTokens         int64                  `protobuf:"varint,3,opt,name=total_tokens,json=totalTokens,proto3" json:"total_tokens,omitempty"`
+	PromptTokensDetails *PromptTokensDetails   `protobuf:"bytes,4,opt,name=prompt_tokens_details,json=promptTokensDetails,proto3" json:"prompt_tokens_details,omitempty"`
+	// estimated reports whether the usage was estimated by the client.
+	Estimated               bool                     `protobuf:"varint,5,opt,name=estimated,proto3" json:"estimated,omitempty"`
+	CompletionTokensDetails *CompletionTokensDetails `protobuf:"bytes,6,opt,name=completion_tokens_details,json=completionTokensDetails,proto3" json:"completion_tokens_details,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *Usage) Reset() {
+	*x = Usage{}
+	mi := &file_openaiclient_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usage) ProtoMessage() {}
+
+func (x *Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usage.ProtoReflect.Descriptor instead.
+func (*Usage) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Usage) GetPromptTokens() int64 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetCompletionTokens() int64 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetTotalTokens() int64 {
+	if x != nil {
+		return x.TotalTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetPromptTokensDetails() *PromptTokensDetails {
+	if x != nil {
+		return x.PromptTokensDetails
+	}
+	return nil
+}
+
+func (x *Usage) GetEstimated() bool {
+	if x != nil {
+		return x.Estimated
+	}
+	return false
+}
+
+func (x *Usage) GetCompletionTokensDetails() *CompletionTokensDetails {
+	if x != nil {
+		return x.CompletionTokensDetails
+	}
+	return nil
+}
+
+// PromptTokensDetails is the breakdown of the prompt tokens.
+type PromptTokensDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CachedTokens  int64                  `protobuf:"varint,1,opt,name=cached_tokens,json=cachedTokens,proto3" json:"cached_tokens,omitempty"`
+	AudioTokens   int64                  `protobuf:"varint,2,opt,name=audio_tokens,json=audioTokens,proto3" json:"audio_tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptTokensDetails) Reset() {
+	*x = PromptTokensDetails{}
+	mi := &file_openaiclient_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptTokensDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptTokensDetails) ProtoMessage() {}
+
+func (x *PromptTokensDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptTokensDetails.ProtoReflect.Descriptor instead.
+func (*PromptTokensDetails) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PromptTokensDetails) GetCachedTokens() int64 {
+	if x != nil {
+		return x.CachedTokens
+	}
+	return 0
+}
+
+func (x *PromptTokensDetails) GetAudioTokens() int64 {
+	if x != nil {
+		return x.AudioTokens
+	}
+	return 0
+}
+
+// CompletionTokensDetails is the breakdown of the completion tokens.
+type CompletionTokensDetails struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ReasoningTokens int64                  `protobuf:"varint,1,opt,name=reasoning_tokens,json=reasoningTokens,proto3" json:"reasoning_tokens,omitempty"`
+	AudioTokens     int64                  `protobuf:"varint,2,opt,name=audio_tokens,json=audioTokens,proto3" json:"audio_tokens,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CompletionTokensDetails) Reset() {
+	*x = CompletionTokensDetails{}
+	mi := &file_openaiclient_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompletionTokensDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompletionTokensDetails) ProtoMessage() {}
+
+func (x *CompletionTokensDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompletionTokensDetails.ProtoReflect.Descriptor instead.
+func (*CompletionTokensDetails) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CompletionTokensDetails) GetReasoningTokens() int64 {
+	if x != nil {
+		return x.ReasoningTokens
+	}
+	return 0
+}
+
+func (x *CompletionTokensDetails) GetAudioTokens() int64 {
+	if x != nil {
+		return x.AudioTokens
+	}
+	return 0
+}
+
+// ChatCompletionRequest is the request body for the chat completion endpoint.
+type ChatCompletionRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Model            string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Messages         []*Message             `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	Temperature      *float64               `protobuf:"fixed64,3,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	TopP             *float64               `protobuf:"fixed64,4,opt,name=top_p,json=topP,proto3,oneof" json:"top_p,omitempty"`
+	N                int64                  `protobuf:"varint,5,opt,name=n,proto3" json:"n,omitempty"`
+	MaxTokens        int64                  `protobuf:"varint,6,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`
+	Stop             []string               `protobuf:"bytes,7,rep,name=stop,proto3" json:"stop,omitempty"`
+	PresencePenalty  *float64               `protobuf:"fixed64,8,opt,name=presence_penalty,json=presencePenalty,proto3,oneof" json:"presence_penalty,omitempty"`
+	FrequencyPenalty *float64               `protobuf:"fixed64,9,opt,name=frequency_penalty,json=frequencyPenalty,proto3,oneof" json:"frequency_penalty,omitempty"`
+	LogitBias        map[string]int64       `protobuf:"bytes,10,rep,name=logit_bias,json=logitBias,proto3" json:"logit_bias,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Seed             *int64                 `protobuf:"varint,11,opt,name=seed,proto3,oneof" json:"seed,omitempty"`
+	User             string                 `protobuf:"bytes,12,opt,name=user,proto3" json:"user,omitempty"`
+	// response_format is the type of the response format, such as "json_object".
+	ResponseFormat    string      `protobuf:"bytes,13,opt,name=response_format,json=responseFormat,proto3" json:"response_format,omitempty"`
+	Tools             []*Tool     `protobuf:"bytes,14,rep,name=tools,proto3" json:"tools,omitempty"`
+	ToolChoice        *ToolChoice `protobuf:"bytes,15,opt,name=tool_choice,json=toolChoice,proto3" json:"tool_choice,omitempty"`
+	ParallelToolCalls *bool       `protobuf:"varint,16,opt,name=parallel_tool_calls,json=parallelToolCalls,proto3,oneof" json:"parallel_tool_calls,omitempty"`
+	// json_schema is the schema of the "json_schema" response format.
+	JsonSchema       *JSONSchema       `protobuf:"bytes,17,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"`
+	Logprobs         bool              `protobuf:"varint,18,opt,name=logprobs,proto3" json:"logprobs,omitempty"`
+	TopLogprobs      int64             `protobuf:"varint,19,opt,name=top_logprobs,json=topLogprobs,proto3" json:"top_logprobs,omitempty"`
+	Metadata         map[string]string `protobuf:"bytes,20,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	PromptCacheKey   string            `protobuf:"bytes,21,opt,name=prompt_cache_key,json=promptCacheKey,proto3" json:"prompt_cache_key,omitempty"`
+	SafetyIdentifier string            `protobuf:"bytes,22,opt,name=safety_identifier,json=safetyIdentifier,proto3" json:"safety_identifier,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ChatCompletionRequest) Reset() {
+	*x = ChatCompletionRequest{}
+	mi := &file_openaiclient_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatCompletionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatCompletionRequest) ProtoMessage() {}
+
+func (x *ChatCompletionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatCompletionRequest.ProtoReflect.Descriptor instead.
+func (*ChatCompletionRequest) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ChatCompletionRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ChatCompletionRequest) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *ChatCompletionRequest) GetTemperature() float64 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *ChatCompletionRequest) GetTopP() float64 {
+	if x != nil && x.TopP != nil {
+		return *x.TopP
+	}
+	return 0
+}
+
+func (x *ChatCompletionRequest) GetN() int64 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *ChatCompletionRequest) GetMaxTokens() int64 {
+	if x != nil {
+		return x.MaxTokens
+	}
+	return 0
+}
+
+func (x *ChatCompletionRequest) GetStop() []string {
+	if x != nil {
+		return x.Stop
+	}
+	return nil
+}
+
+func (x *ChatCompletionRequest) GetPresencePenalty() float64 {
+	if x != nil && x.PresencePenalty != nil {
+		return *x.PresencePenalty
+	}
+	return 0
+}
+
+func (x *ChatCompletionRequest) GetFrequencyPenalty() float64 {
+	if x != nil && x.FrequencyPenalty != nil {
+		return *x.FrequencyPenalty
+	}
+	return 0
+}
+
+func (x *ChatCompletionRequest) GetLogitBias() map[string]int64 {
+	if x != nil {
+		return x.LogitBias
+	}
+	return nil
+}
+
+func (x *ChatCompletionRequest) GetSeed() int64 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
+	}
+	return 0
+}
+
+func (x *ChatCompletionRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *ChatCompletionRequest) GetResponseFormat() string {
+	if x != nil {
+		return x.ResponseFormat
+	}
+	return ""
+}
+
+func (x *ChatCompletionRequest) GetTools() []*Tool {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *ChatCompletionRequest) GetToolChoice() *ToolChoice {
+	if x != nil {
+		return x.ToolChoice
+	}
+	return nil
+}
+
+func (x *ChatCompletionRequest) GetParallelToolCalls() bool {
+	if x != nil && x.ParallelToolCalls != nil {
+		return *x.ParallelToolCalls
+	}
+	return false
+}
+
+func (x *ChatCompletionRequest) GetJsonSchema() *JSONSchema {
+	if x != nil {
+		return x.JsonSchema
+	}
+	return nil
+}
+
+func (x *ChatCompletionRequest) GetLogprobs() bool {
+	if x != nil {
+		return x.Logprobs
+	}
+	return false
+}
+
+func (x *ChatCompletionRequest) GetTopLogprobs() int64 {
+	if x != nil {
+		return x.TopLogprobs
+	}
+	return 0
+}
+
+func (x *ChatCompletionRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ChatCompletionRequest) GetPromptCacheKey() string {
+	if x != nil {
+		return x.PromptCacheKey
+	}
+	return ""
+}
+
+func (x *ChatCompletionRequest) GetSafetyIdentifier() string {
+	if x != nil {
+		return x.SafetyIdentifier
+	}
+	return ""
+}
+
+// JSONSchema is the JSON schema the model output must match.
+type JSONSchema struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// schema is the JSON encoded schema.
+	Schema        []byte `protobuf:"bytes,3,opt,name=schema,proto3" json:"schema,omitempty"`
+	Strict        bool   `protobuf:"varint,4,opt,name=strict,proto3" json:"strict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONSchema) Reset() {
+	*x = JSONSchema{}
+	mi := &file_openaiclient_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONSchema) ProtoMessage() {}
+
+func (x *JSONSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONSchema.ProtoReflect.Descriptor instead.
+func (*JSONSchema) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *JSONSchema) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JSONSchema) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *JSONSchema) GetSchema() []byte {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *JSONSchema) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+// Choice is a chat completion choice.
+type Choice struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Index        int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	FinishReason string                 `protobuf:"bytes,2,opt,name=finish_reason,json=finishReason,proto3" json:"finish_reason,omitempty"`
+	Message      *Message               `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// logprobs are the log probabilities of the output tokens, if requested.
+	Logprobs      *Logprobs `protobuf:"bytes,4,opt,name=logprobs,proto3" json:"logprobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Choice) Reset() {
+	*x = Choice{}
+	mi := &file_openaiclient_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Choice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Choice) ProtoMessage() {}
+
+func (x *Choice) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Choice.ProtoReflect.Descriptor instead.
+func (*Choice) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Choice) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Choice) GetFinishReason() string {
+	if x != nil {
+		return x.FinishReason
+	}
+	return ""
+}
+
+func (x *Choice) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *Choice) GetLogprobs() *Logprobs {
+	if x != nil {
+		return x.Logprobs
+	}
+	return nil
+}
+
+// Logprobs are the log probabilities of the tokens of a choice.
+type Logprobs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       []*TokenLogprob        `protobuf:"bytes,1,rep,name=content,proto3" json:"content,omitempty"`
+	Refusal       []*TokenLogprob        `protobuf:"bytes,2,rep,name=refusal,proto3" json:"refusal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Logprobs) Reset() {
+	*x = Logprobs{}
+	mi := &file_openaiclient_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Logprobs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Logprobs) ProtoMessage() {}
+
+func (x *Logprobs) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Logprobs.ProtoReflect.Descriptor instead.
+func (*Logprobs) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Logprobs) GetContent() []*TokenLogprob {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *Logprobs) GetRefusal() []*TokenLogprob {
+	if x != nil {
+		return x.Refusal
+	}
+	return nil
+}
+
+// TokenLogprob is the log probability of a token, with the most likely alternatives.
+type TokenLogprob struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Token   string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Logprob float64                `protobuf:"fixed64,2,opt,name=logprob,proto3" json:"logprob,omitempty"`
+	// bytes is the UTF-8 encoding of the token.
+	Bytes         []byte          `protobuf:"bytes,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	TopLogprobs   []*TokenLogprob `protobuf:"bytes,4,rep,name=top_logprobs,json=topLogprobs,proto3" json:"top_logprobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenLogprob) Reset() {
+	*x = TokenLogprob{}
+	mi := &file_openaiclient_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenLogprob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenLogprob) ProtoMessage() {}
+
+func (x *TokenLogprob) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenLogprob.ProtoReflect.Descriptor instead.
+func (*TokenLogprob) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TokenLogprob) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *TokenLogprob) GetLogprob() float64 {
+	if x != nil {
+		return x.Logprob
+	}
+	return 0
+}
+
+func (x *TokenLogprob) GetBytes() []byte {
+	if x != nil {
+		return x.Bytes
+	}
+	return nil
+}
+
+func (x *TokenLogprob) GetTopLogprobs() []*TokenLogprob {
+	if x != nil {
+		return x.TopLogprobs
+	}
+	return nil
+}
+
+// ChatCompletionResponse is the response body for the chat completion endpoint.
+type ChatCompletionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Object        string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	Created       int64                  `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"`
+	Choices       []*Choice              `protobuf:"bytes,5,rep,name=choices,proto3" json:"choices,omitempty"`
+	Usage         *Usage                 `protobuf:"bytes,6,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatCompletionResponse) Reset() {
+	*x = ChatCompletionResponse{}
+	mi := &file_openaiclient_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatCompletionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatCompletionResponse) ProtoMessage() {}
+
+func (x *ChatCompletionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatCompletionResponse.ProtoReflect.Descriptor instead.
+func (*ChatCompletionResponse) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ChatCompletionResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChatCompletionResponse) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *ChatCompletionResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ChatCompletionResponse) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ChatCompletionResponse) GetChoices() []*Choice {
+	if x != nil {
+		return x.Choices
+	}
+	return nil
+}
+
+func (x *ChatCompletionResponse) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+// EmbeddingRequest is the request body for the embedding endpoint.
+type EmbeddingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Model string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	// input holds the texts to embed. A single text stays wire compatible with
+	// the former singular field.
+	Input []string `protobuf:"bytes,2,rep,name=input,proto3" json:"input,omitempty"`
+	// tokens holds the token arrays to embed, instead of texts.
+	Tokens         []*TokenArray `protobuf:"bytes,3,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	Dimensions     int64         `protobuf:"varint,4,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	EncodingFormat string        `protobuf:"bytes,5,opt,name=encoding_format,json=encodingFormat,proto3" json:"encoding_format,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EmbeddingRequest) Reset() {
+	*x = EmbeddingRequest{}
+	mi := &file_openaiclient_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbeddingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbeddingRequest) ProtoMessage() {}
+
+func (x *EmbeddingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbeddingRequest.ProtoReflect.Descriptor instead.
+func (*EmbeddingRequest) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EmbeddingRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *EmbeddingRequest) GetInput() []string {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *EmbeddingRequest) GetTokens() []*TokenArray {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *EmbeddingRequest) GetDimensions() int64 {
+	if x != nil {
+		return x.Dimensions
+	}
+	return 0
+}
+
+func (x *EmbeddingRequest) GetEncodingFormat() string {
+	if x != nil {
+		return x.EncodingFormat
+	}
+	return ""
+}
+
+// TokenArray is a tokenized embedding input.
+type TokenArray struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []int64                `protobuf:"varint,1,rep,packed,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenArray) Reset() {
+	*x = TokenArray{}
+	mi := &file_openaiclient_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenArray) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenArray) ProtoMessage() {}
+
+func (x *TokenArray) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenArray.ProtoReflect.Descriptor instead.
+func (*TokenArray) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TokenArray) GetTokens() []int64 {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+// Embedding is the embedding data containing the embedding vector.
+type Embedding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        string                 `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Embedding     []float32              `protobuf:"fixed32,2,rep,packed,name=embedding,proto3" json:"embedding,omitempty"`
+	Index         int64                  `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Embedding) Reset() {
+	*x = Embedding{}
+	mi := &file_openaiclient_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Embedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Embedding) ProtoMessage() {}
+
+func (x *Embedding) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Embedding.ProtoReflect.Descriptor instead.
+func (*Embedding) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Embedding) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *Embedding) GetEmbedding() []float32 {
+	if x != nil {
+		return x.Embedding
+	}
+	return nil
+}
+
+func (x *Embedding) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+// EmbeddingResponse is the response body for the embedding endpoint.
+type EmbeddingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        string                 `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Data          []*Embedding           `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	Usage         *Usage                 `protobuf:"bytes,4,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbeddingResponse) Reset() {
+	*x = EmbeddingResponse{}
+	mi := &file_openaiclient_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbeddingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbeddingResponse) ProtoMessage() {}
+
+func (x *EmbeddingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbeddingResponse.ProtoReflect.Descriptor instead.
+func (*EmbeddingResponse) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EmbeddingResponse) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *EmbeddingResponse) GetData() []*Embedding {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *EmbeddingResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *EmbeddingResponse) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+// ModerationRequest is the request body for the moderation endpoint.
+type ModerationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Input         []string               `protobuf:"bytes,2,rep,name=input,proto3" json:"input,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerationRequest) Reset() {
+	*x = ModerationRequest{}
+	mi := &file_openaiclient_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerationRequest) ProtoMessage() {}
+
+func (x *ModerationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerationRequest.ProtoReflect.Descriptor instead.
+func (*ModerationRequest) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ModerationRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ModerationRequest) GetInput() []string {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+// ModerationResult is the classification of an input.
+type ModerationResult struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Flagged        bool                   `protobuf:"varint,1,opt,name=flagged,proto3" json:"flagged,omitempty"`
+	Categories     map[string]bool        `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	CategoryScores map[string]float64     `protobuf:"bytes,3,rep,name=category_scores,json=categoryScores,proto3" json:"category_scores,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ModerationResult) Reset() {
+	*x = ModerationResult{}
+	mi := &file_openaiclient_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerationResult) ProtoMessage() {}
+
+func (x *ModerationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerationResult.ProtoReflect.Descriptor instead.
+func (*ModerationResult) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ModerationResult) GetFlagged() bool {
+	if x != nil {
+		return x.Flagged
+	}
+	return false
+}
+
+func (x *ModerationResult) GetCategories() map[string]bool {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ModerationResult) GetCategoryScores() map[string]float64 {
+	if x != nil {
+		return x.CategoryScores
+	}
+	return nil
+}
+
+// ModerationResponse is the response body for the moderation endpoint.
+type ModerationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Results       []*ModerationResult    `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerationResponse) Reset() {
+	*x = ModerationResponse{}
+	mi := &file_openaiclient_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerationResponse) ProtoMessage() {}
+
+func (x *ModerationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_openaiclient_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerationResponse.ProtoReflect.Descriptor instead.
+func (*ModerationResponse) Descriptor() ([]byte, []int) {
+	return file_openaiclient_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ModerationResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ModerationResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ModerationResponse) GetResults() []*ModerationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_openaiclient_proto protoreflect.FileDescriptor
+
+const file_openaiclient_proto_rawDesc = "" +
+	"\n" +
+	"\x12openaiclient.proto\x12\x0fopenaiclient.v1\"\xf9\x01\n" +
+	"\aMessage\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\bR\x06prefix\x128\n" +
+	"\n" +
+	"tool_calls\x18\x04 \x03(\v2\x19.openaiclient.v1.ToolCallR\ttoolCalls\x12 \n" +
+	"\ftool_call_id\x18\x05 \x01(\tR\n" +
+	"toolCallId\x122\n" +
+	"\x05parts\x18\x06 \x03(\v2\x1c.openaiclient.v1.ContentPartR\x05parts\x12\x18\n" +
+	"\arefusal\x18\a \x01(\tR\arefusal\"\xcd\x01\n" +
+	"\vContentPart\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1b\n" +
+	"\timage_url\x18\x03 \x01(\tR\bimageUrl\x12!\n" +
+	"\fimage_detail\x18\x04 \x01(\tR\vimageDetail\x12(\n" +
+	"\x10input_audio_data\x18\x05 \x01(\tR\x0einputAudioData\x12,\n" +
+	"\x12input_audio_format\x18\x06 \x01(\tR\x10inputAudioFormat\"\x82\x01\n" +
+	"\bToolCall\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12#\n" +
+	"\rfunction_name\x18\x03 \x01(\tR\ffunctionName\x12-\n" +
+	"\x12function_arguments\x18\x04 \x01(\tR\x11functionArguments\"\x88\x01\n" +
+	"\x04Tool\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1e\n" +
+	"\n" +
+	"parameters\x18\x04 \x01(\fR\n" +
+	"parameters\x12\x16\n" +
+	"\x06strict\x18\x05 \x01(\bR\x06strict\"<\n" +
+	"\n" +
+	"ToolChoice\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x1a\n" +
+	"\bfunction\x18\x02 \x01(\tR\bfunction\"\xda\x02\n" +
+	"\x05Usage\x12#\n" +
+	"\rprompt_tokens\x18\x01 \x01(\x03R\fpromptTokens\x12+\n" +
+	"\x11completion_tokens\x18\x02 \x01(\x03R\x10completionTokens\x12!\n" +
+	"\ftotal_tokens\x18\x03 \x01(\x03R\vtotalTokens\x12X\n" +
+	"\x15prompt_tokens_details\x18\x04 \x01(\v2$.openaiclient.v1.PromptTokensDetailsR\x13promptTokensDetails\x12\x1c\n" +
+	"\testimated\x18\x05 \x01(\bR\testimated\x12d\n" +
+	"\x19completion_tokens_details\x18\x06 \x01(\v2(.openaiclient.v1.CompletionTokensDetailsR\x17completionTokensDetails\"]\n" +
+	"\x13PromptTokensDetails\x12#\n" +
+	"\rcached_tokens\x18\x01 \x01(\x03R\fcachedTokens\x12!\n" +
+	"\faudio_tokens\x18\x02 \x01(\x03R\vaudioTokens\"g\n" +
+	"\x17CompletionTokensDetails\x12)\n" +
+	"\x10reasoning_tokens\x18\x01 \x01(\x03R\x0freasoningTokens\x12!\n" +
+	"\faudio_tokens\x18\x02 \x01(\x03R\vaudioTokens\"\x9a\t\n" +
+	"\x15ChatCompletionRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x124\n" +
+	"\bmessages\x18\x02 \x03(\v2\x18.openaiclient.v1.MessageR\bmessages\x12%\n" +
+	"\vtemperature\x18\x03 \x01(\x01H\x00R\vtemperature\x88\x01\x01\x12\x18\n" +
+	"\x05top_p\x18\x04 \x01(\x01H\x01R\x04topP\x88\x01\x01\x12\f\n" +
+	"\x01n\x18\x05 \x01(\x03R\x01n\x12\x1d\n" +
+	"\n" +
+	"max_tokens\x18\x06 \x01(\x03R\tmaxTokens\x12\x12\n" +
+	"\x04stop\x18\a \x03(\tR\x04stop\x12.\n" +
+	"\x10presence_penalty\x18\b \x01(\x01H\x02R\x0fpresencePenalty\x88\x01\x01\x120\n" +
+	"\x11frequency_penalty\x18\t \x01(\x01H\x03R\x10frequencyPenalty\x88\x01\x01\x12T\n" +
+	"\n" +
+	"logit_bias\x18\n" +
+	" \x03(\v25.openaiclient.v1.ChatCompletionRequest.LogitBiasEntryR\tlogitBias\x12\x17\n" +
+	"\x04seed\x18\v \x01(\x03H\x04R\x04seed\x88\x01\x01\x12\x12\n" +
+	"\x04user\x18\f \x01(\tR\x04user\x12'\n" +
+	"\x0fresponse_format\x18\r \x01(\tR\x0eresponseFormat\x12+\n" +
+	"\x05tools\x18\x0e \x03(\v2\x15.openaiclient.v1.ToolR\x05tools\x12<\n" +
+	"\vtool_choice\x18\x0f \x01(\v2\x1b.openaiclient.v1.ToolChoiceR\n" +
+	"toolChoice\x123\n" +
+	"\x13parallel_tool_calls\x18\x10 \x01(\bH\x05R\x11parallelToolCalls\x88\x01\x01\x12<\n" +
+	"\vjson_schema\x18\x11 \x01(\v2\x1b.openaiclient.v1.JSONSchemaR\n" +
+	"jsonSchema\x12\x1a\n" +
+	"\blogprobs\x18\x12 \x01(\bR\blogprobs\x12!\n" +
+	"\ftop_logprobs\x18\x13 \x01(\x03R\vtopLogprobs\x12P\n" +
+	"\bmetadata\x18\x14 \x03(\v24.openaiclient.v1.ChatCompletionRequest.MetadataEntryR\bmetadata\x12(\n" +
+	"\x10prompt_cache_key\x18\x15 \x01(\tR\x0epromptCacheKey\x12+\n" +
+	"\x11safety_identifier\x18\x16 \x01(\tR\x10safetyIdentifier\x1a<\n" +
+	"\x0eLogitBiasEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_temperatureB\b\n" +
+	"\x06_top_pB\x13\n" +
+	"\x11_presence_penaltyB\x14\n" +
+	"\x12_frequency_penaltyB\a\n" +
+	"\x05_seedB\x16\n" +
+	"\x14_parallel_tool_calls\"r\n" +
+	"\n" +
+	"JSONSchema\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06schema\x18\x03 \x01(\fR\x06schema\x12\x16\n" +
+	"\x06strict\x18\x04 \x01(\bR\x06strict\"\xae\x01\n" +
+	"\x06Choice\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12#\n" +
+	"\rfinish_reason\x18\x02 \x01(\tR\ffinishReason\x122\n" +
+	"\amessage\x18\x03 \x01(\v2\x18.openaiclient.v1.MessageR\amessage\x125\n" +
+	"\blogprobs\x18\x04 \x01(\v2\x19.openaiclient.v1.LogprobsR\blogprobs\"|\n" +
+	"\bLogprobs\x127\n" +
+	"\acontent\x18\x01 \x03(\v2\x1d.openaiclient.v1.TokenLogprobR\acontent\x127\n" +
+	"\arefusal\x18\x02 \x03(\v2\x1d.openaiclient.v1.TokenLogprobR\arefusal\"\x96\x01\n" +
+	"\fTokenLogprob\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x18\n" +
+	"\alogprob\x18\x02 \x01(\x01R\alogprob\x12\x14\n" +
+	"\x05bytes\x18\x03 \x01(\fR\x05bytes\x12@\n" +
+	"\ftop_logprobs\x18\x04 \x03(\v2\x1d.openaiclient.v1.TokenLogprobR\vtopLogprobs\"\xd1\x01\n" +
+	"\x16ChatCompletionResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x18\n" +
+	"\acreated\x18\x04 \x01(\x03R\acreated\x121\n" +
+	"\achoices\x18\x05 \x03(\v2\x17.openaiclient.v1.ChoiceR\achoices\x12,\n" +
+	"\x05usage\x18\x06 \x01(\v2\x16.openaiclient.v1.UsageR\x05usage\"\xbc\x01\n" +
+	"\x10EmbeddingRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12\x14\n" +
+	"\x05input\x18\x02 \x03(\tR\x05input\x123\n" +
+	"\x06tokens\x18\x03 \x03(\v2\x1b.openaiclient.v1.TokenArrayR\x06tokens\x12\x1e\n" +
+	"\n" +
+	"dimensions\x18\x04 \x01(\x03R\n" +
+	"dimensions\x12'\n" +
+	"\x0fencoding_format\x18\x05 \x01(\tR\x0eencodingFormat\"$\n" +
+	"\n" +
+	"TokenArray\x12\x16\n" +
+	"\x06tokens\x18\x01 \x03(\x03R\x06tokens\"W\n" +
+	"\tEmbedding\x12\x16\n" +
+	"\x06object\x18\x01 \x01(\tR\x06object\x12\x1c\n" +
+	"\tembedding\x18\x02 \x03(\x02R\tembedding\x12\x14\n" +
+	"\x05index\x18\x03 \x01(\x03R\x05index\"\x9f\x01\n" +
+	"\x11EmbeddingResponse\x12\x16\n" +
+	"\x06object\x18\x01 \x01(\tR\x06object\x12.\n" +
+	"\x04data\x18\x02 \x03(\v2\x1a.openaiclient.v1.EmbeddingR\x04data\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12,\n" +
+	"\x05usage\x18\x04 \x01(\v2\x16.openaiclient.v1.UsageR\x05usage\"?\n" +
+	"\x11ModerationRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12\x14\n" +
+	"\x05input\x18\x02 \x03(\tR\x05input\"\xe1\x02\n" +
+	"\x10ModerationResult\x12\x18\n" +
+	"\aflagged\x18\x01 \x01(\bR\aflagged\x12Q\n" +
+	"\n" +
+	"categories\x18\x02 \x03(\v21.openaiclient.v1.ModerationResult.CategoriesEntryR\n" +
+	"categories\x12^\n" +
+	"\x0fcategory_scores\x18\x03 \x03(\v25.openaiclient.v1.ModerationResult.CategoryScoresEntryR\x0ecategoryScores\x1a=\n" +
+	"\x0fCategoriesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\x1aA\n" +
+	"\x13CategoryScoresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"w\n" +
+	"\x12ModerationResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12;\n" +
+	"\aresults\x18\x03 \x03(\v2!.openaiclient.v1.ModerationResultR\aresults2\xa8\x02\n" +
+	"\x06OpenAI\x12g\n" +
+	"\x14CreateChatCompletion\x12&.openaiclient.v1.ChatCompletionRequest\x1a'.openaiclient.v1.ChatCompletionResponse\x12X\n" +
+	"\x0fCreateEmbedding\x12!.openaiclient.v1.EmbeddingRequest\x1a\".openaiclient.v1.EmbeddingResponse\x12[\n" +
+	"\x10CreateModeration\x12\".openaiclient.v1.ModerationRequest\x1a#.openaiclient.v1.ModerationResponseB.Z,github.com/alesr/openaiclient/openaiclientpbb\x06proto3"
+
+var (
+	file_openaiclient_proto_rawDescOnce sync.Once
+	file_openaiclient_proto_rawDescData []byte
+)
+
+func file_openaiclient_proto_rawDescGZIP() []byte {
+	file_openaiclient_proto_rawDescOnce.Do(func() {
+		file_openaiclient_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_openaiclient_proto_rawDesc), len(file_openaiclient_proto_rawDesc)))
+	})
+	return file_openaiclient_proto_rawDescData
+}
+
+var file_openaiclient_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_openaiclient_proto_goTypes = []any{
+	(*Message)(nil),                 // 0: openaiclient.v1.Message
+	(*ContentPart)(nil),             // 1: openaiclient.v1.ContentPart
+	(*ToolCall)(nil),                // 2: openaiclient.v1.ToolCall
+	(*Tool)(nil),                    // 3: openaiclient.v1.Tool
+	(*ToolChoice)(nil),              // 4: openaiclient.v1.ToolChoice
+	(*Usage)(nil),                   // 5: openaiclient.v1.Usage
+	(*PromptTokensDetails)(nil),     // 6: openaiclient.v1.PromptTokensDetails
+	(*CompletionTokensDetails)(nil), // 7: openaiclient.v1.CompletionTokensDetails
+	(*ChatCompletionRequest)(nil),   // 8: openaiclient.v1.ChatCompletionRequest
+	(*JSONSchema)(nil),              // 9: openaiclient.v1.JSONSchema
+	(*Choice)(nil),                  // 10: openaiclient.v1.Choice
+	(*Logprobs)(nil),                // 11: openaiclient.v1.Logprobs
+	(*TokenLogprob)(nil),            // 12: openaiclient.v1.TokenLogprob
+	(*ChatCompletionResponse)(nil),  // 13: openaiclient.v1.ChatCompletionResponse
+	(*EmbeddingRequest)(nil),        // 14: openaiclient.v1.EmbeddingRequest
+	(*TokenArray)(nil),              // 15: openaiclient.v1.TokenArray
+	(*Embedding)(nil),               // 16: openaiclient.v1.Embedding
+	(*EmbeddingResponse)(nil),       // 17: openaiclient.v1.EmbeddingResponse
+	(*ModerationRequest)(nil),       // 18: openaiclient.v1.ModerationRequest
+	(*ModerationResult)(nil),        // 19: openaiclient.v1.ModerationResult
+	(*ModerationResponse)(nil),      // 20: openaiclient.v1.ModerationResponse
+	nil,                             // 21: openaiclient.v1.ChatCompletionRequest.LogitBiasEntry
+	nil,                             // 22: openaiclient.v1.ChatCompletionRequest.MetadataEntry
+	nil,                             // 23: openaiclient.v1.ModerationResult.CategoriesEntry
+	nil,                             // 24: openaiclient.v1.ModerationResult.CategoryScoresEntry
+}
+var file_openaiclient_proto_depIdxs = []int32{
+	2,  // 0: openaiclient.v1.Message.tool_calls:type_name -> openaiclient.v1.ToolCall
+	1,  // 1: openaiclient.v1.Message.parts:type_name -> openaiclient.v1.ContentPart
+	6,  // 2: openaiclient.v1.Usage.prompt_tokens_details:type_name -> openaiclient.v1.PromptTokensDetails
+	7,  // 3: openaiclient.v1.Usage.completion_tokens_details:type_name -> openaiclient.v1.CompletionTokensDetails
+	0,  // 4: openaiclient.v1.ChatCompletionRequest.messages:type_name -> openaiclient.v1.Message
+	21, // 5: openaiclient.v1.ChatCompletionRequest.logit_bias:type_name -> openaiclient.v1.ChatCompletionRequest.LogitBiasEntry
+	3,  // 6: openaiclient.v1.ChatCompletionRequest.tools:type_name -> openaiclient.v1.Tool
+	4,  // 7: openaiclient.v1.ChatCompletionRequest.tool_choice:type_name -> openaiclient.v1.ToolChoice
+	9,  // 8: openaiclient.v1.ChatCompletionRequest.json_schema:type_name -> openaiclient.v1.JSONSchema
+	22, // 9: openaiclient.v1.ChatCompletionRequest.metadata:type_name -> openaiclient.v1.ChatCompletionRequest.MetadataEntry
+	0,  // 10: openaiclient.v1.Choice.message:type_name -> openaiclient.v1.Message
+	11, // 11: openaiclient.v1.Choice.logprobs:type_name -> openaiclient.v1.Logprobs
+	12, // 12: openaiclient.v1.Logprobs.content:type_name -> openaiclient.v1.TokenLogprob
+	12, // 13: openaiclient.v1.Logprobs.refusal:type_name -> openaiclient.v1.TokenLogprob
+	12, // 14: openaiclient.v1.TokenLogprob.top_logprobs:type_name -> openaiclient.v1.TokenLogprob
+	10, // 15: openaiclient.v1.ChatCompletionResponse.choices:type_name -> openaiclient.v1.Choice
+	5,  // 16: openaiclient.v1.ChatCompletionResponse.usage:type_name -> openaiclient.v1.Usage
+	15, // 17: openaiclient.v1.EmbeddingRequest.tokens:type_name -> openaiclient.v1.TokenArray
+	16, // 18: openaiclient.v1.EmbeddingResponse.data:type_name -> openaiclient.v1.Embedding
+	5,  // 19: openaiclient.v1.EmbeddingResponse.usage:type_name -> openaiclient.v1.Usage
+	23, // 20: openaiclient.v1.ModerationResult.categories:type_name -> openaiclient.v1.ModerationResult.CategoriesEntry
+	24, // 21: openaiclient.v1.ModerationResult.category_scores:type_name -> openaiclient.v1.ModerationResult.CategoryScoresEntry
+	19, // 22: openaiclient.v1.ModerationResponse.results:type_name -> openaiclient.v1.ModerationResult
+	8,  // 23: openaiclient.v1.OpenAI.CreateChatCompletion:input_type -> openaiclient.v1.ChatCompletionRequest
+	14, // 24: openaiclient.v1.OpenAI.CreateEmbedding:input_type -> openaiclient.v1.EmbeddingRequest
+	18, // 25: openaiclient.v1.OpenAI.CreateModeration:input_type -> openaiclient.v1.ModerationRequest
+	13, // 26: openaiclient.v1.OpenAI.CreateChatCompletion:output_type -> openaiclient.v1.ChatCompletionResponse
+	17, // 27: openaiclient.v1.OpenAI.CreateEmbedding:output_type -> openaiclient.v1.EmbeddingResponse
+	20, // 28: openaiclient.v1.OpenAI.CreateModeration:output_type -> openaiclient.v1.ModerationResponse
+	26, // [26:29] is the sub-list for method output_type
+	23, // [23:26] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
+}
+
+func init() { file_openaiclient_proto_init() }
+func file_openaiclient_proto_init() {
+	if File_openaiclient_proto != nil {
+		return
+	}
+	file_openaiclient_proto_msgTypes[8].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_openaiclient_proto_rawDesc), len(file_openaiclient_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_openaiclient_proto_goTypes,
+		DependencyIndexes: file_openaiclient_proto_depIdxs,
+		MessageInfos:      file_openaiclient_proto_msgTypes,
+	}.Build()
+	File_openaiclient_proto = out.File
+	file_openaiclient_proto_goTypes = nil
+	file_openaiclient_proto_depIdxs = nil
+}
@@ -0,0 +1,147 @@
+// Package openaigo converts between openaiclient types and the types of the
+// official OpenAI Go SDK (github.com/openai/openai-go), so both libraries can
+// be used side by side against shared message stores.
+//
+// Both libraries serialize to the OpenAI wire format, so conversions go
+// through JSON and preserve every field the two type sets have in common.
+package openaigo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/alesr/openaiclient"
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/shared"
+)
+
+// ToChatCompletionParams converts a chat request to the SDK's request parameters.
+func ToChatCompletionParams(in openaiclient.ChatCompletionRequest) (openai.ChatCompletionNewParams, error) {
+	var out openai.ChatCompletionNewParams
+	if err := convert(in, &out); err != nil {
+		return openai.ChatCompletionNewParams{}, err
+	}
+
+	if in.ResponseFormat != nil {
+		format, err := toResponseFormatParam(*in.ResponseFormat)
+		if err != nil {
+			return openai.ChatCompletionNewParams{}, err
+		}
+		out.ResponseFormat = format
+	}
+	return out, nil
+}
+
+// toResponseFormatParam converts the response format to the SDK's union, which
+// decodes every format as text through JSON.
+func toResponseFormatParam(in openaiclient.ResponseFormat) (openai.ChatCompletionNewParamsResponseFormatUnion, error) {
+	switch in.Type {
+	case openaiclient.ResponseFormatJSONObject:
+		return openai.ChatCompletionNewParamsResponseFormatUnion{OfJSONObject: &shared.ResponseFormatJSONObjectParam{}}, nil
+	case openaiclient.ResponseFormatJSONSchema:
+		var format shared.ResponseFormatJSONSchemaParam
+		if err := convert(in, &format); err != nil {
+			return openai.ChatCompletionNewParamsResponseFormatUnion{}, err
+		}
+		return openai.ChatCompletionNewParamsResponseFormatUnion{OfJSONSchema: &format}, nil
+	default:
+		return openai.ChatCompletionNewParamsResponseFormatUnion{OfText: &shared.ResponseFormatTextParam{}}, nil
+	}
+}
+
+// FromChatCompletionParams converts the SDK's request parameters to a chat request.
+func FromChatCompletionParams(in openai.ChatCompletionNewParams) (openaiclient.ChatCompletionRequest, error) {
+	var out openaiclient.ChatCompletionRequest
+	if err := convert(in, &out); err != nil {
+		return openaiclient.ChatCompletionRequest{}, err
+	}
+	return out, nil
+}
+
+// ToMessageParams converts messages to the SDK's message parameters.
+func ToMessageParams(in []openaiclient.Message) ([]openai.ChatCompletionMessageParamUnion, error) {
+	var out []openai.ChatCompletionMessageParamUnion
+	if err := convert(in, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FromMessageParams converts the SDK's message parameters to messages.
+func FromMessageParams(in []openai.ChatCompletionMessageParamUnion) ([]openaiclient.Message, error) {
+	var out []openaiclient.Message
+	if err := convert(in, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FromChatCompletion converts the SDK's chat completion to a chat response.
+func FromChatCompletion(in openai.ChatCompletion) (*openaiclient.ChatCompletionResponse, error) {
+	var out openaiclient.ChatCompletionResponse
+	if err := convertRaw(in.RawJSON(), in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ToChatCompletion converts a chat response to the SDK's chat completion.
+func ToChatCompletion(in openaiclient.ChatCompletionResponse) (openai.ChatCompletion, error) {
+	var out openai.ChatCompletion
+	if err := convert(in, &out); err != nil {
+		return openai.ChatCompletion{}, err
+	}
+	return out, nil
+}
+
+// ToEmbeddingParams converts an embedding request to the SDK's request parameters.
+func ToEmbeddingParams(in openaiclient.EmbeddingRequest) (openai.EmbeddingNewParams, error) {
+	var out openai.EmbeddingNewParams
+	if err := convert(in, &out); err != nil {
+		return openai.EmbeddingNewParams{}, err
+	}
+	return out, nil
+}
+
+// FromEmbeddingParams converts the SDK's request parameters to an embedding request.
+func FromEmbeddingParams(in openai.EmbeddingNewParams) (openaiclient.EmbeddingRequest, error) {
+	var out openaiclient.EmbeddingRequest
+	if err := convert(in, &out); err != nil {
+		return openaiclient.EmbeddingRequest{}, err
+	}
+	return out, nil
+}
+
+// FromEmbeddingResponse converts the SDK's embedding response to an embedding response.
+func FromEmbeddingResponse(in openai.CreateEmbeddingResponse) (*openaiclient.EmbeddingResponse, error) {
+	var out openaiclient.EmbeddingResponse
+	if err := convertRaw(in.RawJSON(), in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// convert copies in to out through their JSON representation.
+func convert(in, out any) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("could not marshal data: %w", err)
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("could not unmarshal data: %w", err)
+	}
+	return nil
+}
+
+// convertRaw converts an SDK response, preferring the raw JSON it was decoded from.
+func convertRaw(raw string, in, out any) error {
+	if raw == "" {
+		return convert(in, out)
+	}
+
+	if err := json.Unmarshal([]byte(raw), out); err != nil {
+		return fmt.Errorf("could not unmarshal data: %w", err)
+	}
+	return nil
+}
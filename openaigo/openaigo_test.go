@@ -0,0 +1,227 @@
+package openaigo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alesr/openaiclient"
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatCompletionParams(t *testing.T) {
+	t.Parallel()
+
+	req := openaiclient.ChatCompletionRequest{
+		Model: "test_model",
+		Messages: []openaiclient.Message{
+			{Role: "system", Content: "test_system"},
+			{Role: "user", Content: "test_user"},
+			{Role: "assistant", Content: "test_assistant"},
+		},
+	}
+
+	params, err := ToChatCompletionParams(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test_model", params.Model)
+	require.Len(t, params.Messages, 3)
+	require.NotNil(t, params.Messages[0].OfSystem)
+	require.NotNil(t, params.Messages[1].OfUser)
+	require.NotNil(t, params.Messages[2].OfAssistant)
+	assert.Equal(t, "test_user", params.Messages[1].OfUser.Content.OfString.Value)
+
+	back, err := FromChatCompletionParams(params)
+	require.NoError(t, err)
+	assert.Equal(t, req, back)
+}
+
+func TestChatCompletionParams_Parameters(t *testing.T) {
+	t.Parallel()
+
+	req := openaiclient.ChatCompletionRequest{
+		Model:       "test_model",
+		Messages:    []openaiclient.Message{{Role: "user", Content: "test_user"}},
+		Temperature: openaiclient.Ptr(0.0),
+		MaxTokens:   100,
+		Stop:        []string{"END"},
+		Seed:        openaiclient.Ptr(42),
+		User:        "user-1",
+	}
+
+	params, err := ToChatCompletionParams(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0.0, params.Temperature.Value)
+	assert.True(t, params.Temperature.Valid())
+	assert.Equal(t, int64(100), params.MaxTokens.Value)
+	assert.Equal(t, int64(42), params.Seed.Value)
+
+	back, err := FromChatCompletionParams(params)
+	require.NoError(t, err)
+	assert.Equal(t, req, back)
+}
+
+func TestChatCompletionParams_Tools(t *testing.T) {
+	t.Parallel()
+
+	req := openaiclient.ChatCompletionRequest{
+		Model: "test_model",
+		Messages: []openaiclient.Message{
+			{Role: "user", Content: "Weather?"},
+			{Role: "assistant", ToolCalls: []openaiclient.ToolCall{
+				{ID: "call_1", Type: "function", Function: openaiclient.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}},
+			}},
+			{Role: "tool", ToolCallID: "call_1", Content: "sunny"},
+		},
+		Tools:      []openaiclient.Tool{openaiclient.NewFunctionTool("weather", "Get weather", []byte(`{"type":"object"}`))},
+		ToolChoice: openaiclient.ToolChoiceFunction("weather"),
+	}
+
+	params, err := ToChatCompletionParams(req)
+	require.NoError(t, err)
+
+	require.Len(t, params.Tools, 1)
+	assert.Equal(t, "weather", params.Tools[0].Function.Name)
+	require.NotNil(t, params.Messages[1].OfAssistant)
+	assert.Equal(t, "call_1", params.Messages[1].OfAssistant.ToolCalls[0].ID)
+	require.NotNil(t, params.Messages[2].OfTool)
+	assert.Equal(t, "call_1", params.Messages[2].OfTool.ToolCallID)
+
+	back, err := FromChatCompletionParams(params)
+	require.NoError(t, err)
+	assert.Equal(t, req, back)
+}
+
+func TestChatCompletionParams_Parts(t *testing.T) {
+	t.Parallel()
+
+	req := openaiclient.ChatCompletionRequest{
+		Model: "test_model",
+		Messages: []openaiclient.Message{{Role: "user", Parts: []openaiclient.ContentPart{
+			openaiclient.TextPart("What is this?"),
+			openaiclient.ImagePart("https://example.com/cat.png", openaiclient.ImageDetailLow),
+		}}},
+	}
+
+	params, err := ToChatCompletionParams(req)
+	require.NoError(t, err)
+
+	require.Len(t, params.Messages, 1)
+	require.NotNil(t, params.Messages[0].OfUser)
+	parts := params.Messages[0].OfUser.Content.OfArrayOfContentParts
+	require.Len(t, parts, 2)
+	assert.Equal(t, "What is this?", parts[0].OfText.Text)
+	assert.Equal(t, "https://example.com/cat.png", parts[1].OfImageURL.ImageURL.URL)
+
+	back, err := FromChatCompletionParams(params)
+	require.NoError(t, err)
+	assert.Equal(t, req, back)
+}
+
+func TestChatCompletionParams_JSONSchema(t *testing.T) {
+	t.Parallel()
+
+	req := openaiclient.ChatCompletionRequest{
+		Model:          "test_model",
+		Messages:       []openaiclient.Message{{Role: "user", Content: "test_user"}},
+		ResponseFormat: openaiclient.JSONSchemaFormat("answer", json.RawMessage(`{"type":"object"}`), true),
+	}
+
+	params, err := ToChatCompletionParams(req)
+	require.NoError(t, err)
+
+	require.NotNil(t, params.ResponseFormat.OfJSONSchema)
+	assert.Equal(t, "answer", params.ResponseFormat.OfJSONSchema.JSONSchema.Name)
+
+	back, err := FromChatCompletionParams(params)
+	require.NoError(t, err)
+	assert.Equal(t, req, back)
+}
+
+func TestMessageParams(t *testing.T) {
+	t.Parallel()
+
+	params := []openai.ChatCompletionMessageParamUnion{
+		openai.SystemMessage("test_system"),
+		openai.UserMessage("test_user"),
+	}
+
+	msgs, err := FromMessageParams(params)
+	require.NoError(t, err)
+
+	assert.Equal(t, []openaiclient.Message{
+		{Role: "system", Content: "test_system"},
+		{Role: "user", Content: "test_user"},
+	}, msgs)
+
+	back, err := ToMessageParams(msgs)
+	require.NoError(t, err)
+	require.Len(t, back, 2)
+	assert.Equal(t, "test_system", back[0].OfSystem.Content.OfString.Value)
+}
+
+func TestChatCompletion(t *testing.T) {
+	t.Parallel()
+
+	var completion openai.ChatCompletion
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": "test_id",
+		"object": "chat.completion",
+		"model": "test_model",
+		"created": 123,
+		"choices": [{"index": 0, "finish_reason": "stop", "message": {"role": "assistant", "content": "test_content"}}],
+		"usage": {"prompt_tokens": 1, "completion_tokens": 1, "total_tokens": 2}
+	}`), &completion))
+
+	resp, err := FromChatCompletion(completion)
+	require.NoError(t, err)
+
+	assert.Equal(t, &openaiclient.ChatCompletionResponse{
+		ID:      "test_id",
+		Object:  "chat.completion",
+		Model:   "test_model",
+		Created: 123,
+		Choices: []openaiclient.Choice{
+			{
+				Index:        0,
+				FinishReason: "stop",
+				Message:      openaiclient.Message{Role: "assistant", Content: "test_content"},
+			},
+		},
+		Usage: openaiclient.Usage{PromptTokens: 1, CompletionTokens: 1, TotalTokens: 2},
+	}, resp)
+
+	back, err := ToChatCompletion(*resp)
+	require.NoError(t, err)
+	assert.Equal(t, "test_content", back.Choices[0].Message.Content)
+	assert.Equal(t, int64(2), back.Usage.TotalTokens)
+}
+
+func TestEmbedding(t *testing.T) {
+	t.Parallel()
+
+	req := openaiclient.EmbeddingRequest{Model: "test_model", Input: openaiclient.TextInput("test_input"), Dimensions: 256, EncodingFormat: openaiclient.EncodingFormatBase64}
+
+	params, err := ToEmbeddingParams(req)
+	require.NoError(t, err)
+	assert.Equal(t, "test_input", params.Input.OfString.Value)
+
+	back, err := FromEmbeddingParams(params)
+	require.NoError(t, err)
+	assert.Equal(t, req, back)
+
+	var created openai.CreateEmbeddingResponse
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"object": "list",
+		"model": "test_model",
+		"data": [{"object": "embedding", "index": 0, "embedding": [0.5, 0.25]}],
+		"usage": {"prompt_tokens": 2, "total_tokens": 2}
+	}`), &created))
+
+	resp, err := FromEmbeddingResponse(created)
+	require.NoError(t, err)
+	assert.Equal(t, []float32{0.5, 0.25}, resp.Data[0].Embedding)
+	assert.Equal(t, 2, resp.Usage.TotalTokens)
+}
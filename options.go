@@ -0,0 +1,19 @@
+package openaiclient
+
+// Option configures optional behaviour of the Client.
+type Option func(*Client)
+
+// WithOrganization sets the organization sent in the OpenAI-Organization header.
+func WithOrganization(org string) Option {
+	return func(c *Client) {
+		c.organization = org
+	}
+}
+
+// WithTokenBudget caps the total number of tokens the client may consume.
+// Once the budget is spent, requests fail with ErrBudgetExceeded.
+func WithTokenBudget(tokens int) Option {
+	return func(c *Client) {
+		c.usage.budget = tokens
+	}
+}
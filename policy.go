@@ -0,0 +1,145 @@
+package openaiclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrPolicyViolation is matched by errors.Is for every PolicyError.
+var ErrPolicyViolation = errors.New("policy violation")
+
+// Policy actions on violations that can be corrected.
+const (
+	// PolicyCorrect clamps the offending parameters and sends the request.
+	PolicyCorrect PolicyAction = iota
+	// PolicyReject fails the request with a PolicyError.
+	PolicyReject
+)
+
+type (
+	// PolicyAction is what a policy does with a correctable violation.
+	PolicyAction int
+
+	// Policy constrains the chat completion requests sent to a model.
+	// Banned models and missing metadata can't be corrected and are always rejected.
+	Policy struct {
+		// MaxTemperature is the highest temperature allowed, if set.
+		MaxTemperature *float64
+		// MaxTokens is the highest max_tokens allowed; requests without
+		// max_tokens are sent with it. Zero means no limit.
+		MaxTokens int
+		// Banned rejects every request to the model.
+		Banned bool
+		// RequiredMetadata are the metadata keys every request must set.
+		RequiredMetadata []string
+		// Action is what to do with the violations of the parameter limits.
+		Action PolicyAction
+		// OnCorrect is called with the violations corrected in a request, if set.
+		OnCorrect func(model string, violations []PolicyViolation)
+	}
+
+	// PolicyViolation is a request parameter violating a policy.
+	PolicyViolation struct {
+		// Field is the JSON name of the parameter, such as "temperature".
+		Field   string
+		Message string
+	}
+
+	// PolicyError is returned for requests violating the policy of their model.
+	PolicyError struct {
+		Model      string
+		Violations []PolicyViolation
+	}
+
+	// policies are the policies of a client, by model.
+	policies map[string]Policy
+)
+
+// WithPolicy applies the policy to every chat completion request to the
+// model, including its dated snapshots, after aliases are resolved. The
+// policy of the empty model applies to models without a policy of their own.
+func WithPolicy(model string, policy Policy) Option {
+	return func(c *Client) {
+		if c.policies == nil {
+			c.policies = policies{}
+		}
+		c.policies[model] = policy
+	}
+}
+
+// Error implements the error interface.
+func (e *PolicyError) Error() string {
+	violations := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		violations = append(violations, v.Field+": "+v.Message)
+	}
+	return fmt.Sprintf("policy violation for model %q: %s", e.Model, strings.Join(violations, "; "))
+}
+
+// Is reports whether target is ErrPolicyViolation.
+func (e *PolicyError) Is(target error) bool {
+	return target == ErrPolicyViolation
+}
+
+// policy returns the policy of the model, if any.
+func (p policies) policy(model string) (Policy, bool) {
+	if policy, ok := lookupModel(p, model); ok {
+		return policy, true
+	}
+	policy, ok := p[""]
+	return policy, ok
+}
+
+// checkModel returns a PolicyError if the model is banned.
+func (p policies) checkModel(model string) error {
+	if policy, ok := p.policy(model); ok && policy.Banned {
+		return &PolicyError{Model: model, Violations: []PolicyViolation{{Field: "model", Message: "model is banned"}}}
+	}
+	return nil
+}
+
+// apply applies the policy of the model of the request, correcting it in
+// place or returning a PolicyError.
+func (p policies) apply(in *ChatCompletionRequest) error {
+	policy, ok := p.policy(in.Model)
+	if !ok {
+		return nil
+	}
+
+	if err := p.checkModel(in.Model); err != nil {
+		return err
+	}
+
+	var rejected, corrected []PolicyViolation
+	for _, key := range policy.RequiredMetadata {
+		if _, ok := in.Metadata[key]; !ok {
+			rejected = append(rejected, PolicyViolation{Field: "metadata", Message: fmt.Sprintf("missing required key %q", key)})
+		}
+	}
+
+	if max := policy.MaxTemperature; max != nil && in.Temperature != nil && *in.Temperature > *max {
+		corrected = append(corrected, PolicyViolation{Field: "temperature", Message: fmt.Sprintf("%g exceeds the maximum of %g", *in.Temperature, *max)})
+	}
+	if policy.MaxTokens > 0 && in.MaxTokens > policy.MaxTokens {
+		corrected = append(corrected, PolicyViolation{Field: "max_tokens", Message: fmt.Sprintf("%d exceeds the maximum of %d", in.MaxTokens, policy.MaxTokens)})
+	}
+
+	if policy.Action == PolicyReject {
+		rejected = append(rejected, corrected...)
+	}
+	if len(rejected) > 0 {
+		return &PolicyError{Model: in.Model, Violations: rejected}
+	}
+
+	if max := policy.MaxTemperature; max != nil && in.Temperature != nil && *in.Temperature > *max {
+		in.Temperature = Ptr(*max)
+	}
+	if policy.MaxTokens > 0 && (in.MaxTokens == 0 || in.MaxTokens > policy.MaxTokens) {
+		in.MaxTokens = policy.MaxTokens
+	}
+	if len(corrected) > 0 && policy.OnCorrect != nil {
+		policy.OnCorrect(in.Model, corrected)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Policy(t *testing.T) {
+	t.Parallel()
+
+	messages := []Message{{Role: RoleUser, Content: "Hi"}}
+
+	tests := []struct {
+		name           string
+		policy         Policy
+		in             ChatCompletionRequest
+		wantErr        []PolicyViolation
+		wantSent       map[string]any
+		wantCorrection []string
+	}{
+		{
+			name:           "clamps parameters",
+			policy:         Policy{MaxTemperature: Ptr(1.0), MaxTokens: 512},
+			in:             ChatCompletionRequest{Model: GPT4o, Messages: messages, Temperature: Ptr(1.8), MaxTokens: 4096},
+			wantSent:       map[string]any{"temperature": 1.0, "max_tokens": 512.0},
+			wantCorrection: []string{"temperature", "max_tokens"},
+		},
+		{
+			name:     "sets unset max tokens",
+			policy:   Policy{MaxTemperature: Ptr(1.0), MaxTokens: 512},
+			in:       ChatCompletionRequest{Model: "gpt-4o-2024-08-06", Messages: messages},
+			wantSent: map[string]any{"max_tokens": 512.0},
+		},
+		{
+			name:    "rejects parameters",
+			policy:  Policy{MaxTemperature: Ptr(1.0), Action: PolicyReject},
+			in:      ChatCompletionRequest{Model: GPT4o, Messages: messages, Temperature: Ptr(1.8)},
+			wantErr: []PolicyViolation{{Field: "temperature", Message: "1.8 exceeds the maximum of 1"}},
+		},
+		{
+			name:    "banned model",
+			policy:  Policy{Banned: true},
+			in:      ChatCompletionRequest{Model: GPT4o, Messages: messages},
+			wantErr: []PolicyViolation{{Field: "model", Message: "model is banned"}},
+		},
+		{
+			name:    "missing metadata",
+			policy:  Policy{RequiredMetadata: []string{"feature", "team"}},
+			in:      ChatCompletionRequest{Model: GPT4o, Messages: messages, Metadata: map[string]string{"team": "search"}},
+			wantErr: []PolicyViolation{{Field: "metadata", Message: `missing required key "feature"`}},
+		},
+		{
+			name:     "required metadata",
+			policy:   Policy{RequiredMetadata: []string{"feature"}},
+			in:       ChatCompletionRequest{Model: GPT4o, Messages: messages, Metadata: map[string]string{"feature": "search"}},
+			wantSent: map[string]any{"metadata": map[string]any{"feature": "search"}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var corrected []string
+			tt.policy.OnCorrect = func(model string, violations []PolicyViolation) {
+				assert.Equal(t, tt.in.Model, model)
+				for _, v := range violations {
+					corrected = append(corrected, v.Field)
+				}
+			}
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					var sent map[string]any
+					require.NoError(t, json.NewDecoder(req.Body).Decode(&sent))
+					for key, want := range tt.wantSent {
+						assert.Equal(t, want, sent[key], key)
+					}
+					return jsonResponse(t, map[string]any{"choices": []any{}}), nil
+				},
+			}, WithPolicy(GPT4o, tt.policy))
+
+			_, err := client.CreateChatCompletion(context.Background(), tt.in)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, ErrPolicyViolation)
+
+				var policyErr *PolicyError
+				require.True(t, errors.As(err, &policyErr))
+				assert.Equal(t, tt.in.Model, policyErr.Model)
+				assert.Equal(t, tt.wantErr, policyErr.Violations)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCorrection, corrected)
+		})
+	}
+}
+
+func TestClient_PolicyDefault(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(t, map[string]any{"choices": []any{}}), nil
+		},
+	}, WithPolicy("", Policy{Banned: true}), WithPolicy(GPT4oMini, Policy{}))
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: GPT4oMini})
+	require.NoError(t, err)
+
+	_, err = client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: GPT4o})
+	assert.ErrorIs(t, err, ErrPolicyViolation)
+
+	_, err = client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: GPT4o})
+	assert.ErrorIs(t, err, ErrPolicyViolation)
+
+	_, err = client.CreateEmbedding(context.Background(), EmbeddingRequest{Model: TextEmbedding3Small, Input: TextInput("test_input")})
+	assert.ErrorIs(t, err, ErrPolicyViolation)
+	assert.EqualError(t, err, `policy violation for model "text-embedding-3-small": model: model is banned`)
+}
@@ -0,0 +1,143 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultPollInterval is the delay between two polls by default.
+const DefaultPollInterval = time.Second
+
+// ErrPollTimeout is matched by errors.Is for every PollTimeoutError.
+var ErrPollTimeout = errors.New("poll timeout")
+
+type (
+	// PollOption configures the pollers, such as PollRun.
+	PollOption func(*pollConfig)
+
+	pollConfig struct {
+		interval       time.Duration
+		maxDuration    time.Duration
+		requestTimeout time.Duration
+	}
+
+	// PollTimeoutError is returned when polling times out, either because of the
+	// poll maximum duration or the deadline of the context, along with the last
+	// status polled, if any.
+	PollTimeoutError struct {
+		Polls   int
+		Elapsed time.Duration
+		// Err is the error of the last poll, if it failed.
+		Err error
+	}
+)
+
+// Error implements the error interface.
+func (e *PollTimeoutError) Error() string {
+	msg := fmt.Sprintf("poll timeout after %d polls in %s", e.Polls, e.Elapsed.Round(time.Millisecond))
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Is reports whether target is ErrPollTimeout or context.DeadlineExceeded.
+func (e *PollTimeoutError) Is(target error) bool {
+	return target == ErrPollTimeout || target == context.DeadlineExceeded
+}
+
+// Unwrap returns the error of the last poll.
+func (e *PollTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// WithPollInterval sets the delay between two polls.
+func WithPollInterval(d time.Duration) PollOption {
+	return func(c *pollConfig) {
+		c.interval = d
+	}
+}
+
+// WithMaxPollDuration stops polling after d, on top of the context deadline.
+func WithMaxPollDuration(d time.Duration) PollOption {
+	return func(c *pollConfig) {
+		c.maxDuration = d
+	}
+}
+
+// WithPollRequestTimeout bounds every poll request. A poll timing out is
+// retried on the next iteration instead of ending the polling.
+func WithPollRequestTimeout(d time.Duration) PollOption {
+	return func(c *pollConfig) {
+		c.requestTimeout = d
+	}
+}
+
+// poll fetches the status until it is no longer pending. Every poll derives
+// its deadline from ctx; on timeout, the last status is returned along with a
+// PollTimeoutError. Other errors are returned as is, with the last status.
+func poll[T any](ctx context.Context, c *Client, opts []PollOption, fetch func(context.Context) (T, error), pending func(T) bool) (T, error) {
+	cfg := pollConfig{interval: DefaultPollInterval}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	start := time.Now()
+
+	pollCtx := ctx
+	if cfg.maxDuration > 0 {
+		var cancel context.CancelFunc
+		pollCtx, cancel = context.WithTimeout(ctx, cfg.maxDuration)
+		defer cancel()
+	}
+
+	var (
+		last  T
+		polls int
+	)
+
+	timeout := func(err error) (T, error) {
+		if ctx.Err() == context.Canceled {
+			return last, ctx.Err()
+		}
+		// The deadline is implied by the timeout.
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = nil
+		}
+		return last, &PollTimeoutError{Polls: polls, Elapsed: time.Since(start), Err: err}
+	}
+
+	for {
+		if pollCtx.Err() != nil {
+			return timeout(nil)
+		}
+
+		reqCtx, cancel := pollCtx, context.CancelFunc(func() {})
+		if cfg.requestTimeout > 0 {
+			reqCtx, cancel = context.WithTimeout(pollCtx, cfg.requestTimeout)
+		}
+
+		status, err := fetch(reqCtx)
+		requestTimedOut := reqCtx.Err() != nil && pollCtx.Err() == nil
+		cancel()
+		polls++
+
+		switch {
+		case err == nil:
+			last = status
+			if !pending(status) {
+				return status, nil
+			}
+		case pollCtx.Err() != nil:
+			return timeout(err)
+		case !requestTimedOut:
+			return last, err
+		}
+
+		if err := c.sleep(pollCtx, cfg.interval); err != nil {
+			return timeout(nil)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package openaiclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoll_MaxDuration(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(t, Run{ID: "run_1", Status: RunStatusInProgress}), nil
+		},
+	}, WithBeta(BetaAssistants))
+
+	run, err := client.PollRun(context.Background(), "thread_1", "run_1",
+		WithPollInterval(5*time.Millisecond),
+		WithMaxPollDuration(30*time.Millisecond),
+	)
+
+	var timeoutErr *PollTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.ErrorIs(t, err, ErrPollTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Positive(t, timeoutErr.Polls)
+	assert.NoError(t, timeoutErr.Err)
+
+	// The last polled status is returned.
+	require.NotNil(t, run)
+	assert.Equal(t, RunStatusInProgress, run.Status)
+}
+
+func TestPoll_ContextDeadline(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int32
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			// The second poll hangs until the deadline.
+			if polls.Add(1) > 1 {
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			}
+			return jsonResponse(t, Run{ID: "run_1", Status: RunStatusQueued}), nil
+		},
+	}, WithBeta(BetaAssistants))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	run, err := client.PollRun(ctx, "thread_1", "run_1", WithPollInterval(time.Millisecond))
+	assert.ErrorIs(t, err, ErrPollTimeout)
+	require.NotNil(t, run)
+	assert.Equal(t, RunStatusQueued, run.Status)
+}
+
+func TestPoll_RequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int32
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			// The first poll hangs, and is retried once it times out.
+			if polls.Add(1) == 1 {
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			}
+			return jsonResponse(t, Run{ID: "run_1", Status: RunStatusCompleted}), nil
+		},
+	}, WithBeta(BetaAssistants))
+
+	run, err := client.PollRun(context.Background(), "thread_1", "run_1",
+		WithPollInterval(time.Millisecond),
+		WithPollRequestTimeout(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, RunStatusCompleted, run.Status)
+	assert.Equal(t, int32(2), polls.Load())
+}
+
+func TestPoll_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			cancel()
+			return jsonResponse(t, Run{ID: "run_1", Status: RunStatusInProgress}), nil
+		},
+	}, WithBeta(BetaAssistants))
+
+	run, err := client.PollRun(ctx, "thread_1", "run_1", WithPollInterval(time.Millisecond))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, errors.Is(err, ErrPollTimeout))
+	require.NotNil(t, run)
+	assert.Equal(t, RunStatusInProgress, run.Status)
+}
+
+func TestPoll_Error(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"error":{"message":"No run found"}}`)),
+			}, nil
+		},
+	}, WithBeta(BetaAssistants))
+
+	run, err := client.PollRun(context.Background(), "thread_1", "run_1")
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.False(t, errors.Is(err, ErrPollTimeout))
+	assert.Nil(t, run)
+}
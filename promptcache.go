@@ -0,0 +1,46 @@
+package openaiclient
+
+import "fmt"
+
+// PromptPrefix is a stable prefix of messages, such as the system prompt and
+// few-shot examples, sent unchanged at the start of requests. The API caches
+// prompt prefixes, so requests that share one, and the same tools, are served
+// their prefix from the prompt cache, as reported by the cached tokens of the
+// usage. Keep content varying between requests after the prefix.
+type PromptPrefix struct {
+	messages []Message
+	key      string
+}
+
+// NewPromptPrefix returns the prefix of the messages, keyed by their hash.
+func NewPromptPrefix(messages ...Message) (*PromptPrefix, error) {
+	hash, err := CanonicalHash(messages)
+	if err != nil {
+		return nil, fmt.Errorf("could not hash prompt prefix: %w", err)
+	}
+	return &PromptPrefix{messages: append([]Message(nil), messages...), key: "prefix-" + hash[:16]}, nil
+}
+
+// Key returns the prompt cache key of the prefix, the same for every prefix
+// of the same messages.
+func (p *PromptPrefix) Key() string {
+	return p.key
+}
+
+// Messages returns the messages of the prefix.
+func (p *PromptPrefix) Messages() []Message {
+	return append([]Message(nil), p.messages...)
+}
+
+// Apply returns the request with the prefix messages before its own and,
+// unless it sets one, the prompt cache key of the prefix.
+func (p *PromptPrefix) Apply(in ChatCompletionRequest) ChatCompletionRequest {
+	messages := make([]Message, 0, len(p.messages)+len(in.Messages))
+	messages = append(messages, p.messages...)
+	in.Messages = append(messages, in.Messages...)
+
+	if in.PromptCacheKey == "" {
+		in.PromptCacheKey = p.key
+	}
+	return in
+}
@@ -0,0 +1,62 @@
+package openaiclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptPrefix(t *testing.T) {
+	t.Parallel()
+
+	system := Message{Role: RoleSystem, Content: "You are a support agent."}
+	example := Message{Role: RoleUser, Content: "How do I reset my password?"}
+
+	prefix, err := NewPromptPrefix(system, example)
+	require.NoError(t, err)
+
+	same, err := NewPromptPrefix(system, example)
+	require.NoError(t, err)
+	assert.Equal(t, prefix.Key(), same.Key())
+
+	other, err := NewPromptPrefix(system)
+	require.NoError(t, err)
+	assert.NotEqual(t, prefix.Key(), other.Key())
+
+	got := prefix.Apply(ChatCompletionRequest{Model: "gpt-4o", Messages: []Message{{Role: RoleUser, Content: "Hi"}}})
+	assert.Equal(t, []Message{system, example, {Role: RoleUser, Content: "Hi"}}, got.Messages)
+	assert.Equal(t, prefix.Key(), got.PromptCacheKey)
+	assert.Len(t, prefix.Messages(), 2)
+
+	got = prefix.Apply(ChatCompletionRequest{PromptCacheKey: "tenant-1"})
+	assert.Equal(t, "tenant-1", got.PromptCacheKey)
+}
+
+func TestConversation_PromptPrefix(t *testing.T) {
+	t.Parallel()
+
+	prefix, err := NewPromptPrefix(Message{Role: RoleSystem, Content: "test_system"})
+	require.NoError(t, err)
+
+	var requests []ChatCompletionRequest
+	conv := NewConversation(newChatClient(t, "test_reply", &requests), "test_model", WithPromptPrefix(prefix))
+
+	_, err = conv.Send(context.Background(), "first")
+	require.NoError(t, err)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, prefix.Key(), requests[0].PromptCacheKey)
+	assert.Equal(t, []Message{
+		{Role: "system", Content: "test_system"},
+		{Role: "user", Content: "first"},
+	}, requests[0].Messages)
+}
+
+func TestUsage_CacheHitRate(t *testing.T) {
+	t.Parallel()
+
+	assert.Zero(t, Usage{}.CacheHitRate())
+	assert.Equal(t, 0.75, Usage{PromptTokens: 2048, PromptTokensDetails: PromptTokensDetails{CachedTokens: 1536}}.CacheHitRate())
+}
@@ -0,0 +1,166 @@
+package openaiclient
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// stopwords are low-information English words removed by the prompt compressor.
+var stopwords = toSet(strings.Fields(`a an the and or but if then else of to in on at by for with
+	about from into onto over under as is are was were be been being am do does did have has had
+	this that these those it its it's there here which who whom whose what so such very just also
+	can could would should may might will shall must`))
+
+var sentenceEnd = regexp.MustCompile(`[.!?]+\s+|\n+`)
+
+type (
+	// PromptCompressor shrinks oversized retrieved context to fit a token budget.
+	// Stopwords are pruned first; if the context is still too large, the
+	// sentences sharing the most terms with the query are kept.
+	PromptCompressor struct {
+		// MaxTokens is the token budget of the compressed context.
+		MaxTokens int
+	}
+
+	// PromptSavings reports the effect of a compression pass.
+	PromptSavings struct {
+		OriginalTokens   int
+		CompressedTokens int
+	}
+)
+
+// Saved returns the number of estimated tokens saved.
+func (s PromptSavings) Saved() int {
+	return s.OriginalTokens - s.CompressedTokens
+}
+
+// Compress returns the chunks compressed to fit the budget, relative to the query.
+// Chunks already within budget are returned unchanged.
+func (p PromptCompressor) Compress(query string, chunks []RetrievedChunk) ([]RetrievedChunk, PromptSavings) {
+	savings := PromptSavings{OriginalTokens: chunkTokens(chunks)}
+
+	if savings.OriginalTokens <= p.MaxTokens {
+		savings.CompressedTokens = savings.OriginalTokens
+		return chunks, savings
+	}
+
+	pruned := make([]RetrievedChunk, len(chunks))
+	for i, chunk := range chunks {
+		pruned[i] = RetrievedChunk{ID: chunk.ID, Text: pruneStopwords(chunk.Text)}
+	}
+
+	if tokens := chunkTokens(pruned); tokens <= p.MaxTokens {
+		savings.CompressedTokens = tokens
+		return pruned, savings
+	}
+
+	selected := p.selectSentences(query, pruned)
+	savings.CompressedTokens = chunkTokens(selected)
+	return selected, savings
+}
+
+// selectSentences keeps the sentences most relevant to the query within budget, in their original order.
+func (p PromptCompressor) selectSentences(query string, chunks []RetrievedChunk) []RetrievedChunk {
+	type sentence struct {
+		chunk, pos int
+		text       string
+		score      int
+		tokens     int
+	}
+
+	terms := toSet(words(query))
+
+	var sentences []sentence
+	for i, chunk := range chunks {
+		for j, text := range sentenceEnd.Split(chunk.Text, -1) {
+			if text = strings.TrimSpace(text); text == "" {
+				continue
+			}
+
+			var score int
+			for _, w := range words(text) {
+				if _, ok := terms[w]; ok {
+					score++
+				}
+			}
+			sentences = append(sentences, sentence{chunk: i, pos: j, text: text, score: score, tokens: EstimateTokens(text)})
+		}
+	}
+
+	ranked := make([]int, len(sentences))
+	for i := range ranked {
+		ranked[i] = i
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return sentences[ranked[i]].score > sentences[ranked[j]].score
+	})
+
+	var (
+		budget = p.MaxTokens
+		keep   = make([]bool, len(sentences))
+	)
+
+	for _, i := range ranked {
+		if sentences[i].tokens <= budget {
+			keep[i] = true
+			budget -= sentences[i].tokens
+		}
+	}
+
+	parts := make([][]string, len(chunks))
+	for i, s := range sentences {
+		if keep[i] {
+			parts[s.chunk] = append(parts[s.chunk], s.text)
+		}
+	}
+
+	var selected []RetrievedChunk
+	for i, chunk := range chunks {
+		if len(parts[i]) > 0 {
+			selected = append(selected, RetrievedChunk{ID: chunk.ID, Text: strings.Join(parts[i], " ")})
+		}
+	}
+	return selected
+}
+
+// pruneStopwords removes stopwords from the text, keeping sentence boundaries.
+func pruneStopwords(text string) string {
+	fields := strings.Fields(text)
+
+	kept := make([]string, 0, len(fields))
+	for _, field := range fields {
+		word := strings.ToLower(strings.TrimFunc(field, func(r rune) bool { return !unicode.IsLetter(r) && r != '\'' }))
+		// Stopwords carrying punctuation are kept so sentences stay delimited.
+		if _, ok := stopwords[word]; ok && word == strings.ToLower(field) {
+			continue
+		}
+		kept = append(kept, field)
+	}
+	return strings.Join(kept, " ")
+}
+
+// words returns the lowercase words of the text.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func chunkTokens(chunks []RetrievedChunk) int {
+	var tokens int
+	for _, chunk := range chunks {
+		tokens += EstimateTokens(chunk.Text)
+	}
+	return tokens
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,50 @@
+package openaiclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptCompressor_Compress(t *testing.T) {
+	t.Parallel()
+
+	chunks := []RetrievedChunk{
+		{ID: "doc-1", Text: "The Eiffel Tower is in Paris. It was built for the world fair of 1889."},
+		{ID: "doc-2", Text: "Bananas are rich in potassium. The tower is made of wrought iron."},
+	}
+
+	t.Run("keeps context within budget", func(t *testing.T) {
+		t.Parallel()
+
+		got, savings := PromptCompressor{MaxTokens: 1000}.Compress("tower", chunks)
+
+		assert.Equal(t, chunks, got)
+		assert.Equal(t, 0, savings.Saved())
+	})
+
+	t.Run("prunes stopwords", func(t *testing.T) {
+		t.Parallel()
+
+		got, savings := PromptCompressor{MaxTokens: 30}.Compress("tower", chunks)
+
+		assert.Equal(t, []RetrievedChunk{
+			{ID: "doc-1", Text: "Eiffel Tower Paris. built world fair 1889."},
+			{ID: "doc-2", Text: "Bananas rich potassium. tower made wrought iron."},
+		}, got)
+		assert.Greater(t, savings.Saved(), 0)
+		assert.LessOrEqual(t, savings.CompressedTokens, 30)
+	})
+
+	t.Run("selects sentences relevant to the query", func(t *testing.T) {
+		t.Parallel()
+
+		got, savings := PromptCompressor{MaxTokens: 12}.Compress("What is the tower made of?", chunks)
+
+		assert.Equal(t, []RetrievedChunk{
+			{ID: "doc-1", Text: "Eiffel Tower Paris"},
+			{ID: "doc-2", Text: "tower made wrought iron."},
+		}, got)
+		assert.LessOrEqual(t, savings.CompressedTokens, 12)
+	})
+}
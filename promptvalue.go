@@ -0,0 +1,235 @@
+package openaiclient
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Quantity is an amount with an explicit unit, such as a weight in "kg" or a
+// price in "EUR". The value is formatted like FormatPromptValue, so decimal
+// types implementing fmt.Stringer keep their exact digits.
+type Quantity struct {
+	Value any
+	Unit  string
+}
+
+// String returns the value followed by the unit, e.g. "1999.99 EUR".
+func (q Quantity) String() string {
+	return FormatPromptValue(q.Value) + " " + q.Unit
+}
+
+// PromptDate formats the date of t as an ISO 8601 calendar date, e.g. "2024-06-01".
+func PromptDate(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+var (
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// FormatPromptValue serializes v into prompt text the model can't misread,
+// independently of any locale: times are RFC 3339 timestamps with their offset,
+// numbers use a dot as decimal separator without grouping or exponents, and
+// values implementing fmt.Stringer, such as Quantity and decimal types, use
+// their String method. Structs and maps are written as indented "key: value"
+// lines, with keys named after the json tags and sorted for maps; strings are
+// quoted inside them and nil values are written as null.
+func FormatPromptValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	rv := reflect.ValueOf(v)
+
+	if !isPromptBlock(rv) {
+		return formatPromptInline(rv)
+	}
+
+	var b strings.Builder
+	writePromptBlock(&b, rv, "")
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// promptScalar formats the values having their own text format, such as times.
+func promptScalar(v reflect.Value) (string, bool) {
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), true
+	}
+	if v.Type() == durationType {
+		return v.Interface().(time.Duration).String(), true
+	}
+	if v.Type().Implements(stringerType) && v.CanInterface() {
+		return v.Interface().(fmt.Stringer).String(), true
+	}
+	return "", false
+}
+
+// derefPrompt returns the value pointers and interfaces point to, or an invalid value for nil.
+func derefPrompt(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		if _, ok := promptScalar(v); ok {
+			return v
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// isPromptBlock reports whether the value is written as lines rather than inline.
+func isPromptBlock(v reflect.Value) bool {
+	v = derefPrompt(v)
+	if !v.IsValid() {
+		return false
+	}
+	if _, ok := promptScalar(v); ok {
+		return false
+	}
+
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map:
+		return v.Kind() == reflect.Struct || v.Len() > 0
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return false
+		}
+		for i := 0; i < v.Len(); i++ {
+			if isPromptBlock(v.Index(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// formatPromptInline formats a value written on a single line.
+func formatPromptInline(v reflect.Value) string {
+	v = derefPrompt(v)
+	if !v.IsValid() {
+		return "null"
+	}
+	if s, ok := promptScalar(v); ok {
+		return s
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String())
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	case reflect.Slice, reflect.Array:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatPromptInline(v.Index(i))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Map:
+		return "{}"
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// writePromptBlock writes the lines of a struct, map or slice, indented by indent.
+func writePromptBlock(b *strings.Builder, v reflect.Value, indent string) {
+	v = derefPrompt(v)
+
+	switch v.Kind() {
+	case reflect.Struct:
+		writePromptFields(b, v, indent)
+	case reflect.Map:
+		keys := v.MapKeys()
+		names := make([]string, len(keys))
+		for i, key := range keys {
+			names[i] = fmt.Sprint(key.Interface())
+		}
+		sort.Sort(promptKeys{names: names, keys: keys})
+
+		for i, key := range keys {
+			writePromptEntry(b, names[i], v.MapIndex(key), indent)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i)
+			if !isPromptBlock(item) {
+				b.WriteString(indent + "- " + formatPromptInline(item) + "\n")
+				continue
+			}
+
+			// The first line of the item follows the dash, like YAML.
+			var lines strings.Builder
+			writePromptBlock(&lines, item, indent+"  ")
+			b.WriteString(indent + "- " + strings.TrimPrefix(lines.String(), indent+"  "))
+		}
+	}
+}
+
+// writePromptFields writes the fields of the struct, flattening embedded structs like encoding/json.
+func writePromptFields(b *strings.Builder, v reflect.Value, indent string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := derefPrompt(v.Field(i))
+			if embedded.Kind() == reflect.Struct {
+				writePromptFields(b, embedded, indent)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(opts, "omitempty") && v.Field(i).IsZero() {
+			continue
+		}
+		writePromptEntry(b, name, v.Field(i), indent)
+	}
+}
+
+// writePromptEntry writes a "key: value" line, or the key followed by the value lines.
+func writePromptEntry(b *strings.Builder, name string, v reflect.Value, indent string) {
+	if !isPromptBlock(v) {
+		b.WriteString(indent + name + ": " + formatPromptInline(v) + "\n")
+		return
+	}
+
+	b.WriteString(indent + name + ":\n")
+	writePromptBlock(b, v, indent+"  ")
+}
+
+// promptKeys sorts map keys by name.
+type promptKeys struct {
+	names []string
+	keys  []reflect.Value
+}
+
+func (k promptKeys) Len() int           { return len(k.names) }
+func (k promptKeys) Less(i, j int) bool { return k.names[i] < k.names[j] }
+func (k promptKeys) Swap(i, j int) {
+	k.names[i], k.names[j] = k.names[j], k.names[i]
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+}
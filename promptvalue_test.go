@@ -0,0 +1,94 @@
+package openaiclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	invoiceLine struct {
+		Item     string   `json:"item"`
+		Quantity int      `json:"quantity"`
+		Price    Quantity `json:"price"`
+	}
+
+	invoice struct {
+		Number   string            `json:"number"`
+		IssuedAt time.Time         `json:"issued_at"`
+		Due      string            `json:"due"`
+		Rate     float64           `json:"rate"`
+		Paid     bool              `json:"paid"`
+		Terms    time.Duration     `json:"terms"`
+		Notes    *string           `json:"notes"`
+		Tags     []string          `json:"tags,omitempty"`
+		Lines    []invoiceLine     `json:"lines"`
+		Meta     map[string]string `json:"meta"`
+		secret   string
+	}
+)
+
+func TestFormatPromptValue(t *testing.T) {
+	t.Parallel()
+
+	issued := time.Date(2024, 3, 4, 9, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{name: "string", v: "as is", want: "as is"},
+		{name: "time", v: issued, want: "2024-03-04T09:30:00+01:00"},
+		{name: "large float", v: 12345678.9, want: "12345678.9"},
+		{name: "small float", v: float32(0.000001), want: "0.000001"},
+		{name: "quantity", v: Quantity{Value: 1999.99, Unit: "EUR"}, want: "1999.99 EUR"},
+		{name: "nil", v: nil, want: "null"},
+		{name: "nil pointer", v: (*int)(nil), want: "null"},
+		{name: "inline slice", v: []any{"a", 1, true}, want: `["a", 1, true]`},
+		{name: "empty map", v: map[string]int{}, want: "{}"},
+		{
+			name: "struct",
+			v: invoice{
+				Number:   "INV-001",
+				IssuedAt: issued,
+				Due:      PromptDate(issued.AddDate(0, 1, 0)),
+				Rate:     0.2,
+				Terms:    30 * 24 * time.Hour,
+				Lines: []invoiceLine{
+					{Item: "Widget", Quantity: 3, Price: Quantity{Value: 10.5, Unit: "EUR"}},
+					{Item: "Gadget", Quantity: 1, Price: Quantity{Value: 2000, Unit: "EUR"}},
+				},
+				Meta:   map[string]string{"zone": "eu", "channel": "web"},
+				secret: "hidden",
+			},
+			want: `number: "INV-001"
+issued_at: 2024-03-04T09:30:00+01:00
+due: "2024-04-04"
+rate: 0.2
+paid: false
+terms: 720h0m0s
+notes: null
+lines:
+  - item: "Widget"
+    quantity: 3
+    price: 10.5 EUR
+  - item: "Gadget"
+    quantity: 1
+    price: 2000 EUR
+meta:
+  channel: "web"
+  zone: "eu"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, FormatPromptValue(tt.v))
+		})
+	}
+}
@@ -0,0 +1,427 @@
+// Package proxy implements an OpenAI-compatible HTTP server forwarding the
+// chat completion, embedding and moderation requests it receives through an
+// openaiclient.Client, turning the client into a lightweight internal LLM
+// gateway: applications point their OpenAI SDK at the proxy, which holds the
+// API key and enforces the client's budget along with the proxy's model
+// allowlist, redaction and access logging.
+//
+// Requests are decoded into the client's request types, so fields the client
+// doesn't model are dropped.
+package proxy
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alesr/openaiclient"
+)
+
+// MaxBodySize is the maximum size of a request body, in bytes.
+const MaxBodySize = 8 << 20
+
+type (
+	// Option configures a Proxy.
+	Option func(*Proxy)
+
+	// Proxy is an http.Handler serving the OpenAI API with a client.
+	Proxy struct {
+		client    *openaiclient.Client
+		models    map[string]struct{}
+		redaction *openaiclient.OutputFilter
+		keys      [][]byte
+		accessLog func(AccessLog)
+		now       func() time.Time
+	}
+
+	// AccessLog describes a request served by the proxy.
+	AccessLog struct {
+		Method     string
+		Path       string
+		Model      string
+		StatusCode int
+		Duration   time.Duration
+		// Usage is the usage of the request, zero if it failed.
+		Usage openaiclient.Usage
+		// Err is the error the request failed with, if any.
+		Err error
+	}
+
+	// errorResponse is the body of an error response, as sent by the API.
+	errorResponse struct {
+		Error errorBody `json:"error"`
+	}
+
+	errorBody struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+		Param   string `json:"param,omitempty"`
+		Code    string `json:"code,omitempty"`
+	}
+
+	// streamParams are the streaming parameters of a chat completion request,
+	// which the client sets itself.
+	streamParams struct {
+		Stream        bool `json:"stream"`
+		StreamOptions *struct {
+			IncludeUsage bool `json:"include_usage"`
+		} `json:"stream_options"`
+	}
+
+	// httpError is an error sent with its status code and type.
+	httpError struct {
+		statusCode int
+		errType    string
+		code       string
+		err        error
+	}
+)
+
+// ErrModelNotAllowed is returned for requests to models outside the allowlist.
+var ErrModelNotAllowed = errors.New("model not allowed")
+
+// New creates a proxy forwarding requests through the client.
+func New(client *openaiclient.Client, opts ...Option) *Proxy {
+	p := &Proxy{client: client, now: time.Now}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// WithAllowedModels rejects requests to models other than the given ones
+// with ErrModelNotAllowed. Models are matched as requested, before the
+// client resolves aliases.
+func WithAllowedModels(models ...string) Option {
+	return func(p *Proxy) {
+		p.models = make(map[string]struct{}, len(models))
+		for _, model := range models {
+			p.models[model] = struct{}{}
+		}
+	}
+}
+
+// WithRedaction applies the filter to the text of requests before they are
+// forwarded: message contents and text parts, embedding and moderation inputs.
+func WithRedaction(filter *openaiclient.OutputFilter) Option {
+	return func(p *Proxy) {
+		p.redaction = filter
+	}
+}
+
+// WithAPIKeys requires requests to authenticate with one of the given keys,
+// as bearer tokens, instead of the API key the proxy holds.
+func WithAPIKeys(keys ...string) Option {
+	return func(p *Proxy) {
+		p.keys = make([][]byte, 0, len(keys))
+		for _, key := range keys {
+			p.keys = append(p.keys, []byte(key))
+		}
+	}
+}
+
+// WithAccessLog calls hook once every request is served.
+func WithAccessLog(hook func(AccessLog)) Option {
+	return func(p *Proxy) {
+		p.accessLog = hook
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := p.now()
+	entry := AccessLog{Method: r.Method, Path: r.URL.Path}
+
+	statusCode, err := p.serve(w, r, &entry)
+	if err != nil {
+		statusCode = writeError(w, err)
+		entry.Err = err
+	}
+	entry.StatusCode = statusCode
+
+	if p.accessLog != nil {
+		entry.Duration = p.now().Sub(start)
+		p.accessLog(entry)
+	}
+}
+
+// serve forwards the request and writes the response, returning its status
+// code. Errors are returned before the response is written; those of a
+// stream already sent are recorded into the entry.
+func (p *Proxy) serve(w http.ResponseWriter, r *http.Request, entry *AccessLog) (int, error) {
+	if !p.authorized(r) {
+		return 0, &httpError{statusCode: http.StatusUnauthorized, errType: "invalid_request_error", code: "invalid_api_key", err: errors.New("invalid API key")}
+	}
+	if r.Method != http.MethodPost {
+		return 0, &httpError{statusCode: http.StatusMethodNotAllowed, errType: "invalid_request_error", err: fmt.Errorf("method %s not allowed", r.Method)}
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	if err != nil {
+		return 0, &httpError{statusCode: http.StatusRequestEntityTooLarge, errType: "invalid_request_error", err: fmt.Errorf("could not read request: %w", err)}
+	}
+
+	switch strings.TrimPrefix(r.URL.Path, "/v1") {
+	case openaiclient.EndpointChatCompletions:
+		return p.chatCompletion(r.Context(), w, body, entry)
+	case openaiclient.EndpointEmbeddings:
+		return p.embedding(r.Context(), w, body, entry)
+	case openaiclient.EndpointModerations:
+		return p.moderation(r.Context(), w, body, entry)
+	default:
+		return 0, &httpError{statusCode: http.StatusNotFound, errType: "invalid_request_error", err: fmt.Errorf("unknown endpoint %s", r.URL.Path)}
+	}
+}
+
+// chatCompletion forwards a chat completion request, streamed or not.
+func (p *Proxy) chatCompletion(ctx context.Context, w http.ResponseWriter, body []byte, entry *AccessLog) (int, error) {
+	var in openaiclient.ChatCompletionRequest
+	if err := decode(body, &in); err != nil {
+		return 0, err
+	}
+	var params streamParams
+	if err := decode(body, &params); err != nil {
+		return 0, err
+	}
+
+	entry.Model = in.Model
+	if err := p.allow(in.Model); err != nil {
+		return 0, err
+	}
+
+	if p.redaction != nil {
+		for i := range in.Messages {
+			msg := &in.Messages[i]
+			msg.Content = p.redaction.Apply(msg.Content)
+			for j := range msg.Parts {
+				msg.Parts[j].Text = p.redaction.Apply(msg.Parts[j].Text)
+			}
+		}
+	}
+
+	if params.Stream {
+		includeUsage := params.StreamOptions != nil && params.StreamOptions.IncludeUsage
+		return p.stream(ctx, w, in, includeUsage, entry)
+	}
+
+	resp, err := p.client.CreateChatCompletion(ctx, in)
+	if err != nil {
+		return 0, err
+	}
+	entry.Usage = resp.Usage
+	return writeJSON(w, http.StatusOK, resp)
+}
+
+// stream forwards a streamed chat completion as server-sent events.
+func (p *Proxy) stream(ctx context.Context, w http.ResponseWriter, in openaiclient.ChatCompletionRequest, includeUsage bool, entry *AccessLog) (int, error) {
+	stream, err := p.client.CreateChatCompletionStream(ctx, in)
+	if err != nil {
+		return 0, err
+	}
+	defer stream.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+
+	// Keep-alives are passed through, so idle clients don't time out either.
+	stream.OnKeepalive(func(comment string) {
+		fmt.Fprintf(w, ": %s\n\n", comment)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	})
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// The status is already sent: end the stream without [DONE], as
+			// the API does when a stream fails.
+			entry.Err = err
+			return http.StatusOK, nil
+		}
+
+		if chunk.Usage != nil {
+			entry.Usage = *chunk.Usage
+			if !includeUsage {
+				continue
+			}
+		}
+
+		data, err := json.Marshal(chunk)
+		if err != nil {
+			entry.Err = fmt.Errorf("could not marshal chunk: %w", err)
+			return http.StatusOK, nil
+		}
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	return http.StatusOK, nil
+}
+
+// embedding forwards an embedding request.
+func (p *Proxy) embedding(ctx context.Context, w http.ResponseWriter, body []byte, entry *AccessLog) (int, error) {
+	var in openaiclient.EmbeddingRequest
+	if err := decode(body, &in); err != nil {
+		return 0, err
+	}
+
+	entry.Model = in.Model
+	if err := p.allow(in.Model); err != nil {
+		return 0, err
+	}
+
+	if p.redaction != nil {
+		for i := range in.Input.Texts {
+			in.Input.Texts[i] = p.redaction.Apply(in.Input.Texts[i])
+		}
+	}
+
+	resp, err := p.client.CreateEmbedding(ctx, in)
+	if err != nil {
+		return 0, err
+	}
+	entry.Usage = resp.Usage
+	return writeJSON(w, http.StatusOK, resp)
+}
+
+// moderation forwards a moderation request.
+func (p *Proxy) moderation(ctx context.Context, w http.ResponseWriter, body []byte, entry *AccessLog) (int, error) {
+	var in openaiclient.ModerationRequest
+	if err := decode(body, &in); err != nil {
+		return 0, err
+	}
+
+	entry.Model = in.Model
+	if in.Model != "" {
+		if err := p.allow(in.Model); err != nil {
+			return 0, err
+		}
+	}
+
+	if p.redaction != nil {
+		for i := range in.Input {
+			in.Input[i] = p.redaction.Apply(in.Input[i])
+		}
+	}
+
+	resp, err := p.client.CreateModeration(ctx, in)
+	if err != nil {
+		return 0, err
+	}
+	return writeJSON(w, http.StatusOK, resp)
+}
+
+// authorized reports whether the request authenticates with an allowed key.
+func (p *Proxy) authorized(r *http.Request) bool {
+	if p.keys == nil {
+		return true
+	}
+
+	key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+
+	allowed := false
+	for _, k := range p.keys {
+		if subtle.ConstantTimeCompare([]byte(key), k) == 1 {
+			allowed = true
+		}
+	}
+	return allowed
+}
+
+// allow returns ErrModelNotAllowed if the model is outside the allowlist.
+func (p *Proxy) allow(model string) error {
+	if p.models == nil {
+		return nil
+	}
+	if _, ok := p.models[model]; !ok {
+		return &httpError{
+			statusCode: http.StatusForbidden,
+			errType:    "invalid_request_error",
+			code:       "model_not_allowed",
+			err:        fmt.Errorf("%w: %q", ErrModelNotAllowed, model),
+		}
+	}
+	return nil
+}
+
+// decode decodes the JSON request body into v.
+func decode(body []byte, v any) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return &httpError{statusCode: http.StatusBadRequest, errType: "invalid_request_error", err: fmt.Errorf("could not decode request: %w", err)}
+	}
+	return nil
+}
+
+// writeJSON writes the JSON encoded response.
+func writeJSON(w http.ResponseWriter, statusCode int, v any) (int, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0, fmt.Errorf("could not marshal response: %w", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(data)
+	return statusCode, nil
+}
+
+// writeError writes the error as the API does, returning its status code.
+// API errors are passed through, an exhausted budget is sent as 429, policy
+// violations as 400 and other client errors as 502.
+func writeError(w http.ResponseWriter, err error) int {
+	body := errorBody{Message: err.Error(), Type: "api_error"}
+	statusCode := http.StatusBadGateway
+
+	var (
+		he     *httpError
+		apiErr *openaiclient.APIError
+	)
+	switch {
+	case errors.As(err, &he):
+		statusCode, body.Type, body.Code = he.statusCode, he.errType, he.code
+	case errors.As(err, &apiErr):
+		statusCode = apiErr.StatusCode
+		body = errorBody{Message: apiErr.Message, Type: apiErr.Type, Param: apiErr.Param, Code: apiErr.Code}
+	case errors.Is(err, openaiclient.ErrBudgetExceeded):
+		statusCode, body.Type, body.Code = http.StatusTooManyRequests, "insufficient_quota", "budget_exceeded"
+	case errors.Is(err, openaiclient.ErrPolicyViolation):
+		statusCode, body.Type, body.Code = http.StatusBadRequest, "invalid_request_error", "policy_violation"
+	case errors.Is(err, openaiclient.ErrInvalidRequest):
+		statusCode, body.Type = http.StatusBadRequest, "invalid_request_error"
+	case errors.Is(err, context.Canceled):
+		// The caller is gone; 499 is the conventional status for it.
+		statusCode = 499
+	}
+
+	writeJSON(w, statusCode, errorResponse{Error: body})
+	return statusCode
+}
+
+// Error implements the error interface.
+func (e *httpError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *httpError) Unwrap() error {
+	return e.err
+}
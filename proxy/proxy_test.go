@@ -0,0 +1,343 @@
+package proxy
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alesr/openaiclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// doFunc is an openaiclient.HTTPClient calling itself.
+type doFunc func(req *http.Request) (*http.Response, error)
+
+func (f doFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// response returns an upstream response with the body.
+func response(statusCode int, contentType, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+const chatResponse = `{
+	"id": "chatcmpl-1",
+	"object": "chat.completion",
+	"model": "gpt-4o-mini",
+	"choices": [{"finish_reason": "stop", "message": {"role": "assistant", "content": "Hello!"}}],
+	"usage": {"prompt_tokens": 5, "completion_tokens": 2, "total_tokens": 7}
+}`
+
+// newServer starts a proxy whose client calls upstream.
+func newServer(t *testing.T, upstream doFunc, clientOpts []openaiclient.Option, opts ...Option) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(New(openaiclient.New("test_api_key", upstream, clientOpts...), opts...))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// post sends the JSON body to the proxy.
+func post(t *testing.T, srv *httptest.Server, path, key, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// decodeError decodes the error body of the response.
+func decodeError(t *testing.T, resp *http.Response) errorBody {
+	t.Helper()
+
+	var out errorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	return out.Error
+}
+
+func TestProxy_ChatCompletion(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu   sync.Mutex
+		logs []AccessLog
+	)
+
+	srv := newServer(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://api.openai.com/v1/chat/completions", req.URL.String())
+		assert.Equal(t, "Bearer test_api_key", req.Header.Get("Authorization"))
+
+		var in openaiclient.ChatCompletionRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+		assert.Equal(t, "My card is [redacted].", in.Messages[0].Content)
+
+		return response(http.StatusOK, "application/json", chatResponse), nil
+	}, nil,
+		WithAPIKeys("team-key"),
+		WithAllowedModels(openaiclient.GPT4oMini),
+		WithRedaction(mustFilter(t, openaiclient.FilterRule{Pattern: `\d{4}-\d{4}`, Replacement: "[redacted]"})),
+		WithAccessLog(func(entry AccessLog) {
+			mu.Lock()
+			defer mu.Unlock()
+			logs = append(logs, entry)
+		}),
+	)
+
+	resp := post(t, srv, "/v1/chat/completions", "team-key", `{"model": "gpt-4o-mini", "messages": [{"role": "user", "content": "My card is 1234-5678."}]}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var out openaiclient.ChatCompletionResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	assert.Equal(t, "Hello!", out.Choices[0].Message.Content)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, logs, 1)
+	assert.Equal(t, http.MethodPost, logs[0].Method)
+	assert.Equal(t, "/v1/chat/completions", logs[0].Path)
+	assert.Equal(t, openaiclient.GPT4oMini, logs[0].Model)
+	assert.Equal(t, http.StatusOK, logs[0].StatusCode)
+	assert.Equal(t, 7, logs[0].Usage.TotalTokens)
+	assert.NoError(t, logs[0].Err)
+}
+
+func TestProxy_ChatCompletionStream(t *testing.T) {
+	t.Parallel()
+
+	upstream := func(*http.Request) (*http.Response, error) {
+		return response(http.StatusOK, "text/event-stream", strings.Join([]string{
+			`data: {"id":"chatcmpl-1","choices":[{"index":0,"delta":{"role":"assistant","content":"Hel"}}]}`,
+			`: ping`,
+			`data: {"id":"chatcmpl-1","choices":[{"index":0,"delta":{"content":"lo"},"finish_reason":"stop"}]}`,
+			`data: {"id":"chatcmpl-1","choices":[],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+			`data: [DONE]`,
+		}, "\n\n")+"\n\n"), nil
+	}
+
+	tests := []struct {
+		name      string
+		body      string
+		wantUsage bool
+	}{
+		{name: "without usage", body: `{"model": "gpt-4o-mini", "stream": true, "messages": [{"role": "user", "content": "Hi"}]}`},
+		{name: "with usage", body: `{"model": "gpt-4o-mini", "stream": true, "stream_options": {"include_usage": true}, "messages": [{"role": "user", "content": "Hi"}]}`, wantUsage: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logged := make(chan AccessLog, 1)
+			srv := newServer(t, upstream, nil, WithAccessLog(func(entry AccessLog) { logged <- entry }))
+
+			resp := post(t, srv, "/v1/chat/completions", "", tt.body)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+			var (
+				content  string
+				usage    *openaiclient.Usage
+				events   []string
+				comments []string
+			)
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				if comment, ok := strings.CutPrefix(scanner.Text(), ": "); ok {
+					comments = append(comments, comment)
+					continue
+				}
+				data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+				if !ok {
+					continue
+				}
+				events = append(events, data)
+				if data == "[DONE]" {
+					continue
+				}
+
+				var chunk openaiclient.ChatCompletionChunk
+				require.NoError(t, json.Unmarshal([]byte(data), &chunk))
+				for _, choice := range chunk.Choices {
+					content += choice.Delta.Content
+				}
+				if chunk.Usage != nil {
+					usage = chunk.Usage
+				}
+			}
+			require.NoError(t, scanner.Err())
+
+			assert.Equal(t, "Hello", content)
+			assert.Equal(t, []string{"ping"}, comments)
+			assert.Equal(t, "[DONE]", events[len(events)-1])
+			assert.Equal(t, tt.wantUsage, usage != nil)
+			assert.Equal(t, 7, (<-logged).Usage.TotalTokens)
+		})
+	}
+}
+
+func TestProxy_Embedding(t *testing.T) {
+	t.Parallel()
+
+	srv := newServer(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/v1/embeddings", req.URL.Path)
+		return response(http.StatusOK, "application/json", `{"object": "list", "model": "text-embedding-3-small", "data": [{"object": "embedding", "embedding": [0.5], "index": 0}], "usage": {"prompt_tokens": 1, "total_tokens": 1}}`), nil
+	}, nil)
+
+	resp := post(t, srv, "/v1/embeddings", "", `{"model": "text-embedding-3-small", "input": "test_input"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var out openaiclient.EmbeddingResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	assert.Equal(t, []float32{0.5}, out.Data[0].Embedding)
+}
+
+func TestProxy_Moderation(t *testing.T) {
+	t.Parallel()
+
+	srv := newServer(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/v1/moderations", req.URL.Path)
+		return response(http.StatusOK, "application/json", `{"id": "modr-1", "results": [{"flagged": false}]}`), nil
+	}, nil, WithAllowedModels(openaiclient.GPT4oMini))
+
+	resp := post(t, srv, "/v1/moderations", "", `{"input": ["test_input"]}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestProxy_Errors(t *testing.T) {
+	t.Parallel()
+
+	unexpected := func(*http.Request) (*http.Response, error) {
+		t.Error("unexpected upstream request")
+		return response(http.StatusOK, "application/json", chatResponse), nil
+	}
+	chat := `{"model": "gpt-4o-mini", "messages": [{"role": "user", "content": "Hi"}]}`
+
+	tests := []struct {
+		name       string
+		upstream   doFunc
+		clientOpts []openaiclient.Option
+		opts       []Option
+		path       string
+		key        string
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "invalid key",
+			upstream:   unexpected,
+			opts:       []Option{WithAPIKeys("team-key")},
+			path:       "/v1/chat/completions",
+			key:        "other-key",
+			body:       chat,
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "invalid_api_key",
+		},
+		{
+			name:       "model not allowed",
+			upstream:   unexpected,
+			opts:       []Option{WithAllowedModels(openaiclient.GPT4o)},
+			path:       "/v1/chat/completions",
+			body:       chat,
+			wantStatus: http.StatusForbidden,
+			wantCode:   "model_not_allowed",
+		},
+		{
+			name:       "policy violation",
+			upstream:   unexpected,
+			clientOpts: []openaiclient.Option{openaiclient.WithPolicy("", openaiclient.Policy{Banned: true})},
+			path:       "/v1/chat/completions",
+			body:       chat,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "policy_violation",
+		},
+		{
+			name:       "unknown endpoint",
+			upstream:   unexpected,
+			path:       "/v1/images/generations",
+			body:       `{}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid body",
+			upstream:   unexpected,
+			path:       "/v1/chat/completions",
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "upstream error",
+			upstream: func(*http.Request) (*http.Response, error) {
+				return response(http.StatusBadRequest, "application/json", `{"error": {"message": "Invalid model", "type": "invalid_request_error", "param": "model", "code": "model_not_found"}}`), nil
+			},
+			path:       "/v1/chat/completions",
+			body:       chat,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "model_not_found",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := newServer(t, tt.upstream, tt.clientOpts, tt.opts...)
+
+			resp := post(t, srv, tt.path, tt.key, tt.body)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+
+			body := decodeError(t, resp)
+			assert.NotEmpty(t, body.Message)
+			assert.Equal(t, tt.wantCode, body.Code)
+		})
+	}
+}
+
+func TestProxy_Budget(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	srv := newServer(t, func(*http.Request) (*http.Response, error) {
+		calls++
+		return response(http.StatusOK, "application/json", chatResponse), nil
+	}, []openaiclient.Option{openaiclient.WithTokenBudget(5)})
+
+	chat := `{"model": "gpt-4o-mini", "messages": [{"role": "user", "content": "Hi"}]}`
+
+	resp := post(t, srv, "/v1/chat/completions", "", chat)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = post(t, srv, "/v1/chat/completions", "", chat)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "budget_exceeded", decodeError(t, resp).Code)
+	assert.Equal(t, 1, calls)
+}
+
+func mustFilter(t *testing.T, rules ...openaiclient.FilterRule) *openaiclient.OutputFilter {
+	t.Helper()
+
+	filter, err := openaiclient.NewOutputFilter(rules...)
+	require.NoError(t, err)
+	return filter
+}
@@ -0,0 +1,104 @@
+package openaiclient
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var firstNumber = regexp.MustCompile(`-?\d+(\.\d+)?`)
+
+const judgePrompt = `You rate candidate answers. Score the answer from 0 (useless) to 10 (excellent)
+for correctness, completeness and clarity. Reply with the score only.`
+
+type (
+	// ChoiceScorer scores completion choices; higher scores rank first.
+	ChoiceScorer interface {
+		Score(ctx context.Context, choice Choice) (float64, error)
+	}
+
+	// ScorerFunc adapts a function to the ChoiceScorer interface.
+	ScorerFunc func(ctx context.Context, choice Choice) (float64, error)
+
+	// RankedChoice is a choice along with its score.
+	RankedChoice struct {
+		Choice
+		Score float64
+	}
+
+	// JudgeScorer scores choices by asking a judge model to rate them.
+	JudgeScorer struct {
+		Client *Client
+		Model  string
+		// Question is the prompt the choices answer, given to the judge as context.
+		Question string
+	}
+)
+
+// Score implements ChoiceScorer.
+func (f ScorerFunc) Score(ctx context.Context, choice Choice) (float64, error) {
+	return f(ctx, choice)
+}
+
+// HeuristicScorer scores choices without model calls: complete answers
+// (finish reason "stop") rank above truncated or filtered ones, and longer
+// answers rank above shorter ones.
+var HeuristicScorer = ScorerFunc(func(_ context.Context, choice Choice) (float64, error) {
+	content := strings.TrimSpace(choice.Message.Content)
+	if content == "" {
+		return 0, nil
+	}
+
+	score := 1 - 1/float64(1+len(content))
+	if choice.FinishReason == "stop" {
+		score++
+	}
+	return score, nil
+})
+
+// Score implements ChoiceScorer.
+func (j JudgeScorer) Score(ctx context.Context, choice Choice) (float64, error) {
+	resp, err := j.Client.CreateChatCompletion(ctx, ChatCompletionRequest{
+		Model: j.Model,
+		Messages: []Message{
+			{Role: RoleSystem, Content: judgePrompt},
+			{Role: RoleUser, Content: fmt.Sprintf("Question:\n%s\n\nAnswer:\n%s", j.Question, choice.Message.Content)},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not judge choice: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return 0, fmt.Errorf("could not judge choice: no choices returned")
+	}
+
+	verdict := resp.Choices[0].Message.Content
+
+	score, err := strconv.ParseFloat(firstNumber.FindString(verdict), 64)
+	if err != nil {
+		return 0, fmt.Errorf("could not judge choice: unexpected verdict %q", verdict)
+	}
+	return score, nil
+}
+
+// RankChoices scores every choice of the response and returns them from best
+// to worst. Choices with equal scores keep their original order.
+func RankChoices(ctx context.Context, resp *ChatCompletionResponse, scorer ChoiceScorer) ([]RankedChoice, error) {
+	ranked := make([]RankedChoice, 0, len(resp.Choices))
+	for _, choice := range resp.Choices {
+		score, err := scorer.Score(ctx, choice)
+		if err != nil {
+			return nil, fmt.Errorf("could not score choice %d: %w", choice.Index, err)
+		}
+		ranked = append(ranked, RankedChoice{Choice: choice, Score: score})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked, nil
+}
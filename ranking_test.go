@@ -0,0 +1,67 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankChoices(t *testing.T) {
+	t.Parallel()
+
+	resp := &ChatCompletionResponse{
+		Choices: []Choice{
+			{Index: 0, FinishReason: "length", Message: Message{Content: "A long but truncated answer"}},
+			{Index: 1, FinishReason: "stop", Message: Message{Content: "Short."}},
+			{Index: 2, FinishReason: "stop", Message: Message{Content: "A complete and longer answer."}},
+			{Index: 3, FinishReason: "stop", Message: Message{Content: ""}},
+		},
+	}
+
+	t.Run("ranks with the heuristic scorer", func(t *testing.T) {
+		t.Parallel()
+
+		ranked, err := RankChoices(context.Background(), resp, HeuristicScorer)
+		require.NoError(t, err)
+
+		var order []int
+		for _, r := range ranked {
+			order = append(order, r.Index)
+		}
+		assert.Equal(t, []int{2, 1, 0, 3}, order)
+	})
+
+	t.Run("ranks with a judge model", func(t *testing.T) {
+		t.Parallel()
+
+		client := New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				var in ChatCompletionRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+				assert.Equal(t, "test_judge", in.Model)
+				assert.Contains(t, in.Messages[1].Content, "What is Go?")
+
+				verdict := "3"
+				if strings.Contains(in.Messages[1].Content, "Short.") {
+					verdict = "Score: 9.5"
+				}
+				return jsonResponse(t, ChatCompletionResponse{
+					Choices: []Choice{{Message: Message{Role: "assistant", Content: verdict}}},
+				}), nil
+			},
+		})
+
+		ranked, err := RankChoices(context.Background(), resp, JudgeScorer{Client: client, Model: "test_judge", Question: "What is Go?"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, ranked[0].Index)
+		assert.Equal(t, 9.5, ranked[0].Score)
+		assert.Len(t, ranked, 4)
+	})
+}
@@ -0,0 +1,129 @@
+package openaiclient
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Rate limit response headers.
+const (
+	HeaderLimitRequests     = "X-Ratelimit-Limit-Requests"
+	HeaderLimitTokens       = "X-Ratelimit-Limit-Tokens"
+	HeaderRemainingRequests = "X-Ratelimit-Remaining-Requests"
+	HeaderRemainingTokens   = "X-Ratelimit-Remaining-Tokens"
+	HeaderResetRequests     = "X-Ratelimit-Reset-Requests"
+	HeaderResetTokens       = "X-Ratelimit-Reset-Tokens"
+)
+
+type (
+	// RateLimitInfo is the rate limit state reported by the API. Values that
+	// weren't reported are zero.
+	RateLimitInfo struct {
+		LimitRequests     int
+		LimitTokens       int
+		RemainingRequests int
+		RemainingTokens   int
+		// ResetRequests is the time until the request limit resets.
+		ResetRequests time.Duration
+		// ResetTokens is the time until the token limit resets.
+		ResetTokens time.Duration
+	}
+
+	// throttle delays requests while the rate limits are near exhaustion.
+	throttle struct {
+		minRequests int
+		minTokens   int
+
+		mu sync.Mutex
+		// until is when the exhausted limits reset.
+		until time.Time
+	}
+)
+
+// ParseRateLimit parses the rate limit headers. It reports false if none is present.
+func ParseRateLimit(header http.Header) (RateLimitInfo, bool) {
+	var (
+		info  RateLimitInfo
+		found bool
+	)
+
+	parseInt := func(name string, dst *int) {
+		if n, err := strconv.Atoi(header.Get(name)); err == nil {
+			*dst = n
+			found = true
+		}
+	}
+	parseDuration := func(name string, dst *time.Duration) {
+		if d, err := time.ParseDuration(header.Get(name)); err == nil {
+			*dst = d
+			found = true
+		}
+	}
+
+	parseInt(HeaderLimitRequests, &info.LimitRequests)
+	parseInt(HeaderLimitTokens, &info.LimitTokens)
+	parseInt(HeaderRemainingRequests, &info.RemainingRequests)
+	parseInt(HeaderRemainingTokens, &info.RemainingTokens)
+	parseDuration(HeaderResetRequests, &info.ResetRequests)
+	parseDuration(HeaderResetTokens, &info.ResetTokens)
+	return info, found
+}
+
+// RateLimit returns the rate limit state reported with the response, if its
+// headers were captured, as they are by DefaultCapturedHeaders.
+func (m ResponseMeta) RateLimit() (RateLimitInfo, bool) {
+	return ParseRateLimit(m.Header)
+}
+
+// WithThrottling delays requests once a response reports at most minRequests
+// remaining requests or minTokens remaining tokens, until the exhausted limit
+// resets. A negative minimum disables throttling on that limit.
+func WithThrottling(minRequests, minTokens int) Option {
+	return func(c *Client) {
+		c.throttle = &throttle{minRequests: minRequests, minTokens: minTokens}
+	}
+}
+
+// wait waits until the exhausted limits reset, if any.
+func (t *throttle) wait(ctx context.Context, sleep func(ctx context.Context, d time.Duration) error) error {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	d := time.Until(t.until)
+	t.mu.Unlock()
+
+	if d <= 0 {
+		return nil
+	}
+	return sleep(ctx, d)
+}
+
+// observe records the rate limits reported by the response headers.
+func (t *throttle) observe(header http.Header) {
+	if t == nil {
+		return
+	}
+
+	info, ok := ParseRateLimit(header)
+	if !ok {
+		return
+	}
+
+	var reset time.Duration
+	if t.minRequests >= 0 && header.Get(HeaderRemainingRequests) != "" && info.RemainingRequests <= t.minRequests {
+		reset = info.ResetRequests
+	}
+	if t.minTokens >= 0 && header.Get(HeaderRemainingTokens) != "" && info.RemainingTokens <= t.minTokens {
+		reset = max(reset, info.ResetTokens)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.until = time.Now().Add(reset)
+}
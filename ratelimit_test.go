@@ -0,0 +1,153 @@
+package openaiclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rateLimitHeader returns rate limit headers with the given remaining requests and tokens.
+func rateLimitHeader(requests, tokens string) http.Header {
+	header := http.Header{}
+	header.Set(HeaderLimitRequests, "500")
+	header.Set(HeaderLimitTokens, "30000")
+	header.Set(HeaderRemainingRequests, requests)
+	header.Set(HeaderRemainingTokens, tokens)
+	header.Set(HeaderResetRequests, "120ms")
+	header.Set(HeaderResetTokens, "6m0s")
+	return header
+}
+
+func TestParseRateLimit(t *testing.T) {
+	t.Parallel()
+
+	info, ok := ParseRateLimit(rateLimitHeader("499", "29500"))
+	require.True(t, ok)
+	assert.Equal(t, RateLimitInfo{
+		LimitRequests:     500,
+		LimitTokens:       30000,
+		RemainingRequests: 499,
+		RemainingTokens:   29500,
+		ResetRequests:     120 * time.Millisecond,
+		ResetTokens:       6 * time.Minute,
+	}, info)
+
+	_, ok = ParseRateLimit(http.Header{"X-Request-Id": []string{"req-123"}})
+	assert.False(t, ok)
+}
+
+func TestRateLimit_Responses(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				resp := jsonResponse(t, ChatCompletionResponse{})
+				resp.Header = rateLimitHeader("499", "29500")
+				return resp, nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     rateLimitHeader("0", "29500"),
+				Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"Rate limit reached"}}`)),
+			}, nil
+		},
+	})
+
+	resp, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	info, ok := resp.RateLimit()
+	require.True(t, ok)
+	assert.Equal(t, 499, info.RemainingRequests)
+
+	_, err = client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.NotNil(t, apiErr.RateLimit)
+	assert.Equal(t, 0, apiErr.RateLimit.RemainingRequests)
+}
+
+func TestWithThrottling(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		requests  string
+		tokens    string
+		minTokens int
+		wantDelay time.Duration
+	}{
+		{name: "requests exhausted", requests: "1", tokens: "29500", minTokens: 1000, wantDelay: 120 * time.Millisecond},
+		{name: "tokens exhausted", requests: "400", tokens: "900", minTokens: 1000, wantDelay: 6 * time.Minute},
+		{name: "token throttling disabled", requests: "400", tokens: "0", minTokens: -1},
+		{name: "limits available", requests: "400", tokens: "29500", minTokens: 1000},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					resp := jsonResponse(t, ChatCompletionResponse{})
+					resp.Header = rateLimitHeader(tt.requests, tt.tokens)
+					return resp, nil
+				},
+			}, WithThrottling(1, tt.minTokens))
+
+			var delays []time.Duration
+			client.sleep = func(_ context.Context, d time.Duration) error {
+				delays = append(delays, d)
+				return nil
+			}
+
+			for i := 0; i < 2; i++ {
+				_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+				require.NoError(t, err)
+			}
+
+			if tt.wantDelay == 0 {
+				assert.Empty(t, delays)
+				return
+			}
+			require.Len(t, delays, 1)
+			assert.InDelta(t, tt.wantDelay, delays[0], float64(100*time.Millisecond))
+		})
+	}
+}
+
+func TestWithThrottling_Canceled(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			resp := jsonResponse(t, ChatCompletionResponse{})
+			resp.Header = rateLimitHeader("0", "0")
+			return resp, nil
+		},
+	}, WithThrottling(0, 0))
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = client.CreateChatCompletion(ctx, ChatCompletionRequest{Model: "gpt-4o"})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
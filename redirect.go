@@ -0,0 +1,89 @@
+package openaiclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// MaxRedirects is the number of redirects followed per request.
+const MaxRedirects = 5
+
+// ErrRedirectNotAllowed is returned when the API redirects a request to a host
+// the client wasn't allowed to send its credentials to.
+var ErrRedirectNotAllowed = errors.New("redirect not allowed")
+
+// redirectPolicy follows 307 and 308 redirects to allowlisted hosts.
+type redirectPolicy struct {
+	hosts map[string]struct{}
+}
+
+// WithRedirects makes the client follow 307 and 308 redirects, as sent by
+// gateways routing to regional endpoints, re-sending the request with its body
+// and credentials. Redirects are followed within the same host and to the
+// given hosts only; other redirects fail with ErrRedirectNotAllowed rather than
+// reaching the target without credentials, and WithAllowedHosts still applies
+// to the targets. Other redirect codes aren't followed. If the HTTP client is an
+// *http.Client, a copy that doesn't follow redirects itself is used.
+func WithRedirects(hosts ...string) Option {
+	return func(c *Client) {
+		c.redirects = &redirectPolicy{hosts: make(map[string]struct{}, len(hosts))}
+		for _, host := range hosts {
+			c.redirects.hosts[strings.ToLower(host)] = struct{}{}
+		}
+
+		if hc, ok := c.httpClient.(*http.Client); ok {
+			clone := *hc
+			clone.CheckRedirect = func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			}
+			c.httpClient = &clone
+		}
+	}
+}
+
+// isFollowedRedirect reports whether the response is a redirect preserving the method and body.
+func isFollowedRedirect(statusCode int) bool {
+	return statusCode == http.StatusTemporaryRedirect || statusCode == http.StatusPermanentRedirect
+}
+
+// redirectTarget returns the URL the response redirects the request to, if allowed.
+func (c *Client) redirectTarget(req *http.Request, resp *http.Response, redirects int) (string, error) {
+	if redirects >= MaxRedirects {
+		return "", fmt.Errorf("%w: stopped after %d redirects", ErrRedirectNotAllowed, redirects)
+	}
+
+	header := resp.Header.Get("Location")
+	location, err := url.Parse(header)
+	if err != nil || header == "" {
+		return "", fmt.Errorf("could not follow redirect: invalid location %q", header)
+	}
+
+	target := req.URL.ResolveReference(location)
+	if target.Scheme != "https" && target.Scheme != "http" {
+		return "", fmt.Errorf("%w: unsupported scheme %q", ErrRedirectNotAllowed, target.Scheme)
+	}
+
+	// Credentials must not be downgraded to plain text.
+	if req.URL.Scheme == "https" && target.Scheme != "https" {
+		return "", fmt.Errorf("%w: %s downgrades to http", ErrRedirectNotAllowed, target.Redacted())
+	}
+
+	if !c.redirects.allows(req.URL, target) {
+		return "", fmt.Errorf("%w: %s", ErrRedirectNotAllowed, target.Hostname())
+	}
+	return target.String(), nil
+}
+
+// allows reports whether credentials may follow a redirect from the source to the target URL.
+func (p *redirectPolicy) allows(source, target *url.URL) bool {
+	host := strings.ToLower(target.Hostname())
+	if host == strings.ToLower(source.Hostname()) {
+		return true
+	}
+
+	_, ok := p.hosts[host]
+	return ok
+}
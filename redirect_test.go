@@ -0,0 +1,172 @@
+package openaiclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// redirectResponse returns a response redirecting to the location with the status.
+func redirectResponse(status int, location string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Location": []string{location}},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+}
+
+func TestWithRedirects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		status    int
+		location  string
+		hosts     []string
+		wantURL   string
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "follows temporary redirects to allowed hosts",
+			status:    http.StatusTemporaryRedirect,
+			location:  "https://eu.api.openai.com/v1/chat/completions",
+			hosts:     []string{"EU.api.openai.com"},
+			wantURL:   "https://eu.api.openai.com/v1/chat/completions",
+			wantCalls: 2,
+		},
+		{
+			name:      "follows permanent redirects within the host",
+			status:    http.StatusPermanentRedirect,
+			location:  "/v2/chat/completions",
+			wantURL:   "https://api.openai.com/v2/chat/completions",
+			wantCalls: 2,
+		},
+		{
+			name:      "rejects redirects to other hosts",
+			status:    http.StatusTemporaryRedirect,
+			location:  "https://eu.api.openai.com/v1/chat/completions",
+			wantErr:   ErrRedirectNotAllowed,
+			wantCalls: 1,
+		},
+		{
+			name:      "rejects downgrades to http",
+			status:    http.StatusTemporaryRedirect,
+			location:  "http://api.openai.com/v1/chat/completions",
+			wantErr:   ErrRedirectNotAllowed,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var reqs []*http.Request
+			var bodies []string
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					body, err := io.ReadAll(req.Body)
+					require.NoError(t, err)
+
+					reqs = append(reqs, req)
+					bodies = append(bodies, string(body))
+
+					if len(reqs) == 1 {
+						return redirectResponse(tt.status, tt.location), nil
+					}
+					return jsonResponse(t, ChatCompletionResponse{ID: "chatcmpl-123"}), nil
+				},
+			}, WithRedirects(tt.hosts...), WithOrganization("org-123"))
+
+			resp, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+
+			require.Len(t, reqs, tt.wantCalls)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "chatcmpl-123", resp.ID)
+
+			redirected := reqs[1]
+			assert.Equal(t, tt.wantURL, redirected.URL.String())
+			assert.Equal(t, http.MethodPost, redirected.Method)
+			assert.Equal(t, "Bearer test_api_key", redirected.Header.Get("Authorization"))
+			assert.Equal(t, "org-123", redirected.Header.Get("OpenAI-Organization"))
+			assert.Equal(t, bodies[0], bodies[1])
+		})
+	}
+}
+
+func TestWithRedirects_StopsAfterMaxRedirects(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return redirectResponse(http.StatusTemporaryRedirect, req.URL.String()), nil
+		},
+	}, WithRedirects())
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+
+	assert.ErrorIs(t, err, ErrRedirectNotAllowed)
+	assert.Equal(t, MaxRedirects+1, calls)
+}
+
+func TestWithRedirects_NotEnabled(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return redirectResponse(http.StatusTemporaryRedirect, "/v2/chat/completions"), nil
+		},
+	})
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTemporaryRedirect, apiErr.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWithRedirects_HTTPClient(t *testing.T) {
+	t.Parallel()
+
+	regional := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test_api_key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id":"chatcmpl-123"}`))
+	}))
+	defer regional.Close()
+
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, regional.URL+r.URL.Path, http.StatusTemporaryRedirect)
+	}))
+	defer gateway.Close()
+
+	httpClient := &http.Client{}
+	client := New("test_api_key", httpClient, WithBaseURL(gateway.URL+"/v1"), WithRedirects())
+
+	resp, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "chatcmpl-123", resp.ID)
+	assert.Nil(t, httpClient.CheckRedirect, "the given client must not be modified")
+}
@@ -0,0 +1,98 @@
+package openaiclient
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrTenantNotFound is returned when no configuration is registered for a tenant.
+var ErrTenantNotFound = errors.New("tenant not found")
+
+type (
+	// TenantConfig holds the client configuration of a single tenant.
+	TenantConfig struct {
+		APIKey       string
+		Organization string
+		// BaseURL defaults to DefaultBaseURL.
+		BaseURL string
+		// TokenBudget caps the tokens the tenant may consume. Zero means no limit.
+		TokenBudget int
+	}
+
+	// Registry maps tenant IDs to clients. Clients are constructed lazily on
+	// first use and share the registry's HTTP client, while usage and budgets
+	// are tracked separately for every tenant.
+	Registry struct {
+		httpClient HTTPClient
+
+		mu      sync.Mutex
+		configs map[string]TenantConfig
+		clients map[string]*Client
+	}
+)
+
+// NewRegistry creates a new tenant registry using the given HTTP client for all tenants.
+func NewRegistry(httpClient HTTPClient) *Registry {
+	return &Registry{
+		httpClient: httpClient,
+		configs:    make(map[string]TenantConfig),
+		clients:    make(map[string]*Client),
+	}
+}
+
+// Register adds or replaces the configuration of a tenant.
+// Replacing a configuration discards the tenant's client and its accumulated usage.
+func (r *Registry) Register(tenantID string, cfg TenantConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.configs[tenantID] = cfg
+	delete(r.clients, tenantID)
+}
+
+// Remove deletes the tenant from the registry.
+func (r *Registry) Remove(tenantID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.configs, tenantID)
+	delete(r.clients, tenantID)
+}
+
+// Client returns the client of the given tenant, constructing it on first use.
+func (r *Registry) Client(tenantID string) (*Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if c, ok := r.clients[tenantID]; ok {
+		return c, nil
+	}
+
+	cfg, ok := r.configs[tenantID]
+	if !ok {
+		return nil, ErrTenantNotFound
+	}
+
+	opts := []Option{
+		WithOrganization(cfg.Organization),
+		WithTokenBudget(cfg.TokenBudget),
+	}
+
+	if cfg.BaseURL != "" {
+		opts = append(opts, WithBaseURL(cfg.BaseURL))
+	}
+
+	c := New(cfg.APIKey, r.httpClient, opts...)
+
+	r.clients[tenantID] = c
+	return c, nil
+}
+
+// Usage returns the token usage accumulated by the given tenant.
+func (r *Registry) Usage(tenantID string) (Usage, error) {
+	c, err := r.Client(tenantID)
+	if err != nil {
+		return Usage{}, err
+	}
+	return c.Usage(), nil
+}
@@ -0,0 +1,133 @@
+package openaiclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	newHTTPClient := func() *mockHTTPClient {
+		return &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader(`{"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}`)),
+				}, nil
+			},
+		}
+	}
+
+	t.Run("returns an error for unknown tenants", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewRegistry(newHTTPClient())
+
+		_, err := registry.Client("unknown")
+		assert.ErrorIs(t, err, ErrTenantNotFound)
+	})
+
+	t.Run("constructs clients lazily and reuses them", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewRegistry(newHTTPClient())
+		registry.Register("acme", TenantConfig{APIKey: "acme_key"})
+
+		first, err := registry.Client("acme")
+		require.NoError(t, err)
+
+		second, err := registry.Client("acme")
+		require.NoError(t, err)
+
+		assert.Same(t, first, second)
+	})
+
+	t.Run("sends tenant credentials", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewRegistry(&mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "Bearer acme_key", req.Header.Get("Authorization"))
+				assert.Equal(t, "acme_org", req.Header.Get("OpenAI-Organization"))
+				assert.Equal(t, "acme.gateway.internal", req.URL.Host)
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader("{}")),
+				}, nil
+			},
+		})
+		registry.Register("acme", TenantConfig{
+			APIKey:       "acme_key",
+			Organization: "acme_org",
+			BaseURL:      "https://acme.gateway.internal/v1",
+		})
+
+		client, err := registry.Client("acme")
+		require.NoError(t, err)
+
+		_, err = client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+		require.NoError(t, err)
+	})
+
+	t.Run("isolates usage per tenant", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewRegistry(newHTTPClient())
+		registry.Register("acme", TenantConfig{APIKey: "acme_key"})
+		registry.Register("globex", TenantConfig{APIKey: "globex_key"})
+
+		client, err := registry.Client("acme")
+		require.NoError(t, err)
+
+		_, err = client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+		require.NoError(t, err)
+
+		acmeUsage, err := registry.Usage("acme")
+		require.NoError(t, err)
+		assert.Equal(t, Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5}, acmeUsage)
+
+		globexUsage, err := registry.Usage("globex")
+		require.NoError(t, err)
+		assert.Equal(t, Usage{}, globexUsage)
+	})
+
+	t.Run("enforces tenant budgets", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewRegistry(newHTTPClient())
+		registry.Register("acme", TenantConfig{APIKey: "acme_key", TokenBudget: 5})
+
+		client, err := registry.Client("acme")
+		require.NoError(t, err)
+
+		_, err = client.CreateEmbedding(context.Background(), EmbeddingRequest{})
+		require.NoError(t, err)
+
+		_, err = client.CreateEmbedding(context.Background(), EmbeddingRequest{})
+		assert.ErrorIs(t, err, ErrBudgetExceeded)
+	})
+
+	t.Run("re-registering resets the tenant", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewRegistry(newHTTPClient())
+		registry.Register("acme", TenantConfig{APIKey: "acme_key"})
+
+		first, err := registry.Client("acme")
+		require.NoError(t, err)
+
+		registry.Register("acme", TenantConfig{APIKey: "new_key"})
+
+		second, err := registry.Client("acme")
+		require.NoError(t, err)
+
+		assert.NotSame(t, first, second)
+	})
+}
@@ -0,0 +1,123 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Clone returns a deep copy of the request, safe to modify concurrently with the original.
+func (r ChatCompletionRequest) Clone() ChatCompletionRequest {
+	clone := r
+	if r.Messages != nil {
+		clone.Messages = make([]Message, len(r.Messages))
+		for i, msg := range r.Messages {
+			clone.Messages[i] = msg.Clone()
+		}
+	}
+
+	if r.Tools != nil {
+		clone.Tools = make([]Tool, len(r.Tools))
+		for i, tool := range r.Tools {
+			clone.Tools[i] = tool
+			clone.Tools[i].Function.Parameters = append(json.RawMessage(nil), tool.Function.Parameters...)
+		}
+	}
+
+	clone.ToolChoice = clonePtr(r.ToolChoice)
+	clone.ParallelToolCalls = clonePtr(r.ParallelToolCalls)
+
+	clone.Temperature = clonePtr(r.Temperature)
+	clone.TopP = clonePtr(r.TopP)
+	clone.PresencePenalty = clonePtr(r.PresencePenalty)
+	clone.FrequencyPenalty = clonePtr(r.FrequencyPenalty)
+	clone.Seed = clonePtr(r.Seed)
+	clone.ResponseFormat = clonePtr(r.ResponseFormat)
+	if r.ResponseFormat != nil && r.ResponseFormat.JSONSchema != nil {
+		schema := *r.ResponseFormat.JSONSchema
+		schema.Schema = append(json.RawMessage(nil), schema.Schema...)
+		clone.ResponseFormat.JSONSchema = &schema
+	}
+
+	if r.Stop != nil {
+		clone.Stop = append([]string(nil), r.Stop...)
+	}
+
+	if r.LogitBias != nil {
+		clone.LogitBias = make(map[string]int, len(r.LogitBias))
+		for token, bias := range r.LogitBias {
+			clone.LogitBias[token] = bias
+		}
+	}
+
+	if r.Metadata != nil {
+		clone.Metadata = make(map[string]string, len(r.Metadata))
+		for key, value := range r.Metadata {
+			clone.Metadata[key] = value
+		}
+	}
+	return clone
+}
+
+// Clone returns a deep copy of the message.
+func (m Message) Clone() Message {
+	clone := m
+	if m.ToolCalls != nil {
+		clone.ToolCalls = append([]ToolCall(nil), m.ToolCalls...)
+	}
+
+	if m.Parts != nil {
+		clone.Parts = make([]ContentPart, len(m.Parts))
+		for i, part := range m.Parts {
+			clone.Parts[i] = part
+			clone.Parts[i].ImageURL = clonePtr(part.ImageURL)
+			clone.Parts[i].InputAudio = clonePtr(part.InputAudio)
+		}
+	}
+	return clone
+}
+
+// Ptr returns a pointer to v, for setting optional request parameters such as Temperature.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// WithVariables returns a copy of the request where every {{name}} placeholder
+// in the message contents is replaced by the value of name in vars.
+// Placeholders without a matching variable are left untouched.
+func (r ChatCompletionRequest) WithVariables(vars map[string]string) ChatCompletionRequest {
+	clone := r.Clone()
+	if len(vars) == 0 {
+		return clone
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	oldnew := make([]string, 0, len(vars)*2)
+	for _, name := range names {
+		oldnew = append(oldnew, "{{"+name+"}}", vars[name])
+	}
+
+	replacer := strings.NewReplacer(oldnew...)
+	for i := range clone.Messages {
+		clone.Messages[i].Content = replacer.Replace(clone.Messages[i].Content)
+		for j, part := range clone.Messages[i].Parts {
+			if part.Type == ContentPartText {
+				clone.Messages[i].Parts[j].Text = replacer.Replace(part.Text)
+			}
+		}
+	}
+	return clone
+}
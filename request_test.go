@@ -0,0 +1,143 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatCompletionRequest_Clone(t *testing.T) {
+	t.Parallel()
+
+	original := ChatCompletionRequest{
+		Model: "test_model",
+		Messages: []Message{
+			{Role: "system", Content: "test_system"},
+		},
+	}
+
+	clone := original.Clone()
+	assert.Equal(t, original, clone)
+
+	clone.Messages[0].Content = "changed"
+	clone.Messages = append(clone.Messages, Message{Role: "user", Content: "test_user"})
+
+	assert.Equal(t, "test_system", original.Messages[0].Content)
+	assert.Len(t, original.Messages, 1)
+}
+
+func TestChatCompletionRequest_Clone_Parts(t *testing.T) {
+	t.Parallel()
+
+	original := ChatCompletionRequest{
+		Messages: []Message{{Role: "user", Parts: []ContentPart{
+			TextPart("What is this?"),
+			ImagePart("https://example.com/cat.png", ImageDetailLow),
+		}}},
+	}
+
+	clone := original.Clone()
+	assert.Equal(t, original, clone)
+
+	clone.Messages[0].Parts[0].Text = "changed"
+	clone.Messages[0].Parts[1].ImageURL.Detail = ImageDetailHigh
+
+	assert.Equal(t, "What is this?", original.Messages[0].Parts[0].Text)
+	assert.Equal(t, ImageDetailLow, original.Messages[0].Parts[1].ImageURL.Detail)
+}
+
+func TestChatCompletionRequest_Clone_Parameters(t *testing.T) {
+	t.Parallel()
+
+	original := ChatCompletionRequest{
+		Model:          "test_model",
+		Temperature:    Ptr(0.5),
+		Seed:           Ptr(42),
+		Stop:           []string{"\n"},
+		LogitBias:      map[string]int{"50256": -100},
+		Metadata:       map[string]string{"feature": "search"},
+		ResponseFormat: &ResponseFormat{Type: "json_object"},
+		Messages:       []Message{{Role: "assistant", ToolCalls: []ToolCall{{ID: "call_1"}}}},
+		Tools:          []Tool{NewFunctionTool("weather", "", json.RawMessage(`{}`))},
+	}
+
+	clone := original.Clone()
+	assert.Equal(t, original, clone)
+
+	*clone.Temperature = 1
+	*clone.Seed = 7
+	clone.Stop[0] = "END"
+	clone.LogitBias["50256"] = 0
+	clone.Metadata["feature"] = "chat"
+	clone.ResponseFormat.Type = "text"
+	clone.Messages[0].ToolCalls[0].ID = "call_2"
+	clone.Tools[0].Function.Parameters[0] = '['
+
+	assert.Equal(t, 0.5, *original.Temperature)
+	assert.Equal(t, 42, *original.Seed)
+	assert.Equal(t, []string{"\n"}, original.Stop)
+	assert.Equal(t, map[string]int{"50256": -100}, original.LogitBias)
+	assert.Equal(t, map[string]string{"feature": "search"}, original.Metadata)
+	assert.Equal(t, "json_object", original.ResponseFormat.Type)
+	assert.Equal(t, "call_1", original.Messages[0].ToolCalls[0].ID)
+	assert.Equal(t, json.RawMessage(`{}`), original.Tools[0].Function.Parameters)
+}
+
+func TestChatCompletionRequest_Clone_JSONSchema(t *testing.T) {
+	t.Parallel()
+
+	original := ChatCompletionRequest{ResponseFormat: JSONSchemaFormat("answer", json.RawMessage(`{}`), true)}
+
+	clone := original.Clone()
+	assert.Equal(t, original, clone)
+
+	clone.ResponseFormat.JSONSchema.Strict = false
+	clone.ResponseFormat.JSONSchema.Schema[0] = '['
+
+	assert.True(t, original.ResponseFormat.JSONSchema.Strict)
+	assert.Equal(t, json.RawMessage(`{}`), original.ResponseFormat.JSONSchema.Schema)
+}
+
+func TestChatCompletionRequest_WithVariables(t *testing.T) {
+	t.Parallel()
+
+	base := ChatCompletionRequest{
+		Model: "test_model",
+		Messages: []Message{
+			{Role: "system", Content: "You are helping {{name}}."},
+			{Role: "user", Content: "{{question}} {{unknown}}"},
+		},
+	}
+
+	got := base.WithVariables(map[string]string{
+		"name":     "Ada",
+		"question": "What is a closure?",
+	})
+
+	assert.Equal(t, []Message{
+		{Role: "system", Content: "You are helping Ada."},
+		{Role: "user", Content: "What is a closure? {{unknown}}"},
+	}, got.Messages)
+
+	assert.Equal(t, "You are helping {{name}}.", base.Messages[0].Content)
+}
+
+func TestChatCompletionRequest_WithVariables_Parts(t *testing.T) {
+	t.Parallel()
+
+	base := ChatCompletionRequest{
+		Messages: []Message{{Role: "user", Parts: []ContentPart{
+			TextPart("Is this {{animal}}?"),
+			ImagePart("https://example.com/{{animal}}.png", ""),
+		}}},
+	}
+
+	got := base.WithVariables(map[string]string{"animal": "cat"})
+
+	assert.Equal(t, []ContentPart{
+		TextPart("Is this cat?"),
+		ImagePart("https://example.com/{{animal}}.png", ""),
+	}, got.Messages[0].Parts)
+	assert.Equal(t, "Is this {{animal}}?", base.Messages[0].Parts[0].Text)
+}
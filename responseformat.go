@@ -0,0 +1,63 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Response format types.
+const (
+	// ResponseFormatAuto lets the Assistants API pick the format; it is sent as a plain string.
+	ResponseFormatAuto       = "auto"
+	ResponseFormatText       = "text"
+	ResponseFormatJSONObject = "json_object"
+	ResponseFormatJSONSchema = "json_schema"
+)
+
+// JSONSchema is the JSON schema of a "json_schema" response format.
+type JSONSchema struct {
+	// Name identifies the schema; it may contain letters, digits, underscores and dashes.
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Schema      json.RawMessage `json:"schema,omitempty"`
+	// Strict makes the model output always match the schema, which must then
+	// require every property and disallow additional ones.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// JSONSchemaFormat returns the response format making the model output JSON matching the schema.
+func JSONSchemaFormat(name string, schema json.RawMessage, strict bool) *ResponseFormat {
+	return &ResponseFormat{
+		Type:       ResponseFormatJSONSchema,
+		JSONSchema: &JSONSchema{Name: name, Schema: schema, Strict: strict},
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (f ResponseFormat) MarshalJSON() ([]byte, error) {
+	if f.Type == ResponseFormatAuto {
+		return json.Marshal(f.Type)
+	}
+
+	type responseFormat ResponseFormat
+	return json.Marshal(responseFormat(f))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting the plain "auto" string
+// returned by the Assistants API.
+func (f *ResponseFormat) UnmarshalJSON(data []byte) error {
+	var mode string
+	if err := json.Unmarshal(data, &mode); err == nil {
+		*f = ResponseFormat{Type: mode}
+		return nil
+	}
+
+	type responseFormat ResponseFormat
+	var format responseFormat
+	if err := json.Unmarshal(data, &format); err != nil {
+		return fmt.Errorf("could not unmarshal response format: %w", err)
+	}
+
+	*f = ResponseFormat(format)
+	return nil
+}
@@ -0,0 +1,51 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseFormat_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format ResponseFormat
+		want   string
+	}{
+		{name: "auto", format: ResponseFormat{Type: ResponseFormatAuto}, want: `"auto"`},
+		{name: "json object", format: ResponseFormat{Type: ResponseFormatJSONObject}, want: `{"type":"json_object"}`},
+		{
+			name:   "json schema",
+			format: *JSONSchemaFormat("answer", json.RawMessage(`{"type":"object"}`), true),
+			want:   `{"type":"json_schema","json_schema":{"name":"answer","schema":{"type":"object"},"strict":true}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := json.Marshal(tt.format)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+
+			var decoded ResponseFormat
+			require.NoError(t, json.Unmarshal(got, &decoded))
+			assert.Equal(t, tt.format, decoded)
+		})
+	}
+}
+
+func TestAssistant_AutoResponseFormat(t *testing.T) {
+	t.Parallel()
+
+	var assistant Assistant
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"asst_123","response_format":"auto"}`), &assistant))
+
+	assert.Equal(t, &ResponseFormat{Type: ResponseFormatAuto}, assistant.ResponseFormat)
+}
@@ -0,0 +1,411 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Responses API input and output item types.
+const (
+	ResponseItemMessage            = "message"
+	ResponseItemFunctionCall       = "function_call"
+	ResponseItemFunctionCallOutput = "function_call_output"
+	ResponseItemReasoning          = "reasoning"
+)
+
+// Responses API content types.
+const (
+	ResponseContentInputText  = "input_text"
+	ResponseContentInputImage = "input_image"
+	ResponseContentOutputText = "output_text"
+	ResponseContentRefusal    = "refusal"
+)
+
+// Response statuses.
+const (
+	ResponseStatusCompleted  = "completed"
+	ResponseStatusIncomplete = "incomplete"
+	ResponseStatusFailed     = "failed"
+)
+
+type (
+	// ResponseRequest is the request body for the Responses API endpoint.
+	ResponseRequest struct {
+		Model             string              `json:"model"`
+		Instructions      string              `json:"instructions,omitempty"`
+		Input             []ResponseItem      `json:"input"`
+		Temperature       *float64            `json:"temperature,omitempty"`
+		TopP              *float64            `json:"top_p,omitempty"`
+		MaxOutputTokens   int                 `json:"max_output_tokens,omitempty"`
+		User              string              `json:"user,omitempty"`
+		Text              *ResponseText       `json:"text,omitempty"`
+		Tools             []ResponseTool      `json:"tools,omitempty"`
+		ToolChoice        *ResponseToolChoice `json:"tool_choice,omitempty"`
+		ParallelToolCalls *bool               `json:"parallel_tool_calls,omitempty"`
+		Metadata          map[string]string   `json:"metadata,omitempty"`
+		PromptCacheKey    string              `json:"prompt_cache_key,omitempty"`
+		SafetyIdentifier  string              `json:"safety_identifier,omitempty"`
+	}
+
+	// ResponseItem is an input or output item of the Responses API: a message,
+	// a function call or its output, or a reasoning summary, depending on its Type.
+	ResponseItem struct {
+		Type    string            `json:"type"`
+		ID      string            `json:"id,omitempty"`
+		Status  string            `json:"status,omitempty"`
+		Role    Role              `json:"role,omitempty"`
+		Content []ResponseContent `json:"content,omitempty"`
+		// CallID identifies a function call and the output answering it.
+		CallID    string `json:"call_id,omitempty"`
+		Name      string `json:"name,omitempty"`
+		Arguments string `json:"arguments,omitempty"`
+		Output    string `json:"output,omitempty"`
+	}
+
+	// ResponseContent is the content of a Responses API message: a text, an
+	// image or a refusal, depending on its Type.
+	ResponseContent struct {
+		Type     string `json:"type"`
+		Text     string `json:"text,omitempty"`
+		ImageURL string `json:"image_url,omitempty"`
+		Detail   string `json:"detail,omitempty"`
+		Refusal  string `json:"refusal,omitempty"`
+	}
+
+	// ResponseText configures the text output of a response.
+	ResponseText struct {
+		Format ResponseTextFormat `json:"format"`
+	}
+
+	// ResponseTextFormat is the format of the text output, such as "text" or
+	// "json_schema", with the schema inlined rather than nested as in ResponseFormat.
+	ResponseTextFormat struct {
+		Type        string          `json:"type"`
+		Name        string          `json:"name,omitempty"`
+		Description string          `json:"description,omitempty"`
+		Schema      json.RawMessage `json:"schema,omitempty"`
+		Strict      bool            `json:"strict,omitempty"`
+	}
+
+	// ResponseTool is a function tool of the Responses API, defined inline
+	// rather than nested as in Tool.
+	ResponseTool struct {
+		// Type is always "function".
+		Type        string          `json:"type"`
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		Parameters  json.RawMessage `json:"parameters,omitempty"`
+		// Strict is always sent: the Responses API defaults to strict schemas.
+		Strict bool `json:"strict"`
+	}
+
+	// ResponseToolChoice controls which tool the model calls, as ToolChoice,
+	// encoded for the Responses API.
+	ResponseToolChoice ToolChoice
+
+	// Response is the response body for the Responses API endpoint.
+	Response struct {
+		ResponseMeta
+		ID                string                     `json:"id"`
+		Object            string                     `json:"object"`
+		CreatedAt         int64                      `json:"created_at"`
+		Model             string                     `json:"model"`
+		Status            string                     `json:"status"`
+		IncompleteDetails *ResponseIncompleteDetails `json:"incomplete_details,omitempty"`
+		Output            []ResponseItem             `json:"output"`
+		Usage             ResponseUsage              `json:"usage"`
+	}
+
+	// ResponseIncompleteDetails is why a response is incomplete, such as
+	// "max_output_tokens" or "content_filter".
+	ResponseIncompleteDetails struct {
+		Reason string `json:"reason"`
+	}
+
+	// ResponseUsage is the token usage of a response.
+	ResponseUsage struct {
+		InputTokens         int                     `json:"input_tokens"`
+		OutputTokens        int                     `json:"output_tokens"`
+		TotalTokens         int                     `json:"total_tokens"`
+		InputTokensDetails  PromptTokensDetails     `json:"input_tokens_details"`
+		OutputTokensDetails CompletionTokensDetails `json:"output_tokens_details"`
+	}
+)
+
+// MarshalJSON implements json.Marshaler.
+func (c ResponseToolChoice) MarshalJSON() ([]byte, error) {
+	if c.Function == "" {
+		return json.Marshal(c.Mode)
+	}
+
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	}{Type: "function", Name: c.Function})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *ResponseToolChoice) UnmarshalJSON(data []byte) error {
+	var mode string
+	if err := json.Unmarshal(data, &mode); err == nil {
+		*c = ResponseToolChoice{Mode: mode}
+		return nil
+	}
+
+	var fn struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &fn); err != nil {
+		return fmt.Errorf("could not unmarshal tool choice: %w", err)
+	}
+
+	*c = ResponseToolChoice{Function: fn.Name}
+	return nil
+}
+
+// CreateResponse creates a response with the Responses API.
+func (c *Client) CreateResponse(ctx context.Context, in ResponseRequest) (*Response, error) {
+	in.Model = c.resolveModel(in.Model)
+	in.User = c.endUser(in.User)
+	in.SafetyIdentifier = c.safetyIdentifier(in.SafetyIdentifier)
+	if err := c.policies.checkModel(in.Model); err != nil {
+		return nil, err
+	}
+	c.deprecations.check(in.Model)
+
+	var resp Response
+	if err := c.post(ctx, c.url(EndpointResponses), in, &resp); err != nil {
+		return nil, err
+	}
+
+	c.usage.record(servedModel(resp.Model, in.Model), resp.Usage.chat())
+	return &resp, nil
+}
+
+// CreateChatCompletionWithResponses creates the chat completion with the
+// Responses API rather than the chat completion endpoint, converting the
+// request with ResponseRequestFromChat and the response with ChatCompletion.
+func (c *Client) CreateChatCompletionWithResponses(ctx context.Context, in ChatCompletionRequest) (*ChatCompletionResponse, error) {
+	in.Model = c.resolveModel(in.Model)
+	if err := c.policies.apply(&in); err != nil {
+		return nil, err
+	}
+
+	req, err := ResponseRequestFromChat(in)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.CreateResponse(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ChatCompletion(), nil
+}
+
+// ResponseRequestFromChat converts the chat completion request into a
+// Responses API request. Messages become input items, with assistant tool
+// calls and tool messages as function calls and their outputs. Parameters
+// the Responses API lacks, such as N, Stop, penalties, logit bias, logprobs, seeds,
+// audio input and assistant prefill, return an error wrapping
+// ErrInvalidRequest rather than being dropped.
+func ResponseRequestFromChat(in ChatCompletionRequest) (ResponseRequest, error) {
+	unsupported := func(field string) error {
+		return fmt.Errorf("%w: %s is not supported by the Responses API", ErrInvalidRequest, field)
+	}
+
+	switch {
+	case in.N > 1:
+		return ResponseRequest{}, unsupported("n")
+	case len(in.Stop) > 0:
+		return ResponseRequest{}, unsupported("stop")
+	case in.PresencePenalty != nil:
+		return ResponseRequest{}, unsupported("presence_penalty")
+	case in.FrequencyPenalty != nil:
+		return ResponseRequest{}, unsupported("frequency_penalty")
+	case len(in.LogitBias) > 0:
+		return ResponseRequest{}, unsupported("logit_bias")
+	case in.Logprobs:
+		return ResponseRequest{}, unsupported("logprobs")
+	case in.Seed != nil:
+		return ResponseRequest{}, unsupported("seed")
+	}
+
+	out := ResponseRequest{
+		Model:             in.Model,
+		Temperature:       in.Temperature,
+		TopP:              in.TopP,
+		MaxOutputTokens:   in.MaxTokens,
+		User:              in.User,
+		ParallelToolCalls: in.ParallelToolCalls,
+		Metadata:          in.Metadata,
+		PromptCacheKey:    in.PromptCacheKey,
+		SafetyIdentifier:  in.SafetyIdentifier,
+	}
+
+	for i, msg := range in.Messages {
+		items, err := responseItems(msg)
+		if err != nil {
+			return ResponseRequest{}, fmt.Errorf("could not convert message %d: %w", i, err)
+		}
+		out.Input = append(out.Input, items...)
+	}
+
+	for _, tool := range in.Tools {
+		out.Tools = append(out.Tools, ResponseTool{
+			Type:        tool.Type,
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			Parameters:  tool.Function.Parameters,
+			Strict:      tool.Function.Strict,
+		})
+	}
+	if in.ToolChoice != nil {
+		choice := ResponseToolChoice(*in.ToolChoice)
+		out.ToolChoice = &choice
+	}
+
+	if f := in.ResponseFormat; f != nil && f.Type != ResponseFormatAuto {
+		format := ResponseTextFormat{Type: f.Type}
+		if f.JSONSchema != nil {
+			format.Name = f.JSONSchema.Name
+			format.Description = f.JSONSchema.Description
+			format.Schema = f.JSONSchema.Schema
+			format.Strict = f.JSONSchema.Strict
+		}
+		out.Text = &ResponseText{Format: format}
+	}
+	return out, nil
+}
+
+// responseItems returns the input items of the chat message.
+func responseItems(msg Message) ([]ResponseItem, error) {
+	if msg.Prefix {
+		return nil, fmt.Errorf("%w: assistant prefill is not supported by the Responses API", ErrInvalidRequest)
+	}
+
+	if msg.Role == RoleTool {
+		return []ResponseItem{{Type: ResponseItemFunctionCallOutput, CallID: msg.ToolCallID, Output: msg.Content}}, nil
+	}
+
+	content, err := responseContent(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []ResponseItem
+	if len(content) > 0 || len(msg.ToolCalls) == 0 {
+		items = append(items, ResponseItem{Type: ResponseItemMessage, Role: msg.Role, Content: content})
+	}
+	for _, call := range msg.ToolCalls {
+		items = append(items, ResponseItem{
+			Type:      ResponseItemFunctionCall,
+			CallID:    call.ID,
+			Name:      call.Function.Name,
+			Arguments: call.Function.Arguments,
+		})
+	}
+	return items, nil
+}
+
+// responseContent returns the content of the chat message. Assistant text is
+// output text, as the Responses API expects for earlier turns.
+func responseContent(msg Message) ([]ResponseContent, error) {
+	textType := ResponseContentInputText
+	if msg.Role == RoleAssistant {
+		textType = ResponseContentOutputText
+	}
+
+	var content []ResponseContent
+	if len(msg.Parts) == 0 && msg.Content != "" {
+		content = append(content, ResponseContent{Type: textType, Text: msg.Content})
+	}
+
+	for _, part := range msg.Parts {
+		switch part.Type {
+		case ContentPartText:
+			content = append(content, ResponseContent{Type: textType, Text: part.Text})
+		case ContentPartImageURL:
+			if part.ImageURL == nil {
+				return nil, fmt.Errorf("%w: image part without an image", ErrInvalidRequest)
+			}
+			content = append(content, ResponseContent{Type: ResponseContentInputImage, ImageURL: part.ImageURL.URL, Detail: part.ImageURL.Detail})
+		default:
+			return nil, fmt.Errorf("%w: %s content is not supported by the Responses API", ErrInvalidRequest, part.Type)
+		}
+	}
+
+	if msg.Refusal != "" {
+		content = append(content, ResponseContent{Type: ResponseContentRefusal, Refusal: msg.Refusal})
+	}
+	return content, nil
+}
+
+// ChatCompletion converts the response into a chat completion with a single
+// choice: its output text, refusal and function calls become the message, and
+// its status the finish reason. Reasoning items are dropped.
+func (r *Response) ChatCompletion() *ChatCompletionResponse {
+	msg := Message{Role: RoleAssistant}
+
+	var text, refusal strings.Builder
+	for _, item := range r.Output {
+		switch item.Type {
+		case ResponseItemMessage:
+			for _, content := range item.Content {
+				switch content.Type {
+				case ResponseContentOutputText:
+					text.WriteString(content.Text)
+				case ResponseContentRefusal:
+					refusal.WriteString(content.Refusal)
+				}
+			}
+		case ResponseItemFunctionCall:
+			msg.ToolCalls = append(msg.ToolCalls, ToolCall{
+				ID:       item.CallID,
+				Type:     "function",
+				Function: FunctionCall{Name: item.Name, Arguments: item.Arguments},
+			})
+		}
+	}
+	msg.Content = text.String()
+	msg.Refusal = refusal.String()
+
+	return &ChatCompletionResponse{
+		ResponseMeta: r.ResponseMeta,
+		ID:           r.ID,
+		Object:       "chat.completion",
+		Model:        r.Model,
+		Created:      int(r.CreatedAt),
+		Choices:      []Choice{{FinishReason: r.finishReason(len(msg.ToolCalls) > 0), Message: msg}},
+		Usage:        r.Usage.chat(),
+	}
+}
+
+// finishReason returns the chat finish reason of the response.
+func (r *Response) finishReason(toolCalls bool) string {
+	if r.Status == ResponseStatusIncomplete && r.IncompleteDetails != nil {
+		switch r.IncompleteDetails.Reason {
+		case "max_output_tokens":
+			return "length"
+		case "content_filter":
+			return "content_filter"
+		}
+	}
+	if toolCalls {
+		return "tool_calls"
+	}
+	return "stop"
+}
+
+// chat returns the usage in the chat completion shape.
+func (u ResponseUsage) chat() Usage {
+	return Usage{
+		PromptTokens:            u.InputTokens,
+		CompletionTokens:        u.OutputTokens,
+		TotalTokens:             u.TotalTokens,
+		PromptTokensDetails:     u.InputTokensDetails,
+		CompletionTokensDetails: u.OutputTokensDetails,
+	}
+}
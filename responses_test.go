@@ -0,0 +1,203 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseRequestFromChat(t *testing.T) {
+	t.Parallel()
+
+	temperature := 0.2
+	parallel := false
+	call := ToolCall{ID: "call_1", Type: "function", Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}}
+
+	got, err := ResponseRequestFromChat(ChatCompletionRequest{
+		Model:            GPT4o,
+		Temperature:      &temperature,
+		MaxTokens:        256,
+		User:             "user-1",
+		PromptCacheKey:   "support-agent",
+		SafetyIdentifier: "3f2a9c",
+		Messages: []Message{
+			{Role: RoleSystem, Content: "Be brief."},
+			{Role: RoleUser, Parts: []ContentPart{TextPart("What's this?"), {Type: ContentPartImageURL, ImageURL: &ImageURL{URL: "https://example.com/cat.png", Detail: ImageDetailLow}}}},
+			{Role: RoleAssistant, Content: "Checking.", ToolCalls: []ToolCall{call}},
+			ToolResult(call, "sunny"),
+		},
+		Tools:             []Tool{NewFunctionTool("get_weather", "Gets the weather", json.RawMessage(`{"type":"object"}`))},
+		ToolChoice:        ToolChoiceFunction("get_weather"),
+		ParallelToolCalls: &parallel,
+		ResponseFormat:    JSONSchemaFormat("answer", json.RawMessage(`{"type":"object"}`), true),
+	})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"model": "gpt-4o",
+		"temperature": 0.2,
+		"max_output_tokens": 256,
+		"user": "user-1",
+		"prompt_cache_key": "support-agent",
+		"safety_identifier": "3f2a9c",
+		"input": [
+			{"type": "message", "role": "system", "content": [{"type": "input_text", "text": "Be brief."}]},
+			{"type": "message", "role": "user", "content": [
+				{"type": "input_text", "text": "What's this?"},
+				{"type": "input_image", "image_url": "https://example.com/cat.png", "detail": "low"}
+			]},
+			{"type": "message", "role": "assistant", "content": [{"type": "output_text", "text": "Checking."}]},
+			{"type": "function_call", "call_id": "call_1", "name": "get_weather", "arguments": "{\"city\":\"Paris\"}"},
+			{"type": "function_call_output", "call_id": "call_1", "output": "sunny"}
+		],
+		"tools": [{"type": "function", "name": "get_weather", "description": "Gets the weather", "parameters": {"type": "object"}, "strict": false}],
+		"tool_choice": {"type": "function", "name": "get_weather"},
+		"parallel_tool_calls": false,
+		"text": {"format": {"type": "json_schema", "name": "answer", "schema": {"type": "object"}, "strict": true}}
+	}`, string(data))
+}
+
+func TestResponseRequestFromChat_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	seed := 1
+	messages := []Message{{Role: RoleUser, Content: "Hi"}}
+
+	tests := []struct {
+		name string
+		in   ChatCompletionRequest
+	}{
+		{name: "n", in: ChatCompletionRequest{Messages: messages, N: 2}},
+		{name: "stop", in: ChatCompletionRequest{Messages: messages, Stop: []string{"\n"}}},
+		{name: "seed", in: ChatCompletionRequest{Messages: messages, Seed: &seed}},
+		{name: "logprobs", in: ChatCompletionRequest{Messages: messages, Logprobs: true}},
+		{name: "logit bias", in: ChatCompletionRequest{Messages: messages, LogitBias: map[string]int{"50256": -100}}},
+		{name: "prefill", in: ChatCompletionRequest{Messages: append(messages, Message{Role: RoleAssistant, Content: "Hel", Prefix: true})}},
+		{name: "audio", in: ChatCompletionRequest{Messages: []Message{{Role: RoleUser, Parts: []ContentPart{{Type: ContentPartInputAudio, InputAudio: &InputAudio{Data: "AAAA", Format: AudioInputWAV}}}}}}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ResponseRequestFromChat(tt.in)
+			assert.ErrorIs(t, err, ErrInvalidRequest)
+		})
+	}
+}
+
+func TestResponse_ChatCompletion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		resp Response
+		want Choice
+	}{
+		{
+			name: "text",
+			resp: Response{Status: ResponseStatusCompleted, Output: []ResponseItem{
+				{Type: ResponseItemReasoning},
+				{Type: ResponseItemMessage, Role: RoleAssistant, Content: []ResponseContent{
+					{Type: ResponseContentOutputText, Text: "Hello, "},
+					{Type: ResponseContentOutputText, Text: "world."},
+				}},
+			}},
+			want: Choice{FinishReason: "stop", Message: Message{Role: RoleAssistant, Content: "Hello, world."}},
+		},
+		{
+			name: "function calls",
+			resp: Response{Status: ResponseStatusCompleted, Output: []ResponseItem{
+				{Type: ResponseItemFunctionCall, CallID: "call_1", Name: "get_weather", Arguments: "{}"},
+			}},
+			want: Choice{FinishReason: "tool_calls", Message: Message{Role: RoleAssistant, ToolCalls: []ToolCall{
+				{ID: "call_1", Type: "function", Function: FunctionCall{Name: "get_weather", Arguments: "{}"}},
+			}}},
+		},
+		{
+			name: "refusal",
+			resp: Response{Status: ResponseStatusCompleted, Output: []ResponseItem{
+				{Type: ResponseItemMessage, Content: []ResponseContent{{Type: ResponseContentRefusal, Refusal: "No."}}},
+			}},
+			want: Choice{FinishReason: "stop", Message: Message{Role: RoleAssistant, Refusal: "No."}},
+		},
+		{
+			name: "truncated",
+			resp: Response{
+				Status:            ResponseStatusIncomplete,
+				IncompleteDetails: &ResponseIncompleteDetails{Reason: "max_output_tokens"},
+				Output:            []ResponseItem{{Type: ResponseItemMessage, Content: []ResponseContent{{Type: ResponseContentOutputText, Text: "Once"}}}},
+			},
+			want: Choice{FinishReason: "length", Message: Message{Role: RoleAssistant, Content: "Once"}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.resp.ChatCompletion()
+			require.Len(t, got.Choices, 1)
+			assert.Equal(t, tt.want, got.Choices[0])
+		})
+	}
+}
+
+func TestClient_CreateChatCompletionWithResponses(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "https://api.openai.com/v1/responses", req.URL.String())
+
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+
+			var in ResponseRequest
+			require.NoError(t, json.Unmarshal(body, &in))
+			assert.Equal(t, GPT4oMini, in.Model)
+			require.Len(t, in.Input, 1)
+			assert.Equal(t, "Hi", in.Input[0].Content[0].Text)
+
+			return jsonResponse(t, map[string]any{
+				"id":         "resp_1",
+				"object":     "response",
+				"created_at": 1700000000,
+				"model":      "gpt-4o-mini-2024-07-18",
+				"status":     "completed",
+				"output": []map[string]any{{
+					"type":    "message",
+					"role":    "assistant",
+					"content": []map[string]any{{"type": "output_text", "text": "Hello!"}},
+				}},
+				"usage": map[string]any{
+					"input_tokens":         5,
+					"output_tokens":        2,
+					"total_tokens":         7,
+					"input_tokens_details": map[string]any{"cached_tokens": 1},
+				},
+			}), nil
+		},
+	})
+
+	resp, err := client.CreateChatCompletionWithResponses(context.Background(), ChatCompletionRequest{
+		Model:    GPT4oMini,
+		Messages: []Message{{Role: RoleUser, Content: "Hi"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "resp_1", resp.ID)
+	assert.Equal(t, 1700000000, resp.Created)
+	assert.Equal(t, "Hello!", resp.Choices[0].Message.Content)
+	assert.Equal(t, Usage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7, PromptTokensDetails: PromptTokensDetails{CachedTokens: 1}}, resp.Usage)
+	assert.Equal(t, resp.Usage, client.Usage())
+}
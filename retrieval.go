@@ -0,0 +1,191 @@
+package openaiclient
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+type (
+	// RetrievalHistory stores every past message with its embedding and only
+	// sends the messages most relevant to the new turn, keeping long-lived
+	// conversations within the model's context window.
+	RetrievalHistory struct {
+		client *Client
+		model  string
+		topK   int
+		recent int
+		err    error
+
+		mu      sync.Mutex
+		entries []historyEntry
+		// query is the last message embedded by Select, reused when it is added.
+		query historyEntry
+	}
+
+	historyEntry struct {
+		msg    Message
+		vector []float32
+		// model is the model that embedded the message, as served.
+		model string
+	}
+)
+
+// NewRetrievalHistory creates a history strategy that embeds messages with the
+// given embedding model and selects the topK most similar past messages per turn.
+// A negative topK makes the strategy fail on use.
+func NewRetrievalHistory(client *Client, model string, topK int) *RetrievalHistory {
+	h := &RetrievalHistory{
+		client: client,
+		model:  model,
+		topK:   topK,
+	}
+
+	if topK < 0 {
+		h.err = fmt.Errorf("invalid topK %d: must not be negative", topK)
+	}
+	return h
+}
+
+// KeepRecent makes the strategy always send the last n messages in addition to
+// the retrieved ones, so the immediate context of the conversation is kept.
+func (h *RetrievalHistory) KeepRecent(n int) *RetrievalHistory {
+	h.recent = n
+	return h
+}
+
+// Add implements HistoryStrategy.
+func (h *RetrievalHistory) Add(ctx context.Context, msg Message) error {
+	if h.err != nil {
+		return h.err
+	}
+
+	h.mu.Lock()
+	entry := h.query
+	if entry.msg.Text() != msg.Text() {
+		entry = historyEntry{}
+	}
+	h.query = historyEntry{}
+	h.mu.Unlock()
+
+	if entry.vector == nil {
+		var err error
+		if entry, err = h.embed(ctx, msg.Text()); err != nil {
+			return err
+		}
+	}
+	entry.msg = msg
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = append(h.entries, entry)
+	return nil
+}
+
+// Select implements HistoryStrategy. Selected messages are returned in conversation order.
+func (h *RetrievalHistory) Select(ctx context.Context, next Message) ([]Message, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+
+	h.mu.Lock()
+	entries := append([]historyEntry(nil), h.entries...)
+	h.mu.Unlock()
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	recentFrom := len(entries) - h.recent
+	if recentFrom < 0 {
+		recentFrom = 0
+	}
+
+	query, err := h.embed(ctx, next.Text())
+	if err != nil {
+		return nil, err
+	}
+	query.msg = next
+
+	h.mu.Lock()
+	h.query = query
+	h.mu.Unlock()
+
+	type scored struct {
+		index int
+		score float64
+	}
+
+	candidates := make([]scored, 0, recentFrom)
+	for i, entry := range entries[:recentFrom] {
+		score, err := CompareEmbeddings(
+			EmbeddingRecord{Model: query.model, Vector: query.vector},
+			EmbeddingRecord{Model: entry.model, Vector: entry.vector},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not score message %d: %w", i, err)
+		}
+		candidates = append(candidates, scored{index: i, score: score})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	if len(candidates) > h.topK {
+		candidates = candidates[:h.topK]
+	}
+
+	indexes := make([]int, 0, len(candidates)+len(entries)-recentFrom)
+	for _, c := range candidates {
+		indexes = append(indexes, c.index)
+	}
+
+	for i := recentFrom; i < len(entries); i++ {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	selected := make([]Message, 0, len(indexes))
+	for _, i := range indexes {
+		selected = append(selected, entries[i].msg)
+	}
+	return selected, nil
+}
+
+// embed returns the entry of the embedded text, without its message.
+func (h *RetrievalHistory) embed(ctx context.Context, text string) (historyEntry, error) {
+	resp, err := h.client.CreateEmbedding(ctx, EmbeddingRequest{Model: h.model, Input: TextInput(text)})
+	if err != nil {
+		return historyEntry{}, fmt.Errorf("could not embed message: %w", err)
+	}
+
+	if len(resp.Data) == 0 {
+		return historyEntry{}, fmt.Errorf("could not embed message: no embedding returned")
+	}
+	return historyEntry{vector: resp.Data[0].Embedding, model: resp.Model}, nil
+}
+
+// CosineSimilarity returns the cosine similarity of two vectors.
+// It returns zero if either vector has no magnitude. Vectors of different
+// dimensions are compared over the shorter one; CheckedCosineSimilarity and
+// CompareEmbeddings return an error instead.
+func CosineSimilarity(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		if i >= len(b) {
+			break
+		}
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
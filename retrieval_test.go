@@ -0,0 +1,144 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrievalHistory(t *testing.T) {
+	t.Parallel()
+
+	// Messages about cats and dogs are embedded on different axes.
+	vectors := map[string][]float32{
+		"cat": {1, 0},
+		"dog": {0, 1},
+	}
+
+	var (
+		requests   []ChatCompletionRequest
+		embeddings int
+	)
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(req.URL.Path, "/embeddings") {
+				embeddings++
+
+				var in EmbeddingRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+				assert.Equal(t, "test_embedding_model", in.Model)
+				for keyword, vector := range vectors {
+					if strings.Contains(in.Input.Texts[0], keyword) {
+						return jsonResponse(t, EmbeddingResponse{Data: []Embedding{{Embedding: vector}}}), nil
+					}
+				}
+				return jsonResponse(t, EmbeddingResponse{Data: []Embedding{{Embedding: []float32{1, 1}}}}), nil
+			}
+
+			var in ChatCompletionRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+			requests = append(requests, in)
+			return jsonResponse(t, ChatCompletionResponse{
+				Choices: []Choice{{Message: Message{Role: "assistant", Content: "ok"}}},
+			}), nil
+		},
+	})
+
+	conv := NewConversation(client, "test_model",
+		WithHistoryStrategy(NewRetrievalHistory(client, "test_embedding_model", 1)),
+	)
+
+	for _, content := range []string{"my cat is grey", "my dog is brown", "what colour is my cat?"} {
+		_, err := conv.Send(context.Background(), content)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, requests, 3)
+	assert.Equal(t, []Message{
+		{Role: "user", Content: "my cat is grey"},
+		{Role: "user", Content: "what colour is my cat?"},
+	}, requests[2].Messages)
+
+	// The user message embedded to select the history is reused when storing it.
+	assert.Equal(t, 6, embeddings)
+}
+
+func TestRetrievalHistory_KeepRecent(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(t, EmbeddingResponse{Data: []Embedding{{Embedding: []float32{1}}}}), nil
+		},
+	})
+
+	history := NewRetrievalHistory(client, "test_embedding_model", 0).KeepRecent(1)
+
+	for _, content := range []string{"first", "second"} {
+		require.NoError(t, history.Add(context.Background(), Message{Role: "user", Content: content}))
+	}
+
+	got, err := history.Select(context.Background(), Message{Role: "user", Content: "third"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []Message{{Role: "user", Content: "second"}}, got)
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a, b []float32
+		want float64
+	}{
+		{name: "identical vectors", a: []float32{1, 2}, b: []float32{1, 2}, want: 1},
+		{name: "orthogonal vectors", a: []float32{1, 0}, b: []float32{0, 1}, want: 0},
+		{name: "opposite vectors", a: []float32{1, 0}, b: []float32{-1, 0}, want: -1},
+		{name: "zero vector", a: []float32{0, 0}, b: []float32{1, 0}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, CosineSimilarity(tt.a, tt.b), 1e-9)
+		})
+	}
+}
+
+func TestRetrievalHistory_NegativeTopK(t *testing.T) {
+	t.Parallel()
+
+	history := NewRetrievalHistory(New("test_api_key", nil), "test_embedding_model", -1)
+
+	_, err := history.Select(context.Background(), Message{Role: "user", Content: "hi"})
+	assert.Error(t, err)
+	assert.Error(t, history.Add(context.Background(), Message{Role: "user", Content: "hi"}))
+}
+
+func TestRetrievalHistory_ModelMismatch(t *testing.T) {
+	t.Parallel()
+
+	// The deployment behind the alias changes model between calls.
+	served := []string{"text-embedding-3-small", "text-embedding-3-large"}
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			model := served[0]
+			served = served[1:]
+			return jsonResponse(t, EmbeddingResponse{Model: model, Data: []Embedding{{Embedding: []float32{1, 0}}}}), nil
+		},
+	})
+
+	history := NewRetrievalHistory(client, "test_embedding_model", 1)
+	require.NoError(t, history.Add(context.Background(), Message{Role: "user", Content: "first"}))
+
+	_, err := history.Select(context.Background(), Message{Role: "user", Content: "second"})
+	assert.ErrorIs(t, err, ErrModelMismatch)
+}
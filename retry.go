@@ -0,0 +1,116 @@
+package openaiclient
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRetryPolicy is a sensible retry policy for the OpenAI API.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+	Jitter:      0.2,
+}
+
+// RetryPolicy retries requests failing with 429 or 5xx status codes, with
+// exponential backoff. Delays requested by the server through the Retry-After
+// header are honored, up to MaxDelay.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled on every retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts.
+	MaxDelay time.Duration
+	// Jitter randomizes delays by up to the given fraction (0-1) in either direction.
+	Jitter float64
+}
+
+// WithRetry retries failed requests according to the policy.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = &policy
+	}
+}
+
+// isRetryableStatus reports whether the status code denotes a transient failure.
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// shouldRetry reports whether the failed attempt should be retried.
+// Rate limits caused by an exhausted quota don't clear up by waiting, so they aren't retried.
+func (p *RetryPolicy) shouldRetry(apiErr *APIError, attempt int) bool {
+	return attempt < p.MaxAttempts && apiErr.Code != "insufficient_quota"
+}
+
+// delay returns how long to wait before the next attempt.
+func (p *RetryPolicy) delay(attempt int, header http.Header) time.Duration {
+	if d, ok := retryAfter(header); ok {
+		return p.capDelay(float64(d))
+	}
+
+	d := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return p.capDelay(d)
+}
+
+// capDelay bounds the delay to [0, MaxDelay], before converting it so that
+// large backoffs can't overflow time.Duration.
+func (p *RetryPolicy) capDelay(d float64) time.Duration {
+	if p.MaxDelay > 0 && d > float64(p.MaxDelay) {
+		return p.MaxDelay
+	}
+	if d < 0 {
+		return 0
+	}
+	if d > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(d)
+}
+
+// retryAfter parses the delay requested by the server, either in milliseconds
+// through retry-after-ms or in seconds or as an HTTP date through Retry-After.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	if ms, err := strconv.ParseFloat(header.Get("Retry-After-Ms"), 64); err == nil && ms >= 0 {
+		return time.Duration(ms * float64(time.Millisecond)), true
+	}
+
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleep waits for the given duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
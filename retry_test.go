@@ -0,0 +1,177 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRetry(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+	tests := []struct {
+		name        string
+		statusCodes []int
+		header      http.Header
+		wantErr     bool
+		wantCalls   int
+		wantDelays  []time.Duration
+	}{
+		{
+			name:        "retries rate limits and server errors with backoff",
+			statusCodes: []int{429, 503, 200},
+			wantCalls:   3,
+			wantDelays:  []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:        "gives up after max attempts",
+			statusCodes: []int{500, 500, 500},
+			wantErr:     true,
+			wantCalls:   3,
+			wantDelays:  []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:        "does not retry client errors",
+			statusCodes: []int{400},
+			wantErr:     true,
+			wantCalls:   1,
+		},
+		{
+			name:        "honors retry-after",
+			statusCodes: []int{429, 200},
+			header:      http.Header{"Retry-After": {"2"}},
+			wantCalls:   2,
+			wantDelays:  []time.Duration{2 * time.Second},
+		},
+		{
+			name:        "caps retry-after",
+			statusCodes: []int{429, 200},
+			header:      http.Header{"Retry-After": {"60"}},
+			wantCalls:   2,
+			wantDelays:  []time.Duration{5 * time.Second},
+		},
+		{
+			name:        "honors retry-after-ms",
+			statusCodes: []int{429, 200},
+			header:      http.Header{"Retry-After-Ms": {"250"}},
+			wantCalls:   2,
+			wantDelays:  []time.Duration{250 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				calls  int
+				delays []time.Duration
+			)
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					body, err := io.ReadAll(req.Body)
+					require.NoError(t, err)
+					assert.Equal(t, `{"model":"test_model","messages":null}`, string(body))
+
+					statusCode := tt.statusCodes[calls]
+					calls++
+					return &http.Response{
+						StatusCode: statusCode,
+						Header:     tt.header,
+						Body:       io.NopCloser(strings.NewReader("{}")),
+					}, nil
+				},
+			}, WithRetry(policy))
+
+			client.sleep = func(_ context.Context, d time.Duration) error {
+				delays = append(delays, d)
+				return nil
+			}
+
+			_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "test_model"})
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, tt.wantDelays, delays)
+		})
+	}
+}
+
+func TestWithRetry_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 429,
+				Body:       io.NopCloser(strings.NewReader("{}")),
+			}, nil
+		},
+	}, WithRetry(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Hour}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.CreateChatCompletion(ctx, ChatCompletionRequest{})
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestRetryPolicy_Jitter(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{BaseDelay: time.Second, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		d := policy.delay(1, nil)
+		assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+		assert.LessOrEqual(t, d, 1500*time.Millisecond)
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{MaxAttempts: 100, BaseDelay: 500 * time.Millisecond, MaxDelay: 30 * time.Second}
+
+	for _, attempt := range []int{1, 35, 40, 100} {
+		d := policy.delay(attempt, nil)
+		assert.Greater(t, d, time.Duration(0), "attempt %d", attempt)
+		assert.LessOrEqual(t, d, policy.MaxDelay, "attempt %d", attempt)
+	}
+
+	uncapped := RetryPolicy{BaseDelay: 500 * time.Millisecond}
+	assert.Equal(t, time.Duration(math.MaxInt64), uncapped.delay(100, nil))
+}
+
+func TestWithRetry_InsufficientQuota(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{
+				StatusCode: 429,
+				Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"quota exceeded","type":"insufficient_quota","code":"insufficient_quota"}}`)),
+			}, nil
+		},
+	}, WithRetry(DefaultRetryPolicy))
+
+	client.sleep = func(context.Context, time.Duration) error { return nil }
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "insufficient_quota", apiErr.Code)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,55 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Message roles.
+const (
+	RoleSystem    Role = "system"
+	RoleDeveloper Role = "developer"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+	RoleTool      Role = "tool"
+)
+
+// Role is the role of the author of a chat message.
+type Role string
+
+// knownRoles are the message roles accepted by the chat completions endpoint.
+var knownRoles = map[Role]struct{}{
+	RoleSystem:    {},
+	RoleDeveloper: {},
+	RoleUser:      {},
+	RoleAssistant: {},
+	RoleTool:      {},
+}
+
+// Valid reports whether the role is known.
+func (r Role) Valid() bool {
+	_, ok := knownRoles[r]
+	return ok
+}
+
+// Validate returns an error matching ErrInvalidRequest if the role is unknown.
+func (r Role) Validate() error {
+	if !r.Valid() {
+		return fmt.Errorf("%w: unknown role %q", ErrInvalidRequest, r)
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, rejecting roles that aren't
+// strings. Unknown roles are kept, as OpenAI-compatible servers may define
+// their own, and are reported by Validate and Lint.
+func (r *Role) UnmarshalJSON(data []byte) error {
+	var role *string
+	if err := json.Unmarshal(data, &role); err != nil {
+		return fmt.Errorf("could not unmarshal role: %w", err)
+	}
+	if role != nil {
+		*r = Role(*role)
+	}
+	return nil
+}
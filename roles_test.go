@@ -0,0 +1,57 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRole_Validate(t *testing.T) {
+	t.Parallel()
+
+	for _, role := range []Role{RoleSystem, RoleDeveloper, RoleUser, RoleAssistant, RoleTool} {
+		assert.True(t, role.Valid(), role)
+		assert.NoError(t, role.Validate())
+	}
+
+	for _, role := range []Role{"", "User", "asistant"} {
+		assert.False(t, role.Valid(), role)
+		assert.ErrorIs(t, role.Validate(), ErrInvalidRequest)
+	}
+}
+
+func TestRole_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		want    Role
+		wantErr bool
+	}{
+		{name: "known", data: `{"role":"assistant"}`, want: RoleAssistant},
+		{name: "custom", data: `{"role":"critic"}`, want: "critic"},
+		{name: "null", data: `{"role":null}`},
+		{name: "number", data: `{"role":1}`, wantErr: true},
+		{name: "object", data: `{"role":{"name":"user"}}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var msg Message
+			err := json.Unmarshal([]byte(tt.data), &msg)
+
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "could not unmarshal role")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, msg.Role)
+		})
+	}
+}
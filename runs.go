@@ -0,0 +1,216 @@
+package openaiclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// Run statuses.
+const (
+	RunStatusQueued         = "queued"
+	RunStatusInProgress     = "in_progress"
+	RunStatusRequiresAction = "requires_action"
+	RunStatusCancelling     = "cancelling"
+	RunStatusCancelled      = "cancelled"
+	RunStatusFailed         = "failed"
+	RunStatusCompleted      = "completed"
+	RunStatusIncomplete     = "incomplete"
+	RunStatusExpired        = "expired"
+)
+
+type (
+	// RunRequest is the request body to create a run of an assistant on a thread.
+	// The fields but AssistantID override the assistant configuration for the run.
+	RunRequest struct {
+		AssistantID            string            `json:"assistant_id"`
+		Model                  string            `json:"model,omitempty"`
+		Instructions           string            `json:"instructions,omitempty"`
+		AdditionalInstructions string            `json:"additional_instructions,omitempty"`
+		AdditionalMessages     []MessageRequest  `json:"additional_messages,omitempty"`
+		Tools                  []AssistantTool   `json:"tools,omitempty"`
+		Metadata               map[string]string `json:"metadata,omitempty"`
+		Temperature            *float64          `json:"temperature,omitempty"`
+		TopP                   *float64          `json:"top_p,omitempty"`
+		MaxPromptTokens        int               `json:"max_prompt_tokens,omitempty"`
+		MaxCompletionTokens    int               `json:"max_completion_tokens,omitempty"`
+		ToolChoice             *ToolChoice       `json:"tool_choice,omitempty"`
+		ParallelToolCalls      *bool             `json:"parallel_tool_calls,omitempty"`
+		ResponseFormat         *ResponseFormat   `json:"response_format,omitempty"`
+	}
+
+	// Run is an execution of an assistant on a thread.
+	Run struct {
+		ResponseMeta
+		ID                  string             `json:"id"`
+		Object              string             `json:"object"`
+		CreatedAt           int64              `json:"created_at"`
+		ThreadID            string             `json:"thread_id"`
+		AssistantID         string             `json:"assistant_id"`
+		Status              string             `json:"status"`
+		RequiredAction      *RunRequiredAction `json:"required_action,omitempty"`
+		LastError           *RunError          `json:"last_error,omitempty"`
+		IncompleteDetails   *RunIncomplete     `json:"incomplete_details,omitempty"`
+		ExpiresAt           int64              `json:"expires_at,omitempty"`
+		StartedAt           int64              `json:"started_at,omitempty"`
+		CancelledAt         int64              `json:"cancelled_at,omitempty"`
+		FailedAt            int64              `json:"failed_at,omitempty"`
+		CompletedAt         int64              `json:"completed_at,omitempty"`
+		Model               string             `json:"model"`
+		Instructions        string             `json:"instructions"`
+		Tools               []AssistantTool    `json:"tools"`
+		Metadata            map[string]string  `json:"metadata"`
+		Usage               *Usage             `json:"usage,omitempty"`
+		Temperature         *float64           `json:"temperature,omitempty"`
+		TopP                *float64           `json:"top_p,omitempty"`
+		MaxPromptTokens     int                `json:"max_prompt_tokens,omitempty"`
+		MaxCompletionTokens int                `json:"max_completion_tokens,omitempty"`
+		ParallelToolCalls   bool               `json:"parallel_tool_calls"`
+	}
+
+	// RunRequiredAction is the action a run waits for, with RunStatusRequiresAction.
+	RunRequiredAction struct {
+		// Type is always "submit_tool_outputs".
+		Type              string               `json:"type"`
+		SubmitToolOutputs RunSubmitToolOutputs `json:"submit_tool_outputs"`
+	}
+
+	// RunSubmitToolOutputs are the tool calls whose outputs the run waits for.
+	RunSubmitToolOutputs struct {
+		ToolCalls []ToolCall `json:"tool_calls"`
+	}
+
+	// RunError is the error a run failed with.
+	RunError struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+
+	// RunIncomplete is the reason a run is incomplete.
+	RunIncomplete struct {
+		Reason string `json:"reason"`
+	}
+
+	// ToolOutput is the output of a tool call requested by a run.
+	ToolOutput struct {
+		ToolCallID string `json:"tool_call_id"`
+		Output     string `json:"output"`
+	}
+)
+
+// Pending reports whether the run is still being processed, as opposed to
+// awaiting an action or being over.
+func (r Run) Pending() bool {
+	switch r.Status {
+	case RunStatusQueued, RunStatusInProgress, RunStatusCancelling:
+		return true
+	}
+	return false
+}
+
+// CreateRun runs the assistant on the thread.
+func (c *Client) CreateRun(ctx context.Context, threadID string, in RunRequest) (*Run, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	if in.Model != "" {
+		in.Model = c.resolveModel(in.Model)
+		c.deprecations.check(in.Model)
+	}
+
+	var run Run
+	if err := c.post(ctx, c.threadURL(threadID)+"/runs", in, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ListRuns lists the runs of the thread.
+func (c *Client) ListRuns(ctx context.Context, threadID string, opts ListOptions) (*List[Run], error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var list List[Run]
+	if err := c.get(ctx, withQuery(c.threadURL(threadID)+"/runs", opts.query()), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetRun retrieves the run of the thread.
+func (c *Client) GetRun(ctx context.Context, threadID, runID string) (*Run, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var run Run
+	if err := c.get(ctx, c.runURL(threadID, runID), &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ModifyRun replaces the metadata of the run.
+func (c *Client) ModifyRun(ctx context.Context, threadID, runID string, metadata map[string]string) (*Run, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	in := struct {
+		Metadata map[string]string `json:"metadata"`
+	}{Metadata: metadata}
+
+	var run Run
+	if err := c.post(ctx, c.runURL(threadID, runID), in, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// CancelRun cancels the in progress run.
+func (c *Client) CancelRun(ctx context.Context, threadID, runID string) (*Run, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var run Run
+	if err := c.post(ctx, c.runURL(threadID, runID)+"/cancel", struct{}{}, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// SubmitToolOutputs submits the outputs of the tool calls the run requires,
+// resuming it. All outputs must be submitted at once.
+func (c *Client) SubmitToolOutputs(ctx context.Context, threadID, runID string, outputs []ToolOutput) (*Run, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	in := struct {
+		ToolOutputs []ToolOutput `json:"tool_outputs"`
+	}{ToolOutputs: outputs}
+
+	var run Run
+	if err := c.post(ctx, c.runURL(threadID, runID)+"/submit_tool_outputs", in, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// PollRun polls the run until it is no longer pending, i.e. it requires an
+// action such as submitting tool outputs, or it is over, and returns it.
+// If polling times out, the last polled run is returned along with a PollTimeoutError.
+func (c *Client) PollRun(ctx context.Context, threadID, runID string, opts ...PollOption) (*Run, error) {
+	return poll(ctx, c, opts, func(ctx context.Context) (*Run, error) {
+		return c.GetRun(ctx, threadID, runID)
+	}, func(run *Run) bool {
+		return run.Pending()
+	})
+}
+
+// runURL returns the URL of the run of the thread.
+func (c *Client) runURL(threadID, runID string) string {
+	return c.threadURL(threadID) + "/runs/" + url.PathEscape(runID)
+}
@@ -0,0 +1,123 @@
+package openaiclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Runs(t *testing.T) {
+	t.Parallel()
+
+	bodies := map[string]map[string]any{}
+	client := New("test_api_key", routeClient(t, map[string]any{
+		"POST /v1/threads/thread_1/runs":                           Run{ID: "run_1", Status: RunStatusQueued},
+		"GET /v1/threads/thread_1/runs?limit=1":                    List[Run]{Data: []Run{{ID: "run_1"}}},
+		"GET /v1/threads/thread_1/runs/run_1":                      Run{ID: "run_1", Status: RunStatusInProgress},
+		"POST /v1/threads/thread_1/runs/run_1":                     Run{ID: "run_1", Metadata: map[string]string{"job": "7"}},
+		"POST /v1/threads/thread_1/runs/run_1/cancel":              Run{ID: "run_1", Status: RunStatusCancelling},
+		"POST /v1/threads/thread_1/runs/run_1/submit_tool_outputs": Run{ID: "run_1", Status: RunStatusQueued},
+	}, bodies), WithBeta(BetaAssistants))
+
+	ctx := context.Background()
+
+	run, err := client.CreateRun(ctx, "thread_1", RunRequest{
+		AssistantID:            "asst_1",
+		AdditionalInstructions: "Be brief.",
+		ToolChoice:             ToolChoiceMode(ToolChoiceRequired),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, RunStatusQueued, run.Status)
+	assert.Equal(t, map[string]any{
+		"assistant_id":            "asst_1",
+		"additional_instructions": "Be brief.",
+		"tool_choice":             "required",
+	}, bodies["POST /v1/threads/thread_1/runs"])
+
+	list, err := client.ListRuns(ctx, "thread_1", ListOptions{Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, list.Data, 1)
+
+	run, err = client.GetRun(ctx, "thread_1", "run_1")
+	require.NoError(t, err)
+	assert.True(t, run.Pending())
+
+	run, err = client.ModifyRun(ctx, "thread_1", "run_1", map[string]string{"job": "7"})
+	require.NoError(t, err)
+	assert.Equal(t, "7", run.Metadata["job"])
+
+	run, err = client.CancelRun(ctx, "thread_1", "run_1")
+	require.NoError(t, err)
+	assert.Equal(t, RunStatusCancelling, run.Status)
+
+	run, err = client.SubmitToolOutputs(ctx, "thread_1", "run_1", []ToolOutput{{ToolCallID: "call_1", Output: `{"ok":true}`}})
+	require.NoError(t, err)
+	assert.Equal(t, RunStatusQueued, run.Status)
+	assert.Equal(t, map[string]any{
+		"tool_outputs": []any{map[string]any{"tool_call_id": "call_1", "output": `{"ok":true}`}},
+	}, bodies["POST /v1/threads/thread_1/runs/run_1/submit_tool_outputs"])
+}
+
+func TestClient_PollRun(t *testing.T) {
+	t.Parallel()
+
+	statuses := []string{RunStatusQueued, RunStatusInProgress, RunStatusRequiresAction}
+
+	var polls int
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/v1/threads/thread_1/runs/run_1", req.URL.Path)
+
+			run := Run{ID: "run_1", Status: statuses[polls]}
+			if run.Status == RunStatusRequiresAction {
+				run.RequiredAction = &RunRequiredAction{
+					Type: "submit_tool_outputs",
+					SubmitToolOutputs: RunSubmitToolOutputs{ToolCalls: []ToolCall{{
+						ID:       "call_1",
+						Type:     "function",
+						Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Lisbon"}`},
+					}}},
+				}
+			}
+
+			polls++
+			return jsonResponse(t, run), nil
+		},
+	}, WithBeta(BetaAssistants))
+
+	var sleeps []time.Duration
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+
+	run, err := client.PollRun(context.Background(), "thread_1", "run_1", WithPollInterval(200*time.Millisecond))
+	require.NoError(t, err)
+
+	assert.Equal(t, RunStatusRequiresAction, run.Status)
+	assert.Equal(t, "get_weather", run.RequiredAction.SubmitToolOutputs.ToolCalls[0].Function.Name)
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, []time.Duration{200 * time.Millisecond, 200 * time.Millisecond}, sleeps)
+}
+
+func TestRun_Pending(t *testing.T) {
+	t.Parallel()
+
+	for status, want := range map[string]bool{
+		RunStatusQueued:         true,
+		RunStatusInProgress:     true,
+		RunStatusCancelling:     true,
+		RunStatusRequiresAction: false,
+		RunStatusCompleted:      false,
+		RunStatusFailed:         false,
+		RunStatusCancelled:      false,
+		RunStatusExpired:        false,
+		RunStatusIncomplete:     false,
+	} {
+		assert.Equal(t, want, Run{Status: status}.Pending(), status)
+	}
+}
@@ -0,0 +1,156 @@
+package openaiclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// RunStepIncludeFileSearchResults includes the content of the file_search
+// results in the run steps, on top of their files and scores.
+const RunStepIncludeFileSearchResults = "step_details.tool_calls[*].file_search.results[*].content"
+
+type (
+	// RunStep is a step of a run: a message creation or tool calls.
+	RunStep struct {
+		ResponseMeta
+		ID          string `json:"id"`
+		Object      string `json:"object"`
+		CreatedAt   int64  `json:"created_at"`
+		AssistantID string `json:"assistant_id"`
+		ThreadID    string `json:"thread_id"`
+		RunID       string `json:"run_id"`
+		// Type is "message_creation" or "tool_calls".
+		Type        string         `json:"type"`
+		Status      string         `json:"status"`
+		StepDetails RunStepDetails `json:"step_details"`
+		LastError   *RunError      `json:"last_error,omitempty"`
+		CompletedAt int64          `json:"completed_at,omitempty"`
+		Usage       *Usage         `json:"usage,omitempty"`
+	}
+
+	// RunStepDetails are the details of a run step, depending on its type.
+	RunStepDetails struct {
+		Type            string                  `json:"type"`
+		MessageCreation *RunStepMessageCreation `json:"message_creation,omitempty"`
+		ToolCalls       []RunStepToolCall       `json:"tool_calls,omitempty"`
+	}
+
+	// RunStepMessageCreation is the message created by a run step.
+	RunStepMessageCreation struct {
+		MessageID string `json:"message_id"`
+	}
+
+	// RunStepToolCall is a tool call of a run step, of type
+	// AssistantToolCodeInterpreter, AssistantToolFileSearch or AssistantToolFunction.
+	RunStepToolCall struct {
+		ID              string               `json:"id"`
+		Type            string               `json:"type"`
+		CodeInterpreter *CodeInterpreterCall `json:"code_interpreter,omitempty"`
+		FileSearch      *FileSearchCall      `json:"file_search,omitempty"`
+		Function        *RunStepFunctionCall `json:"function,omitempty"`
+	}
+
+	// CodeInterpreterCall is a call to the code_interpreter tool.
+	CodeInterpreterCall struct {
+		Input   string                  `json:"input"`
+		Outputs []CodeInterpreterOutput `json:"outputs"`
+	}
+
+	// CodeInterpreterOutput is an output of the code_interpreter tool, of type "logs" or "image".
+	CodeInterpreterOutput struct {
+		Type  string            `json:"type"`
+		Logs  string            `json:"logs,omitempty"`
+		Image *MessageImageFile `json:"image,omitempty"`
+	}
+
+	// FileSearchCall is a call to the file_search tool, with the ranked chunks it retrieved.
+	FileSearchCall struct {
+		RankingOptions *FileSearchRankingOptions `json:"ranking_options,omitempty"`
+		Results        []FileSearchResult        `json:"results,omitempty"`
+	}
+
+	// FileSearchRankingOptions configures how file_search ranks chunks.
+	FileSearchRankingOptions struct {
+		// Ranker is e.g. "auto" or "default_2024_08_21".
+		Ranker string `json:"ranker,omitempty"`
+		// ScoreThreshold drops the chunks scoring less, between 0 and 1.
+		ScoreThreshold float64 `json:"score_threshold"`
+	}
+
+	// FileSearchResult is a chunk retrieved by file_search. Content is only set
+	// with RunStepIncludeFileSearchResults.
+	FileSearchResult struct {
+		FileID   string                    `json:"file_id"`
+		FileName string                    `json:"file_name"`
+		Score    float64                   `json:"score"`
+		Content  []FileSearchResultContent `json:"content,omitempty"`
+	}
+
+	// FileSearchResultContent is the content of a retrieved chunk.
+	FileSearchResultContent struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+
+	// RunStepFunctionCall is a call to a function tool, with its output once submitted.
+	RunStepFunctionCall struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+		Output    string `json:"output,omitempty"`
+	}
+
+	// RunStepListOptions paginates the run step listing and selects the extra fields to include.
+	RunStepListOptions struct {
+		ListOptions
+		// Include are extra fields such as RunStepIncludeFileSearchResults.
+		Include []string
+	}
+)
+
+// FileSearchResults returns the chunks retrieved by the file_search calls of the step, in ranking order.
+func (s RunStep) FileSearchResults() []FileSearchResult {
+	var results []FileSearchResult
+	for _, call := range s.StepDetails.ToolCalls {
+		if call.FileSearch != nil {
+			results = append(results, call.FileSearch.Results...)
+		}
+	}
+	return results
+}
+
+// ListRunSteps lists the steps of the run, e.g. to inspect the chunks
+// file_search ranked when debugging retrieval quality.
+func (c *Client) ListRunSteps(ctx context.Context, threadID, runID string, opts RunStepListOptions) (*List[RunStep], error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	query := opts.query()
+	for _, include := range opts.Include {
+		query.Add("include[]", include)
+	}
+
+	var list List[RunStep]
+	if err := c.get(ctx, withQuery(c.runURL(threadID, runID)+"/steps", query), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetRunStep retrieves the step of the run, with the given extra fields.
+func (c *Client) GetRunStep(ctx context.Context, threadID, runID, stepID string, include ...string) (*RunStep, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	for _, field := range include {
+		query.Add("include[]", field)
+	}
+
+	var step RunStep
+	if err := c.get(ctx, withQuery(c.runURL(threadID, runID)+"/steps/"+url.PathEscape(stepID), query), &step); err != nil {
+		return nil, err
+	}
+	return &step, nil
+}
@@ -0,0 +1,82 @@
+package openaiclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListRunSteps(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/v1/threads/thread_1/runs/run_1/steps", req.URL.Path)
+			assert.Equal(t, []string{RunStepIncludeFileSearchResults}, req.URL.Query()["include[]"])
+			assert.Equal(t, "10", req.URL.Query().Get("limit"))
+
+			return jsonResponse(t, map[string]any{
+				"object": "list",
+				"data": []map[string]any{{
+					"id":   "step_1",
+					"type": "tool_calls",
+					"step_details": map[string]any{
+						"type": "tool_calls",
+						"tool_calls": []map[string]any{{
+							"id":   "call_1",
+							"type": "file_search",
+							"file_search": map[string]any{
+								"ranking_options": map[string]any{"ranker": "default_2024_08_21", "score_threshold": 0.5},
+								"results": []map[string]any{
+									{"file_id": "file_1", "file_name": "manual.pdf", "score": 0.92, "content": []map[string]any{{"type": "text", "text": "Hold the button."}}},
+									{"file_id": "file_2", "file_name": "faq.md", "score": 0.61},
+								},
+							},
+						}},
+					},
+				}, {
+					"id":           "step_2",
+					"type":         "message_creation",
+					"step_details": map[string]any{"type": "message_creation", "message_creation": map[string]any{"message_id": "msg_1"}},
+				}},
+			}), nil
+		},
+	}, WithBeta(BetaAssistants))
+
+	list, err := client.ListRunSteps(context.Background(), "thread_1", "run_1", RunStepListOptions{
+		ListOptions: ListOptions{Limit: 10},
+		Include:     []string{RunStepIncludeFileSearchResults},
+	})
+	require.NoError(t, err)
+	require.Len(t, list.Data, 2)
+
+	step := list.Data[0]
+	assert.Equal(t, "default_2024_08_21", step.StepDetails.ToolCalls[0].FileSearch.RankingOptions.Ranker)
+	assert.Equal(t, []FileSearchResult{
+		{FileID: "file_1", FileName: "manual.pdf", Score: 0.92, Content: []FileSearchResultContent{{Type: "text", Text: "Hold the button."}}},
+		{FileID: "file_2", FileName: "faq.md", Score: 0.61},
+	}, step.FileSearchResults())
+
+	assert.Empty(t, list.Data[1].FileSearchResults())
+	assert.Equal(t, "msg_1", list.Data[1].StepDetails.MessageCreation.MessageID)
+}
+
+func TestClient_GetRunStep(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, BetaAssistantsV2, req.Header.Get("OpenAI-Beta"))
+			assert.Equal(t, "/v1/threads/thread_1/runs/run_1/steps/step_1", req.URL.Path)
+			assert.Equal(t, []string{RunStepIncludeFileSearchResults}, req.URL.Query()["include[]"])
+			return jsonResponse(t, RunStep{ID: "step_1", Status: "completed"}), nil
+		},
+	}, WithBeta(BetaAssistants))
+
+	step, err := client.GetRunStep(context.Background(), "thread_1", "run_1", "step_1", RunStepIncludeFileSearchResults)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", step.Status)
+}
@@ -0,0 +1,88 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrStreamInterrupted is matched by errors.Is for every StreamInterruptedError.
+var ErrStreamInterrupted = errors.New("stream interrupted")
+
+// StreamInterruptedError is returned when a stream fails after its first chunk,
+// such as on a connection reset. It holds what was received before the failure.
+type StreamInterruptedError struct {
+	// Partial is the text of the first choice received so far.
+	Partial string
+	// Response is the response accumulated so far.
+	Response *ChatCompletionResponse
+	Err      error
+}
+
+// newStreamInterruptedError returns the error interrupting the accumulated response.
+func newStreamInterruptedError(resp *ChatCompletionResponse, err error) *StreamInterruptedError {
+	e := StreamInterruptedError{Response: resp, Err: err}
+	if len(resp.Choices) > 0 {
+		e.Partial = resp.Choices[0].Message.Content
+	}
+	return &e
+}
+
+// Error implements the error interface.
+func (e *StreamInterruptedError) Error() string {
+	return "stream interrupted: " + e.Err.Error()
+}
+
+// Unwrap returns the cause of the interruption.
+func (e *StreamInterruptedError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrStreamInterrupted.
+func (e *StreamInterruptedError) Is(target error) bool {
+	return target == ErrStreamInterrupted
+}
+
+// AccumulateChatCompletion streams the chat completion into the accumulator.
+// When the stream is interrupted, it re-sends the request with the partial
+// answer as an assistant prefill, up to maxRetries times, so the model picks
+// up where it stopped and the accumulator keeps growing the same answer.
+// Only single choice answers without tool calls are resumed; API errors,
+// aborted streams and canceled contexts are returned as is.
+func (c *Client) AccumulateChatCompletion(ctx context.Context, in ChatCompletionRequest, acc *StreamAccumulator, maxRetries int) (*ChatCompletionResponse, error) {
+	// A prefilled request is resumed from its prefix, which the model doesn't repeat.
+	var prefix string
+	base := in
+	if n := len(in.Messages); n > 0 && in.Messages[n-1].Prefix {
+		prefix = in.Messages[n-1].Content
+		base = in.Clone()
+		base.Messages = base.Messages[:n-1]
+	}
+
+	for retry := 0; ; retry++ {
+		stream, err := c.CreateChatCompletionStream(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := stream.Accumulate(acc)
+		stream.Close()
+
+		var interrupted *StreamInterruptedError
+		if err == nil || retry == maxRetries || !errors.As(err, &interrupted) || !resumable(ctx, interrupted) {
+			return resp, err
+		}
+
+		in = base.WithPrefill(prefix + interrupted.Partial)
+	}
+}
+
+// resumable reports whether the interrupted stream can be resumed from its partial answer.
+func resumable(ctx context.Context, e *StreamInterruptedError) bool {
+	var apiErr *APIError
+	if ctx.Err() != nil || errors.As(e.Err, &apiErr) || errors.Is(e.Err, ErrWatermarkExceeded) {
+		return false
+	}
+
+	choices := e.Response.Choices
+	return len(choices) == 1 && len(choices[0].Message.ToolCalls) == 0
+}
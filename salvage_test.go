@@ -0,0 +1,136 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// interruptedStream is the body of a stream sending the content, then failing.
+func interruptedStream(t *testing.T, content string) string {
+	t.Helper()
+
+	data, err := json.Marshal(ChatCompletionChunk{ID: "chatcmpl-1", Choices: []ChunkChoice{{Delta: MessageDelta{Content: content}}}})
+	require.NoError(t, err)
+	return "data: " + string(data) + "\n\n"
+}
+
+func TestChatCompletionStream_AccumulateInterrupted(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return sseResponse(interruptedStream(t, "The answer")), nil
+		},
+	})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	_, err = stream.Accumulate(NewStreamAccumulator())
+
+	var interrupted *StreamInterruptedError
+	require.ErrorAs(t, err, &interrupted)
+	assert.ErrorIs(t, err, ErrStreamInterrupted)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, "The answer", interrupted.Partial)
+	assert.Equal(t, "chatcmpl-1", interrupted.Response.ID)
+}
+
+func TestClient_AccumulateChatCompletion(t *testing.T) {
+	t.Parallel()
+
+	var reqs []ChatCompletionRequest
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in ChatCompletionRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+			reqs = append(reqs, in)
+
+			switch len(reqs) {
+			case 1:
+				return sseResponse(interruptedStream(t, "The answer")), nil
+			case 2:
+				return sseResponse(interruptedStream(t, " is")), nil
+			default:
+				return sseResponse(sseEvents(t, ChatCompletionChunk{ID: "chatcmpl-3", Choices: []ChunkChoice{{Delta: MessageDelta{Content: " 42."}, FinishReason: "stop"}}})), nil
+			}
+		},
+	})
+
+	var tokens []string
+	acc := NewStreamAccumulator().OnToken(func(_ int, token string) {
+		tokens = append(tokens, token)
+	})
+
+	in := ChatCompletionRequest{Model: "gpt-4o", Messages: []Message{
+		{Role: "user", Content: "What is the answer?"},
+		{Role: "assistant", Content: "Well,", Prefix: true},
+	}}
+	resp, err := client.AccumulateChatCompletion(context.Background(), in, acc, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, "The answer is 42.", resp.Choices[0].Message.Content)
+	assert.Equal(t, "stop", resp.Choices[0].FinishReason)
+	assert.Equal(t, []string{"The answer", " is", " 42."}, tokens)
+
+	require.Len(t, reqs, 3)
+	assert.Equal(t, in.Messages, reqs[0].Messages)
+	assert.Equal(t, []Message{in.Messages[0], {Role: "assistant", Content: "Well,The answer", Prefix: true}}, reqs[1].Messages)
+	assert.Equal(t, []Message{in.Messages[0], {Role: "assistant", Content: "Well,The answer is", Prefix: true}}, reqs[2].Messages)
+}
+
+func TestClient_AccumulateChatCompletion_NotResumed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       string
+		maxRetries int
+		wantErr    error
+	}{
+		{
+			name:    "no retries",
+			body:    "data: {\"choices\":[{\"delta\":{\"content\":\"Hel\"}}]}\n\n",
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:       "error event",
+			body:       "data: {\"choices\":[{\"delta\":{\"content\":\"Hel\"}}]}\n\ndata: {\"error\":{\"message\":\"The server had an error\"}}\n\n",
+			maxRetries: 3,
+			wantErr:    ErrStreamInterrupted,
+		},
+		{
+			name:       "several choices",
+			body:       "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hel\"}},{\"index\":1,\"delta\":{\"content\":\"Hi\"}}]}\n\n",
+			maxRetries: 3,
+			wantErr:    io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					calls++
+					return sseResponse(tt.body), nil
+				},
+			})
+
+			_, err := client.AccumulateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"}, NewStreamAccumulator(), tt.maxRetries)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, 1, calls)
+		})
+	}
+}
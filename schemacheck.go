@@ -0,0 +1,252 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type (
+	// ToolSchemaChecker compares the tool schemas with the arguments the model
+	// actually generates over a sample run, to guide schema tightening.
+	// It is safe for concurrent use.
+	ToolSchemaChecker struct {
+		schemas map[string]*toolSchema
+
+		mu    sync.Mutex
+		usage map[string]*toolUsage
+	}
+
+	// ToolSchemaReport is how the model filled the arguments of a tool.
+	// Properties are named by path, such as "address.city" or "items[].sku".
+	ToolSchemaReport struct {
+		Tool string
+		// Registered reports whether the schema of the tool is known;
+		// the arguments of unknown tools aren't checked.
+		Registered bool
+		Calls      int
+		// InvalidCalls are the calls whose arguments aren't a JSON object.
+		InvalidCalls int
+		// Filled counts the calls filling each schema property with a non-null value.
+		Filled map[string]int
+		// Unused are the schema properties no call filled, in sorted order.
+		Unused []string
+		// Hallucinated counts the calls filling each property missing from the schema.
+		Hallucinated map[string]int
+	}
+
+	// toolSchema holds the property paths of a tool schema.
+	toolSchema struct {
+		known map[string]struct{}
+		// free are the paths of the objects accepting any property.
+		free map[string]struct{}
+	}
+
+	// toolUsage accumulates the calls of a tool.
+	toolUsage struct {
+		calls        int
+		invalid      int
+		filled       map[string]int
+		hallucinated map[string]int
+	}
+)
+
+// NewToolSchemaChecker creates a checker of the tools' schemas, such as the
+// tools of a ToolRunner. Objects without properties, or with typed or allowed
+// additional properties, accept any property; references aren't resolved.
+func NewToolSchemaChecker(tools ...Tool) (*ToolSchemaChecker, error) {
+	c := ToolSchemaChecker{
+		schemas: make(map[string]*toolSchema, len(tools)),
+		usage:   make(map[string]*toolUsage),
+	}
+
+	for _, tool := range tools {
+		schema := toolSchema{known: map[string]struct{}{}, free: map[string]struct{}{}}
+
+		if len(tool.Function.Parameters) == 0 {
+			schema.free[""] = struct{}{}
+		} else {
+			var root map[string]any
+			if err := json.Unmarshal(tool.Function.Parameters, &root); err != nil {
+				return nil, fmt.Errorf("could not parse parameters of tool %s: %w", tool.Function.Name, err)
+			}
+			schema.add(root, "")
+		}
+		c.schemas[tool.Function.Name] = &schema
+	}
+	return &c, nil
+}
+
+// add records the properties of the schema found at the path.
+func (s *toolSchema) add(schema map[string]any, path string) {
+	if _, ok := schema["$ref"]; ok {
+		s.free[path] = struct{}{}
+		return
+	}
+
+	properties, hasProperties := schema["properties"].(map[string]any)
+	for name, property := range properties {
+		child := joinSchemaPath(path, name)
+		s.known[child] = struct{}{}
+		if property, ok := property.(map[string]any); ok {
+			s.add(property, child)
+		}
+	}
+
+	switch additional := schema["additionalProperties"].(type) {
+	case bool:
+		if additional {
+			s.free[path] = struct{}{}
+		}
+	case map[string]any:
+		s.free[path] = struct{}{}
+	case nil:
+		if !hasProperties && isObjectSchema(schema) {
+			s.free[path] = struct{}{}
+		}
+	}
+
+	if items, ok := schema["items"].(map[string]any); ok {
+		s.add(items, path+"[]")
+	}
+
+	for _, keyword := range []string{"anyOf", "oneOf", "allOf"} {
+		variants, _ := schema[keyword].([]any)
+		for _, variant := range variants {
+			if variant, ok := variant.(map[string]any); ok {
+				s.add(variant, path)
+			}
+		}
+	}
+}
+
+// isObjectSchema reports whether the schema describes objects.
+func isObjectSchema(schema map[string]any) bool {
+	switch typ := schema["type"].(type) {
+	case string:
+		return typ == "object"
+	case []any:
+		for _, t := range typ {
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// collect adds the paths of the value found at the path to filled, or to
+// hallucinated for the properties missing from the schema.
+func (s *toolSchema) collect(v any, path string, filled, hallucinated map[string]struct{}) {
+	switch v := v.(type) {
+	case map[string]any:
+		if _, ok := s.free[path]; ok {
+			return
+		}
+
+		for name, value := range v {
+			child := joinSchemaPath(path, name)
+			if _, ok := s.known[child]; !ok {
+				hallucinated[child] = struct{}{}
+				continue
+			}
+			if value == nil {
+				continue
+			}
+			filled[child] = struct{}{}
+			s.collect(value, child, filled, hallucinated)
+		}
+	case []any:
+		for _, item := range v {
+			s.collect(item, path+"[]", filled, hallucinated)
+		}
+	}
+}
+
+func joinSchemaPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// Observe records the arguments of the tool calls.
+func (c *ToolSchemaChecker) Observe(calls ...ToolCall) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, call := range calls {
+		usage, ok := c.usage[call.Function.Name]
+		if !ok {
+			usage = &toolUsage{filled: map[string]int{}, hallucinated: map[string]int{}}
+			c.usage[call.Function.Name] = usage
+		}
+		usage.calls++
+
+		schema, ok := c.schemas[call.Function.Name]
+		if !ok {
+			continue
+		}
+
+		var arguments map[string]any
+		if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil || arguments == nil {
+			usage.invalid++
+			continue
+		}
+
+		filled, hallucinated := map[string]struct{}{}, map[string]struct{}{}
+		schema.collect(arguments, "", filled, hallucinated)
+		for path := range filled {
+			usage.filled[path]++
+		}
+		for path := range hallucinated {
+			usage.hallucinated[path]++
+		}
+	}
+}
+
+// Report returns the report of every registered or called tool, sorted by name.
+func (c *ToolSchemaChecker) Report() []ToolSchemaReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.schemas))
+	for name := range c.schemas {
+		names = append(names, name)
+	}
+	for name := range c.usage {
+		if _, ok := c.schemas[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	reports := make([]ToolSchemaReport, 0, len(names))
+	for _, name := range names {
+		report := ToolSchemaReport{Tool: name, Filled: map[string]int{}, Hallucinated: map[string]int{}}
+
+		if usage, ok := c.usage[name]; ok {
+			report.Calls = usage.calls
+			report.InvalidCalls = usage.invalid
+			for path, n := range usage.filled {
+				report.Filled[path] = n
+			}
+			for path, n := range usage.hallucinated {
+				report.Hallucinated[path] = n
+			}
+		}
+
+		if schema, ok := c.schemas[name]; ok {
+			report.Registered = true
+			for path := range schema.known {
+				if report.Filled[path] == 0 {
+					report.Unused = append(report.Unused, path)
+				}
+			}
+			sort.Strings(report.Unused)
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
@@ -0,0 +1,67 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolSchemaChecker(t *testing.T) {
+	t.Parallel()
+
+	order := NewFunctionTool("create_order", "Creates an order.", json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"customer": {"type": "string"},
+			"note": {"type": ["string", "null"]},
+			"address": {
+				"type": "object",
+				"properties": {"city": {"type": "string"}, "zip": {"type": "string"}}
+			},
+			"items": {
+				"type": "array",
+				"items": {"type": "object", "properties": {"sku": {"type": "string"}, "quantity": {"type": "integer"}}}
+			},
+			"metadata": {"type": "object"}
+		}
+	}`))
+	ping := NewFunctionTool("ping", "", nil)
+
+	checker, err := NewToolSchemaChecker(order, ping)
+	require.NoError(t, err)
+
+	call := func(name, arguments string) ToolCall {
+		return ToolCall{Type: "function", Function: FunctionCall{Name: name, Arguments: arguments}}
+	}
+	checker.Observe(
+		call("create_order", `{"customer":"c1","note":null,"items":[{"sku":"a","qty":2},{"sku":"b","qty":1}],"metadata":{"source":"web"}}`),
+		call("create_order", `{"customer":"c2","priority":"high","address":{"city":"Paris","country":"FR"}}`),
+		call("create_order", `{"customer":`),
+		call("ping", `{"anything":true}`),
+		call("refund", `{"order":"o1"}`),
+	)
+
+	assert.Equal(t, []ToolSchemaReport{
+		{
+			Tool:         "create_order",
+			Registered:   true,
+			Calls:        3,
+			InvalidCalls: 1,
+			Filled:       map[string]int{"customer": 2, "items": 1, "items[].sku": 1, "metadata": 1, "address": 1, "address.city": 1},
+			Unused:       []string{"address.zip", "items[].quantity", "note"},
+			Hallucinated: map[string]int{"items[].qty": 1, "priority": 1, "address.country": 1},
+		},
+		{Tool: "ping", Registered: true, Calls: 1, Filled: map[string]int{}, Hallucinated: map[string]int{}},
+		{Tool: "refund", Calls: 1, Filled: map[string]int{}, Hallucinated: map[string]int{}},
+	}, checker.Report())
+}
+
+func TestNewToolSchemaChecker_InvalidSchema(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewToolSchemaChecker(NewFunctionTool("broken", "", json.RawMessage(`{"type":`)))
+
+	assert.Error(t, err)
+}
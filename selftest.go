@@ -0,0 +1,153 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// Self-test check names.
+const (
+	CheckConnectivity = "connectivity"
+	CheckAuth         = "auth"
+	CheckModel        = "model"
+	CheckStreaming    = "streaming"
+)
+
+type (
+	// SelfTestReport is the outcome of Client.SelfTest.
+	SelfTestReport struct {
+		Checks []SelfTestCheck
+	}
+
+	// SelfTestCheck is the outcome of a single self-test check.
+	SelfTestCheck struct {
+		Name string
+		// Target is the model checked, if any.
+		Target   string
+		Err      error
+		Duration time.Duration
+	}
+)
+
+// Passed reports whether every check passed.
+func (r SelfTestReport) Passed() bool {
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Err returns the errors of the failed checks joined, or nil if every check passed.
+func (r SelfTestReport) Err() error {
+	var errs []error
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			errs = append(errs, check.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SelfTest verifies the client can reach the API through the configured
+// gateway, that its key is accepted, that every given model is available and
+// that chat completions stream with the first model. It is meant for
+// deploy-time smoke checks; the streaming check consumes a single token.
+// Checks depending on a failed one are skipped.
+func (c *Client) SelfTest(ctx context.Context, models ...string) SelfTestReport {
+	var report SelfTestReport
+
+	run := func(name, target string, check func() error) bool {
+		startedAt := time.Now()
+		err := check()
+		if err != nil {
+			if target != "" {
+				err = fmt.Errorf("%s check failed for %s: %w", name, target, err)
+			} else {
+				err = fmt.Errorf("%s check failed: %w", name, err)
+			}
+		}
+
+		report.Checks = append(report.Checks, SelfTestCheck{
+			Name:     name,
+			Target:   target,
+			Err:      err,
+			Duration: time.Since(startedAt),
+		})
+		return err == nil
+	}
+
+	var listErr error
+	connected := run(CheckConnectivity, "", func() error {
+		_, listErr = c.ListModels(ctx)
+
+		// Any API response proves connectivity; authentication is checked next.
+		var apiErr *APIError
+		if errors.As(listErr, &apiErr) {
+			return nil
+		}
+		return listErr
+	})
+	if !connected {
+		return report
+	}
+
+	if !run(CheckAuth, "", func() error { return listErr }) {
+		return report
+	}
+
+	for _, model := range models {
+		model := model
+		run(CheckModel, model, func() error {
+			_, err := c.GetModel(ctx, model)
+			return err
+		})
+	}
+
+	if len(models) > 0 {
+		run(CheckStreaming, models[0], func() error {
+			return c.checkStreaming(ctx, models[0])
+		})
+	}
+	return report
+}
+
+// checkStreaming requests a one token streamed completion and verifies the
+// response is an event stream, which buffering gateways tend to break.
+func (c *Client) checkStreaming(ctx context.Context, model string) error {
+	in := streamRequest{
+		ChatCompletionRequest: ChatCompletionRequest{
+			Model:     model,
+			Messages:  []Message{{Role: RoleUser, Content: "ping"}},
+			MaxTokens: 1,
+		},
+		Stream: true,
+	}
+
+	jsonData, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("could not marshal data: %w", err)
+	}
+
+	resp, err := c.send(ctx, http.MethodPost, c.url(EndpointChatCompletions), &requestBody{data: jsonData, contentType: "application/json"})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/event-stream" {
+		return fmt.Errorf("unexpected content type %q", resp.Header.Get("Content-Type"))
+	}
+	return nil
+}
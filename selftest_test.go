@@ -0,0 +1,121 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SelfTest(t *testing.T) {
+	t.Parallel()
+
+	// newServer simulates the API, available models and whether the gateway streams.
+	newServer := func(validKey string, models map[string]bool, streams bool) HTTPClient {
+		return &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				if req.Header.Get("Authorization") != "Bearer "+validKey {
+					return &http.Response{StatusCode: 401, Body: io.NopCloser(strings.NewReader(`{"error":{"message":"bad key"}}`))}, nil
+				}
+
+				switch {
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/models":
+					return jsonResponse(t, map[string]any{"object": "list"}), nil
+				case req.Method == http.MethodGet:
+					if models[strings.TrimPrefix(req.URL.Path, "/v1/models/")] {
+						return jsonResponse(t, map[string]any{"object": "model"}), nil
+					}
+					return &http.Response{StatusCode: 404, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+				default:
+					var in struct {
+						Stream    bool `json:"stream"`
+						MaxTokens int  `json:"max_tokens"`
+					}
+					require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+					assert.True(t, in.Stream)
+					assert.Equal(t, 1, in.MaxTokens)
+
+					contentType := "application/json"
+					if streams {
+						contentType = "text/event-stream; charset=utf-8"
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Header:     http.Header{"Content-Type": {contentType}},
+						Body:       io.NopCloser(strings.NewReader("data: [DONE]\n\n")),
+					}, nil
+				}
+			},
+		}
+	}
+
+	names := func(report SelfTestReport) []string {
+		var names []string
+		for _, check := range report.Checks {
+			names = append(names, check.Name+":"+check.Target)
+		}
+		return names
+	}
+
+	t.Run("passes", func(t *testing.T) {
+		t.Parallel()
+
+		client := New("test_api_key", newServer("test_api_key", map[string]bool{"gpt-4o": true}, true))
+
+		report := client.SelfTest(context.Background(), "gpt-4o")
+
+		assert.True(t, report.Passed())
+		assert.NoError(t, report.Err())
+		assert.Equal(t, []string{"connectivity:", "auth:", "model:gpt-4o", "streaming:gpt-4o"}, names(report))
+	})
+
+	t.Run("fails authentication", func(t *testing.T) {
+		t.Parallel()
+
+		client := New("wrong_key", newServer("test_api_key", nil, true))
+
+		report := client.SelfTest(context.Background(), "gpt-4o")
+
+		assert.False(t, report.Passed())
+		assert.Equal(t, []string{"connectivity:", "auth:"}, names(report))
+
+		var apiErr *APIError
+		require.True(t, errors.As(report.Checks[1].Err, &apiErr))
+		assert.Equal(t, 401, apiErr.StatusCode)
+	})
+
+	t.Run("fails connectivity", func(t *testing.T) {
+		t.Parallel()
+
+		client := New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			},
+		})
+
+		report := client.SelfTest(context.Background())
+
+		assert.False(t, report.Passed())
+		assert.Equal(t, []string{"connectivity:"}, names(report))
+	})
+
+	t.Run("reports unavailable models and buffering gateways", func(t *testing.T) {
+		t.Parallel()
+
+		client := New("test_api_key", newServer("test_api_key", map[string]bool{"gpt-4o": true}, false))
+
+		report := client.SelfTest(context.Background(), "gpt-4o", "gpt-5-preview")
+
+		assert.False(t, report.Passed())
+		require.Len(t, report.Checks, 5)
+		assert.NoError(t, report.Checks[2].Err)
+		assert.Error(t, report.Checks[3].Err)
+		assert.ErrorContains(t, report.Checks[4].Err, "unexpected content type")
+	})
+}
@@ -0,0 +1,42 @@
+package openaiclient
+
+import (
+	"crypto/hmac"
+	"encoding/hex"
+	"hash"
+	"net/http"
+)
+
+// DefaultSignatureHeader is the header carrying the request signature when none is configured.
+const DefaultSignatureHeader = "X-Signature"
+
+// requestSigner signs request bodies with an HMAC.
+type requestSigner struct {
+	header  string
+	newHash func() hash.Hash
+	key     []byte
+}
+
+// WithRequestSigning signs every request body with an HMAC using the given hash
+// function (e.g. sha256.New) and key. The hex encoded signature is sent in the
+// given header, or in DefaultSignatureHeader if header is empty.
+func WithRequestSigning(header string, newHash func() hash.Hash, key []byte) Option {
+	if header == "" {
+		header = DefaultSignatureHeader
+	}
+
+	return func(c *Client) {
+		c.signer = &requestSigner{
+			header:  header,
+			newHash: newHash,
+			key:     key,
+		}
+	}
+}
+
+// sign sets the signature header for the given body on the request.
+func (s *requestSigner) sign(req *http.Request, body []byte) {
+	mac := hmac.New(s.newHash, s.key)
+	mac.Write(body)
+	req.Header.Set(s.header, hex.EncodeToString(mac.Sum(nil)))
+}
@@ -0,0 +1,82 @@
+package openaiclient
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequestSigning(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		header     string
+		wantHeader string
+	}{
+		{
+			name:       "signs with the default header",
+			header:     "",
+			wantHeader: DefaultSignatureHeader,
+		},
+		{
+			name:       "signs with a custom header",
+			header:     "X-Gateway-Signature",
+			wantHeader: "X-Gateway-Signature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := []byte("test_key")
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					body, err := io.ReadAll(req.Body)
+					require.NoError(t, err)
+
+					mac := hmac.New(sha256.New, key)
+					mac.Write(body)
+
+					assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), req.Header.Get(tt.wantHeader))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       io.NopCloser(strings.NewReader("{}")),
+					}, nil
+				},
+			}, WithRequestSigning(tt.header, sha256.New, key))
+
+			_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "test_model"})
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("uses the configured algorithm", func(t *testing.T) {
+		t.Parallel()
+
+		client := New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				signature, err := hex.DecodeString(req.Header.Get(DefaultSignatureHeader))
+				require.NoError(t, err)
+
+				assert.Len(t, signature, sha512.Size)
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader("{}")),
+				}, nil
+			},
+		}, WithRequestSigning("", sha512.New, []byte("test_key")))
+
+		_, err := client.CreateEmbedding(context.Background(), EmbeddingRequest{})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,74 @@
+package openaiclient
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrDimensionMismatch is matched by errors.Is for EmbeddingMismatchErrors
+	// between vectors of different dimensions.
+	ErrDimensionMismatch = errors.New("embedding dimension mismatch")
+	// ErrModelMismatch is matched by errors.Is for EmbeddingMismatchErrors
+	// between vectors of different models.
+	ErrModelMismatch = errors.New("embedding model mismatch")
+)
+
+// EmbeddingMismatchError is returned when comparing vectors that aren't
+// comparable: their similarity would be meaningless.
+type EmbeddingMismatchError struct {
+	QueryDimensions  int
+	StoredDimensions int
+	// QueryModel and StoredModel are the models of the vectors, if recorded.
+	QueryModel  string
+	StoredModel string
+}
+
+// Error implements the error interface.
+func (e *EmbeddingMismatchError) Error() string {
+	if e.modelMismatch() {
+		return fmt.Sprintf("embedding model mismatch: query embedded with %q, stored vector with %q", e.QueryModel, e.StoredModel)
+	}
+	return fmt.Sprintf("embedding dimension mismatch: query has %d dimensions, stored vector %d", e.QueryDimensions, e.StoredDimensions)
+}
+
+// Is reports whether target is ErrModelMismatch or ErrDimensionMismatch, as applicable.
+func (e *EmbeddingMismatchError) Is(target error) bool {
+	switch target {
+	case ErrModelMismatch:
+		return e.modelMismatch()
+	case ErrDimensionMismatch:
+		return e.QueryDimensions != e.StoredDimensions
+	}
+	return false
+}
+
+// modelMismatch reports whether both models are recorded and differ.
+func (e *EmbeddingMismatchError) modelMismatch() bool {
+	return e.QueryModel != "" && e.StoredModel != "" && e.QueryModel != e.StoredModel
+}
+
+// CheckedCosineSimilarity returns the cosine similarity of two vectors, or
+// an EmbeddingMismatchError if their dimensions differ.
+func CheckedCosineSimilarity(query, stored []float32) (float64, error) {
+	return CompareEmbeddings(EmbeddingRecord{Vector: query}, EmbeddingRecord{Vector: stored})
+}
+
+// CompareEmbeddings returns the cosine similarity of the query and stored
+// vectors, or an EmbeddingMismatchError if their dimensions differ or both
+// record their model and the models differ.
+func CompareEmbeddings(query, stored EmbeddingRecord) (float64, error) {
+	mismatch := EmbeddingMismatchError{
+		QueryDimensions:  len(query.Vector),
+		StoredDimensions: len(stored.Vector),
+		QueryModel:       query.Model,
+		StoredModel:      stored.Model,
+	}
+	if mismatch.modelMismatch() || mismatch.QueryDimensions != mismatch.StoredDimensions {
+		if stored.ID != "" {
+			return 0, fmt.Errorf("could not compare with %s: %w", stored.ID, &mismatch)
+		}
+		return 0, &mismatch
+	}
+	return CosineSimilarity(query.Vector, stored.Vector), nil
+}
@@ -0,0 +1,92 @@
+package openaiclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckedCosineSimilarity(t *testing.T) {
+	t.Parallel()
+
+	got, err := CheckedCosineSimilarity([]float32{1, 0}, []float32{1, 0})
+	require.NoError(t, err)
+	assert.InDelta(t, 1, got, 1e-9)
+
+	_, err = CheckedCosineSimilarity([]float32{1, 0, 0}, []float32{1, 0})
+
+	var mismatch *EmbeddingMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, 3, mismatch.QueryDimensions)
+	assert.Equal(t, 2, mismatch.StoredDimensions)
+	assert.ErrorIs(t, err, ErrDimensionMismatch)
+	assert.NotErrorIs(t, err, ErrModelMismatch)
+	assert.EqualError(t, err, "embedding dimension mismatch: query has 3 dimensions, stored vector 2")
+}
+
+func TestCompareEmbeddings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		query   EmbeddingRecord
+		stored  EmbeddingRecord
+		want    float64
+		wantErr []error
+		errMsg  string
+	}{
+		{
+			name:   "same model",
+			query:  EmbeddingRecord{Model: "text-embedding-3-small", Vector: []float32{1, 0}},
+			stored: EmbeddingRecord{Model: "text-embedding-3-small", Vector: []float32{0, 1}},
+			want:   0,
+		},
+		{
+			name:   "model not recorded",
+			query:  EmbeddingRecord{Vector: []float32{1, 1}},
+			stored: EmbeddingRecord{Model: "text-embedding-3-small", Vector: []float32{1, 1}},
+			want:   1,
+		},
+		{
+			name:    "different model",
+			query:   EmbeddingRecord{Model: "text-embedding-3-large", Vector: []float32{1, 0}},
+			stored:  EmbeddingRecord{ID: "doc-1", Model: "text-embedding-3-small", Vector: []float32{1, 0}},
+			wantErr: []error{ErrModelMismatch},
+			errMsg:  `could not compare with doc-1: embedding model mismatch: query embedded with "text-embedding-3-large", stored vector with "text-embedding-3-small"`,
+		},
+		{
+			name:    "different model and dimensions",
+			query:   EmbeddingRecord{Model: "text-embedding-3-large", Vector: []float32{1, 0, 0}},
+			stored:  EmbeddingRecord{Model: "text-embedding-3-small", Vector: []float32{1, 0}},
+			wantErr: []error{ErrModelMismatch, ErrDimensionMismatch},
+		},
+		{
+			name:    "different dimensions",
+			query:   EmbeddingRecord{Model: "text-embedding-3-small", Vector: []float32{1}},
+			stored:  EmbeddingRecord{Model: "text-embedding-3-small", Vector: []float32{1, 0}},
+			wantErr: []error{ErrDimensionMismatch},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := CompareEmbeddings(tt.query, tt.stored)
+
+			if tt.wantErr != nil {
+				for _, want := range tt.wantErr {
+					assert.ErrorIs(t, err, want)
+				}
+				if tt.errMsg != "" {
+					assert.EqualError(t, err, tt.errMsg)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package openaiclient
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// DefaultSizeSamples is the number of recent requests per path the size
+// percentiles are computed over by default.
+const DefaultSizeSamples = 1024
+
+type (
+	// SizeMetrics records the request and response body sizes of the requests
+	// sent through its middleware, by URL path, to catch prompt bloat.
+	// It is safe for concurrent use.
+	SizeMetrics struct {
+		mu            sync.Mutex
+		samples       int
+		paths         map[string]*sizeSamples
+		requestLimit  int64
+		responseLimit int64
+		alert         func(SizeAlert)
+	}
+
+	// SizeSummary summarizes the body sizes of the requests to a path.
+	SizeSummary struct {
+		// Requests is the number of requests recorded, including those beyond the samples.
+		Requests int
+		Request  SizePercentiles
+		Response SizePercentiles
+	}
+
+	// SizePercentiles are percentiles of body sizes, in bytes.
+	SizePercentiles struct {
+		P50 int64
+		P90 int64
+		P99 int64
+		Max int64
+	}
+
+	// SizeAlert reports a request or response body above its limit.
+	SizeAlert struct {
+		Path string
+		// Response reports whether the response body, rather than the request
+		// body, exceeds the limit.
+		Response bool
+		Bytes    int64
+		Limit    int64
+	}
+
+	// sizeSamples holds the most recent body sizes of a path.
+	sizeSamples struct {
+		requests  int
+		responses int
+		request   []int64
+		response  []int64
+	}
+
+	// countingBody counts the bytes read from a response body, recording them on close.
+	countingBody struct {
+		io.ReadCloser
+		n      int64
+		closed bool
+		record func(n int64)
+	}
+)
+
+// NewSizeMetrics returns size metrics keeping DefaultSizeSamples samples per path.
+func NewSizeMetrics() *SizeMetrics {
+	return &SizeMetrics{samples: DefaultSizeSamples, paths: map[string]*sizeSamples{}}
+}
+
+// OnAlert calls hook for every request body above requestBytes and every
+// response body above responseBytes. A zero limit disables the alert.
+func (m *SizeMetrics) OnAlert(requestBytes, responseBytes int64, hook func(SizeAlert)) *SizeMetrics {
+	m.requestLimit, m.responseLimit, m.alert = requestBytes, responseBytes, hook
+	return m
+}
+
+// Middleware returns the middleware recording the sizes, for WithMiddleware.
+// Response sizes are recorded once their body is closed.
+func (m *SizeMetrics) Middleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			path := req.URL.Path
+			m.recordRequest(path, max(req.ContentLength, 0))
+
+			resp, err := next(req)
+			if err != nil {
+				return resp, err
+			}
+
+			resp.Body = &countingBody{
+				ReadCloser: resp.Body,
+				record:     func(n int64) { m.recordResponse(path, n) },
+			}
+			return resp, nil
+		}
+	}
+}
+
+// Summary returns the size summary of every path.
+func (m *SizeMetrics) Summary() map[string]SizeSummary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	summary := make(map[string]SizeSummary, len(m.paths))
+	for path, s := range m.paths {
+		summary[path] = SizeSummary{
+			Requests: s.requests,
+			Request:  percentiles(s.request),
+			Response: percentiles(s.response),
+		}
+	}
+	return summary
+}
+
+// recordRequest records the size of a request body.
+func (m *SizeMetrics) recordRequest(path string, n int64) {
+	m.mu.Lock()
+	s, ok := m.paths[path]
+	if !ok {
+		s = &sizeSamples{}
+		m.paths[path] = s
+	}
+	s.request = appendSample(s.request, s.requests, m.samples, n)
+	s.requests++
+	m.mu.Unlock()
+
+	if m.alert != nil && m.requestLimit > 0 && n > m.requestLimit {
+		m.alert(SizeAlert{Path: path, Bytes: n, Limit: m.requestLimit})
+	}
+}
+
+// recordResponse records the size of a response body.
+func (m *SizeMetrics) recordResponse(path string, n int64) {
+	m.mu.Lock()
+	s := m.paths[path]
+	s.response = appendSample(s.response, s.responses, m.samples, n)
+	s.responses++
+	m.mu.Unlock()
+
+	if m.alert != nil && m.responseLimit > 0 && n > m.responseLimit {
+		m.alert(SizeAlert{Path: path, Response: true, Bytes: n, Limit: m.responseLimit})
+	}
+}
+
+// appendSample adds the sample to the ring of at most limit samples, given the
+// number of samples recorded so far, replacing the oldest one once full.
+func appendSample(samples []int64, recorded, limit int, n int64) []int64 {
+	if len(samples) < limit {
+		return append(samples, n)
+	}
+	samples[recorded%limit] = n
+	return samples
+}
+
+// percentiles returns the nearest-rank percentiles of the samples.
+func percentiles(samples []int64) SizePercentiles {
+	if len(samples) == 0 {
+		return SizePercentiles{}
+	}
+
+	sorted := append([]int64(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := func(p int) int64 {
+		return sorted[(p*len(sorted)+99)/100-1]
+	}
+	return SizePercentiles{P50: rank(50), P90: rank(90), P99: rank(99), Max: sorted[len(sorted)-1]}
+}
+
+// Read implements io.Reader.
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+// Close implements io.Closer, recording the bytes read once.
+func (b *countingBody) Close() error {
+	if !b.closed {
+		b.closed = true
+		b.record(b.n)
+	}
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,80 @@
+package openaiclient
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizeMetrics(t *testing.T) {
+	t.Parallel()
+
+	var alerts []SizeAlert
+	metrics := NewSizeMetrics().OnAlert(1000, 1<<20, func(alert SizeAlert) {
+		alerts = append(alerts, alert)
+	})
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(t, ChatCompletionResponse{ID: "chatcmpl-123", Model: "gpt-4o"}), nil
+		},
+	}, WithMiddleware(metrics.Middleware()))
+
+	var sizes []int
+	for i := 1; i <= 10; i++ {
+		in := ChatCompletionRequest{Model: "gpt-4o", Messages: []Message{{Role: "user", Content: strings.Repeat("a", 100*i)}}}
+
+		data, err := marshal(in)
+		require.NoError(t, err)
+		sizes = append(sizes, len(data))
+
+		_, err = client.CreateChatCompletion(context.Background(), in)
+		require.NoError(t, err)
+	}
+
+	summary := metrics.Summary()
+	require.Contains(t, summary, "/v1/chat/completions")
+
+	got := summary["/v1/chat/completions"]
+	assert.Equal(t, 10, got.Requests)
+	assert.Equal(t, SizePercentiles{P50: int64(sizes[4]), P90: int64(sizes[8]), P99: int64(sizes[9]), Max: int64(sizes[9])}, got.Request)
+	assert.Positive(t, got.Response.P50)
+	assert.Equal(t, got.Response.P50, got.Response.Max)
+
+	require.NotEmpty(t, alerts)
+	assert.Equal(t, SizeAlert{Path: "/v1/chat/completions", Bytes: int64(sizes[9]), Limit: 1000}, alerts[len(alerts)-1])
+	for _, alert := range alerts {
+		assert.False(t, alert.Response)
+		assert.Greater(t, alert.Bytes, alert.Limit)
+	}
+}
+
+func TestSizeMetrics_Samples(t *testing.T) {
+	t.Parallel()
+
+	var alerts []SizeAlert
+	metrics := NewSizeMetrics().OnAlert(0, 5, func(alert SizeAlert) {
+		alerts = append(alerts, alert)
+	})
+	metrics.samples = 3
+
+	for _, n := range []int64{500, 400, 1, 2, 3} {
+		metrics.recordRequest("/v1/embeddings", n)
+		metrics.recordResponse("/v1/embeddings", 2*n)
+	}
+
+	got := metrics.Summary()["/v1/embeddings"]
+	assert.Equal(t, 5, got.Requests)
+	assert.Equal(t, SizePercentiles{P50: 2, P90: 3, P99: 3, Max: 3}, got.Request, "only the recent samples count")
+	assert.Equal(t, SizePercentiles{P50: 4, P90: 6, P99: 6, Max: 6}, got.Response)
+
+	assert.Equal(t, []SizeAlert{
+		{Path: "/v1/embeddings", Response: true, Bytes: 1000, Limit: 5},
+		{Path: "/v1/embeddings", Response: true, Bytes: 800, Limit: 5},
+		{Path: "/v1/embeddings", Response: true, Bytes: 6, Limit: 5},
+	}, alerts)
+}
@@ -0,0 +1,155 @@
+package openaiclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+// Speech models.
+const (
+	SpeechModelTTS1         = "tts-1"
+	SpeechModelTTS1HD       = "tts-1-hd"
+	SpeechModelGPT4oMiniTTS = "gpt-4o-mini-tts"
+)
+
+// Speech voices. VoiceBallad and VoiceVerse are only available with SpeechModelGPT4oMiniTTS.
+const (
+	VoiceAlloy   = "alloy"
+	VoiceAsh     = "ash"
+	VoiceBallad  = "ballad"
+	VoiceCoral   = "coral"
+	VoiceEcho    = "echo"
+	VoiceFable   = "fable"
+	VoiceNova    = "nova"
+	VoiceOnyx    = "onyx"
+	VoiceSage    = "sage"
+	VoiceShimmer = "shimmer"
+	VoiceVerse   = "verse"
+)
+
+// Speech audio formats.
+const (
+	SpeechFormatMP3  = "mp3"
+	SpeechFormatOpus = "opus"
+	SpeechFormatAAC  = "aac"
+	SpeechFormatFLAC = "flac"
+	SpeechFormatWAV  = "wav"
+	SpeechFormatPCM  = "pcm"
+)
+
+// MaxSpeechInput is the maximum length of the speech input, in characters.
+const MaxSpeechInput = 4096
+
+// SpeechRequest is the request body for the speech endpoint.
+type SpeechRequest struct {
+	Model string `json:"model"`
+	// Input is the text to speak, of at most MaxSpeechInput characters.
+	Input string `json:"input"`
+	Voice string `json:"voice"`
+	// Instructions control the voice style, such as the tone or accent.
+	// They aren't supported by the tts-1 models.
+	Instructions string `json:"instructions,omitempty"`
+	// ResponseFormat is the audio format, such as SpeechFormatOpus; mp3 by default.
+	ResponseFormat string `json:"response_format,omitempty"`
+	// Speed is the speed of the audio, from 0.25 to 4; 1 by default.
+	// It isn't supported by gpt-4o-mini-tts.
+	Speed *float64 `json:"speed,omitempty"`
+}
+
+// speechModel holds the options supported by a speech model.
+type speechModel struct {
+	voices       map[string]struct{}
+	formats      map[string]struct{}
+	instructions bool
+	speed        bool
+}
+
+var (
+	ttsVoices = []string{
+		VoiceAlloy, VoiceAsh, VoiceCoral, VoiceEcho, VoiceFable,
+		VoiceNova, VoiceOnyx, VoiceSage, VoiceShimmer,
+	}
+	speechFormats = toSet([]string{
+		SpeechFormatMP3, SpeechFormatOpus, SpeechFormatAAC,
+		SpeechFormatFLAC, SpeechFormatWAV, SpeechFormatPCM,
+	})
+
+	// speechModels are the known speech models, by model ID.
+	speechModels = map[string]speechModel{
+		SpeechModelTTS1:   {voices: toSet(ttsVoices), formats: speechFormats, speed: true},
+		SpeechModelTTS1HD: {voices: toSet(ttsVoices), formats: speechFormats, speed: true},
+		SpeechModelGPT4oMiniTTS: {
+			voices:       toSet(append([]string{VoiceBallad, VoiceVerse}, ttsVoices...)),
+			formats:      speechFormats,
+			instructions: true,
+		},
+	}
+)
+
+// Validate checks the request against the options supported by its model.
+// Only the input and speed are checked for unknown models.
+// The returned errors match ErrInvalidRequest.
+func (r SpeechRequest) Validate() error {
+	if r.Input == "" {
+		return fmt.Errorf("%w: speech input is empty", ErrInvalidRequest)
+	}
+	if n := utf8.RuneCountInString(r.Input); n > MaxSpeechInput {
+		return fmt.Errorf("%w: speech input has %d characters, over the limit of %d", ErrInvalidRequest, n, MaxSpeechInput)
+	}
+	if r.Speed != nil && (*r.Speed < 0.25 || *r.Speed > 4) {
+		return fmt.Errorf("%w: speech speed %g is out of the 0.25-4 range", ErrInvalidRequest, *r.Speed)
+	}
+
+	model, ok := speechModels[r.Model]
+	if !ok {
+		return nil
+	}
+
+	if _, ok := model.voices[r.Voice]; !ok {
+		return fmt.Errorf("%w: voice %q is not supported by %s", ErrInvalidRequest, r.Voice, r.Model)
+	}
+	if _, ok := model.formats[r.ResponseFormat]; !ok && r.ResponseFormat != "" {
+		return fmt.Errorf("%w: format %q is not supported by %s", ErrInvalidRequest, r.ResponseFormat, r.Model)
+	}
+	if r.Instructions != "" && !model.instructions {
+		return fmt.Errorf("%w: instructions are not supported by %s", ErrInvalidRequest, r.Model)
+	}
+	if r.Speed != nil && !model.speed {
+		return fmt.Errorf("%w: speed is not supported by %s", ErrInvalidRequest, r.Model)
+	}
+	return nil
+}
+
+// CreateSpeech generates the audio of the input, which the caller must close.
+// The request is validated before it's sent.
+func (c *Client) CreateSpeech(ctx context.Context, in SpeechRequest) (io.ReadCloser, error) {
+	in.Model = c.resolveModel(in.Model)
+	c.deprecations.check(in.Model)
+
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	if c.usage.exhausted() {
+		return nil, ErrBudgetExceeded
+	}
+
+	jsonData, err := marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal data: %w", err)
+	}
+
+	resp, err := c.send(ctx, http.MethodPost, c.url(EndpointSpeech), &requestBody{data: jsonData, contentType: "application/json"})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, newAPIError(resp)
+	}
+	return resp.Body, nil
+}
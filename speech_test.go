@@ -0,0 +1,141 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpeechRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      SpeechRequest
+		wantErr string
+	}{
+		{
+			name: "instructions with gpt-4o-mini-tts",
+			in:   SpeechRequest{Model: SpeechModelGPT4oMiniTTS, Input: "Hello", Voice: VoiceBallad, Instructions: "Speak cheerfully.", ResponseFormat: SpeechFormatOpus},
+		},
+		{
+			name: "speed with tts-1",
+			in:   SpeechRequest{Model: SpeechModelTTS1, Input: "Hello", Voice: VoiceAlloy, Speed: Ptr(1.5)},
+		},
+		{
+			name: "unknown model",
+			in:   SpeechRequest{Model: "custom-tts", Input: "Hello", Voice: "custom", Instructions: "Whisper."},
+		},
+		{
+			name:    "empty input",
+			in:      SpeechRequest{Model: SpeechModelTTS1, Voice: VoiceAlloy},
+			wantErr: "speech input is empty",
+		},
+		{
+			name:    "input too long",
+			in:      SpeechRequest{Model: SpeechModelTTS1, Input: strings.Repeat("é", MaxSpeechInput+1), Voice: VoiceAlloy},
+			wantErr: "over the limit",
+		},
+		{
+			name:    "voice not supported by the model",
+			in:      SpeechRequest{Model: SpeechModelTTS1HD, Input: "Hello", Voice: VoiceVerse},
+			wantErr: `voice "verse" is not supported by tts-1-hd`,
+		},
+		{
+			name:    "unknown format",
+			in:      SpeechRequest{Model: SpeechModelTTS1, Input: "Hello", Voice: VoiceAlloy, ResponseFormat: "ogg"},
+			wantErr: `format "ogg" is not supported`,
+		},
+		{
+			name:    "instructions with tts-1",
+			in:      SpeechRequest{Model: SpeechModelTTS1, Input: "Hello", Voice: VoiceAlloy, Instructions: "Speak slowly."},
+			wantErr: "instructions are not supported by tts-1",
+		},
+		{
+			name:    "speed with gpt-4o-mini-tts",
+			in:      SpeechRequest{Model: SpeechModelGPT4oMiniTTS, Input: "Hello", Voice: VoiceAlloy, Speed: Ptr(1.0)},
+			wantErr: "speed is not supported by gpt-4o-mini-tts",
+		},
+		{
+			name:    "speed out of range",
+			in:      SpeechRequest{Model: "custom-tts", Input: "Hello", Speed: Ptr(5.0)},
+			wantErr: "out of the 0.25-4 range",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.in.Validate()
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidRequest)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestClient_CreateSpeech(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "https://api.openai.com/v1/audio/speech", req.URL.String())
+
+			var in map[string]any
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+			assert.Equal(t, map[string]any{
+				"model":           SpeechModelGPT4oMiniTTS,
+				"input":           "Hello",
+				"voice":           VoiceCoral,
+				"instructions":    "Speak like a pirate.",
+				"response_format": SpeechFormatWAV,
+			}, in)
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("RIFF")),
+			}, nil
+		},
+	})
+
+	audio, err := client.CreateSpeech(context.Background(), SpeechRequest{
+		Model:          SpeechModelGPT4oMiniTTS,
+		Input:          "Hello",
+		Voice:          VoiceCoral,
+		Instructions:   "Speak like a pirate.",
+		ResponseFormat: SpeechFormatWAV,
+	})
+	require.NoError(t, err)
+	defer audio.Close()
+
+	data, err := io.ReadAll(audio)
+	require.NoError(t, err)
+	assert.Equal(t, "RIFF", string(data))
+}
+
+func TestClient_CreateSpeech_Invalid(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			t.Error("request must not be sent")
+			return nil, nil
+		},
+	})
+
+	_, err := client.CreateSpeech(context.Background(), SpeechRequest{Model: SpeechModelTTS1, Input: "Hello", Voice: VoiceBallad})
+
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+}
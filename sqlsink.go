@@ -0,0 +1,169 @@
+package openaiclient
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+type (
+	// EmbeddingRecord is an embedding vector ready to be stored.
+	EmbeddingRecord struct {
+		ID       string
+		Model    string
+		Vector   []float32
+		Metadata map[string]string
+	}
+
+	// EmbeddingSink stores embedding records.
+	EmbeddingSink interface {
+		WriteEmbeddings(ctx context.Context, records []EmbeddingRecord) error
+	}
+
+	// SQLSinkConfig maps embedding records to a SQL table.
+	SQLSinkConfig struct {
+		Table string
+		// IDColumn and VectorColumn are required.
+		IDColumn     string
+		VectorColumn string
+		// ModelColumn and MetadataColumn are optional; metadata is stored as JSON.
+		ModelColumn    string
+		MetadataColumn string
+		// Placeholder returns the bind parameter for the n-th (1-based) argument.
+		// Defaults to "?"; use PostgresPlaceholder for PostgreSQL drivers.
+		Placeholder func(n int) string
+		// EncodeVector encodes vectors for the vector column. Defaults to PGVector.
+		EncodeVector func(vector []float32) any
+	}
+
+	// SQLSink writes embedding records to any database/sql backend.
+	SQLSink struct {
+		db     *sql.DB
+		cfg    SQLSinkConfig
+		insert string
+	}
+)
+
+// NewSQLSink creates a sink inserting records into the table described by cfg.
+func NewSQLSink(db *sql.DB, cfg SQLSinkConfig) (*SQLSink, error) {
+	if cfg.IDColumn == "" || cfg.VectorColumn == "" {
+		return nil, fmt.Errorf("could not create sql sink: id and vector columns are required")
+	}
+
+	if cfg.Placeholder == nil {
+		cfg.Placeholder = func(int) string { return "?" }
+	}
+
+	if cfg.EncodeVector == nil {
+		cfg.EncodeVector = func(vector []float32) any { return PGVector(vector) }
+	}
+
+	columns := []string{cfg.IDColumn, cfg.VectorColumn}
+	for _, column := range []string{cfg.ModelColumn, cfg.MetadataColumn} {
+		if column != "" {
+			columns = append(columns, column)
+		}
+	}
+
+	for _, identifier := range append([]string{cfg.Table}, columns...) {
+		if !sqlIdentifier.MatchString(identifier) {
+			return nil, fmt.Errorf("could not create sql sink: invalid identifier %q", identifier)
+		}
+	}
+
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = cfg.Placeholder(i + 1)
+	}
+
+	return &SQLSink{
+		db:  db,
+		cfg: cfg,
+		insert: fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+			cfg.Table, strings.Join(columns, ", "), strings.Join(placeholders, ", "),
+		),
+	}, nil
+}
+
+// WriteEmbeddings implements EmbeddingSink. All records are written in a single transaction.
+func (s *SQLSink) WriteEmbeddings(ctx context.Context, records []EmbeddingRecord) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, s.insert)
+	if err != nil {
+		return fmt.Errorf("could not prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, record := range records {
+		args := []any{record.ID, s.cfg.EncodeVector(record.Vector)}
+
+		if s.cfg.ModelColumn != "" {
+			args = append(args, record.Model)
+		}
+
+		if s.cfg.MetadataColumn != "" {
+			metadata, err := json.Marshal(record.Metadata)
+			if err != nil {
+				return fmt.Errorf("could not marshal metadata: %w", err)
+			}
+			args = append(args, string(metadata))
+		}
+
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return fmt.Errorf("could not insert embedding %q: %w", record.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+	return nil
+}
+
+// EmbeddingRecords pairs the embeddings of a response with the given IDs, by embedding index.
+func EmbeddingRecords(resp *EmbeddingResponse, ids []string) ([]EmbeddingRecord, error) {
+	records := make([]EmbeddingRecord, 0, len(resp.Data))
+	for _, emb := range resp.Data {
+		if emb.Index < 0 || emb.Index >= len(ids) {
+			return nil, fmt.Errorf("could not map embedding: index %d out of range", emb.Index)
+		}
+
+		records = append(records, EmbeddingRecord{
+			ID:     ids[emb.Index],
+			Model:  resp.Model,
+			Vector: emb.Embedding,
+		})
+	}
+	return records, nil
+}
+
+// PostgresPlaceholder returns PostgreSQL style bind parameters ($1, $2, ...).
+func PostgresPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// PGVector encodes the vector in the pgvector text format, e.g. "[0.1,0.2,0.3]".
+func PGVector(vector []float32) string {
+	var b strings.Builder
+
+	b.WriteByte('[')
+	for i, v := range vector {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
@@ -0,0 +1,159 @@
+package openaiclient
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver is a database/sql driver recording the executed statements.
+type fakeDriver struct {
+	mu        sync.Mutex
+	queries   []string
+	args      [][]driver.Value
+	committed bool
+}
+
+type fakeConn struct{ d *fakeDriver }
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d: d}, nil }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{d: c.d, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) { return c, nil }
+
+func (c *fakeConn) Commit() error {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+
+	c.d.committed = true
+	return nil
+}
+
+func (c *fakeConn) Rollback() error { return nil }
+
+func (s *fakeStmt) Close() error { return nil }
+
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+
+	s.d.queries = append(s.d.queries, s.query)
+	s.d.args = append(s.d.args, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("not implemented")
+}
+
+// openFakeDB registers a fake driver for the test and opens a database with it.
+func openFakeDB(t *testing.T) (*sql.DB, *fakeDriver) {
+	t.Helper()
+
+	d := &fakeDriver{}
+	name := "fake_" + t.Name()
+	sql.Register(name, d)
+
+	db, err := sql.Open(name, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db, d
+}
+
+func TestSQLSink(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes records with the configured mapping", func(t *testing.T) {
+		t.Parallel()
+
+		db, d := openFakeDB(t)
+
+		sink, err := NewSQLSink(db, SQLSinkConfig{
+			Table:          "public.documents",
+			IDColumn:       "id",
+			VectorColumn:   "embedding",
+			ModelColumn:    "model",
+			MetadataColumn: "metadata",
+			Placeholder:    PostgresPlaceholder,
+		})
+		require.NoError(t, err)
+
+		err = sink.WriteEmbeddings(context.Background(), []EmbeddingRecord{
+			{ID: "doc_1", Model: "test_model", Vector: []float32{0.5, -1}, Metadata: map[string]string{"lang": "en"}},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"INSERT INTO public.documents (id, embedding, model, metadata) VALUES ($1, $2, $3, $4)"}, d.queries)
+		assert.Equal(t, [][]driver.Value{{"doc_1", "[0.5,-1]", "test_model", `{"lang":"en"}`}}, d.args)
+		assert.True(t, d.committed)
+	})
+
+	t.Run("rejects invalid identifiers", func(t *testing.T) {
+		t.Parallel()
+
+		db, _ := openFakeDB(t)
+
+		_, err := NewSQLSink(db, SQLSinkConfig{
+			Table:        "documents; DROP TABLE users",
+			IDColumn:     "id",
+			VectorColumn: "embedding",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("requires id and vector columns", func(t *testing.T) {
+		t.Parallel()
+
+		db, _ := openFakeDB(t)
+
+		_, err := NewSQLSink(db, SQLSinkConfig{Table: "documents", IDColumn: "id"})
+		assert.Error(t, err)
+	})
+}
+
+func TestEmbeddingRecords(t *testing.T) {
+	t.Parallel()
+
+	resp := &EmbeddingResponse{
+		Model: "test_model",
+		Data: []Embedding{
+			{Index: 1, Embedding: []float32{2}},
+			{Index: 0, Embedding: []float32{1}},
+		},
+	}
+
+	records, err := EmbeddingRecords(resp, []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, []EmbeddingRecord{
+		{ID: "b", Model: "test_model", Vector: []float32{2}},
+		{ID: "a", Model: "test_model", Vector: []float32{1}},
+	}, records)
+
+	_, err = EmbeddingRecords(resp, []string{"a"})
+	assert.Error(t, err)
+}
+
+func TestPGVector(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "[]", PGVector(nil))
+	assert.Equal(t, "[0.1,0.2,3]", PGVector([]float32{0.1, 0.2, 3}))
+}
@@ -0,0 +1,383 @@
+package openaiclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"unicode/utf8"
+)
+
+type (
+	// ChatCompletionChunk is a server-sent event of a streamed chat completion.
+	ChatCompletionChunk struct {
+		ID      string        `json:"id"`
+		Object  string        `json:"object"`
+		Model   string        `json:"model"`
+		Created int           `json:"created"`
+		Choices []ChunkChoice `json:"choices"`
+		// Usage is only sent in the last chunk, which has no choices.
+		Usage *Usage `json:"usage,omitempty"`
+	}
+
+	// ChunkChoice is the delta of a choice in a chunk.
+	ChunkChoice struct {
+		Index int          `json:"index"`
+		Delta MessageDelta `json:"delta"`
+		// FinishReason is only set in the last chunk of the choice.
+		FinishReason string `json:"finish_reason,omitempty"`
+		// Logprobs are the log probabilities of the tokens of the delta, if requested.
+		Logprobs *Logprobs `json:"logprobs,omitempty"`
+	}
+
+	// MessageDelta is a fragment of a streamed message.
+	MessageDelta struct {
+		Role      Role            `json:"role,omitempty"`
+		Content   string          `json:"content,omitempty"`
+		Refusal   string          `json:"refusal,omitempty"`
+		ToolCalls []ToolCallDelta `json:"tool_calls,omitempty"`
+	}
+
+	// ToolCallDelta is a fragment of a streamed tool call. The first fragment
+	// of a call carries its ID and function name, the next ones carry pieces
+	// of its arguments.
+	ToolCallDelta struct {
+		// Index is the position of the call in the message. Some
+		// OpenAI-compatible servers omit it and send every call at once.
+		Index    *int         `json:"index,omitempty"`
+		ID       string       `json:"id,omitempty"`
+		Type     string       `json:"type,omitempty"`
+		Function FunctionCall `json:"function"`
+	}
+
+	// ChatCompletionStream is a streamed chat completion. It must be closed.
+	ChatCompletionStream struct {
+		ResponseMeta
+
+		client    *Client
+		body      io.ReadCloser
+		reader    *bufio.Reader
+		maxTokens int
+		done      bool
+		// pending are the chunks already received, returned first.
+		pending []ChatCompletionChunk
+		// cancel, if set, releases the context of the stream.
+		cancel context.CancelFunc
+		// generated is the number of characters generated so far, counted
+		// for the token watermark and the usage estimate.
+		generated int
+		// promptTokens is the estimate of the prompt tokens of the request.
+		promptTokens int
+		// usage reports whether the stream sent its usage.
+		usage bool
+		// id and model are the ID and model of the last chunk.
+		id, model string
+		// err is the error the stream was aborted with.
+		err error
+		// recorder, if set, records the stream into the stream cache.
+		recorder *streamRecorder
+		// replayed reports whether the stream is replayed from the stream cache.
+		replayed bool
+		// deltas holds the UTF-8 sequences split across chunks.
+		deltas deltaBuffer
+		// keepalive, if set, is called with the comments of the stream.
+		keepalive func(comment string)
+
+		// line and data are reused between events.
+		line []byte
+		data []byte
+	}
+
+	// streamRequest is a chat completion request asking for a stream.
+	streamRequest struct {
+		ChatCompletionRequest
+		Stream        bool           `json:"stream"`
+		StreamOptions *streamOptions `json:"stream_options,omitempty"`
+	}
+
+	streamOptions struct {
+		IncludeUsage bool `json:"include_usage"`
+	}
+)
+
+// CreateChatCompletionStream creates a chat completion streamed as chunks.
+// The usage sent at the end of the stream is recorded like for CreateChatCompletion.
+func (c *Client) CreateChatCompletionStream(ctx context.Context, in ChatCompletionRequest) (*ChatCompletionStream, error) {
+	in.Model = c.resolveModel(in.Model)
+	in.User = c.endUser(in.User)
+	in.SafetyIdentifier = c.safetyIdentifier(in.SafetyIdentifier)
+
+	if err := c.policies.apply(&in); err != nil {
+		return nil, err
+	}
+
+	if c.lint {
+		if err := in.Lint(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.checkContextWindow(in); err != nil {
+		return nil, err
+	}
+
+	c.deprecations.check(in.Model)
+
+	if c.usage.exhausted() {
+		return nil, ErrBudgetExceeded
+	}
+
+	req := streamRequest{
+		ChatCompletionRequest: in,
+		Stream:                true,
+		StreamOptions:         &streamOptions{IncludeUsage: true},
+	}
+
+	replay, recorder, err := c.streamCache.open(ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if replay != nil {
+		return replay, nil
+	}
+
+	jsonData, err := marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal data: %w", err)
+	}
+
+	resp, err := c.send(ctx, http.MethodPost, c.url(EndpointChatCompletions), &requestBody{data: jsonData, contentType: "application/json"})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, newAPIError(resp)
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "" && mediaType != "text/event-stream" {
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("could not stream response: unexpected content type %q", resp.Header.Get("Content-Type"))
+	}
+
+	stream := ChatCompletionStream{
+		client:       c,
+		body:         resp.Body,
+		reader:       bufio.NewReader(resp.Body),
+		maxTokens:    in.MaxTokens,
+		recorder:     recorder,
+		promptTokens: EstimateRequest(in).Total,
+	}
+	if recorder != nil {
+		recorder.stream.Header = resp.Header.Clone()
+	}
+	stream.setHeader(c.headerPolicy.capture(resp.Header))
+	return &stream, nil
+}
+
+// OnKeepalive calls fn with the comment of every keep-alive the server sends,
+// such as ": ping", from Recv while it waits for the next chunk. Servers send
+// them while the model produces no deltas, such as during long tool or
+// reasoning steps, so UIs can tell a slow stream from a stalled one.
+func (s *ChatCompletionStream) OnKeepalive(fn func(comment string)) *ChatCompletionStream {
+	s.keepalive = fn
+	return s
+}
+
+// Recv returns the next chunk of the stream, or io.EOF once it is complete.
+// The last chunk may have no choices: it only carries the usage, sent by the
+// server or, if it sent none, estimated (see Usage.Estimated).
+func (s *ChatCompletionStream) Recv() (*ChatCompletionChunk, error) {
+	var chunk ChatCompletionChunk
+	if err := s.recv(&chunk); err != nil {
+		return nil, err
+	}
+	return &chunk, nil
+}
+
+// recv decodes the next chunk of the stream into chunk, reusing its choices.
+func (s *ChatCompletionStream) recv(chunk *ChatCompletionChunk) error {
+	if len(s.pending) > 0 {
+		*chunk = s.pending[0]
+		s.pending = s.pending[1:]
+		return nil
+	}
+	if s.err != nil {
+		return s.err
+	}
+	if s.done {
+		return io.EOF
+	}
+
+	for {
+		data, err := s.event()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("could not read stream: %w", io.ErrUnexpectedEOF)
+			}
+			return fmt.Errorf("could not read stream: %w", err)
+		}
+
+		if data == nil {
+			continue
+		}
+		s.recorder.record(data)
+
+		if bytes.Equal(data, []byte("[DONE]")) {
+			s.done = true
+			if err := s.recorder.done(); err != nil {
+				return err
+			}
+			if s.usage {
+				return io.EOF
+			}
+
+			// Servers may not send the usage, which is then estimated.
+			*chunk = ChatCompletionChunk{ID: s.id, Model: s.model, Usage: s.estimateUsage()}
+			s.recordUsage(chunk)
+			return nil
+		}
+
+		if err := streamError(data); err != nil {
+			return err
+		}
+
+		// Decoding into a slice doesn't zero its reused elements.
+		choices := chunk.Choices[:cap(chunk.Choices)]
+		clear(choices)
+		*chunk = ChatCompletionChunk{Choices: choices[:0]}
+
+		if err := s.client.decodeBytes(data, chunk); err != nil {
+			return fmt.Errorf("could not decode chunk: %w", err)
+		}
+		if err := s.deltas.repair(data, chunk, s.client.deltaMode == DeltaRaw); err != nil {
+			return fmt.Errorf("could not decode chunk: %w", err)
+		}
+
+		s.id, s.model = chunk.ID, chunk.Model
+		if chunk.Usage != nil {
+			s.usage = true
+			s.recordUsage(chunk)
+			return nil
+		}
+
+		for _, choice := range chunk.Choices {
+			s.generated += utf8.RuneCountInString(choice.Delta.Content) + utf8.RuneCountInString(choice.Delta.Refusal)
+			for _, call := range choice.Delta.ToolCalls {
+				s.generated += utf8.RuneCountInString(call.Function.Name) + utf8.RuneCountInString(call.Function.Arguments)
+			}
+		}
+
+		if err := s.client.watermark.checkStream(s, chunk); err != nil {
+			s.err = err
+			return err
+		}
+		return nil
+	}
+}
+
+// recordUsage records the usage of the chunk.
+func (s *ChatCompletionStream) recordUsage(chunk *ChatCompletionChunk) {
+	if !s.replayed {
+		s.client.usage.record(servedModel(chunk.Model, s.model), *chunk.Usage)
+	}
+	s.client.watermark.check(chunk.ID, chunk.Model, *chunk.Usage)
+}
+
+// estimateUsage estimates the usage of the stream from the request and the
+// received deltas.
+func (s *ChatCompletionStream) estimateUsage() *Usage {
+	completion := estimateRuneTokens(s.generated)
+	return &Usage{
+		PromptTokens:     s.promptTokens,
+		CompletionTokens: completion,
+		TotalTokens:      s.promptTokens + completion,
+		Estimated:        true,
+	}
+}
+
+// Close closes the stream.
+func (s *ChatCompletionStream) Close() error {
+	err := s.body.Close()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return err
+}
+
+// event reads the data of the next server-sent event, or nil for events without data.
+// Data split over several lines is joined with newlines, as per the SSE specification.
+// The data is only valid until the next event.
+func (s *ChatCompletionStream) event() ([]byte, error) {
+	data, found := s.data[:0], false
+	for {
+		line, err := s.readLine()
+		if len(line) == 0 && err != nil {
+			// The last event may not be followed by a blank line.
+			if found && errors.Is(err, io.EOF) {
+				s.data = data
+				return data, nil
+			}
+			return nil, err
+		}
+
+		line = bytes.TrimRight(line, "\r\n")
+		if len(line) == 0 {
+			if found {
+				s.data = data
+				return data, nil
+			}
+			continue
+		}
+
+		// Comments are keep-alives; other fields are ignored.
+		field, value, _ := bytes.Cut(line, []byte(":"))
+		if len(field) == 0 && s.keepalive != nil {
+			s.keepalive(string(bytes.TrimPrefix(value, []byte(" "))))
+			continue
+		}
+		if !bytes.Equal(field, []byte("data")) {
+			continue
+		}
+
+		if found {
+			data = append(data, '\n')
+		}
+		data = append(data, bytes.TrimPrefix(value, []byte(" "))...)
+		found = true
+	}
+}
+
+// readLine reads the next line, which is only valid until the next read.
+func (s *ChatCompletionStream) readLine() ([]byte, error) {
+	line, err := s.reader.ReadSlice('\n')
+	if !errors.Is(err, bufio.ErrBufferFull) {
+		return line, err
+	}
+
+	// Lines longer than the read buffer are copied.
+	s.line = append(s.line[:0], line...)
+	for errors.Is(err, bufio.ErrBufferFull) {
+		line, err = s.reader.ReadSlice('\n')
+		s.line = append(s.line, line...)
+	}
+	return s.line, err
+}
+
+// streamError returns the error sent in the stream, if the data is an error event.
+func streamError(data []byte) error {
+	if !bytes.Contains(data, []byte(`"error"`)) {
+		return nil
+	}
+
+	apiErr := APIError{StatusCode: http.StatusOK}
+	if !apiErr.parseBody(data) {
+		return nil
+	}
+	return &apiErr
+}
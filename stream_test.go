@@ -0,0 +1,281 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sseResponse returns an event stream response with the given body.
+func sseResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream; charset=utf-8"}, "X-Request-Id": []string{"req-123"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// sseEvents returns the body of an event stream sending the chunks, then [DONE].
+func sseEvents(t *testing.T, chunks ...any) string {
+	t.Helper()
+
+	var body strings.Builder
+	for _, chunk := range chunks {
+		data, err := json.Marshal(chunk)
+		require.NoError(t, err)
+		body.WriteString("data: " + string(data) + "\n\n")
+	}
+	body.WriteString("data: [DONE]\n\n")
+	return body.String()
+}
+
+func TestClient_CreateChatCompletionStream(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in map[string]any
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+			assert.Equal(t, true, in["stream"])
+			assert.Equal(t, map[string]any{"include_usage": true}, in["stream_options"])
+			assert.Equal(t, "gpt-4o", in["model"])
+
+			return sseResponse(": keep-alive\n\n" +
+				"data: {\"id\":\"chatcmpl-1\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"Hel\"}}]}\r\n\r\n" +
+				"event: message\n" +
+				"data: {\"id\":\"chatcmpl-1\",\"choices\":[{\"index\":0,\n" +
+				"data: \"delta\":{\"content\":\"lo\"},\"finish_reason\":\"stop\"}]}\n\n" +
+				"data: {\"id\":\"chatcmpl-1\",\"choices\":[],\"usage\":{\"prompt_tokens\":5,\"completion_tokens\":2,\"total_tokens\":7}}\n\n" +
+				"data: [DONE]\n\n"), nil
+		},
+	})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	assert.Equal(t, "req-123", stream.Header.Get("X-Request-Id"))
+
+	var chunks []*ChatCompletionChunk
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+
+	require.Len(t, chunks, 3)
+	assert.Equal(t, "Hel", chunks[0].Choices[0].Delta.Content)
+	assert.Equal(t, "lo", chunks[1].Choices[0].Delta.Content)
+	assert.Equal(t, "stop", chunks[1].Choices[0].FinishReason)
+	require.NotNil(t, chunks[2].Usage)
+
+	assert.Equal(t, Usage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7}, client.Usage())
+
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestChatCompletionStream_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		resp    *http.Response
+		wantErr error
+	}{
+		{
+			name:    "error event",
+			resp:    sseResponse("data: {\"error\":{\"message\":\"The server had an error\",\"type\":\"server_error\"}}\n\n"),
+			wantErr: &APIError{StatusCode: http.StatusOK, Message: "The server had an error", Type: "server_error"},
+		},
+		{
+			name:    "truncated stream",
+			resp:    sseResponse("data: {\"choices\":[{\"delta\":{\"content\":\"Hel\"}}]}\n\n"),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return tt.resp, nil
+				},
+			})
+
+			stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+			require.NoError(t, err)
+			defer stream.Close()
+
+			for err == nil {
+				_, err = stream.Recv()
+			}
+
+			if apiErr, ok := tt.wantErr.(*APIError); ok {
+				var got *APIError
+				require.ErrorAs(t, err, &got)
+				assert.Equal(t, apiErr, got)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestClient_CreateChatCompletionStream_Failures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		resp *http.Response
+	}{
+		{
+			name: "error status",
+			resp: &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader(`{"error":{"message":"bad"}}`))},
+		},
+		{
+			name: "not an event stream",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{}`)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return tt.resp, nil
+				},
+			})
+
+			_, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestChatCompletionStream_EstimatedUsage(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return sseResponse(sseEvents(t,
+				ChatCompletionChunk{ID: "chatcmpl-1", Model: "local-llm", Choices: []ChunkChoice{{Delta: MessageDelta{Role: "assistant", Content: "Hel"}}}},
+				ChatCompletionChunk{ID: "chatcmpl-1", Model: "local-llm", Choices: []ChunkChoice{{Delta: MessageDelta{Content: "lo, world"}, FinishReason: "stop"}}},
+			)), nil
+		},
+	})
+
+	in := ChatCompletionRequest{Model: "local-llm", Messages: []Message{{Role: "user", Content: "Hello there!"}}}
+	stream, err := client.CreateChatCompletionStream(context.Background(), in)
+	require.NoError(t, err)
+	defer stream.Close()
+
+	resp, err := stream.Accumulate(NewStreamAccumulator())
+	require.NoError(t, err)
+
+	prompt := EstimateRequest(in).Total
+	want := Usage{PromptTokens: prompt, CompletionTokens: 3, TotalTokens: prompt + 3, Estimated: true}
+	assert.Equal(t, want, resp.Usage)
+	assert.Equal(t, "Hello, world", resp.Choices[0].Message.Content)
+	assert.Equal(t, want, client.Usage())
+
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestChatCompletionStream_OnKeepalive(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return sseResponse(": ping\n\n" +
+				`data: {"id":"chatcmpl-1","choices":[{"index":0,"delta":{"role":"assistant","content":"Hi"}}]}` + "\n\n" +
+				":\n: ping\n\n" +
+				`data: {"id":"chatcmpl-1","choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}` + "\n\n" +
+				"data: [DONE]\n\n"), nil
+		},
+	})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	var events []string
+	stream.OnKeepalive(func(comment string) { events = append(events, "keepalive "+comment) })
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		if len(chunk.Choices) > 0 {
+			events = append(events, "chunk "+chunk.Choices[0].Delta.Content)
+		}
+	}
+
+	// Keep-alives are reported as they arrive, between the chunks.
+	assert.Equal(t, []string{"keepalive ping", "chunk Hi", "keepalive ", "keepalive ping", "chunk "}, events)
+}
+
+func TestChatCompletionStream_EstimatedUsageChunk(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return sseResponse(sseEvents(t,
+				ChatCompletionChunk{ID: "chatcmpl-1", Choices: []ChunkChoice{{Delta: MessageDelta{Role: "assistant", Content: "Hel"}}}},
+				ChatCompletionChunk{ID: "chatcmpl-1", Choices: []ChunkChoice{{Delta: MessageDelta{Content: "lo, world"}, FinishReason: "stop"}}},
+			)), nil
+		},
+	})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "local-llm"})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	// A consumer reading only the choices must expect chunks without any.
+	var (
+		content string
+		last    *ChatCompletionChunk
+	)
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		if len(chunk.Choices) > 0 {
+			content += chunk.Choices[0].Delta.Content
+		}
+		last = chunk
+	}
+
+	assert.Equal(t, "Hello, world", content)
+	require.NotNil(t, last)
+	assert.Empty(t, last.Choices)
+	require.NotNil(t, last.Usage)
+	assert.True(t, last.Usage.Estimated)
+	assert.Equal(t, EstimateTokens("Hello, world"), last.Usage.CompletionTokens)
+}
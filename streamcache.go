@@ -0,0 +1,195 @@
+package openaiclient
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Stream replay modes.
+const (
+	// ReplayInstant replays cached streams as fast as they're read.
+	ReplayInstant StreamReplay = iota
+	// ReplayTimed replays cached streams with their original timing.
+	ReplayTimed
+)
+
+type (
+	// StreamReplay is how cached streams are replayed.
+	StreamReplay int
+
+	// CachedStream is a streamed chat completion recorded for replay.
+	CachedStream struct {
+		Header http.Header   `json:"header"`
+		Events []CachedEvent `json:"events"`
+	}
+
+	// CachedEvent is the data of a server-sent event of a cached stream.
+	CachedEvent struct {
+		// Offset is the time the event was received at, since the start of the stream.
+		Offset time.Duration `json:"offset"`
+		Data   string        `json:"data"`
+	}
+
+	// StreamCache stores streamed chat completions by request key.
+	StreamCache interface {
+		// Get returns the stream cached under the key, or false if there's none.
+		Get(ctx context.Context, key string) (*CachedStream, bool, error)
+		Put(ctx context.Context, key string, stream *CachedStream) error
+	}
+
+	// streamCache records and replays the streams of a client.
+	streamCache struct {
+		cache  StreamCache
+		replay StreamReplay
+	}
+
+	// streamRecorder records the events of a stream being read.
+	streamRecorder struct {
+		cache  StreamCache
+		ctx    context.Context
+		key    string
+		start  time.Time
+		stream CachedStream
+	}
+
+	// replayBody is the body of a replayed stream.
+	replayBody struct {
+		ctx    context.Context
+		sleep  func(ctx context.Context, d time.Duration) error
+		timed  bool
+		events []CachedEvent
+		// offset is the offset of the last event replayed.
+		offset time.Duration
+		buf    []byte
+	}
+)
+
+// WithStreamCache records the streamed chat completions that complete into
+// the cache and replays them on later identical requests, without calling the
+// API. Replayed streams aren't recorded into the usage.
+func WithStreamCache(cache StreamCache, replay StreamReplay) Option {
+	return func(c *Client) {
+		c.streamCache = &streamCache{cache: cache, replay: replay}
+	}
+}
+
+// open returns the replay of the stream cached for the request, or nil with
+// a recorder for the stream to send.
+func (sc *streamCache) open(ctx context.Context, c *Client, in streamRequest) (*ChatCompletionStream, *streamRecorder, error) {
+	if sc == nil {
+		return nil, nil, nil
+	}
+
+	key, err := CanonicalHash(struct {
+		URL     string        `json:"url"`
+		Request streamRequest `json:"request"`
+	}{URL: c.url(EndpointChatCompletions), Request: in})
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not hash request: %w", err)
+	}
+
+	cached, ok, err := sc.cache.Get(ctx, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read stream cache: %w", err)
+	}
+	if !ok {
+		return nil, &streamRecorder{cache: sc.cache, ctx: ctx, key: key, start: time.Now()}, nil
+	}
+
+	body := &replayBody{ctx: ctx, sleep: c.sleep, timed: sc.replay == ReplayTimed, events: cached.Events}
+	stream := ChatCompletionStream{
+		client:    c,
+		body:      body,
+		reader:    bufio.NewReader(body),
+		maxTokens: in.MaxTokens,
+		replayed:  true,
+	}
+	stream.setHeader(c.headerPolicy.capture(cached.Header))
+	return &stream, nil, nil
+}
+
+// record records the data of an event.
+func (r *streamRecorder) record(data []byte) {
+	if r == nil {
+		return
+	}
+	r.stream.Events = append(r.stream.Events, CachedEvent{Offset: time.Since(r.start), Data: string(data)})
+}
+
+// done stores the recorded stream once it completed.
+func (r *streamRecorder) done() error {
+	if r == nil {
+		return nil
+	}
+	if err := r.cache.Put(r.ctx, r.key, &r.stream); err != nil {
+		return fmt.Errorf("could not cache stream: %w", err)
+	}
+	return nil
+}
+
+// Read implements io.Reader, writing the events as server-sent events.
+func (b *replayBody) Read(p []byte) (int, error) {
+	for len(b.buf) == 0 {
+		if len(b.events) == 0 {
+			return 0, io.EOF
+		}
+
+		event := b.events[0]
+		b.events = b.events[1:]
+
+		if d := event.Offset - b.offset; b.timed && d > 0 {
+			if err := b.sleep(b.ctx, d); err != nil {
+				return 0, err
+			}
+		}
+		b.offset = event.Offset
+
+		b.buf = append(b.buf, "data: "...)
+		b.buf = append(b.buf, event.Data...)
+		b.buf = append(b.buf, "\n\n"...)
+	}
+
+	n := copy(p, b.buf)
+	b.buf = b.buf[n:]
+	return n, nil
+}
+
+// Close implements io.Closer.
+func (b *replayBody) Close() error {
+	b.events = nil
+	return nil
+}
+
+// MemoryStreamCache is a StreamCache in memory, safe for concurrent use.
+type MemoryStreamCache struct {
+	mu      sync.Mutex
+	streams map[string]*CachedStream
+}
+
+// NewMemoryStreamCache returns an empty MemoryStreamCache.
+func NewMemoryStreamCache() *MemoryStreamCache {
+	return &MemoryStreamCache{streams: map[string]*CachedStream{}}
+}
+
+// Get implements StreamCache.
+func (m *MemoryStreamCache) Get(_ context.Context, key string) (*CachedStream, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stream, ok := m.streams[key]
+	return stream, ok, nil
+}
+
+// Put implements StreamCache.
+func (m *MemoryStreamCache) Put(_ context.Context, key string, stream *CachedStream) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.streams[key] = stream
+	return nil
+}
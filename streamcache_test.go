@@ -0,0 +1,131 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithStreamCache(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		replay     StreamReplay
+		wantSleeps []time.Duration
+	}{
+		{name: "instant", replay: ReplayInstant},
+		{name: "timed", replay: ReplayTimed, wantSleeps: []time.Duration{10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cache := NewMemoryStreamCache()
+
+			var calls int
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					calls++
+					return sseResponse(sseEvents(t,
+						ChatCompletionChunk{ID: "chatcmpl-123", Choices: []ChunkChoice{{Delta: MessageDelta{Role: "assistant", Content: "Hel"}}}},
+						ChatCompletionChunk{ID: "chatcmpl-123", Choices: []ChunkChoice{{Delta: MessageDelta{Content: "lo"}, FinishReason: "stop"}}},
+						ChatCompletionChunk{ID: "chatcmpl-123", Usage: &Usage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7}},
+					)), nil
+				},
+			}, WithStreamCache(cache, tt.replay))
+
+			var sleeps []time.Duration
+			client.sleep = func(_ context.Context, d time.Duration) error {
+				sleeps = append(sleeps, d)
+				return nil
+			}
+
+			in := ChatCompletionRequest{Model: "gpt-4o", Messages: []Message{{Role: "user", Content: "Hi"}}}
+			stream, err := client.CreateChatCompletionStream(context.Background(), in)
+			require.NoError(t, err)
+
+			recorded, err := stream.Accumulate(NewStreamAccumulator())
+			require.NoError(t, err)
+			require.NoError(t, stream.Close())
+			require.Len(t, cache.streams, 1)
+
+			// Space the events 10ms apart, for the timed replay.
+			for _, cached := range cache.streams {
+				require.Len(t, cached.Events, 4)
+				for i := range cached.Events {
+					cached.Events[i].Offset = time.Duration(i*10) * time.Millisecond
+				}
+				assert.Equal(t, "req-123", cached.Header.Get("X-Request-Id"))
+			}
+
+			stream, err = client.CreateChatCompletionStream(context.Background(), in)
+			require.NoError(t, err)
+			defer stream.Close()
+
+			replayed, err := stream.Accumulate(NewStreamAccumulator())
+			require.NoError(t, err)
+
+			assert.Equal(t, 1, calls)
+			assert.Equal(t, recorded, replayed)
+			assert.Equal(t, "req-123", stream.Header.Get("X-Request-Id"))
+			assert.Equal(t, tt.wantSleeps, sleeps)
+			assert.Equal(t, 7, client.Usage().TotalTokens, "replays must not be recorded")
+
+			_, err = client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o-mini", Messages: in.Messages})
+			require.NoError(t, err)
+			assert.Equal(t, 2, calls, "other requests must not be replayed")
+		})
+	}
+}
+
+func TestWithStreamCache_Incomplete(t *testing.T) {
+	t.Parallel()
+
+	cache := NewMemoryStreamCache()
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return sseResponse("data: {\"id\":\"chatcmpl-123\"}\n\n"), nil
+		},
+	}, WithStreamCache(cache, ReplayInstant))
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	_, err = stream.Accumulate(NewStreamAccumulator())
+	require.Error(t, err)
+
+	assert.Empty(t, cache.streams)
+}
+
+// failingStreamCache is a StreamCache failing to store streams.
+type failingStreamCache struct {
+	*MemoryStreamCache
+}
+
+func (failingStreamCache) Put(context.Context, string, *CachedStream) error {
+	return errors.New("disk full")
+}
+
+func TestWithStreamCache_PutError(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return sseResponse(sseEvents(t, ChatCompletionChunk{ID: "chatcmpl-123"})), nil
+		},
+	}, WithStreamCache(&failingStreamCache{MemoryStreamCache: NewMemoryStreamCache()}, ReplayInstant))
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	_, err = stream.Accumulate(NewStreamAccumulator())
+	assert.ErrorContains(t, err, "could not cache stream: disk full")
+}
@@ -0,0 +1,197 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Stream delta modes.
+const (
+	// DeltaBuffered holds back the UTF-8 sequences of the content and refusal
+	// deltas split across chunks until they complete, so every delta is valid
+	// UTF-8. It is the default.
+	DeltaBuffered DeltaMode = iota
+	// DeltaRaw passes the bytes of the deltas as sent, so deltas may end or
+	// start mid-sequence, for callers doing their own buffering.
+	DeltaRaw
+)
+
+type (
+	// DeltaMode is how streams handle the UTF-8 sequences split across chunks.
+	DeltaMode int
+
+	// deltaBuffer holds the incomplete trailing sequences of the deltas of a stream.
+	deltaBuffer struct {
+		pending map[deltaKey]string
+	}
+
+	// deltaKey identifies the content or refusal deltas of a choice.
+	deltaKey struct {
+		choice  int
+		refusal bool
+	}
+
+	// rawDeltaChunk holds the strings of a chunk as sent.
+	rawDeltaChunk struct {
+		Choices []struct {
+			Delta struct {
+				Content   json.RawMessage `json:"content"`
+				Refusal   json.RawMessage `json:"refusal"`
+				ToolCalls []struct {
+					Function struct {
+						Arguments json.RawMessage `json:"arguments"`
+					} `json:"function"`
+				} `json:"tool_calls"`
+			} `json:"delta"`
+		} `json:"choices"`
+	}
+)
+
+// WithDeltaMode sets how streams handle the UTF-8 sequences split across chunks.
+// Tool call arguments are always passed as sent, to be joined.
+func WithDeltaMode(mode DeltaMode) Option {
+	return func(c *Client) {
+		c.deltaMode = mode
+	}
+}
+
+// repair restores the bytes of the deltas of the chunk decoded from data,
+// which encoding/json replaces when they aren't valid UTF-8, and, unless raw,
+// holds back their incomplete trailing sequences. The sequences held back
+// when a choice finishes are replaced by U+FFFD.
+func (b *deltaBuffer) repair(data []byte, chunk *ChatCompletionChunk, raw bool) error {
+	valid := utf8.Valid(data)
+	if valid && len(b.pending) == 0 {
+		return nil
+	}
+
+	if !valid {
+		var sent rawDeltaChunk
+		if err := json.Unmarshal(data, &sent); err != nil {
+			return err
+		}
+
+		for i, choice := range sent.Choices[:min(len(sent.Choices), len(chunk.Choices))] {
+			delta := &chunk.Choices[i].Delta
+			delta.Content = unquoteRaw(choice.Delta.Content, delta.Content)
+			delta.Refusal = unquoteRaw(choice.Delta.Refusal, delta.Refusal)
+
+			for j, call := range choice.Delta.ToolCalls[:min(len(choice.Delta.ToolCalls), len(delta.ToolCalls))] {
+				delta.ToolCalls[j].Function.Arguments = unquoteRaw(call.Function.Arguments, delta.ToolCalls[j].Function.Arguments)
+			}
+		}
+	}
+
+	if raw {
+		return nil
+	}
+	for i := range chunk.Choices {
+		choice := &chunk.Choices[i]
+		final := choice.FinishReason != ""
+		choice.Delta.Content = b.complete(deltaKey{choice: choice.Index}, choice.Delta.Content, final)
+		choice.Delta.Refusal = b.complete(deltaKey{choice: choice.Index, refusal: true}, choice.Delta.Refusal, final)
+	}
+	return nil
+}
+
+// complete returns the delta after the sequence held back for the key,
+// holding back its own incomplete trailing sequence unless final.
+func (b *deltaBuffer) complete(key deltaKey, delta string, final bool) string {
+	if pending, ok := b.pending[key]; ok {
+		delta = pending + delta
+		delete(b.pending, key)
+	}
+
+	if !final {
+		if n := incompleteSuffix(delta); n > 0 {
+			if b.pending == nil {
+				b.pending = map[deltaKey]string{}
+			}
+			b.pending[key] = delta[len(delta)-n:]
+			delta = delta[:len(delta)-n]
+		}
+	}
+	return strings.ToValidUTF8(delta, string(utf8.RuneError))
+}
+
+// incompleteSuffix returns the length of the UTF-8 sequence the string ends
+// with, if it is the start of a valid but incomplete sequence.
+func incompleteSuffix(s string) int {
+	for i := len(s) - 1; i >= 0 && i >= len(s)-utf8.UTFMax+1; i-- {
+		if utf8.RuneStart(s[i]) {
+			if utf8.FullRuneInString(s[i:]) {
+				return 0
+			}
+			return len(s) - i
+		}
+	}
+	return 0
+}
+
+// unquoteRaw returns the JSON string, keeping the bytes that aren't valid
+// UTF-8 instead of replacing them like encoding/json, or the decoded string
+// if the value isn't a string.
+func unquoteRaw(raw json.RawMessage, decoded string) string {
+	if len(raw) < 2 || raw[0] != '"' {
+		return decoded
+	}
+
+	s := raw[1 : len(raw)-1]
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			out = append(out, s[i])
+			continue
+		}
+
+		i++
+		if i == len(s) {
+			return decoded
+		}
+		switch s[i] {
+		case 'b':
+			out = append(out, '\b')
+		case 'f':
+			out = append(out, '\f')
+		case 'n':
+			out = append(out, '\n')
+		case 'r':
+			out = append(out, '\r')
+		case 't':
+			out = append(out, '\t')
+		case 'u':
+			r, ok := unquoteEscape(s[i+1:])
+			if !ok {
+				return decoded
+			}
+			i += 4
+
+			// Surrogate pairs are escaped as two code units.
+			if utf16.IsSurrogate(r) {
+				low, ok := rune(-1), false
+				if len(s) > i+2 && s[i+1] == '\\' && s[i+2] == 'u' {
+					low, ok = unquoteEscape(s[i+3:])
+				}
+				if r = utf16.DecodeRune(r, low); ok && r != utf8.RuneError {
+					i += 6
+				}
+			}
+			out = utf8.AppendRune(out, r)
+		default:
+			out = append(out, s[i])
+		}
+	}
+	return string(out)
+}
+
+// unquoteEscape returns the code unit of the four hex digits starting s.
+func unquoteEscape(s []byte) (rune, bool) {
+	if len(s) < 4 {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(string(s[:4]), 16, 16)
+	return rune(n), err == nil
+}
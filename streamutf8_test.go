@@ -0,0 +1,127 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// splitStream streams "Hello, 世界" with the runes of 世 and 界 split across
+// chunks, then a tool call whose arguments split é.
+const splitStream = "data: {\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"Hello, \xe4\xb8\"}}]}\n\n" +
+	"data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"\x96\xe7\"}}]}\n\n" +
+	"data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"\x95\"}}]}\n\n" +
+	"data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"\x8c\"}}]}\n\n" +
+	"data: {\"choices\":[{\"index\":0,\"delta\":{\"tool_calls\":[{\"index\":0,\"id\":\"call_1\",\"type\":\"function\",\"function\":{\"name\":\"greet\",\"arguments\":\"{\\\"name\\\":\\\"Ren\xc3\"}}]}}]}\n\n" +
+	"data: {\"choices\":[{\"index\":0,\"delta\":{\"tool_calls\":[{\"index\":0,\"function\":{\"arguments\":\"\xa9\\\"}\"}}]},\"finish_reason\":\"tool_calls\"}]}\n\n" +
+	"data: [DONE]\n\n"
+
+// streamDeltas returns the content deltas and the accumulated message of the stream.
+func streamDeltas(t *testing.T, body string, opts ...Option) ([]string, Message) {
+	t.Helper()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return sseResponse(body), nil
+		},
+	}, opts...)
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	var (
+		deltas []string
+		acc    = NewStreamAccumulator()
+	)
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		acc.Add(chunk)
+		for _, choice := range chunk.Choices {
+			deltas = append(deltas, choice.Delta.Content)
+		}
+	}
+	return deltas, acc.Response().Choices[0].Message
+}
+
+func TestChatCompletionStream_SplitUTF8(t *testing.T) {
+	t.Parallel()
+
+	deltas, msg := streamDeltas(t, splitStream)
+
+	assert.Equal(t, []string{"Hello, ", "世", "", "界", "", ""}, deltas)
+	assert.Equal(t, "Hello, 世界", msg.Content)
+
+	require.Len(t, msg.ToolCalls, 1)
+	assert.Equal(t, `{"name":"René"}`, msg.ToolCalls[0].Function.Arguments)
+}
+
+func TestChatCompletionStream_RawDeltas(t *testing.T) {
+	t.Parallel()
+
+	deltas, msg := streamDeltas(t, splitStream, WithDeltaMode(DeltaRaw))
+
+	assert.Equal(t, []string{"Hello, \xe4\xb8", "\x96\xe7", "\x95", "\x8c", "", ""}, deltas)
+	assert.Equal(t, "Hello, 世界", msg.Content)
+}
+
+func TestChatCompletionStream_IncompleteUTF8(t *testing.T) {
+	t.Parallel()
+
+	// The choice finishes mid-sequence, which is then replaced.
+	deltas, _ := streamDeltas(t, "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"ok \xe4\xb8\"}}]}\n\n"+
+		"data: {\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"length\"}]}\n\n"+
+		"data: [DONE]\n\n")
+
+	assert.Equal(t, []string{"ok ", "�"}, deltas)
+}
+
+func TestUnquoteRaw(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{name: "plain", raw: `"hello"`, want: "hello"},
+		{name: "escapes", raw: `"a\"b\\c\/d\n\t\r\b\f"`, want: "a\"b\\c/d\n\t\r\b\f"},
+		{name: "unicode escape", raw: `"caf\u00e9"`, want: "café"},
+		{name: "surrogate pair", raw: `"\ud83d\ude00!"`, want: "😀!"},
+		{name: "lone surrogate", raw: `"\ud83d!"`, want: "�!"},
+		{name: "invalid bytes", raw: "\"a\xe4\xb8\"", want: "a\xe4\xb8"},
+		{name: "null", raw: `null`, want: "decoded"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, unquoteRaw(json.RawMessage(tt.raw), "decoded"))
+		})
+	}
+}
+
+func TestIncompleteSuffix(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, incompleteSuffix(""))
+	assert.Equal(t, 0, incompleteSuffix("世界"))
+	assert.Equal(t, 2, incompleteSuffix("a\xe4\xb8"))
+	assert.Equal(t, 3, incompleteSuffix("\xf0\x9f\x98"))
+	// Invalid bytes aren't the start of a sequence, and are replaced.
+	assert.Equal(t, 0, incompleteSuffix("a\x8c"))
+	assert.Equal(t, 0, incompleteSuffix("a\xff"))
+}
@@ -0,0 +1,222 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrRefusal is matched by errors.Is for every RefusalError.
+var ErrRefusal = errors.New("model refused")
+
+// RefusalError is returned when the model refuses to produce a structured output.
+type RefusalError struct {
+	Refusal string
+}
+
+// Error implements the error interface.
+func (e *RefusalError) Error() string {
+	return "model refused: " + e.Refusal
+}
+
+// Is reports whether target is ErrRefusal.
+func (e *RefusalError) Is(target error) bool {
+	return target == ErrRefusal
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	// schemaNameInvalid matches the characters not allowed in JSON schema names.
+	schemaNameInvalid = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+)
+
+// SchemaFor returns the strict JSON schema of the type of v, as encoded by
+// encoding/json: every property is required, pointers are nullable and
+// additional properties are disallowed. Fields are described by their
+// description tag, e.g. `description:"The city name"`. Maps, interfaces and
+// recursive types aren't supported by strict schemas and return an error.
+func SchemaFor(v any) (json.RawMessage, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, fmt.Errorf("could not generate schema: nil value")
+	}
+
+	schema, err := typeSchema(t, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, fmt.Errorf("could not generate schema of %s: %w", t, err)
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal schema: %w", err)
+	}
+	return data, nil
+}
+
+// typeSchema returns the schema of the type. Visiting holds the structs being
+// generated, to detect recursion.
+func typeSchema(t reflect.Type, visiting map[reflect.Type]bool) (map[string]any, error) {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.Pointer:
+		schema, err := typeSchema(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		schema["type"] = []any{schema["type"], "null"}
+		return schema, nil
+	case reflect.Slice, reflect.Array:
+		// Byte slices are encoded as base64 strings.
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string"}, nil
+		}
+
+		items, err := typeSchema(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Struct:
+		if visiting[t] {
+			return nil, fmt.Errorf("recursive type %s is not supported", t)
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		properties := map[string]any{}
+		required := []string{}
+		if err := addProperties(t, visiting, properties, &required); err != nil {
+			return nil, err
+		}
+
+		return map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%s values are not supported", t.Kind())
+	}
+}
+
+// addProperties adds the properties of the struct fields, flattening embedded
+// structs like encoding/json.
+func addProperties(t reflect.Type, visiting map[reflect.Type]bool, properties map[string]any, required *[]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := addProperties(embedded, visiting, properties, required); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema, err := typeSchema(field.Type, visiting)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if description := field.Tag.Get("description"); description != "" {
+			schema["description"] = description
+		}
+
+		properties[name] = schema
+		*required = append(*required, name)
+	}
+	return nil
+}
+
+// StructuredFormat returns the strict "json_schema" response format making the
+// model output a JSON encoded T, named after the type of T.
+func StructuredFormat[T any]() (*ResponseFormat, error) {
+	var v T
+	schema, err := SchemaFor(v)
+	if err != nil {
+		return nil, err
+	}
+
+	name := schemaNameInvalid.ReplaceAllString(reflect.TypeOf(v).Name(), "_")
+	if name == "" {
+		name = "response"
+	}
+	return JSONSchemaFormat(name, schema, true), nil
+}
+
+// ParseStructured decodes the content of the first choice of the response into a T.
+// It returns a RefusalError if the model refused to answer.
+func ParseStructured[T any](resp *ChatCompletionResponse) (T, error) {
+	var out T
+	if len(resp.Choices) == 0 {
+		return out, fmt.Errorf("could not parse structured output: no choices")
+	}
+
+	msg := resp.Choices[0].Message
+	if msg.Refusal != "" {
+		return out, &RefusalError{Refusal: msg.Refusal}
+	}
+
+	if err := json.Unmarshal([]byte(msg.Content), &out); err != nil {
+		return out, fmt.Errorf("could not parse structured output (finish reason %q): %w", resp.Choices[0].FinishReason, err)
+	}
+	return out, nil
+}
+
+// CreateStructured creates a chat completion whose output is a JSON encoded T,
+// using the strict schema of T as the response format, and decodes it.
+func CreateStructured[T any](ctx context.Context, c *Client, in ChatCompletionRequest) (T, *ChatCompletionResponse, error) {
+	var zero T
+
+	format, err := StructuredFormat[T]()
+	if err != nil {
+		return zero, nil, err
+	}
+	in.ResponseFormat = format
+
+	resp, err := c.CreateChatCompletion(ctx, in)
+	if err != nil {
+		return zero, nil, err
+	}
+
+	out, err := ParseStructured[T](resp)
+	if err != nil {
+		return zero, resp, err
+	}
+	return out, resp, nil
+}
@@ -0,0 +1,202 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	weatherBase struct {
+		City string `json:"city" description:"The city name"`
+	}
+
+	weatherReport struct {
+		weatherBase
+		Celsius   float64    `json:"celsius"`
+		Humid     bool       `json:"humid"`
+		Days      int        `json:"days,omitempty"`
+		Alerts    []string   `json:"alerts"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		Wind      *windSpeed `json:"wind"`
+		Ignored   string     `json:"-"`
+		internal  string
+	}
+
+	windSpeed struct {
+		Kmh int
+	}
+)
+
+func TestSchemaFor(t *testing.T) {
+	t.Parallel()
+
+	schema, err := SchemaFor(weatherReport{})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"city": {"type": "string", "description": "The city name"},
+			"celsius": {"type": "number"},
+			"humid": {"type": "boolean"},
+			"days": {"type": "integer"},
+			"alerts": {"type": "array", "items": {"type": "string"}},
+			"updated_at": {"type": "string", "format": "date-time"},
+			"wind": {
+				"type": ["object", "null"],
+				"properties": {"Kmh": {"type": "integer"}},
+				"required": ["Kmh"],
+				"additionalProperties": false
+			}
+		},
+		"required": ["city", "celsius", "humid", "days", "alerts", "updated_at", "wind"],
+		"additionalProperties": false
+	}`, string(schema))
+}
+
+func TestSchemaFor_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	type node struct {
+		Next *node `json:"next"`
+	}
+
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{name: "nil", v: nil},
+		{name: "map", v: struct{ Labels map[string]string }{}},
+		{name: "interface", v: struct{ Value any }{}},
+		{name: "recursive", v: node{}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := SchemaFor(tt.v)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestStructuredFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := StructuredFormat[windSpeed]()
+	require.NoError(t, err)
+
+	assert.Equal(t, ResponseFormatJSONSchema, format.Type)
+	assert.Equal(t, "windSpeed", format.JSONSchema.Name)
+	assert.True(t, format.JSONSchema.Strict)
+
+	data, err := json.Marshal(format)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "json_schema",
+		"json_schema": {
+			"name": "windSpeed",
+			"strict": true,
+			"schema": {
+				"type": "object",
+				"properties": {"Kmh": {"type": "integer"}},
+				"required": ["Kmh"],
+				"additionalProperties": false
+			}
+		}
+	}`, string(data))
+}
+
+func TestParseStructured(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		resp    ChatCompletionResponse
+		want    windSpeed
+		wantErr error
+	}{
+		{
+			name: "valid output",
+			resp: ChatCompletionResponse{Choices: []Choice{{Message: Message{Role: "assistant", Content: `{"Kmh":12}`}}}},
+			want: windSpeed{Kmh: 12},
+		},
+		{
+			name:    "refusal",
+			resp:    ChatCompletionResponse{Choices: []Choice{{Message: Message{Role: "assistant", Refusal: "I can't help with that."}}}},
+			wantErr: ErrRefusal,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseStructured[windSpeed](&tt.resp)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseStructured_Truncated(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseStructured[windSpeed](&ChatCompletionResponse{
+		Choices: []Choice{{FinishReason: "length", Message: Message{Content: `{"Kmh":`}}},
+	})
+
+	assert.ErrorContains(t, err, `finish reason "length"`)
+}
+
+func TestCreateStructured(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in ChatCompletionRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+			require.NotNil(t, in.ResponseFormat)
+			assert.Equal(t, ResponseFormatJSONSchema, in.ResponseFormat.Type)
+			assert.Equal(t, "weatherReport", in.ResponseFormat.JSONSchema.Name)
+			assert.True(t, in.ResponseFormat.JSONSchema.Strict)
+
+			return jsonResponse(t, map[string]any{
+				"choices": []map[string]any{{
+					"finish_reason": "stop",
+					"message": map[string]any{
+						"role":    "assistant",
+						"content": `{"city":"Paris","celsius":21.5,"humid":false,"days":1,"alerts":[],"updated_at":"2024-06-01T12:00:00Z","wind":null}`,
+					},
+				}},
+			}), nil
+		},
+	})
+
+	got, resp, err := CreateStructured[weatherReport](context.Background(), client, ChatCompletionRequest{
+		Model:    "gpt-4o",
+		Messages: []Message{{Role: "user", Content: "Weather in Paris?"}},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+
+	assert.Equal(t, "Paris", got.City)
+	assert.Equal(t, 21.5, got.Celsius)
+	assert.Equal(t, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), got.UpdatedAt)
+	assert.Nil(t, got.Wind)
+}
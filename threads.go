@@ -0,0 +1,259 @@
+package openaiclient
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+type (
+	// ThreadRequest is the request body to create or modify a thread.
+	// Messages are only accepted on creation.
+	ThreadRequest struct {
+		Messages      []MessageRequest  `json:"messages,omitempty"`
+		ToolResources *ToolResources    `json:"tool_resources,omitempty"`
+		Metadata      map[string]string `json:"metadata,omitempty"`
+	}
+
+	// Thread is a conversation of the Assistants API.
+	Thread struct {
+		ResponseMeta
+		ID            string            `json:"id"`
+		Object        string            `json:"object"`
+		CreatedAt     int64             `json:"created_at"`
+		ToolResources *ToolResources    `json:"tool_resources,omitempty"`
+		Metadata      map[string]string `json:"metadata"`
+	}
+
+	// MessageRequest is the request body to create a thread message.
+	MessageRequest struct {
+		// Role is "user" or "assistant".
+		Role        string            `json:"role"`
+		Content     string            `json:"content"`
+		Attachments []Attachment      `json:"attachments,omitempty"`
+		Metadata    map[string]string `json:"metadata,omitempty"`
+	}
+
+	// Attachment is a file attached to a message, along with the tools it is added to.
+	Attachment struct {
+		FileID string          `json:"file_id"`
+		Tools  []AssistantTool `json:"tools,omitempty"`
+	}
+
+	// ThreadMessage is a message of a thread.
+	ThreadMessage struct {
+		ResponseMeta
+		ID          string            `json:"id"`
+		Object      string            `json:"object"`
+		CreatedAt   int64             `json:"created_at"`
+		ThreadID    string            `json:"thread_id"`
+		Status      string            `json:"status,omitempty"`
+		Role        string            `json:"role"`
+		Content     []MessageContent  `json:"content"`
+		AssistantID string            `json:"assistant_id,omitempty"`
+		RunID       string            `json:"run_id,omitempty"`
+		Attachments []Attachment      `json:"attachments,omitempty"`
+		Metadata    map[string]string `json:"metadata"`
+	}
+
+	// MessageContent is a part of a thread message, of type "text", "image_file" or "image_url".
+	MessageContent struct {
+		Type      string            `json:"type"`
+		Text      *MessageText      `json:"text,omitempty"`
+		ImageFile *MessageImageFile `json:"image_file,omitempty"`
+		ImageURL  *MessageImageURL  `json:"image_url,omitempty"`
+	}
+
+	// MessageText is the text of a message content part.
+	MessageText struct {
+		Value       string              `json:"value"`
+		Annotations []MessageAnnotation `json:"annotations,omitempty"`
+	}
+
+	// MessageAnnotation annotates a span of a message text, of type
+	// "file_citation" or "file_path".
+	MessageAnnotation struct {
+		Type         string               `json:"type"`
+		Text         string               `json:"text"`
+		StartIndex   int                  `json:"start_index"`
+		EndIndex     int                  `json:"end_index"`
+		FileCitation *MessageFileCitation `json:"file_citation,omitempty"`
+		FilePath     *MessageFilePath     `json:"file_path,omitempty"`
+	}
+
+	// MessageFileCitation cites a file searched by the file_search tool.
+	MessageFileCitation struct {
+		FileID string `json:"file_id"`
+	}
+
+	// MessageFilePath is a file generated by the code_interpreter tool.
+	MessageFilePath struct {
+		FileID string `json:"file_id"`
+	}
+
+	// MessageImageFile is an uploaded image of a message.
+	MessageImageFile struct {
+		FileID string `json:"file_id"`
+		Detail string `json:"detail,omitempty"`
+	}
+
+	// MessageImageURL is an external image of a message.
+	MessageImageURL struct {
+		URL    string `json:"url"`
+		Detail string `json:"detail,omitempty"`
+	}
+
+	// MessageListOptions paginates and filters the message listing.
+	MessageListOptions struct {
+		ListOptions
+		// RunID restricts the listing to the messages of the run.
+		RunID string
+	}
+)
+
+// Text returns the text parts of the message, joined.
+func (m ThreadMessage) Text() string {
+	var texts []string
+	for _, content := range m.Content {
+		if content.Text != nil {
+			texts = append(texts, content.Text.Value)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// CreateThread creates a thread, optionally with initial messages.
+func (c *Client) CreateThread(ctx context.Context, in ThreadRequest) (*Thread, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var thread Thread
+	if err := c.post(ctx, c.url(EndpointThreads), in, &thread); err != nil {
+		return nil, err
+	}
+	return &thread, nil
+}
+
+// GetThread retrieves the thread.
+func (c *Client) GetThread(ctx context.Context, id string) (*Thread, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var thread Thread
+	if err := c.get(ctx, c.threadURL(id), &thread); err != nil {
+		return nil, err
+	}
+	return &thread, nil
+}
+
+// ModifyThread modifies the tool resources and metadata of the thread.
+func (c *Client) ModifyThread(ctx context.Context, id string, in ThreadRequest) (*Thread, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var thread Thread
+	if err := c.post(ctx, c.threadURL(id), in, &thread); err != nil {
+		return nil, err
+	}
+	return &thread, nil
+}
+
+// DeleteThread deletes the thread.
+func (c *Client) DeleteThread(ctx context.Context, id string) (*DeletedObject, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var deleted DeletedObject
+	if err := c.delete(ctx, c.threadURL(id), &deleted); err != nil {
+		return nil, err
+	}
+	return &deleted, nil
+}
+
+// CreateMessage adds a message to the thread.
+func (c *Client) CreateMessage(ctx context.Context, threadID string, in MessageRequest) (*ThreadMessage, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var msg ThreadMessage
+	if err := c.post(ctx, c.threadURL(threadID)+"/messages", in, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// ListMessages lists the messages of the thread.
+func (c *Client) ListMessages(ctx context.Context, threadID string, opts MessageListOptions) (*List[ThreadMessage], error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	query := opts.query()
+	if opts.RunID != "" {
+		query.Set("run_id", opts.RunID)
+	}
+
+	var list List[ThreadMessage]
+	if err := c.get(ctx, withQuery(c.threadURL(threadID)+"/messages", query), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetMessage retrieves the message of the thread.
+func (c *Client) GetMessage(ctx context.Context, threadID, messageID string) (*ThreadMessage, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var msg ThreadMessage
+	if err := c.get(ctx, c.messageURL(threadID, messageID), &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// ModifyMessage replaces the metadata of the message.
+func (c *Client) ModifyMessage(ctx context.Context, threadID, messageID string, metadata map[string]string) (*ThreadMessage, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	in := struct {
+		Metadata map[string]string `json:"metadata"`
+	}{Metadata: metadata}
+
+	var msg ThreadMessage
+	if err := c.post(ctx, c.messageURL(threadID, messageID), in, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// DeleteMessage deletes the message of the thread.
+func (c *Client) DeleteMessage(ctx context.Context, threadID, messageID string) (*DeletedObject, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var deleted DeletedObject
+	if err := c.delete(ctx, c.messageURL(threadID, messageID), &deleted); err != nil {
+		return nil, err
+	}
+	return &deleted, nil
+}
+
+// threadURL returns the URL of the thread.
+func (c *Client) threadURL(id string) string {
+	return c.url(EndpointThreads) + "/" + url.PathEscape(id)
+}
+
+// messageURL returns the URL of the message of the thread.
+func (c *Client) messageURL(threadID, messageID string) string {
+	return c.threadURL(threadID) + "/messages/" + url.PathEscape(messageID)
+}
@@ -0,0 +1,104 @@
+package openaiclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Threads(t *testing.T) {
+	t.Parallel()
+
+	bodies := map[string]map[string]any{}
+	client := New("test_api_key", routeClient(t, map[string]any{
+		"POST /v1/threads":            Thread{ID: "thread_1"},
+		"GET /v1/threads/thread_1":    Thread{ID: "thread_1", Metadata: map[string]string{"user": "42"}},
+		"POST /v1/threads/thread_1":   Thread{ID: "thread_1", Metadata: map[string]string{"user": "43"}},
+		"DELETE /v1/threads/thread_1": DeletedObject{ID: "thread_1", Deleted: true},
+	}, bodies), WithBeta(BetaAssistants))
+
+	ctx := context.Background()
+
+	thread, err := client.CreateThread(ctx, ThreadRequest{
+		Messages: []MessageRequest{{
+			Role:        "user",
+			Content:     "What does the manual say about resets?",
+			Attachments: []Attachment{{FileID: "file_1", Tools: []AssistantTool{{Type: AssistantToolFileSearch}}}},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "thread_1", thread.ID)
+	assert.Equal(t, map[string]any{
+		"messages": []any{map[string]any{
+			"role":        "user",
+			"content":     "What does the manual say about resets?",
+			"attachments": []any{map[string]any{"file_id": "file_1", "tools": []any{map[string]any{"type": "file_search"}}}},
+		}},
+	}, bodies["POST /v1/threads"])
+
+	thread, err = client.GetThread(ctx, "thread_1")
+	require.NoError(t, err)
+	assert.Equal(t, "42", thread.Metadata["user"])
+
+	thread, err = client.ModifyThread(ctx, "thread_1", ThreadRequest{Metadata: map[string]string{"user": "43"}})
+	require.NoError(t, err)
+	assert.Equal(t, "43", thread.Metadata["user"])
+
+	deleted, err := client.DeleteThread(ctx, "thread_1")
+	require.NoError(t, err)
+	assert.True(t, deleted.Deleted)
+}
+
+func TestClient_Messages(t *testing.T) {
+	t.Parallel()
+
+	answer := ThreadMessage{
+		ID:   "msg_2",
+		Role: "assistant",
+		Content: []MessageContent{
+			{Type: "text", Text: &MessageText{Value: "Hold the button for 10 seconds.", Annotations: []MessageAnnotation{{
+				Type:         "file_citation",
+				Text:         "【4:0†manual.pdf】",
+				FileCitation: &MessageFileCitation{FileID: "file_1"},
+			}}}},
+			{Type: "image_file", ImageFile: &MessageImageFile{FileID: "file_2"}},
+			{Type: "text", Text: &MessageText{Value: "Then restart it."}},
+		},
+	}
+
+	bodies := map[string]map[string]any{}
+	client := New("test_api_key", routeClient(t, map[string]any{
+		"POST /v1/threads/thread_1/messages":                       ThreadMessage{ID: "msg_1", Role: "user"},
+		"GET /v1/threads/thread_1/messages?order=asc&run_id=run_1": List[ThreadMessage]{Data: []ThreadMessage{answer}},
+		"GET /v1/threads/thread_1/messages/msg_2":                  answer,
+		"POST /v1/threads/thread_1/messages/msg_2":                 ThreadMessage{ID: "msg_2", Metadata: map[string]string{"rating": "good"}},
+		"DELETE /v1/threads/thread_1/messages/msg_2":               DeletedObject{ID: "msg_2", Deleted: true},
+	}, bodies), WithBeta(BetaAssistants))
+
+	ctx := context.Background()
+
+	msg, err := client.CreateMessage(ctx, "thread_1", MessageRequest{Role: "user", Content: "How do I reset it?"})
+	require.NoError(t, err)
+	assert.Equal(t, "msg_1", msg.ID)
+	assert.Equal(t, map[string]any{"role": "user", "content": "How do I reset it?"}, bodies["POST /v1/threads/thread_1/messages"])
+
+	list, err := client.ListMessages(ctx, "thread_1", MessageListOptions{ListOptions: ListOptions{Order: "asc"}, RunID: "run_1"})
+	require.NoError(t, err)
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, "Hold the button for 10 seconds.\nThen restart it.", list.Data[0].Text())
+
+	msg, err = client.GetMessage(ctx, "thread_1", "msg_2")
+	require.NoError(t, err)
+	assert.Equal(t, "file_1", msg.Content[0].Text.Annotations[0].FileCitation.FileID)
+
+	msg, err = client.ModifyMessage(ctx, "thread_1", "msg_2", map[string]string{"rating": "good"})
+	require.NoError(t, err)
+	assert.Equal(t, "good", msg.Metadata["rating"])
+	assert.Equal(t, map[string]any{"metadata": map[string]any{"rating": "good"}}, bodies["POST /v1/threads/thread_1/messages/msg_2"])
+
+	deleted, err := client.DeleteMessage(ctx, "thread_1", "msg_2")
+	require.NoError(t, err)
+	assert.True(t, deleted.Deleted)
+}
@@ -0,0 +1,77 @@
+package openaiclient
+
+import "unicode/utf8"
+
+// EstimateTokens returns a rough estimate of the number of tokens of the text,
+// based on the average of four characters per token of OpenAI tokenizers on
+// English text. It is meant for budgeting, not billing.
+func EstimateTokens(text string) int {
+	return estimateRuneTokens(utf8.RuneCountInString(text))
+}
+
+// estimateRuneTokens estimates the number of tokens of a text of n characters,
+// like EstimateTokens, for texts only counted, such as streamed deltas.
+func estimateRuneTokens(n int) int {
+	return (n + 3) / 4
+}
+
+const (
+	// messageOverhead is the number of tokens framing every chat message.
+	messageOverhead = 3
+	// replyOverhead is the number of tokens priming the assistant reply.
+	replyOverhead = 3
+	// lowDetailImageTokens is the cost of a low detail image.
+	lowDetailImageTokens = 85
+	// highDetailImageTokens is the cost of a 1024x1024 image in high or auto detail.
+	highDetailImageTokens = 765
+)
+
+// RequestEstimate is the breakdown of the estimated prompt tokens of a chat request.
+type RequestEstimate struct {
+	// Messages holds the estimate of every message, framing included.
+	Messages []int
+	// Tools is the estimate of the tool definitions.
+	Tools int
+	Total int
+}
+
+// EstimateRequest estimates the prompt tokens of the chat request, message by message.
+func EstimateRequest(req ChatCompletionRequest) RequestEstimate {
+	estimate := RequestEstimate{
+		Messages: make([]int, 0, len(req.Messages)),
+		Total:    replyOverhead,
+	}
+
+	for _, msg := range req.Messages {
+		tokens := messageOverhead + EstimateTokens(string(msg.Role)) + EstimateTokens(msg.Content) + estimateParts(msg.Parts)
+		for _, call := range msg.ToolCalls {
+			tokens += EstimateTokens(call.Function.Name) + EstimateTokens(call.Function.Arguments)
+		}
+
+		estimate.Messages = append(estimate.Messages, tokens)
+		estimate.Total += tokens
+	}
+
+	// Tool definitions are rendered into the prompt, roughly as written.
+	for _, tool := range req.Tools {
+		estimate.Tools += EstimateTokens(tool.Function.Name) + EstimateTokens(tool.Function.Description) + EstimateTokens(string(tool.Function.Parameters))
+	}
+	estimate.Total += estimate.Tools
+	return estimate
+}
+
+// estimateParts estimates the tokens of the content parts. Audio parts aren't counted.
+func estimateParts(parts []ContentPart) int {
+	var tokens int
+	for _, part := range parts {
+		switch {
+		case part.Type == ContentPartText:
+			tokens += EstimateTokens(part.Text)
+		case part.Type == ContentPartImageURL && part.ImageURL != nil && part.ImageURL.Detail == ImageDetailLow:
+			tokens += lowDetailImageTokens
+		case part.Type == ContentPartImageURL:
+			tokens += highDetailImageTokens
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,71 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, EstimateTokens(""))
+	assert.Equal(t, 1, EstimateTokens("hi"))
+	assert.Equal(t, 2, EstimateTokens("hello!!"))
+	assert.Equal(t, 1, EstimateTokens("été"))
+}
+
+func TestEstimateRequest(t *testing.T) {
+	t.Parallel()
+
+	got := EstimateRequest(ChatCompletionRequest{
+		Model: "test_model",
+		Messages: []Message{
+			{Role: "system", Content: "Be brief."},
+			{Role: "user", Content: "What is the capital of France?"},
+		},
+	})
+
+	assert.Equal(t, RequestEstimate{
+		Messages: []int{3 + 2 + 3, 3 + 1 + 8},
+		Total:    3 + 8 + 12,
+	}, got)
+}
+
+func TestEstimateRequest_Parts(t *testing.T) {
+	t.Parallel()
+
+	got := EstimateRequest(ChatCompletionRequest{
+		Model: "gpt-4o",
+		Messages: []Message{{Role: "user", Parts: []ContentPart{
+			TextPart("What is this?"),
+			ImagePart("https://example.com/a.png", ImageDetailLow),
+			ImagePart("https://example.com/b.png", ""),
+			AudioPart([]byte("RIFF"), AudioInputWAV),
+		}}},
+	})
+
+	assert.Equal(t, RequestEstimate{
+		Messages: []int{3 + 1 + 4 + 85 + 765},
+		Total:    3 + 858,
+	}, got)
+}
+
+func TestEstimateRequest_Tools(t *testing.T) {
+	t.Parallel()
+
+	got := EstimateRequest(ChatCompletionRequest{
+		Model: "test_model",
+		Messages: []Message{
+			{Role: "assistant", ToolCalls: []ToolCall{{Function: FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}}}},
+		},
+		Tools: []Tool{NewFunctionTool("weather", "Get weather", json.RawMessage(`{"type":"object"}`))},
+	})
+
+	assert.Equal(t, RequestEstimate{
+		Messages: []int{3 + 3 + 0 + 2 + 4},
+		Tools:    2 + 3 + 5,
+		Total:    3 + 12 + 10,
+	}, got)
+}
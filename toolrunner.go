@@ -0,0 +1,171 @@
+package openaiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultMaxToolSteps is the number of model round trips a ToolRunner allows by default.
+const DefaultMaxToolSteps = 10
+
+// ErrMaxToolSteps is returned when the model keeps calling tools past the runner's step limit.
+var ErrMaxToolSteps = errors.New("too many tool steps")
+
+const summarizeToolPrompt = `You condense the output of a tool called by an assistant.
+Keep every fact, identifier and number the assistant may need, drop repetition and boilerplate.
+Reply with the condensed output only.`
+
+type (
+	// ToolFunc executes a tool call with the JSON encoded arguments generated by the model.
+	ToolFunc func(ctx context.Context, arguments string) (string, error)
+
+	// ToolRunner runs chat completions with tools, executing the tool calls of
+	// the model and sending their results back until it answers.
+	ToolRunner struct {
+		client   *Client
+		tools    []Tool
+		funcs    map[string]ToolFunc
+		maxSteps int
+
+		resultLimit    int
+		summarizeModel string
+		schemaChecker  *ToolSchemaChecker
+	}
+)
+
+// NewToolRunner creates a tool runner sending requests with the client.
+func NewToolRunner(client *Client) *ToolRunner {
+	return &ToolRunner{
+		client:   client,
+		funcs:    make(map[string]ToolFunc),
+		maxSteps: DefaultMaxToolSteps,
+	}
+}
+
+// Register makes the function available to the model as a tool.
+func (r *ToolRunner) Register(def FunctionDefinition, fn ToolFunc) *ToolRunner {
+	r.tools = append(r.tools, Tool{Type: "function", Function: def})
+	r.funcs[def.Name] = fn
+	return r
+}
+
+// WithMaxSteps sets the maximum number of model round trips of a run.
+func (r *ToolRunner) WithMaxSteps(n int) *ToolRunner {
+	r.maxSteps = n
+	return r
+}
+
+// Tools returns the registered tools.
+func (r *ToolRunner) Tools() []Tool {
+	return append([]Tool(nil), r.tools...)
+}
+
+// WithSchemaChecker records the tool calls of the model in the checker, e.g.
+// one created with NewToolSchemaChecker(runner.Tools()...).
+func (r *ToolRunner) WithSchemaChecker(checker *ToolSchemaChecker) *ToolRunner {
+	r.schemaChecker = checker
+	return r
+}
+
+// WithResultLimit caps the estimated tokens of every tool result appended to
+// the conversation. Longer results are condensed by the summarize model or,
+// if it is empty or its summary is still too long, truncated.
+func (r *ToolRunner) WithResultLimit(tokens int, summarizeModel string) *ToolRunner {
+	r.resultLimit = tokens
+	r.summarizeModel = summarizeModel
+	return r
+}
+
+// Run sends the request with the registered tools, executing tool calls until
+// the model replies without any. It returns the final response along with
+// every message of the run, the request messages included. Tool errors are
+// reported to the model as the tool result so it can recover.
+func (r *ToolRunner) Run(ctx context.Context, in ChatCompletionRequest) (*ChatCompletionResponse, []Message, error) {
+	req := in.Clone()
+	req.Tools = append(req.Tools, r.tools...)
+
+	for step := 0; step < r.maxSteps; step++ {
+		resp, err := r.client.CreateChatCompletion(ctx, req)
+		if err != nil {
+			return nil, req.Messages, err
+		}
+
+		if len(resp.Choices) == 0 {
+			return nil, req.Messages, fmt.Errorf("could not run tools: no choices returned")
+		}
+
+		reply := resp.Choices[0].Message
+		req.Messages = append(req.Messages, reply)
+
+		if len(reply.ToolCalls) == 0 {
+			return resp, req.Messages, nil
+		}
+
+		if r.schemaChecker != nil {
+			r.schemaChecker.Observe(reply.ToolCalls...)
+		}
+
+		for _, call := range reply.ToolCalls {
+			result, err := r.limit(ctx, call, r.call(ctx, call))
+			if err != nil {
+				return nil, req.Messages, err
+			}
+			req.Messages = append(req.Messages, ToolResult(call, result))
+		}
+	}
+	return nil, req.Messages, fmt.Errorf("%w: %d", ErrMaxToolSteps, r.maxSteps)
+}
+
+// call executes the tool call, returning errors as the result.
+func (r *ToolRunner) call(ctx context.Context, call ToolCall) string {
+	fn, ok := r.funcs[call.Function.Name]
+	if !ok {
+		return fmt.Sprintf("error: unknown tool %q", call.Function.Name)
+	}
+
+	result, err := fn(ctx, call.Function.Arguments)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return result
+}
+
+// limit condenses or truncates the result to the result limit.
+func (r *ToolRunner) limit(ctx context.Context, call ToolCall, result string) (string, error) {
+	if r.resultLimit <= 0 || EstimateTokens(result) <= r.resultLimit {
+		return result, nil
+	}
+
+	if r.summarizeModel != "" {
+		resp, err := r.client.CreateChatCompletion(ctx, ChatCompletionRequest{
+			Model: r.summarizeModel,
+			Messages: []Message{
+				{Role: RoleSystem, Content: summarizeToolPrompt},
+				{Role: RoleUser, Content: fmt.Sprintf("Tool: %s\nArguments: %s\n\nOutput:\n%s", call.Function.Name, call.Function.Arguments, result)},
+			},
+			MaxTokens: r.resultLimit,
+		})
+		if err != nil {
+			return "", fmt.Errorf("could not summarize tool result: %w", err)
+		}
+
+		if len(resp.Choices) > 0 {
+			result = resp.Choices[0].Message.Content
+		}
+	}
+	return truncateTokens(result, r.resultLimit), nil
+}
+
+// truncateTokens cuts the text to roughly the given number of tokens, noting how much was cut.
+func truncateTokens(text string, tokens int) string {
+	total := EstimateTokens(text)
+	if total <= tokens {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := strings.TrimSpace(string(runes[:min(len(runes), tokens*4)]))
+	return fmt.Sprintf("%s\n[truncated %d of %d tokens]", cut, total-tokens, total)
+}
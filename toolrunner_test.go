@@ -0,0 +1,185 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// toolCallReply returns a response calling the named tools.
+func toolCallReply(names ...string) ChatCompletionResponse {
+	var calls []ToolCall
+	for i, name := range names {
+		calls = append(calls, ToolCall{ID: fmt.Sprintf("call_%d", i), Type: "function", Function: FunctionCall{Name: name, Arguments: "{}"}})
+	}
+	return ChatCompletionResponse{Choices: []Choice{{FinishReason: "tool_calls", Message: Message{Role: "assistant", ToolCalls: calls}}}}
+}
+
+func TestToolRunner_Run(t *testing.T) {
+	t.Parallel()
+
+	var requests []ChatCompletionRequest
+	replies := []ChatCompletionResponse{
+		toolCallReply("weather", "broken", "missing"),
+		{Choices: []Choice{{FinishReason: "stop", Message: Message{Role: "assistant", Content: "Sunny."}}}},
+	}
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in ChatCompletionRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+			reply := replies[len(requests)]
+			requests = append(requests, in)
+			return jsonResponse(t, reply), nil
+		},
+	}, WithLint())
+
+	runner := NewToolRunner(client).
+		Register(FunctionDefinition{Name: "weather"}, func(ctx context.Context, arguments string) (string, error) {
+			return "sunny", nil
+		}).
+		Register(FunctionDefinition{Name: "broken"}, func(ctx context.Context, arguments string) (string, error) {
+			return "", errors.New("service down")
+		})
+
+	resp, messages, err := runner.Run(context.Background(), ChatCompletionRequest{
+		Model:    "test_model",
+		Messages: []Message{{Role: "user", Content: "Weather?"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Sunny.", resp.Choices[0].Message.Content)
+	require.Len(t, requests, 2)
+	assert.Len(t, requests[0].Tools, 2)
+
+	require.Len(t, messages, 6)
+	assert.Equal(t, "sunny", messages[2].Content)
+	assert.Equal(t, "error: service down", messages[3].Content)
+	assert.Equal(t, `error: unknown tool "missing"`, messages[4].Content)
+	assert.Equal(t, messages[:5], requests[1].Messages)
+}
+
+func TestToolRunner_MaxSteps(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(t, toolCallReply("loop")), nil
+		},
+	})
+
+	runner := NewToolRunner(client).
+		Register(FunctionDefinition{Name: "loop"}, func(ctx context.Context, arguments string) (string, error) {
+			return "again", nil
+		}).
+		WithMaxSteps(2)
+
+	_, messages, err := runner.Run(context.Background(), ChatCompletionRequest{Messages: []Message{{Role: "user", Content: "Go"}}})
+	assert.ErrorIs(t, err, ErrMaxToolSteps)
+	assert.Len(t, messages, 5)
+}
+
+func TestToolRunner_WithResultLimit(t *testing.T) {
+	t.Parallel()
+
+	verbose := strings.Repeat("lorem ipsum ", 100)
+
+	newRunner := func(summary string, summaries *int) *ToolRunner {
+		var step int
+		client := New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				var in ChatCompletionRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+				if in.Model == "summary_model" {
+					*summaries++
+					assert.Contains(t, in.Messages[1].Content, verbose)
+					assert.Equal(t, 10, in.MaxTokens)
+					return jsonResponse(t, ChatCompletionResponse{Choices: []Choice{{Message: Message{Role: "assistant", Content: summary}}}}), nil
+				}
+
+				step++
+				if step == 1 {
+					return jsonResponse(t, toolCallReply("search")), nil
+				}
+				return jsonResponse(t, ChatCompletionResponse{Choices: []Choice{{Message: Message{Role: "assistant", Content: "done"}}}}), nil
+			},
+		})
+
+		return NewToolRunner(client).Register(FunctionDefinition{Name: "search"}, func(ctx context.Context, arguments string) (string, error) {
+			return verbose, nil
+		})
+	}
+
+	t.Run("truncates", func(t *testing.T) {
+		t.Parallel()
+
+		var summaries int
+		_, messages, err := newRunner("", &summaries).WithResultLimit(10, "").Run(context.Background(), ChatCompletionRequest{Model: "test_model"})
+		require.NoError(t, err)
+
+		assert.Zero(t, summaries)
+		assert.Equal(t, "lorem ipsum lorem ipsum lorem ipsum lore\n[truncated 290 of 300 tokens]", messages[1].Content)
+	})
+
+	t.Run("summarizes", func(t *testing.T) {
+		t.Parallel()
+
+		var summaries int
+		_, messages, err := newRunner("lorem ipsum, repeated", &summaries).WithResultLimit(10, "summary_model").Run(context.Background(), ChatCompletionRequest{Model: "test_model"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, summaries)
+		assert.Equal(t, "lorem ipsum, repeated", messages[1].Content)
+	})
+}
+
+func TestToolRunner_WithSchemaChecker(t *testing.T) {
+	t.Parallel()
+
+	replies := []ChatCompletionResponse{
+		{Choices: []Choice{{Message: Message{Role: "assistant", ToolCalls: []ToolCall{
+			{ID: "call_1", Type: "function", Function: FunctionCall{Name: "weather", Arguments: `{"city":"Paris","units":"metric"}`}},
+		}}}}},
+		{Choices: []Choice{{Message: Message{Role: "assistant", Content: "Sunny."}}}},
+	}
+
+	calls := 0
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			reply := replies[calls]
+			calls++
+			return jsonResponse(t, reply), nil
+		},
+	})
+
+	runner := NewToolRunner(client).
+		Register(FunctionDefinition{
+			Name:       "weather",
+			Parameters: json.RawMessage(`{"type":"object","properties":{"city":{"type":"string"},"day":{"type":"string"}}}`),
+		}, func(ctx context.Context, arguments string) (string, error) {
+			return "sunny", nil
+		})
+
+	checker, err := NewToolSchemaChecker(runner.Tools()...)
+	require.NoError(t, err)
+
+	_, _, err = runner.WithSchemaChecker(checker).Run(context.Background(), ChatCompletionRequest{
+		Messages: []Message{{Role: "user", Content: "Weather?"}},
+	})
+	require.NoError(t, err)
+
+	report := checker.Report()
+	require.Len(t, report, 1)
+	assert.Equal(t, 1, report[0].Calls)
+	assert.Equal(t, []string{"day"}, report[0].Unused)
+	assert.Equal(t, map[string]int{"units": 1}, report[0].Hallucinated)
+}
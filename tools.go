@@ -0,0 +1,119 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Tool choice modes.
+const (
+	ToolChoiceNone     = "none"
+	ToolChoiceAuto     = "auto"
+	ToolChoiceRequired = "required"
+)
+
+type (
+	// Tool is a tool the model may call.
+	Tool struct {
+		// Type is always "function".
+		Type     string             `json:"type"`
+		Function FunctionDefinition `json:"function"`
+	}
+
+	// FunctionDefinition describes a function the model may call.
+	FunctionDefinition struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		// Parameters is the JSON schema of the function arguments.
+		Parameters json.RawMessage `json:"parameters,omitempty"`
+		Strict     bool            `json:"strict,omitempty"`
+	}
+
+	// ToolCall is a call to a tool requested by the model.
+	ToolCall struct {
+		ID string `json:"id"`
+		// Type is always "function".
+		Type     string       `json:"type"`
+		Function FunctionCall `json:"function"`
+	}
+
+	// FunctionCall is the function called by a ToolCall.
+	FunctionCall struct {
+		Name string `json:"name"`
+		// Arguments are the JSON encoded arguments generated by the model.
+		// They may be invalid JSON or not match the schema.
+		Arguments string `json:"arguments"`
+	}
+
+	// ToolChoice controls which tool the model calls: either a mode such as
+	// ToolChoiceAuto, or a specific function.
+	ToolChoice struct {
+		Mode     string
+		Function string
+	}
+)
+
+// NewFunctionTool returns a function tool with the given JSON schema parameters.
+func NewFunctionTool(name, description string, parameters json.RawMessage) Tool {
+	return Tool{
+		Type: "function",
+		Function: FunctionDefinition{
+			Name:        name,
+			Description: description,
+			Parameters:  parameters,
+		},
+	}
+}
+
+// ToolChoiceFunction forces the model to call the named function.
+func ToolChoiceFunction(name string) *ToolChoice {
+	return &ToolChoice{Function: name}
+}
+
+// ToolChoiceMode sets the tool choice mode, such as ToolChoiceRequired.
+func ToolChoiceMode(mode string) *ToolChoice {
+	return &ToolChoice{Mode: mode}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c ToolChoice) MarshalJSON() ([]byte, error) {
+	if c.Function == "" {
+		return json.Marshal(c.Mode)
+	}
+
+	return json.Marshal(toolChoiceFunction{
+		Type: "function",
+		Function: struct {
+			Name string `json:"name"`
+		}{Name: c.Function},
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *ToolChoice) UnmarshalJSON(data []byte) error {
+	var mode string
+	if err := json.Unmarshal(data, &mode); err == nil {
+		*c = ToolChoice{Mode: mode}
+		return nil
+	}
+
+	var fn toolChoiceFunction
+	if err := json.Unmarshal(data, &fn); err != nil {
+		return fmt.Errorf("could not unmarshal tool choice: %w", err)
+	}
+
+	*c = ToolChoice{Function: fn.Function.Name}
+	return nil
+}
+
+type toolChoiceFunction struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name string `json:"name"`
+	} `json:"function"`
+}
+
+// ToolResult returns the tool message answering the tool call with the given content.
+func ToolResult(call ToolCall, content string) Message {
+	return Message{Role: RoleTool, ToolCallID: call.ID, Content: content}
+}
@@ -0,0 +1,101 @@
+package openaiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolChoice_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		choice *ToolChoice
+		want   string
+	}{
+		{name: "mode", choice: ToolChoiceMode(ToolChoiceRequired), want: `"required"`},
+		{name: "function", choice: ToolChoiceFunction("weather"), want: `{"type":"function","function":{"name":"weather"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.choice)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+
+			var decoded ToolChoice
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.Equal(t, *tt.choice, decoded)
+		})
+	}
+
+	var invalid ToolChoice
+	assert.Error(t, json.Unmarshal([]byte(`42`), &invalid))
+}
+
+func TestClient_CreateChatCompletion_Tools(t *testing.T) {
+	t.Parallel()
+
+	weather := NewFunctionTool("weather", "Get the weather of a city", json.RawMessage(`{"type":"object","properties":{"city":{"type":"string"}}}`))
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var in map[string]any
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+
+			assert.Equal(t, "auto", in["tool_choice"])
+			assert.Len(t, in["tools"], 1)
+
+			messages := in["messages"].([]any)
+			if len(messages) == 1 {
+				return jsonResponse(t, map[string]any{
+					"choices": []any{map[string]any{
+						"finish_reason": "tool_calls",
+						"message": map[string]any{
+							"role":    "assistant",
+							"content": nil,
+							"tool_calls": []any{map[string]any{
+								"id":       "call_1",
+								"type":     "function",
+								"function": map[string]any{"name": "weather", "arguments": `{"city":"Paris"}`},
+							}},
+						},
+					}},
+				}), nil
+			}
+
+			result := messages[2].(map[string]any)
+			assert.Equal(t, "tool", result["role"])
+			assert.Equal(t, "call_1", result["tool_call_id"])
+
+			return jsonResponse(t, ChatCompletionResponse{
+				Choices: []Choice{{FinishReason: "stop", Message: Message{Role: "assistant", Content: "Sunny."}}},
+			}), nil
+		},
+	}, WithLint())
+
+	req := ChatCompletionRequest{
+		Model:      "test_model",
+		Messages:   []Message{{Role: "user", Content: "Weather in Paris?"}},
+		Tools:      []Tool{weather},
+		ToolChoice: ToolChoiceMode(ToolChoiceAuto),
+	}
+
+	resp, err := client.CreateChatCompletion(context.Background(), req)
+	require.NoError(t, err)
+
+	reply := resp.Choices[0].Message
+	require.Len(t, reply.ToolCalls, 1)
+	assert.Equal(t, FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}, reply.ToolCalls[0].Function)
+
+	req.Messages = append(req.Messages, reply, ToolResult(reply.ToolCalls[0], "sunny"))
+
+	resp, err = client.CreateChatCompletion(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "Sunny.", resp.Choices[0].Message.Content)
+}
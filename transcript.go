@@ -0,0 +1,102 @@
+package openaiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// Transcript is the record of a conversation, exportable to Markdown, HTML and JSONL.
+type Transcript []Turn
+
+// transcriptRecord is the JSONL representation of a turn.
+type transcriptRecord struct {
+	Turn       int       `json:"turn"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMS int64     `json:"duration_ms"`
+	Request    Message   `json:"request"`
+	Reply      Message   `json:"reply"`
+	Usage      Usage     `json:"usage"`
+}
+
+var transcriptHTML = template.Must(template.New("transcript").Funcs(template.FuncMap{
+	"inc":  func(i int) int { return i + 1 },
+	"list": func(msgs ...Message) []Message { return msgs },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Transcript</title></head>
+<body>
+{{- range $i, $turn := . }}
+<section class="turn">
+<h2>Turn {{ inc $i }}</h2>
+<p class="timing">{{ $turn.StartedAt.UTC.Format "2006-01-02T15:04:05Z07:00" }} &middot; {{ $turn.Duration }} &middot; {{ $turn.Usage.TotalTokens }} tokens</p>
+{{- range $msg := list $turn.Request $turn.Reply }}
+<div class="message {{ $msg.Role }}"><h3>{{ $msg.Role }}</h3><pre>{{ $msg.Text }}</pre>
+{{- range $msg.ToolCalls }}<pre class="tool-call">{{ .Function.Name }}({{ .Function.Arguments }})</pre>{{ end }}</div>
+{{- end }}
+</section>
+{{- end }}
+</body>
+</html>
+`))
+
+// WriteMarkdown renders the transcript as Markdown.
+func (t Transcript) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	for i, turn := range t {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(&b, "## Turn %d\n\n", i+1)
+		fmt.Fprintf(&b, "_%s · %s · %d tokens_\n\n", turn.StartedAt.UTC().Format(time.RFC3339), turn.Duration, turn.Usage.TotalTokens)
+
+		for _, msg := range []Message{turn.Request, turn.Reply} {
+			fmt.Fprintf(&b, "**%s**\n\n", msg.Role)
+			if text := msg.Text(); text != "" {
+				fmt.Fprintf(&b, "%s\n\n", text)
+			}
+			for _, call := range msg.ToolCalls {
+				fmt.Fprintf(&b, "`%s(%s)`\n\n", call.Function.Name, call.Function.Arguments)
+			}
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("could not write transcript: %w", err)
+	}
+	return nil
+}
+
+// WriteHTML renders the transcript as a standalone HTML document.
+func (t Transcript) WriteHTML(w io.Writer) error {
+	if err := transcriptHTML.Execute(w, t); err != nil {
+		return fmt.Errorf("could not write transcript: %w", err)
+	}
+	return nil
+}
+
+// WriteJSONL renders the transcript as JSON Lines, one turn per line.
+func (t Transcript) WriteJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+
+	for i, turn := range t {
+		record := transcriptRecord{
+			Turn:       i + 1,
+			StartedAt:  turn.StartedAt,
+			DurationMS: turn.Duration.Milliseconds(),
+			Request:    turn.Request,
+			Reply:      turn.Reply,
+			Usage:      turn.Usage,
+		}
+
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("could not write transcript: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package openaiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTranscript() Transcript {
+	return Transcript{
+		{
+			Request:   Message{Role: "user", Content: "Is 1 < 2?"},
+			Reply:     Message{Role: "assistant", Content: "Yes."},
+			StartedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Duration:  1500 * time.Millisecond,
+			Usage:     Usage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+	}
+}
+
+func TestTranscript_WriteMarkdown(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, testTranscript().WriteMarkdown(&buf))
+
+	want := "## Turn 1\n\n" +
+		"_2024-01-02T03:04:05Z · 1.5s · 7 tokens_\n\n" +
+		"**user**\n\nIs 1 < 2?\n\n" +
+		"**assistant**\n\nYes.\n\n"
+
+	assert.Equal(t, want, buf.String())
+}
+
+func TestTranscript_ToolCalls(t *testing.T) {
+	t.Parallel()
+
+	transcript := Transcript{{
+		Request: Message{Role: "user", Content: "Weather?"},
+		Reply: Message{Role: "assistant", ToolCalls: []ToolCall{
+			{ID: "call_1", Function: FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}},
+		}},
+	}}
+
+	var md bytes.Buffer
+	require.NoError(t, transcript.WriteMarkdown(&md))
+	assert.Contains(t, md.String(), "**assistant**\n\n`weather({\"city\":\"Paris\"})`\n\n")
+
+	var html bytes.Buffer
+	require.NoError(t, transcript.WriteHTML(&html))
+	assert.Contains(t, html.String(), `<pre class="tool-call">weather({&#34;city&#34;:&#34;Paris&#34;})</pre>`)
+}
+
+func TestTranscript_WriteHTML(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, testTranscript().WriteHTML(&buf))
+
+	got := buf.String()
+	assert.Contains(t, got, "<h2>Turn 1</h2>")
+	assert.Contains(t, got, "Is 1 &lt; 2?")
+	assert.Contains(t, got, "1.5s")
+	assert.NotContains(t, got, "Is 1 < 2?")
+}
+
+func TestTranscript_WriteJSONL(t *testing.T) {
+	t.Parallel()
+
+	transcript := append(testTranscript(), testTranscript()...)
+
+	var buf bytes.Buffer
+	require.NoError(t, transcript.WriteJSONL(&buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var record transcriptRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+
+	assert.Equal(t, 2, record.Turn)
+	assert.Equal(t, int64(1500), record.DurationMS)
+	assert.Equal(t, "Yes.", record.Reply.Content)
+	assert.Equal(t, 7, record.Usage.TotalTokens)
+}
@@ -0,0 +1,103 @@
+package openaiclient
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultMaxIdleConnsPerHost is the number of idle connections kept per host by NewTransport.
+	// Go's default of two is far too low for embedding fan-out workloads.
+	DefaultMaxIdleConnsPerHost = 100
+
+	// DefaultKeepAlive is the TCP keep-alive period used by NewTransport.
+	DefaultKeepAlive = 30 * time.Second
+
+	// DefaultTimeout is the timeout of the HTTP client used when New is given none.
+	// It bounds whole requests, including reading streamed responses.
+	DefaultTimeout = 10 * time.Minute
+
+	dialTimeout = 30 * time.Second
+)
+
+// TransportOption configures the transport constructed by NewTransport.
+type TransportOption func(*http.Transport)
+
+// NewTransport constructs an HTTP transport tuned for the OpenAI API.
+// It starts from Go's default transport settings, keeps more idle
+// connections per host and attempts HTTP/2.
+func NewTransport(opts ...TransportOption) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	t.ForceAttemptHTTP2 = true
+	t.DialContext = (&net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: DefaultKeepAlive,
+	}).DialContext
+
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// newDefaultHTTPClient returns the HTTP client used when New is given none,
+// using NewTransport, which takes the proxy from the environment.
+func newDefaultHTTPClient() *http.Client {
+	return &http.Client{Transport: NewTransport(), Timeout: DefaultTimeout}
+}
+
+// WithTimeout sets the timeout of the HTTP client, bounding whole requests
+// including reading streamed responses; zero means no timeout. It only applies
+// to an *http.Client, which is copied, such as the default one.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		if hc, ok := c.httpClient.(*http.Client); ok {
+			clone := *hc
+			clone.Timeout = d
+			c.httpClient = &clone
+		}
+	}
+}
+
+// WithMaxIdleConnsPerHost sets the maximum number of idle connections kept per host.
+func WithMaxIdleConnsPerHost(n int) TransportOption {
+	return func(t *http.Transport) {
+		t.MaxIdleConnsPerHost = n
+		if t.MaxIdleConns != 0 && t.MaxIdleConns < n {
+			t.MaxIdleConns = n
+		}
+	}
+}
+
+// WithKeepAlive sets the TCP keep-alive probe period of new connections.
+// A negative period disables the probes. Connection reuse is controlled by WithConnectionReuse.
+func WithKeepAlive(period time.Duration) TransportOption {
+	return func(t *http.Transport) {
+		t.DialContext = (&net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: period,
+		}).DialContext
+	}
+}
+
+// WithConnectionReuse enables or disables HTTP connection reuse.
+// When disabled, every request uses a new connection.
+func WithConnectionReuse(enabled bool) TransportOption {
+	return func(t *http.Transport) {
+		t.DisableKeepAlives = !enabled
+	}
+}
+
+// WithHTTP2 enables or disables HTTP/2.
+func WithHTTP2(enabled bool) TransportOption {
+	return func(t *http.Transport) {
+		t.ForceAttemptHTTP2 = enabled
+		if !enabled {
+			// A non-nil empty map stops the transport from negotiating HTTP/2.
+			t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package openaiclient
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uses tuned defaults", func(t *testing.T) {
+		t.Parallel()
+
+		transport := NewTransport()
+
+		assert.Equal(t, DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+		assert.True(t, transport.ForceAttemptHTTP2)
+		assert.False(t, transport.DisableKeepAlives)
+		assert.NotNil(t, transport.DialContext)
+	})
+
+	t.Run("max idle conns per host", func(t *testing.T) {
+		t.Parallel()
+
+		transport := NewTransport(WithMaxIdleConnsPerHost(500))
+
+		assert.Equal(t, 500, transport.MaxIdleConnsPerHost)
+		assert.GreaterOrEqual(t, transport.MaxIdleConns, 500)
+	})
+
+	t.Run("disabling keep-alive probes keeps connection reuse", func(t *testing.T) {
+		t.Parallel()
+
+		transport := NewTransport(WithKeepAlive(-1))
+
+		assert.False(t, transport.DisableKeepAlives)
+		assert.NotNil(t, transport.DialContext)
+	})
+
+	t.Run("custom keep-alive keeps connection reuse", func(t *testing.T) {
+		t.Parallel()
+
+		transport := NewTransport(WithKeepAlive(time.Minute))
+
+		assert.False(t, transport.DisableKeepAlives)
+	})
+
+	t.Run("disables connection reuse", func(t *testing.T) {
+		t.Parallel()
+
+		transport := NewTransport(WithConnectionReuse(false))
+
+		assert.True(t, transport.DisableKeepAlives)
+	})
+
+	t.Run("disables http2", func(t *testing.T) {
+		t.Parallel()
+
+		transport := NewTransport(WithHTTP2(false))
+
+		assert.False(t, transport.ForceAttemptHTTP2)
+		assert.NotNil(t, transport.TLSNextProto)
+		assert.Empty(t, transport.TLSNextProto)
+	})
+}
+
+func TestNew_DefaultHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("falls back to a tuned client", func(t *testing.T) {
+		t.Parallel()
+
+		client := New("test_api_key", nil)
+
+		hc, ok := client.httpClient.(*http.Client)
+		require.True(t, ok)
+		assert.Equal(t, DefaultTimeout, hc.Timeout)
+
+		transport, ok := hc.Transport.(*http.Transport)
+		require.True(t, ok)
+		assert.Equal(t, DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+		assert.NotNil(t, transport.Proxy)
+	})
+
+	t.Run("sets the timeout", func(t *testing.T) {
+		t.Parallel()
+
+		client := New("test_api_key", nil, WithTimeout(time.Minute))
+
+		hc, ok := client.httpClient.(*http.Client)
+		require.True(t, ok)
+		assert.Equal(t, time.Minute, hc.Timeout)
+	})
+
+	t.Run("copies the given client", func(t *testing.T) {
+		t.Parallel()
+
+		given := &http.Client{}
+		client := New("test_api_key", given, WithTimeout(time.Minute))
+
+		assert.Zero(t, given.Timeout)
+		assert.Equal(t, time.Minute, client.httpClient.(*http.Client).Timeout)
+	})
+
+	t.Run("ignores other clients", func(t *testing.T) {
+		t.Parallel()
+
+		given := &mockHTTPClient{}
+		client := New("test_api_key", given, WithTimeout(time.Minute))
+
+		assert.Same(t, given, client.httpClient)
+	})
+}
@@ -0,0 +1,72 @@
+package openaiclient
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrBudgetExceeded is returned when the client has spent its token budget.
+var ErrBudgetExceeded = errors.New("token budget exceeded")
+
+// usageTracker accumulates the token usage reported by the API.
+type usageTracker struct {
+	mu     sync.Mutex
+	budget int
+	usage  Usage
+	costs  *costRecorder
+}
+
+// add returns the sum of both usages.
+func (u Usage) add(other Usage) Usage {
+	return Usage{
+		PromptTokens:     u.PromptTokens + other.PromptTokens,
+		TotalTokens:      u.TotalTokens + other.TotalTokens,
+		CompletionTokens: u.CompletionTokens + other.CompletionTokens,
+		PromptTokensDetails: PromptTokensDetails{
+			CachedTokens: u.PromptTokensDetails.CachedTokens + other.PromptTokensDetails.CachedTokens,
+			AudioTokens:  u.PromptTokensDetails.AudioTokens + other.PromptTokensDetails.AudioTokens,
+		},
+		CompletionTokensDetails: CompletionTokensDetails{
+			ReasoningTokens: u.CompletionTokensDetails.ReasoningTokens + other.CompletionTokensDetails.ReasoningTokens,
+			AudioTokens:     u.CompletionTokensDetails.AudioTokens + other.CompletionTokensDetails.AudioTokens,
+		},
+		Estimated: u.Estimated || other.Estimated,
+	}
+}
+
+// FreshPromptTokens returns the prompt tokens not served from the prompt cache.
+func (u Usage) FreshPromptTokens() int {
+	return u.PromptTokens - u.PromptTokensDetails.CachedTokens
+}
+
+// CacheHitRate returns the share of the prompt tokens served from the prompt
+// cache, between 0 and 1, or 0 without prompt tokens.
+func (u Usage) CacheHitRate() float64 {
+	if u.PromptTokens == 0 {
+		return 0
+	}
+	return float64(u.PromptTokensDetails.CachedTokens) / float64(u.PromptTokens)
+}
+
+// record records the usage of a request served by the model.
+func (u *usageTracker) record(model string, usage Usage) {
+	u.mu.Lock()
+	u.usage = u.usage.add(usage)
+	u.mu.Unlock()
+
+	u.costs.record(model, usage)
+}
+
+func (u *usageTracker) total() Usage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.usage
+}
+
+func (u *usageTracker) exhausted() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.budget > 0 && u.usage.TotalTokens >= u.budget
+}
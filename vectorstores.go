@@ -0,0 +1,353 @@
+package openaiclient
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Vector store file statuses.
+const (
+	VectorStoreFileInProgress = "in_progress"
+	VectorStoreFileCompleted  = "completed"
+	VectorStoreFileCancelled  = "cancelled"
+	VectorStoreFileFailed     = "failed"
+)
+
+type (
+	// VectorStoreRequest is the request body to create or modify a vector store.
+	// FileIDs are only accepted on creation.
+	VectorStoreRequest struct {
+		Name     string            `json:"name,omitempty"`
+		FileIDs  []string          `json:"file_ids,omitempty"`
+		Metadata map[string]string `json:"metadata,omitempty"`
+		// ExpiresAfter sets the expiration policy of the store.
+		ExpiresAfter *VectorStoreExpiration `json:"expires_after,omitempty"`
+		// ChunkingStrategy applies to FileIDs, the API default is used when nil.
+		ChunkingStrategy *ChunkingStrategy `json:"chunking_strategy,omitempty"`
+	}
+
+	// VectorStoreExpiration expires a vector store a number of days after its anchor.
+	VectorStoreExpiration struct {
+		// Anchor is always "last_active_at".
+		Anchor string `json:"anchor"`
+		Days   int    `json:"days"`
+	}
+
+	// VectorStore is a store of files searched by the file_search tool.
+	VectorStore struct {
+		ResponseMeta
+		ID         string                `json:"id"`
+		Object     string                `json:"object"`
+		CreatedAt  int64                 `json:"created_at"`
+		Name       string                `json:"name"`
+		UsageBytes int64                 `json:"usage_bytes"`
+		FileCounts VectorStoreFileCounts `json:"file_counts"`
+		// Status is "expired", "in_progress" or "completed".
+		Status       string                 `json:"status"`
+		Metadata     map[string]string      `json:"metadata"`
+		ExpiresAfter *VectorStoreExpiration `json:"expires_after,omitempty"`
+		// ExpiresAt is when the store expires, as a Unix timestamp, if it has an expiration policy.
+		ExpiresAt *int64 `json:"expires_at,omitempty"`
+		// LastActiveAt is when the store was last used, as a Unix timestamp.
+		LastActiveAt *int64 `json:"last_active_at,omitempty"`
+	}
+
+	// VectorStoreFileCounts counts the files of a vector store or batch by status.
+	VectorStoreFileCounts struct {
+		InProgress int `json:"in_progress"`
+		Completed  int `json:"completed"`
+		Failed     int `json:"failed"`
+		Cancelled  int `json:"cancelled"`
+		Total      int `json:"total"`
+	}
+
+	// VectorStoreFileRequest is the request body to attach a file to a vector store.
+	VectorStoreFileRequest struct {
+		FileID string `json:"file_id"`
+		// ChunkingStrategy splits the file, the API default is used when nil.
+		ChunkingStrategy *ChunkingStrategy `json:"chunking_strategy,omitempty"`
+	}
+
+	// VectorStoreFile is a file attached to a vector store.
+	VectorStoreFile struct {
+		ResponseMeta
+		ID            string                `json:"id"`
+		Object        string                `json:"object"`
+		CreatedAt     int64                 `json:"created_at"`
+		VectorStoreID string                `json:"vector_store_id"`
+		UsageBytes    int64                 `json:"usage_bytes"`
+		Status        string                `json:"status"`
+		LastError     *VectorStoreFileError `json:"last_error,omitempty"`
+		// ChunkingStrategy is the strategy the file was effectively chunked with.
+		ChunkingStrategy *ChunkingStrategy `json:"chunking_strategy,omitempty"`
+	}
+
+	// VectorStoreFileError is the error a file failed to be processed with.
+	VectorStoreFileError struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+
+	// VectorStoreFileListOptions paginates and filters the vector store file listings.
+	VectorStoreFileListOptions struct {
+		ListOptions
+		// Filter restricts the listing to the files with the status, such as VectorStoreFileFailed.
+		Filter string
+	}
+
+	// VectorStoreFileBatchRequest is the request body to attach files to a vector store at once.
+	VectorStoreFileBatchRequest struct {
+		FileIDs []string `json:"file_ids"`
+		// ChunkingStrategy splits the files, the API default is used when nil.
+		ChunkingStrategy *ChunkingStrategy `json:"chunking_strategy,omitempty"`
+	}
+
+	// VectorStoreFileBatch is a batch of files attached to a vector store.
+	VectorStoreFileBatch struct {
+		ResponseMeta
+		ID            string                `json:"id"`
+		Object        string                `json:"object"`
+		CreatedAt     int64                 `json:"created_at"`
+		VectorStoreID string                `json:"vector_store_id"`
+		Status        string                `json:"status"`
+		FileCounts    VectorStoreFileCounts `json:"file_counts"`
+	}
+)
+
+// ExpireAfterInactivity returns a policy expiring a vector store after it
+// wasn't used for the given number of days, between 1 and 365.
+func ExpireAfterInactivity(days int) *VectorStoreExpiration {
+	return &VectorStoreExpiration{Anchor: "last_active_at", Days: days}
+}
+
+// Expiry returns when the store expires, if it has an expiration policy.
+func (s VectorStore) Expiry() (time.Time, bool) {
+	if s.ExpiresAt == nil {
+		return time.Time{}, false
+	}
+	return time.Unix(*s.ExpiresAt, 0), true
+}
+
+// LastActive returns when the store was last used, if known.
+func (s VectorStore) LastActive() (time.Time, bool) {
+	if s.LastActiveAt == nil {
+		return time.Time{}, false
+	}
+	return time.Unix(*s.LastActiveAt, 0), true
+}
+
+// CreateVectorStore creates a vector store, optionally with files.
+func (c *Client) CreateVectorStore(ctx context.Context, in VectorStoreRequest) (*VectorStore, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	if err := in.ChunkingStrategy.validate(); err != nil {
+		return nil, err
+	}
+
+	var store VectorStore
+	if err := c.post(ctx, c.url(EndpointVectorStores), in, &store); err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+// ListVectorStores lists the vector stores.
+func (c *Client) ListVectorStores(ctx context.Context, opts ListOptions) (*List[VectorStore], error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var list List[VectorStore]
+	if err := c.get(ctx, withQuery(c.url(EndpointVectorStores), opts.query()), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetVectorStore retrieves the vector store.
+func (c *Client) GetVectorStore(ctx context.Context, id string) (*VectorStore, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var store VectorStore
+	if err := c.get(ctx, c.vectorStoreURL(id), &store); err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+// ModifyVectorStore modifies the name, metadata and expiration policy of the vector store.
+func (c *Client) ModifyVectorStore(ctx context.Context, id string, in VectorStoreRequest) (*VectorStore, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var store VectorStore
+	if err := c.post(ctx, c.vectorStoreURL(id), in, &store); err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+// DeleteVectorStore deletes the vector store. Its files aren't deleted.
+func (c *Client) DeleteVectorStore(ctx context.Context, id string) (*DeletedObject, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var deleted DeletedObject
+	if err := c.delete(ctx, c.vectorStoreURL(id), &deleted); err != nil {
+		return nil, err
+	}
+	return &deleted, nil
+}
+
+// CreateVectorStoreFile attaches the file to the vector store.
+func (c *Client) CreateVectorStoreFile(ctx context.Context, storeID string, in VectorStoreFileRequest) (*VectorStoreFile, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	if err := in.ChunkingStrategy.validate(); err != nil {
+		return nil, err
+	}
+
+	var file VectorStoreFile
+	if err := c.post(ctx, c.vectorStoreURL(storeID)+"/files", in, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// ListVectorStoreFiles lists the files of the vector store.
+func (c *Client) ListVectorStoreFiles(ctx context.Context, storeID string, opts VectorStoreFileListOptions) (*List[VectorStoreFile], error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var list List[VectorStoreFile]
+	if err := c.get(ctx, withQuery(c.vectorStoreURL(storeID)+"/files", opts.query()), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetVectorStoreFile retrieves the file of the vector store.
+func (c *Client) GetVectorStoreFile(ctx context.Context, storeID, fileID string) (*VectorStoreFile, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var file VectorStoreFile
+	if err := c.get(ctx, c.vectorStoreFileURL(storeID, fileID), &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// DeleteVectorStoreFile detaches the file from the vector store. The file itself isn't deleted.
+func (c *Client) DeleteVectorStoreFile(ctx context.Context, storeID, fileID string) (*DeletedObject, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var deleted DeletedObject
+	if err := c.delete(ctx, c.vectorStoreFileURL(storeID, fileID), &deleted); err != nil {
+		return nil, err
+	}
+	return &deleted, nil
+}
+
+// CreateVectorStoreFileBatch attaches the files to the vector store at once.
+func (c *Client) CreateVectorStoreFileBatch(ctx context.Context, storeID string, in VectorStoreFileBatchRequest) (*VectorStoreFileBatch, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	if err := in.ChunkingStrategy.validate(); err != nil {
+		return nil, err
+	}
+
+	var batch VectorStoreFileBatch
+	if err := c.post(ctx, c.vectorStoreURL(storeID)+"/file_batches", in, &batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// GetVectorStoreFileBatch retrieves the file batch of the vector store.
+func (c *Client) GetVectorStoreFileBatch(ctx context.Context, storeID, batchID string) (*VectorStoreFileBatch, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var batch VectorStoreFileBatch
+	if err := c.get(ctx, c.vectorStoreBatchURL(storeID, batchID), &batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// CancelVectorStoreFileBatch cancels the processing of the files of the batch.
+func (c *Client) CancelVectorStoreFileBatch(ctx context.Context, storeID, batchID string) (*VectorStoreFileBatch, error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var batch VectorStoreFileBatch
+	if err := c.post(ctx, c.vectorStoreBatchURL(storeID, batchID)+"/cancel", struct{}{}, &batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// ListVectorStoreFileBatchFiles lists the files of the batch.
+func (c *Client) ListVectorStoreFileBatchFiles(ctx context.Context, storeID, batchID string, opts VectorStoreFileListOptions) (*List[VectorStoreFile], error) {
+	if err := c.requireBeta(BetaAssistants); err != nil {
+		return nil, err
+	}
+
+	var list List[VectorStoreFile]
+	if err := c.get(ctx, withQuery(c.vectorStoreBatchURL(storeID, batchID)+"/files", opts.query()), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// PollVectorStoreFileBatch polls the batch until its files are processed and returns it.
+// If polling times out, the last polled batch is returned along with a PollTimeoutError.
+func (c *Client) PollVectorStoreFileBatch(ctx context.Context, storeID, batchID string, opts ...PollOption) (*VectorStoreFileBatch, error) {
+	return poll(ctx, c, opts, func(ctx context.Context) (*VectorStoreFileBatch, error) {
+		return c.GetVectorStoreFileBatch(ctx, storeID, batchID)
+	}, func(batch *VectorStoreFileBatch) bool {
+		return batch.Status == VectorStoreFileInProgress
+	})
+}
+
+// query returns the pagination and filter query.
+func (o VectorStoreFileListOptions) query() url.Values {
+	query := o.ListOptions.query()
+	if o.Filter != "" {
+		query.Set("filter", o.Filter)
+	}
+	return query
+}
+
+// vectorStoreURL returns the URL of the vector store.
+func (c *Client) vectorStoreURL(id string) string {
+	return c.url(EndpointVectorStores) + "/" + url.PathEscape(id)
+}
+
+// vectorStoreFileURL returns the URL of the file of the vector store.
+func (c *Client) vectorStoreFileURL(storeID, fileID string) string {
+	return c.vectorStoreURL(storeID) + "/files/" + url.PathEscape(fileID)
+}
+
+// vectorStoreBatchURL returns the URL of the file batch of the vector store.
+func (c *Client) vectorStoreBatchURL(storeID, batchID string) string {
+	return c.vectorStoreURL(storeID) + "/file_batches/" + url.PathEscape(batchID)
+}
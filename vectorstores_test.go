@@ -0,0 +1,182 @@
+package openaiclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_VectorStores(t *testing.T) {
+	t.Parallel()
+
+	bodies := map[string]map[string]any{}
+	client := New("test_api_key", routeClient(t, map[string]any{
+		"POST /v1/vector_stores":          VectorStore{ID: "vs_1", Status: "in_progress", FileCounts: VectorStoreFileCounts{InProgress: 2, Total: 2}},
+		"GET /v1/vector_stores?order=asc": List[VectorStore]{Data: []VectorStore{{ID: "vs_1"}}},
+		"GET /v1/vector_stores/vs_1":      VectorStore{ID: "vs_1", Status: "completed", UsageBytes: 2048},
+		"POST /v1/vector_stores/vs_1":     VectorStore{ID: "vs_1", Name: "manuals v2"},
+		"DELETE /v1/vector_stores/vs_1":   DeletedObject{ID: "vs_1", Deleted: true},
+	}, bodies), WithBeta(BetaAssistants))
+
+	ctx := context.Background()
+
+	store, err := client.CreateVectorStore(ctx, VectorStoreRequest{Name: "manuals", FileIDs: []string{"file_1", "file_2"}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, store.FileCounts.Total)
+	assert.Equal(t, map[string]any{"name": "manuals", "file_ids": []any{"file_1", "file_2"}}, bodies["POST /v1/vector_stores"])
+
+	list, err := client.ListVectorStores(ctx, ListOptions{Order: "asc"})
+	require.NoError(t, err)
+	assert.Len(t, list.Data, 1)
+
+	store, err = client.GetVectorStore(ctx, "vs_1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2048), store.UsageBytes)
+
+	store, err = client.ModifyVectorStore(ctx, "vs_1", VectorStoreRequest{Name: "manuals v2"})
+	require.NoError(t, err)
+	assert.Equal(t, "manuals v2", store.Name)
+
+	deleted, err := client.DeleteVectorStore(ctx, "vs_1")
+	require.NoError(t, err)
+	assert.True(t, deleted.Deleted)
+}
+
+func TestClient_VectorStoreFiles(t *testing.T) {
+	t.Parallel()
+
+	bodies := map[string]map[string]any{}
+	client := New("test_api_key", routeClient(t, map[string]any{
+		"POST /v1/vector_stores/vs_1/files":              VectorStoreFile{ID: "file_1", Status: VectorStoreFileInProgress},
+		"GET /v1/vector_stores/vs_1/files?filter=failed": List[VectorStoreFile]{Data: []VectorStoreFile{{ID: "file_2", Status: VectorStoreFileFailed, LastError: &VectorStoreFileError{Code: "unsupported_file"}}}},
+		"GET /v1/vector_stores/vs_1/files/file_1":        VectorStoreFile{ID: "file_1", Status: VectorStoreFileCompleted},
+		"DELETE /v1/vector_stores/vs_1/files/file_1":     DeletedObject{ID: "file_1", Deleted: true},
+	}, bodies), WithBeta(BetaAssistants))
+
+	ctx := context.Background()
+
+	file, err := client.CreateVectorStoreFile(ctx, "vs_1", VectorStoreFileRequest{FileID: "file_1"})
+	require.NoError(t, err)
+	assert.Equal(t, VectorStoreFileInProgress, file.Status)
+	assert.Equal(t, map[string]any{"file_id": "file_1"}, bodies["POST /v1/vector_stores/vs_1/files"])
+
+	list, err := client.ListVectorStoreFiles(ctx, "vs_1", VectorStoreFileListOptions{Filter: VectorStoreFileFailed})
+	require.NoError(t, err)
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, "unsupported_file", list.Data[0].LastError.Code)
+
+	file, err = client.GetVectorStoreFile(ctx, "vs_1", "file_1")
+	require.NoError(t, err)
+	assert.Equal(t, VectorStoreFileCompleted, file.Status)
+
+	deleted, err := client.DeleteVectorStoreFile(ctx, "vs_1", "file_1")
+	require.NoError(t, err)
+	assert.True(t, deleted.Deleted)
+}
+
+func TestClient_VectorStoreFileBatches(t *testing.T) {
+	t.Parallel()
+
+	bodies := map[string]map[string]any{}
+	client := New("test_api_key", routeClient(t, map[string]any{
+		"POST /v1/vector_stores/vs_1/file_batches":                     VectorStoreFileBatch{ID: "vsfb_1", Status: VectorStoreFileInProgress},
+		"POST /v1/vector_stores/vs_1/file_batches/vsfb_1/cancel":       VectorStoreFileBatch{ID: "vsfb_1", Status: "cancelling"},
+		"GET /v1/vector_stores/vs_1/file_batches/vsfb_1/files?limit=5": List[VectorStoreFile]{Data: []VectorStoreFile{{ID: "file_1"}}},
+	}, bodies), WithBeta(BetaAssistants))
+
+	ctx := context.Background()
+
+	batch, err := client.CreateVectorStoreFileBatch(ctx, "vs_1", VectorStoreFileBatchRequest{FileIDs: []string{"file_1", "file_2"}})
+	require.NoError(t, err)
+	assert.Equal(t, "vsfb_1", batch.ID)
+	assert.Equal(t, map[string]any{"file_ids": []any{"file_1", "file_2"}}, bodies["POST /v1/vector_stores/vs_1/file_batches"])
+
+	batch, err = client.CancelVectorStoreFileBatch(ctx, "vs_1", "vsfb_1")
+	require.NoError(t, err)
+	assert.Equal(t, "cancelling", batch.Status)
+
+	list, err := client.ListVectorStoreFileBatchFiles(ctx, "vs_1", "vsfb_1", VectorStoreFileListOptions{ListOptions: ListOptions{Limit: 5}})
+	require.NoError(t, err)
+	assert.Len(t, list.Data, 1)
+}
+
+func TestClient_PollVectorStoreFileBatch(t *testing.T) {
+	t.Parallel()
+
+	var polls int
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/v1/vector_stores/vs_1/file_batches/vsfb_1", req.URL.Path)
+
+			polls++
+			batch := VectorStoreFileBatch{ID: "vsfb_1", Status: VectorStoreFileInProgress, FileCounts: VectorStoreFileCounts{InProgress: 1, Completed: 1, Total: 2}}
+			if polls == 3 {
+				batch.Status = VectorStoreFileCompleted
+				batch.FileCounts = VectorStoreFileCounts{Completed: 2, Total: 2}
+			}
+			return jsonResponse(t, batch), nil
+		},
+	}, WithBeta(BetaAssistants))
+	client.sleep = func(context.Context, time.Duration) error { return nil }
+
+	batch, err := client.PollVectorStoreFileBatch(context.Background(), "vs_1", "vsfb_1")
+	require.NoError(t, err)
+	assert.Equal(t, VectorStoreFileCompleted, batch.Status)
+	assert.Equal(t, 2, batch.FileCounts.Completed)
+	assert.Equal(t, 3, polls)
+}
+
+func TestClient_VectorStoreExpiration(t *testing.T) {
+	t.Parallel()
+
+	bodies := map[string]map[string]any{}
+	client := New("test_api_key", routeClient(t, map[string]any{
+		"POST /v1/vector_stores": map[string]any{
+			"id":             "vs_1",
+			"expires_after":  map[string]any{"anchor": "last_active_at", "days": 7},
+			"expires_at":     1700604800,
+			"last_active_at": 1700000000,
+		},
+		"POST /v1/vector_stores/vs_1": map[string]any{
+			"id":             "vs_1",
+			"expires_after":  map[string]any{"anchor": "last_active_at", "days": 30},
+			"expires_at":     1702592000,
+			"last_active_at": 1700000000,
+		},
+		"GET /v1/vector_stores/vs_2": map[string]any{"id": "vs_2", "expires_at": nil, "last_active_at": nil},
+	}, bodies), WithBeta(BetaAssistants))
+
+	ctx := context.Background()
+
+	store, err := client.CreateVectorStore(ctx, VectorStoreRequest{Name: "scratch", ExpiresAfter: ExpireAfterInactivity(7)})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":          "scratch",
+		"expires_after": map[string]any{"anchor": "last_active_at", "days": float64(7)},
+	}, bodies["POST /v1/vector_stores"])
+	assert.Equal(t, ExpireAfterInactivity(7), store.ExpiresAfter)
+
+	expiry, ok := store.Expiry()
+	require.True(t, ok)
+	assert.Equal(t, time.Unix(1700604800, 0), expiry)
+
+	lastActive, ok := store.LastActive()
+	require.True(t, ok)
+	assert.Equal(t, time.Unix(1700000000, 0), lastActive)
+
+	store, err = client.ModifyVectorStore(ctx, "vs_1", VectorStoreRequest{ExpiresAfter: ExpireAfterInactivity(30)})
+	require.NoError(t, err)
+	assert.Equal(t, 30, store.ExpiresAfter.Days)
+
+	store, err = client.GetVectorStore(ctx, "vs_2")
+	require.NoError(t, err)
+
+	_, ok = store.Expiry()
+	assert.False(t, ok)
+	_, ok = store.LastActive()
+	assert.False(t, ok)
+}
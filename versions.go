@@ -0,0 +1,73 @@
+package openaiclient
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OpenAI-Beta feature flags.
+const (
+	BetaAssistantsV2 = "assistants=v2"
+	BetaRealtimeV1   = "realtime=v1"
+)
+
+var (
+	knownBetaFeatures = toSet([]string{BetaAssistantsV2, BetaRealtimeV1})
+
+	// knownAPIVersions are the Azure OpenAI data plane API versions.
+	knownAPIVersions = toSet([]string{
+		"2024-02-01",
+		"2024-06-01",
+		"2024-10-21",
+		"2024-02-15-preview",
+		"2024-05-01-preview",
+		"2024-08-01-preview",
+		"2024-09-01-preview",
+		"2024-10-01-preview",
+		"2024-12-01-preview",
+		"2025-01-01-preview",
+		"2025-03-01-preview",
+		"2025-04-01-preview",
+	})
+)
+
+// WithAPIVersion pins the API version sent in the api-version query parameter,
+// as required by Azure OpenAI. Unknown versions make every request fail.
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		if _, ok := knownAPIVersions[version]; !ok {
+			c.optionErr = fmt.Errorf("unknown api version: %q", version)
+			return
+		}
+		c.apiVersion = version
+	}
+}
+
+// WithBetaFeatures enables the given OpenAI-Beta feature flags, such as
+// BetaAssistantsV2. Unknown flags make every request fail.
+// WithBeta is the typed equivalent for the beta APIs of the client.
+func WithBetaFeatures(features ...string) Option {
+	return func(c *Client) {
+		for _, feature := range features {
+			if _, ok := knownBetaFeatures[feature]; !ok {
+				c.optionErr = fmt.Errorf("unknown beta feature: %q", feature)
+				return
+			}
+		}
+		c.betaFeatures = append(c.betaFeatures, features...)
+	}
+}
+
+// setVersionHeaders applies the pinned API version and beta features to the request.
+func (c *Client) setVersionHeaders(req *http.Request) {
+	if c.apiVersion != "" {
+		query := req.URL.Query()
+		query.Set("api-version", c.apiVersion)
+		req.URL.RawQuery = query.Encode()
+	}
+
+	if len(c.betaFeatures) > 0 {
+		req.Header.Set("OpenAI-Beta", strings.Join(c.betaFeatures, ","))
+	}
+}
@@ -0,0 +1,56 @@
+package openaiclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends version query and beta header", func(t *testing.T) {
+		t.Parallel()
+
+		client := New("test_api_key", &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "2024-10-21", req.URL.Query().Get("api-version"))
+				assert.Equal(t, "assistants=v2,realtime=v1", req.Header.Get("OpenAI-Beta"))
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader("{}")),
+				}, nil
+			},
+		}, WithAPIVersion("2024-10-21"), WithBetaFeatures(BetaAssistantsV2, BetaRealtimeV1))
+
+		_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+		require.NoError(t, err)
+	})
+
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{name: "rejects unknown api versions", opt: WithAPIVersion("1999-01-01")},
+		{name: "rejects unknown beta features", opt: WithBetaFeatures("assistants=v1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					t.Error("request must not be sent")
+					return nil, nil
+				},
+			}, tt.opt)
+
+			_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package openaiclient
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrWatermarkExceeded is returned by streams aborted above the token watermark.
+var ErrWatermarkExceeded = errors.New("token watermark exceeded")
+
+type (
+	// TokenAlert reports a response whose completion tokens exceed the
+	// watermark, such as a runaway generation.
+	TokenAlert struct {
+		// ID is the ID of the response.
+		ID               string
+		Model            string
+		CompletionTokens int
+		Watermark        int
+	}
+
+	// tokenWatermark alerts on responses above a number of completion tokens.
+	tokenWatermark struct {
+		tokens int
+		hook   func(TokenAlert)
+		// abort aborts streams passing the watermark.
+		abort bool
+	}
+)
+
+// WithTokenWatermark calls hook for every chat or legacy completion response
+// whose completion tokens exceed the watermark, from the goroutine that made
+// the request. The hook may be nil.
+func WithTokenWatermark(tokens int, hook func(TokenAlert)) Option {
+	return func(c *Client) {
+		c.watermark = &tokenWatermark{tokens: tokens, hook: hook}
+	}
+}
+
+// WithTokenWatermarkAbort is like WithTokenWatermark, also aborting streamed
+// chat completions as soon as their completion tokens, estimated from the
+// received deltas, exceed the watermark. Aborted streams return an error
+// matching ErrWatermarkExceeded. A nil hook only aborts.
+func WithTokenWatermarkAbort(tokens int, hook func(TokenAlert)) Option {
+	return func(c *Client) {
+		c.watermark = &tokenWatermark{tokens: tokens, hook: hook, abort: true}
+	}
+}
+
+// check calls the hook if the usage of the response exceeds the watermark.
+func (w *tokenWatermark) check(id, model string, usage Usage) {
+	if w == nil || w.hook == nil || usage.CompletionTokens <= w.tokens {
+		return
+	}
+
+	w.hook(TokenAlert{
+		ID:               id,
+		Model:            model,
+		CompletionTokens: usage.CompletionTokens,
+		Watermark:        w.tokens,
+	})
+}
+
+// checkStream aborts the stream if its completion tokens, estimated after the
+// chunk, exceed the watermark.
+func (w *tokenWatermark) checkStream(s *ChatCompletionStream, chunk *ChatCompletionChunk) error {
+	if w == nil || !w.abort {
+		return nil
+	}
+
+	tokens := estimateRuneTokens(s.generated)
+	if tokens <= w.tokens {
+		return nil
+	}
+
+	if w.hook != nil {
+		w.hook(TokenAlert{
+			ID:               chunk.ID,
+			Model:            chunk.Model,
+			CompletionTokens: tokens,
+			Watermark:        w.tokens,
+		})
+	}
+
+	s.body.Close()
+	return fmt.Errorf("could not read stream: %w: %d completion tokens above %d", ErrWatermarkExceeded, tokens, w.tokens)
+}
@@ -0,0 +1,162 @@
+package openaiclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTokenWatermark(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		completionTokens int
+		want             []TokenAlert
+	}{
+		{
+			name:             "below the watermark",
+			completionTokens: 100,
+		},
+		{
+			name:             "above the watermark",
+			completionTokens: 4096,
+			want:             []TokenAlert{{ID: "chatcmpl-123", Model: "gpt-4o", CompletionTokens: 4096, Watermark: 100}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var alerts []TokenAlert
+			client := New("test_api_key", &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(t, map[string]any{
+						"id":    "chatcmpl-123",
+						"model": "gpt-4o",
+						"usage": map[string]int{"prompt_tokens": 10, "completion_tokens": tt.completionTokens},
+					}), nil
+				},
+			}, WithTokenWatermark(100, func(alert TokenAlert) {
+				alerts = append(alerts, alert)
+			}))
+
+			_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, alerts)
+		})
+	}
+}
+
+func TestWithTokenWatermark_Completion(t *testing.T) {
+	t.Parallel()
+
+	var alerts []TokenAlert
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(t, map[string]any{
+				"id":    "cmpl-123",
+				"model": "davinci-002",
+				"usage": map[string]int{"completion_tokens": 2048},
+			}), nil
+		},
+	}, WithTokenWatermark(1024, func(alert TokenAlert) {
+		alerts = append(alerts, alert)
+	}))
+
+	_, err := client.CreateCompletion(context.Background(), CompletionRequest{Model: "davinci-002"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []TokenAlert{{ID: "cmpl-123", Model: "davinci-002", CompletionTokens: 2048, Watermark: 1024}}, alerts)
+}
+
+func TestWithTokenWatermarkAbort(t *testing.T) {
+	t.Parallel()
+
+	var alerts []TokenAlert
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			delta := ChatCompletionChunk{ID: "chatcmpl-123", Model: "gpt-4o", Choices: []ChunkChoice{{Delta: MessageDelta{Content: "runaway "}}}}
+			return sseResponse(sseEvents(t, delta, delta, delta, delta)), nil
+		},
+	}, WithTokenWatermarkAbort(3, func(alert TokenAlert) {
+		alerts = append(alerts, alert)
+	}))
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	// Each delta is about two tokens.
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, ErrWatermarkExceeded)
+
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, ErrWatermarkExceeded, "aborted streams stay aborted")
+
+	assert.Equal(t, []TokenAlert{{ID: "chatcmpl-123", Model: "gpt-4o", CompletionTokens: 4, Watermark: 3}}, alerts)
+}
+
+func TestWithTokenWatermarkAbort_Usage(t *testing.T) {
+	t.Parallel()
+
+	var alerts []TokenAlert
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return sseResponse(sseEvents(t,
+				ChatCompletionChunk{ID: "chatcmpl-123", Model: "gpt-4o", Choices: []ChunkChoice{{Delta: MessageDelta{Content: "short"}}}},
+				ChatCompletionChunk{ID: "chatcmpl-123", Model: "gpt-4o", Usage: &Usage{CompletionTokens: 200}},
+			)), nil
+		},
+	}, WithTokenWatermarkAbort(100, func(alert TokenAlert) {
+		alerts = append(alerts, alert)
+	}))
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	_, err = stream.Accumulate(NewStreamAccumulator())
+	require.NoError(t, err)
+
+	assert.Equal(t, []TokenAlert{{ID: "chatcmpl-123", Model: "gpt-4o", CompletionTokens: 200, Watermark: 100}}, alerts)
+}
+
+func TestWithTokenWatermarkAbort_NilHook(t *testing.T) {
+	t.Parallel()
+
+	client := New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			delta := ChatCompletionChunk{ID: "chatcmpl-123", Model: "gpt-4o", Choices: []ChunkChoice{{Delta: MessageDelta{Content: "runaway "}}}}
+			return sseResponse(sseEvents(t, delta, delta,
+				ChatCompletionChunk{ID: "chatcmpl-123", Model: "gpt-4o", Usage: &Usage{CompletionTokens: 200}},
+			)), nil
+		},
+	}, WithTokenWatermarkAbort(3, nil))
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, ErrWatermarkExceeded)
+
+	// Responses above the watermark don't alert either.
+	client = New("test_api_key", &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(t, ChatCompletionResponse{Usage: Usage{CompletionTokens: 200}}), nil
+		},
+	}, WithTokenWatermark(3, nil))
+
+	_, err = client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	assert.NoError(t, err)
+}